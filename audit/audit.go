@@ -0,0 +1,153 @@
+// Package audit emits structured security events, such as failed handshakes
+// or replayed messages, to a sink dedicated to them, such that operators may
+// feed them into SIEM tooling apart from regular logs.
+//
+// Events follow a stable schema identified by SchemaVersion. New kinds,
+// reasons and details may be added without bumping the version; existing ones
+// are never renamed or removed. Events of each kind carry the following:
+//
+//	kind               reason                                  details
+//	handshake_failed   dial_failed, session_failed, id_mismatch,
+//	                   refused, attestation
+//	invalid_signature  message
+//	replay_detected    unsolicited_pong
+//	rate_limited       set by the emitter                      limit
+//	peer_banned        revoked, or set by the emitter          duration
+package audit
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+// SchemaVersion is the version of the schema events are emitted under.
+const SchemaVersion = 1
+
+// Kind categorizes a security event.
+type Kind string
+
+const (
+	// HandshakeFailed is emitted when a connection to a peer could not be
+	// established or its session could not be initialized.
+	HandshakeFailed Kind = "handshake_failed"
+	// InvalidSignature is emitted when a peer sends data whose signature does
+	// not verify.
+	InvalidSignature Kind = "invalid_signature"
+	// ReplayDetected is emitted when a peer sends a message which answers no
+	// outstanding challenge, or was already answered.
+	ReplayDetected Kind = "replay_detected"
+	// RateLimited is emitted when a peer exceeds a rate limit.
+	RateLimited Kind = "rate_limited"
+	// PeerBanned is emitted when a peer is banned.
+	PeerBanned Kind = "peer_banned"
+	// PluginPanicked is emitted when a plugin panics handling a message sent
+	// by a peer.
+	PluginPanicked Kind = "plugin_panicked"
+)
+
+// Reasons accompanying events emitted by noise itself.
+const (
+	// ReasonDialFailed is the reason of a HandshakeFailed event where a peer
+	// could not be dialed.
+	ReasonDialFailed = "dial_failed"
+	// ReasonSessionFailed is the reason of a HandshakeFailed event where the
+	// session of a peer could not be initialized.
+	ReasonSessionFailed = "session_failed"
+	// ReasonIDMismatch is the reason of a HandshakeFailed event where a peer
+	// sent a message under an ID other than the one it connected with.
+	ReasonIDMismatch = "id_mismatch"
+	// ReasonRefused is the reason of a HandshakeFailed event where a peer was
+	// refused by a plugin, such as for its key having been revoked.
+	ReasonRefused = "refused"
+	// ReasonAttestation is the reason of a HandshakeFailed event where a peer
+	// presented no attestation evidence, or evidence which failed to be
+	// verified.
+	ReasonAttestation = "attestation"
+	// ReasonMessage is the reason of an InvalidSignature event where the
+	// signature of a message does not verify.
+	ReasonMessage = "message"
+	// ReasonUnsolicitedPong is the reason of a ReplayDetected event where a
+	// pong answers no outstanding ping.
+	ReasonUnsolicitedPong = "unsolicited_pong"
+	// ReasonQoSClass is the reason of a RateLimited event where a peer
+	// exceeds the rate limit of the QoS class of a message.
+	ReasonQoSClass = "qos_class"
+	// ReasonRevoked is the reason of a PeerBanned event where the key of a
+	// peer has been revoked.
+	ReasonRevoked = "revoked"
+)
+
+// Event is a security event.
+type Event struct {
+	// Version is the schema version the event is emitted under.
+	Version int `json:"version"`
+	// Kind categorizes the event.
+	Kind Kind `json:"kind"`
+	// Reason describes what triggered the event, as a stable identifier.
+	Reason string `json:"reason"`
+	// Time is when the event occurred.
+	Time time.Time `json:"time"`
+	// Node is the address of the node which emitted the event.
+	Node string `json:"node"`
+	// PeerAddress is the address of the peer the event concerns, if known.
+	PeerAddress string `json:"peer_address,omitempty"`
+	// PeerPublicKey is the hex-encoded public key of the peer the event
+	// concerns, if known.
+	PeerPublicKey string `json:"peer_public_key,omitempty"`
+	// TraceID is the hex-encoded ID of the trace of the message which
+	// triggered the event, if any, correlating it with logs and spans.
+	TraceID string `json:"trace_id,omitempty"`
+	// Error is the error which triggered the event, if any. Unlike Reason, it
+	// is meant for humans and is not stable.
+	Error string `json:"error,omitempty"`
+	// Details holds additional data specific to the kind of the event.
+	Details map[string]string `json:"details,omitempty"`
+}
+
+// Sink receives security events. A sink must be safe for concurrent use, and
+// should not block for long as events are emitted inline.
+type Sink interface {
+	Emit(event Event)
+}
+
+// SinkFunc is an adapter to allow the use of ordinary functions as sinks.
+type SinkFunc func(event Event)
+
+// Emit calls f(event).
+func (f SinkFunc) Emit(event Event) {
+	f(event)
+}
+
+// Channel returns a sink which sends events to ch. Events are dropped should
+// ch be full, such that a slow consumer may not stall the node.
+func Channel(ch chan<- Event) Sink {
+	return SinkFunc(func(event Event) {
+		select {
+		case ch <- event:
+		default:
+		}
+	})
+}
+
+// JSON returns a sink which writes events to w as newline-delimited JSON.
+func JSON(w io.Writer) Sink {
+	var mutex sync.Mutex
+	encoder := json.NewEncoder(w)
+
+	return SinkFunc(func(event Event) {
+		mutex.Lock()
+		encoder.Encode(event)
+		mutex.Unlock()
+	})
+}
+
+// Multi returns a sink which emits events to all given sinks.
+func Multi(sinks ...Sink) Sink {
+	return SinkFunc(func(event Event) {
+		for _, sink := range sinks {
+			sink.Emit(event)
+		}
+	})
+}
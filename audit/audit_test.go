@@ -0,0 +1,58 @@
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestChannel(t *testing.T) {
+	t.Parallel()
+
+	ch := make(chan Event, 1)
+	sink := Channel(ch)
+
+	sink.Emit(Event{Kind: RateLimited})
+
+	// The channel is full, so the event should be dropped rather than block.
+	sink.Emit(Event{Kind: PeerBanned})
+
+	if event := <-ch; event.Kind != RateLimited {
+		t.Fatalf("expected a %s event, got %s", RateLimited, event.Kind)
+	}
+
+	select {
+	case event := <-ch:
+		t.Fatalf("expected event to be dropped, got %+v", event)
+	default:
+	}
+}
+
+func TestJSON(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	Multi(JSON(&buf)).Emit(Event{
+		Version:     SchemaVersion,
+		Kind:        HandshakeFailed,
+		Reason:      ReasonDialFailed,
+		Time:        time.Unix(0, 0).UTC(),
+		Node:        "tcp://127.0.0.1:3000",
+		PeerAddress: "tcp://127.0.0.1:3001",
+		Error:       "connection refused",
+	})
+
+	expected := `{"version":1,"kind":"handshake_failed","reason":"dial_failed","time":"1970-01-01T00:00:00Z",` +
+		`"node":"tcp://127.0.0.1:3000","peer_address":"tcp://127.0.0.1:3001","error":"connection refused"}` + "\n"
+
+	if buf.String() != expected {
+		t.Fatalf("expected %s, got %s", expected, buf.String())
+	}
+
+	var event Event
+	if err := json.Unmarshal(buf.Bytes(), &event); err != nil || event.Kind != HandshakeFailed {
+		t.Fatalf("expected event to round-trip, got %+v (err: %v)", event, err)
+	}
+}
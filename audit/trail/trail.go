@@ -0,0 +1,199 @@
+// Package trail appends records of every message a node sends to a
+// tamper-evident log, such that what a node sent may be proven after the fact.
+//
+// Records are written as JSON, one per line. Every record holds the hash of
+// the record before it, such that records may not be altered, removed or
+// reordered without breaking the chain from then on. Publishing the head of
+// the chain from time to time, such as to peers or a transparency log, keeps
+// the chain from being rewritten wholesale.
+package trail
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrTampered is returned upon verifying a log whose chain of records is
+// broken.
+var ErrTampered = errors.New("trail: log has been tampered with")
+
+// Record is a record of a message sent to a peer.
+type Record struct {
+	// Sequence numbers records from 1.
+	Sequence uint64 `json:"sequence"`
+	// Time is when the message was sent.
+	Time time.Time `json:"time"`
+	// Recipient is the address of the peer the message was sent to.
+	Recipient string `json:"recipient"`
+	// Opcode is the opcode of the message.
+	Opcode uint32 `json:"opcode"`
+	// Digest is the SHA-256 digest of the message as sent.
+	Digest []byte `json:"digest"`
+	// Signature is the signature of the sender over the body of the message.
+	Signature []byte `json:"signature,omitempty"`
+
+	// Previous is the hash of the record before, or nil for the first record.
+	Previous []byte `json:"previous,omitempty"`
+	// Hash is the hash of all fields of the record above.
+	Hash []byte `json:"hash"`
+}
+
+// hash deterministically hashes all fields of a record except for its own
+// hash.
+func (r Record) hash() []byte {
+	h := sha256.New()
+
+	writeBytes := func(b []byte) {
+		binary.Write(h, binary.BigEndian, uint32(len(b)))
+		h.Write(b)
+	}
+
+	writeBytes(r.Previous)
+	binary.Write(h, binary.BigEndian, r.Sequence)
+	binary.Write(h, binary.BigEndian, r.Time.UnixNano())
+	writeBytes([]byte(r.Recipient))
+	binary.Write(h, binary.BigEndian, r.Opcode)
+	writeBytes(r.Digest)
+	writeBytes(r.Signature)
+
+	return h.Sum(nil)
+}
+
+// Log appends records to a writer. It is safe for concurrent use.
+type Log struct {
+	mutex sync.Mutex
+
+	w    io.Writer
+	file *os.File
+
+	// Sequence and hash of the last record appended.
+	sequence uint64
+	head     []byte
+}
+
+// New creates a log starting a new chain of records on a writer.
+func New(w io.Writer) *Log {
+	return &Log{w: w}
+}
+
+// Open opens a log persisted within a file, creating it should it not exist.
+// Records already within the file are verified, and the chain is carried on
+// from the last of them.
+func Open(path string) (*Log, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, errors.Wrapf(err, "trail: failed to open %s", path)
+	}
+
+	l := &Log{w: file, file: file}
+
+	last, err := verify(file)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	l.sequence, l.head = last.Sequence, last.Hash
+
+	return l, nil
+}
+
+// Append appends a record of a message sent to a recipient, and returns it.
+func (l *Log) Append(at time.Time, recipient string, opcode uint32, digest []byte, signature []byte) (Record, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	record := Record{
+		Sequence:  l.sequence + 1,
+		Time:      at.UTC(),
+		Recipient: recipient,
+		Opcode:    opcode,
+		Digest:    digest,
+		Signature: signature,
+		Previous:  l.head,
+	}
+	record.Hash = record.hash()
+
+	line, err := json.Marshal(record)
+	if err != nil {
+		return Record{}, errors.Wrap(err, "trail: failed to encode record")
+	}
+
+	if _, err := l.w.Write(append(line, '\n')); err != nil {
+		return Record{}, errors.Wrap(err, "trail: failed to append record")
+	}
+
+	l.sequence, l.head = record.Sequence, record.Hash
+
+	return record, nil
+}
+
+// Head returns the sequence number and hash of the last record appended.
+// Publishing it commits to all records up to it.
+func (l *Log) Head() (uint64, []byte) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	return l.sequence, l.head
+}
+
+// Close syncs the log to disk and closes it, should it have been opened from
+// a file.
+func (l *Log) Close() error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.file == nil {
+		return nil
+	}
+
+	if err := l.file.Sync(); err != nil {
+		l.file.Close()
+		return errors.Wrap(err, "trail: failed to sync log")
+	}
+
+	return l.file.Close()
+}
+
+// Verify checks that the chain of records read from r is unbroken, and returns
+// the number of records read. Errors whose cause is ErrTampered are returned
+// should it be broken.
+func Verify(r io.Reader) (int, error) {
+	last, err := verify(r)
+	return int(last.Sequence), err
+}
+
+// verify checks the chain of records read from r, and returns the last record.
+func verify(r io.Reader) (last Record, err error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1024*1024)
+
+	for scanner.Scan() {
+		var record Record
+
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return last, errors.Wrapf(ErrTampered, "record %d is malformed", last.Sequence+1)
+		}
+
+		if record.Sequence != last.Sequence+1 || !bytes.Equal(record.Previous, last.Hash) || !bytes.Equal(record.Hash, record.hash()) {
+			return last, errors.Wrapf(ErrTampered, "chain breaks at record %d", last.Sequence+1)
+		}
+
+		last = record
+	}
+
+	if err := scanner.Err(); err != nil {
+		return last, errors.Wrap(err, "trail: failed to read log")
+	}
+
+	return last, nil
+}
@@ -0,0 +1,76 @@
+package trail
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+func TestChain(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := New(&buf)
+
+	for i := 0; i < 3; i++ {
+		if _, err := l.Append(time.Now(), "tcp://localhost:3000", 1, []byte{byte(i)}, []byte("signature")); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if sequence, head := l.Head(); sequence != 3 || len(head) == 0 {
+		t.Fatalf("expected head to be the third record, got %d", sequence)
+	}
+
+	if count, err := Verify(bytes.NewReader(buf.Bytes())); err != nil || count != 3 {
+		t.Fatalf("expected 3 records to verify, got %d (err: %v)", count, err)
+	}
+
+	lines := strings.SplitAfter(buf.String(), "\n")
+
+	tampered := map[string]string{
+		"altered":   lines[0] + strings.Replace(lines[1], "localhost:3000", "localhost:3001", 1) + lines[2],
+		"removed":   lines[0] + lines[2],
+		"reordered": lines[1] + lines[0] + lines[2],
+	}
+
+	for name, log := range tampered {
+		if _, err := Verify(strings.NewReader(log)); errors.Cause(err) != ErrTampered {
+			t.Fatalf("expected %s records to be detected, got %v", name, err)
+		}
+	}
+}
+
+func TestOpen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "trail")
+
+	l, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first, _ := l.Append(time.Now(), "tcp://localhost:3000", 1, []byte("digest"), nil)
+
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Reopened logs carry on the chain from their last record.
+	l, err = Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	second, err := l.Append(time.Now(), "tcp://localhost:3000", 1, []byte("digest"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if second.Sequence != 2 || !bytes.Equal(second.Previous, first.Hash) {
+		t.Fatalf("expected the chain to be carried on, got record %d", second.Sequence)
+	}
+}
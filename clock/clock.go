@@ -0,0 +1,195 @@
+// Package clock abstracts time away from noise and its plugins, such that
+// multi-node scenarios may be run against a virtual clock which only moves
+// forward when told to, and thus be reproduced exactly.
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock tells the time, and schedules timers and tickers against it.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// After returns a channel which receives the current time once d has passed.
+	After(d time.Duration) <-chan time.Time
+	// Sleep blocks until d has passed.
+	Sleep(d time.Duration)
+	// NewTicker returns a ticker which ticks every d.
+	NewTicker(d time.Duration) Ticker
+}
+
+// Ticker delivers ticks at intervals.
+type Ticker interface {
+	// C returns the channel ticks are delivered on.
+	C() <-chan time.Time
+	// Stop turns off the ticker.
+	Stop()
+}
+
+// Real returns a clock backed by the system clock.
+func Real() Clock {
+	return realClock{}
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+func (realClock) Sleep(d time.Duration)                  { time.Sleep(d) }
+
+func (realClock) NewTicker(d time.Duration) Ticker {
+	return realTicker{time.NewTicker(d)}
+}
+
+type realTicker struct {
+	*time.Ticker
+}
+
+func (t realTicker) C() <-chan time.Time { return t.Ticker.C }
+
+// Virtual is a clock which only moves forward once advanced, firing all
+// timers and tickers due along the way in order.
+type Virtual struct {
+	mutex   sync.Mutex
+	cond    *sync.Cond
+	now     time.Time
+	waiters []*waiter
+}
+
+var _ Clock = (*Virtual)(nil)
+
+// waiter is a timer or ticker scheduled against a virtual clock.
+type waiter struct {
+	clock  *Virtual
+	at     time.Time
+	period time.Duration
+	c      chan time.Time
+}
+
+// NewVirtual returns a virtual clock set to start.
+func NewVirtual(start time.Time) *Virtual {
+	v := &Virtual{now: start}
+	v.cond = sync.NewCond(&v.mutex)
+	return v
+}
+
+// Now returns the current virtual time.
+func (v *Virtual) Now() time.Time {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	return v.now
+}
+
+// After returns a channel which receives the virtual time once the clock has
+// been advanced by d.
+func (v *Virtual) After(d time.Duration) <-chan time.Time {
+	return v.schedule(d, 0).c
+}
+
+// Sleep blocks until the clock has been advanced by d.
+func (v *Virtual) Sleep(d time.Duration) {
+	<-v.After(d)
+}
+
+// NewTicker returns a ticker which ticks every time the clock is advanced
+// by d. As with time.Ticker, ticks are dropped for slow receivers.
+func (v *Virtual) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("clock: non-positive interval for NewTicker")
+	}
+	return v.schedule(d, d)
+}
+
+func (v *Virtual) schedule(d time.Duration, period time.Duration) *waiter {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	w := &waiter{clock: v, at: v.now.Add(d), period: period, c: make(chan time.Time, 1)}
+
+	if d <= 0 && period == 0 {
+		w.c <- v.now
+		return w
+	}
+
+	v.waiters = append(v.waiters, w)
+	v.cond.Broadcast()
+
+	return w
+}
+
+// Advance moves the clock forward by d, firing all timers and tickers due
+// within d in the order they are due.
+func (v *Virtual) Advance(d time.Duration) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	end := v.now.Add(d)
+
+	for {
+		next := -1
+		for i, w := range v.waiters {
+			if !w.at.After(end) && (next < 0 || w.at.Before(v.waiters[next].at)) {
+				next = i
+			}
+		}
+
+		if next < 0 {
+			break
+		}
+
+		w := v.waiters[next]
+		v.now = w.at
+
+		select {
+		case w.c <- v.now:
+		default:
+		}
+
+		if w.period > 0 {
+			w.at = w.at.Add(w.period)
+		} else {
+			v.waiters = append(v.waiters[:next], v.waiters[next+1:]...)
+		}
+	}
+
+	v.now = end
+}
+
+// Waiters returns the number of timers and tickers pending on the clock.
+func (v *Virtual) Waiters() int {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	return len(v.waiters)
+}
+
+// BlockUntil blocks until at least n timers and tickers are pending on the
+// clock, such that a test may wait for goroutines to start sleeping before
+// advancing the clock.
+func (v *Virtual) BlockUntil(n int) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	for len(v.waiters) < n {
+		v.cond.Wait()
+	}
+}
+
+func (w *waiter) C() <-chan time.Time {
+	return w.c
+}
+
+func (w *waiter) Stop() {
+	v := w.clock
+
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	for i, other := range v.waiters {
+		if other == w {
+			v.waiters = append(v.waiters[:i], v.waiters[i+1:]...)
+			return
+		}
+	}
+}
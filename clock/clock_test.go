@@ -0,0 +1,90 @@
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+func TestVirtualAfter(t *testing.T) {
+	t.Parallel()
+
+	start := time.Unix(0, 0)
+	v := NewVirtual(start)
+
+	late := v.After(2 * time.Second)
+	early := v.After(1 * time.Second)
+
+	v.Advance(500 * time.Millisecond)
+
+	select {
+	case <-early:
+		t.Fatal("expected timer to not fire before being due")
+	default:
+	}
+
+	v.Advance(2 * time.Second)
+
+	if at := <-early; !at.Equal(start.Add(1 * time.Second)) {
+		t.Fatalf("expected timer to fire at 1s, got %s", at.Sub(start))
+	}
+
+	if at := <-late; !at.Equal(start.Add(2 * time.Second)) {
+		t.Fatalf("expected timer to fire at 2s, got %s", at.Sub(start))
+	}
+
+	if now := v.Now(); !now.Equal(start.Add(2500 * time.Millisecond)) {
+		t.Fatalf("expected clock to be at 2.5s, got %s", now.Sub(start))
+	}
+
+	if v.Waiters() != 0 {
+		t.Fatalf("expected fired timers to no longer be pending, got %d", v.Waiters())
+	}
+}
+
+func TestVirtualTicker(t *testing.T) {
+	t.Parallel()
+
+	v := NewVirtual(time.Unix(0, 0))
+
+	ticker := v.NewTicker(1 * time.Second)
+
+	for i := 0; i < 3; i++ {
+		v.Advance(1 * time.Second)
+
+		select {
+		case <-ticker.C():
+		default:
+			t.Fatalf("expected tick %d", i)
+		}
+	}
+
+	ticker.Stop()
+	v.Advance(1 * time.Second)
+
+	select {
+	case <-ticker.C():
+		t.Fatal("expected stopped ticker to not tick")
+	default:
+	}
+}
+
+func TestVirtualSleep(t *testing.T) {
+	t.Parallel()
+
+	v := NewVirtual(time.Unix(0, 0))
+
+	done := make(chan struct{})
+	go func() {
+		v.Sleep(1 * time.Minute)
+		close(done)
+	}()
+
+	v.BlockUntil(1)
+	v.Advance(1 * time.Minute)
+
+	select {
+	case <-done:
+	case <-time.After(1 * time.Second):
+		t.Fatal("expected sleep to end once the clock advanced")
+	}
+}
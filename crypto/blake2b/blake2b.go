@@ -1,16 +1,30 @@
 package blake2b
 
 import (
+	"hash"
+	"sync"
+
 	"github.com/perlin-network/noise/crypto"
 
 	blake2blib "github.com/minio/blake2b-simd"
 )
 
+// Size is the size of a BLAKE2 hash in bytes.
+const Size = 32
+
 // Blake2b represents the BLAKE2 cryptographic hash algorithm.
 type Blake2b struct{}
 
 var (
 	_ crypto.HashPolicy = (*Blake2b)(nil)
+
+	// hashers pools BLAKE2 hash states, such that hashing does not allocate
+	// a fresh state on every call.
+	hashers = sync.Pool{
+		New: func() interface{} {
+			return blake2blib.New256()
+		},
+	}
 )
 
 // New returns a BLAKE2 hash policy.
@@ -20,6 +34,22 @@ func New() *Blake2b {
 
 // HashBytes hashes the given bytes using the BLAKE2 hash algorithm.
 func (p *Blake2b) HashBytes(bytes []byte) []byte {
-	result := blake2blib.Sum256(bytes)
-	return result[:]
+	return Sum(make([]byte, 0, Size), bytes)
+}
+
+// Sum appends the BLAKE2 hash of the concatenation of parts to dst using a
+// pooled hash state, and returns the resulting slice. Passing a dst with
+// enough spare capacity avoids allocating altogether.
+func Sum(dst []byte, parts ...[]byte) []byte {
+	h := hashers.Get().(hash.Hash)
+	h.Reset()
+
+	for _, part := range parts {
+		h.Write(part)
+	}
+
+	dst = h.Sum(dst)
+	hashers.Put(h)
+
+	return dst
 }
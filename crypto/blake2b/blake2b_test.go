@@ -25,6 +25,23 @@ func BenchmarkHash(b *testing.B) {
 	}
 }
 
+func BenchmarkSum(b *testing.B) {
+	message := make([]byte, 64)
+	_, err := rand.Read(message)
+	if err != nil {
+		panic(err)
+	}
+
+	digest := make([]byte, 0, Size)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		digest = Sum(digest[:0], message)
+	}
+}
+
 func TestHash(t *testing.T) {
 	t.Parallel()
 	hp := New()
@@ -52,3 +69,19 @@ func TestHashBytes(t *testing.T) {
 		t.Errorf("Equal() n = %v, want %v", n, r)
 	}
 }
+
+func TestSum(t *testing.T) {
+	t.Parallel()
+	hp := New()
+
+	expected := hp.HashBytes([]byte("123"))
+
+	if r := Sum(nil, []byte("1"), []byte("23")); !bytes.Equal(expected, r) {
+		t.Errorf("Sum() = %v, want %v", r, expected)
+	}
+
+	prefix := []byte("prefix")
+	if r := Sum(prefix, []byte("123")); !bytes.Equal(append([]byte("prefix"), expected...), r) {
+		t.Errorf("Sum() expected hash to be appended to dst, got %v", r)
+	}
+}
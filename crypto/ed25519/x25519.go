@@ -0,0 +1,56 @@
+package ed25519
+
+import (
+	"crypto/sha512"
+	"errors"
+
+	"github.com/perlin-network/noise/crypto/ed25519/internal/edwards25519"
+)
+
+// PublicKeyToCurve25519 converts an ed25519 public key into the X25519 public
+// key of the same key pair, such that keys nodes sign with may also be used
+// for Diffie-Hellman key agreement.
+func PublicKeyToCurve25519(publicKey []byte) ([]byte, error) {
+	if len(publicKey) != PublicKeySize {
+		return nil, errors.New("ed25519: bad public key length")
+	}
+
+	var encoded [32]byte
+	copy(encoded[:], publicKey)
+
+	var A edwards25519.ExtendedGroupElement
+	if !A.FromBytes(&encoded) {
+		return nil, errors.New("ed25519: invalid public key")
+	}
+
+	// The Montgomery u-coordinate is (1 + y) / (1 - y), where y = Y / Z.
+	var num, den, u edwards25519.FieldElement
+	edwards25519.FeAdd(&num, &A.Z, &A.Y)
+	edwards25519.FeSub(&den, &A.Z, &A.Y)
+	edwards25519.FeInvert(&den, &den)
+	edwards25519.FeMul(&u, &num, &den)
+
+	var converted [32]byte
+	edwards25519.FeToBytes(&converted, &u)
+
+	return converted[:], nil
+}
+
+// PrivateKeyToCurve25519 converts an ed25519 private key into the X25519
+// private key of the same key pair.
+func PrivateKeyToCurve25519(privateKey []byte) ([]byte, error) {
+	if len(privateKey) != PrivateKeySize {
+		return nil, errors.New("ed25519: bad private key length")
+	}
+
+	digest := sha512.Sum512(privateKey[:32])
+
+	converted := make([]byte, 32)
+	copy(converted, digest[:32])
+
+	converted[0] &= 248
+	converted[31] &= 127
+	converted[31] |= 64
+
+	return converted, nil
+}
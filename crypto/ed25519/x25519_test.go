@@ -0,0 +1,42 @@
+package ed25519
+
+import (
+	"bytes"
+	"testing"
+
+	"golang.org/x/crypto/curve25519"
+)
+
+func TestCurve25519Conversion(t *testing.T) {
+	t.Parallel()
+
+	for i := 0; i < 16; i++ {
+		keys := RandomKeyPair()
+
+		publicKey, err := PublicKeyToCurve25519(keys.PublicKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		privateKey, err := PrivateKeyToCurve25519(keys.PrivateKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var derived, scalar [32]byte
+		copy(scalar[:], privateKey)
+		curve25519.ScalarBaseMult(&derived, &scalar)
+
+		if !bytes.Equal(derived[:], publicKey) {
+			t.Fatalf("expected converted keys to form a key pair, got public key %x for %x", publicKey, derived)
+		}
+	}
+
+	if _, err := PublicKeyToCurve25519(make([]byte, 31)); err == nil {
+		t.Fatal("expected malformed public keys to not convert")
+	}
+
+	if _, err := PrivateKeyToCurve25519(make([]byte, 31)); err == nil {
+		t.Fatal("expected malformed private keys to not convert")
+	}
+}
@@ -0,0 +1,87 @@
+package dht
+
+import (
+	"container/heap"
+
+	"github.com/perlin-network/noise/peer"
+)
+
+// candidate is a peer alongside its XOR distance to a target.
+type candidate struct {
+	id       peer.ID
+	distance peer.ID
+}
+
+// candidateHeap is a max-heap of candidates keyed by their distance to a target,
+// such that the furthest candidate may be evicted in O(log k).
+type candidateHeap []candidate
+
+func (h candidateHeap) Len() int            { return len(h) }
+func (h candidateHeap) Less(i, j int) bool  { return h[j].distance.Less(h[i].distance) }
+func (h candidateHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *candidateHeap) Push(x interface{}) { *h = append(*h, x.(candidate)) }
+func (h *candidateHeap) Pop() interface{} {
+	old := *h
+	c := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return c
+}
+
+// FindClosestPeers returns a list of k(count) peers with smallest XorID distance,
+// excluding ourselves.
+//
+// Given that the target shares a prefix of b bits with ourselves, peers in bucket b
+// are closest to the target, followed by peers in buckets after b, followed by peers
+// in buckets b-1 through 0 in that order. Buckets are visited in said order, and
+// visiting stops once k peers have been selected out of a group of buckets that are
+// strictly closer than all groups that follow.
+func (t *RoutingTable) FindClosestPeers(target peer.ID, count int) (peers []peer.ID) {
+	if len(t.self.Id) != len(target.Id) || count <= 0 {
+		return []peer.ID{}
+	}
+
+	closest := make(candidateHeap, 0, count)
+
+	visit := func(bucket *Bucket) {
+		bucket.mutex.RLock()
+		defer bucket.mutex.RUnlock()
+
+		for e := bucket.Front(); e != nil; e = e.Next() {
+			id := e.Value.(peer.ID)
+			if id.Equals(t.self) {
+				continue
+			}
+
+			distance := id.XorID(target)
+
+			if closest.Len() < count {
+				heap.Push(&closest, candidate{id: id, distance: distance})
+			} else if distance.Less(closest[0].distance) {
+				closest[0] = candidate{id: id, distance: distance}
+				heap.Fix(&closest, 0)
+			}
+		}
+	}
+
+	bucketID := target.XorID(t.self).PrefixLen()
+
+	visit(t.buckets[bucketID])
+
+	if closest.Len() < count {
+		for i := bucketID + 1; i < len(t.buckets); i++ {
+			visit(t.buckets[i])
+		}
+	}
+
+	for i := bucketID - 1; i >= 0 && closest.Len() < count; i-- {
+		visit(t.buckets[i])
+	}
+
+	// Pop candidates furthest first to yield peers sorted by distance.
+	peers = make([]peer.ID, closest.Len())
+	for i := len(peers) - 1; i >= 0; i-- {
+		peers[i] = heap.Pop(&closest).(candidate).id
+	}
+
+	return peers
+}
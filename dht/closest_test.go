@@ -0,0 +1,63 @@
+package dht
+
+import (
+	"bytes"
+	"sort"
+	"testing"
+
+	"github.com/perlin-network/noise/peer"
+)
+
+func TestFindClosestPeersSparse(t *testing.T) {
+	t.Parallel()
+
+	routingTable := CreateRoutingTable(peer.CreateID("self", MustReadRand(32)))
+
+	var all []peer.ID
+	for i := 0; i < 200; i++ {
+		id := peer.CreateID(string(MustReadRand(4)), MustReadRand(32))
+		if routingTable.Update(id) == nil {
+			all = append(all, id)
+		}
+	}
+
+	for i := 0; i < 50; i++ {
+		target := peer.CreateID("target", MustReadRand(32))
+
+		expected := append([]peer.ID(nil), all...)
+		sort.Slice(expected, func(i, j int) bool {
+			return expected[i].XorID(target).Less(expected[j].XorID(target))
+		})
+		expected = expected[:BucketSize]
+
+		found := routingTable.FindClosestPeers(target, BucketSize)
+		if len(found) != len(expected) {
+			t.Fatalf("expected %d closest peers, got %d", len(expected), len(found))
+		}
+
+		for j := range expected {
+			if !bytes.Equal(found[j].Id, expected[j].Id) {
+				t.Fatalf("%d th closest peer is wrong, expected %v, found %v", j, expected[j], found[j])
+			}
+		}
+	}
+}
+
+func BenchmarkFindClosestPeers(b *testing.B) {
+	routingTable := CreateRoutingTable(peer.CreateID("self", MustReadRand(32)))
+	for i := 0; i < 2000; i++ {
+		routingTable.Update(peer.CreateID(string(MustReadRand(4)), MustReadRand(32)))
+	}
+
+	targets := make([]peer.ID, 256)
+	for i := range targets {
+		targets[i] = peer.CreateID("target", MustReadRand(32))
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		routingTable.FindClosestPeers(targets[i%len(targets)], BucketSize)
+	}
+}
@@ -0,0 +1,155 @@
+package dht
+
+import (
+	"net"
+	"net/url"
+	"sync"
+
+	"github.com/perlin-network/noise/peer"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrDiversityLimit is returned when a peer could not be added as too many
+	// peers sharing its address group are already held.
+	ErrDiversityLimit = errors.New("dht: too many peers within the same address group")
+)
+
+// GroupResolver maps a peer address to the group it belongs to for the purpose
+// of limiting how many peers within a single group may be routed to, such as a
+// subnet or an autonomous system. Peers mapped to an empty group are unlimited.
+type GroupResolver func(address string) string
+
+// SubnetGroup groups peers by their /24 IPv4 subnet, or their /48 IPv6 subnet.
+// Loopback addresses and unresolved hostnames are left ungrouped.
+func SubnetGroup(address string) string {
+	host := address
+	if info, err := url.Parse(address); err == nil && len(info.Host) > 0 {
+		host = info.Host
+	}
+
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil || ip.IsLoopback() {
+		return ""
+	}
+
+	if v4 := ip.To4(); v4 != nil {
+		return v4.Mask(net.CIDRMask(24, 32)).String() + "/24"
+	}
+
+	return ip.Mask(net.CIDRMask(48, 128)).String() + "/48"
+}
+
+// WithDiversityLimits limits how many peers sharing an address group may be held
+// per bucket and throughout the whole table, so that an attacker controlling a
+// single host or subnet cannot monopolize the routing table. A limit of zero
+// leaves it unbounded.
+func WithDiversityLimits(perBucket int, perTable int) RoutingTableOption {
+	return func(t *RoutingTable) {
+		t.diversity.perBucket = perBucket
+		t.diversity.perTable = perTable
+	}
+}
+
+// WithGroupResolver sets how peers are grouped for the purpose of enforcing
+// diversity limits (default: SubnetGroup).
+func WithGroupResolver(resolver GroupResolver) RoutingTableOption {
+	return func(t *RoutingTable) {
+		if resolver != nil {
+			t.diversity.resolver = resolver
+		}
+	}
+}
+
+// diversity tracks how many peers are held per address group.
+type diversity struct {
+	sync.Mutex
+
+	resolver  GroupResolver
+	perBucket int
+	perTable  int
+
+	groups map[string]int
+}
+
+func newDiversity() *diversity {
+	return &diversity{
+		resolver: SubnetGroup,
+		groups:   make(map[string]int),
+	}
+}
+
+func (d *diversity) enabled() bool {
+	return d.perBucket > 0 || d.perTable > 0
+}
+
+// allows returns whether or not a peer may be added to a bucket without
+// exceeding diversity limits. Expects the bucket to be locked.
+func (d *diversity) allows(bucket *Bucket, target peer.ID) bool {
+	if !d.enabled() {
+		return true
+	}
+
+	group := d.resolver(target.Address)
+	if len(group) == 0 {
+		return true
+	}
+
+	if d.perBucket > 0 {
+		count := 0
+		for e := bucket.Front(); e != nil; e = e.Next() {
+			if d.resolver(e.Value.(peer.ID).Address) == group {
+				count++
+			}
+		}
+
+		if count >= d.perBucket {
+			return false
+		}
+	}
+
+	if d.perTable > 0 {
+		d.Lock()
+		count := d.groups[group]
+		d.Unlock()
+
+		if count >= d.perTable {
+			return false
+		}
+	}
+
+	return true
+}
+
+// added accounts for a peer having been added to the table.
+func (d *diversity) added(target peer.ID) {
+	if !d.enabled() {
+		return
+	}
+
+	if group := d.resolver(target.Address); len(group) > 0 {
+		d.Lock()
+		d.groups[group]++
+		d.Unlock()
+	}
+}
+
+// removed accounts for a peer having been removed from the table.
+func (d *diversity) removed(target peer.ID) {
+	if !d.enabled() {
+		return
+	}
+
+	if group := d.resolver(target.Address); len(group) > 0 {
+		d.Lock()
+		if d.groups[group]--; d.groups[group] <= 0 {
+			delete(d.groups, group)
+		}
+		d.Unlock()
+	}
+}
@@ -0,0 +1,65 @@
+package dht
+
+import (
+	"testing"
+
+	"github.com/perlin-network/noise/peer"
+)
+
+func TestSubnetGroup(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		address string
+		group   string
+	}{
+		{"tcp://10.0.1.5:3000", "10.0.1.0/24"},
+		{"10.0.1.200:3000", "10.0.1.0/24"},
+		{"kcp://[2001:db8:1:2::1]:3000", "2001:db8:1::/48"},
+		{"tcp://127.0.0.1:3000", ""},
+		{"tcp://localhost:3000", ""},
+	}
+
+	for _, tt := range testCases {
+		if group := SubnetGroup(tt.address); group != tt.group {
+			t.Fatalf("SubnetGroup(%q) = %q, expected %q", tt.address, group, tt.group)
+		}
+	}
+}
+
+func TestDiversityLimits(t *testing.T) {
+	t.Parallel()
+
+	self := peer.CreateID("tcp://192.168.0.1:3000", MustReadRand(32))
+	routingTable := CreateRoutingTable(self, WithDiversityLimits(0, 2))
+
+	var added, rejected int
+	for i := 0; i < 4; i++ {
+		id := peer.CreateID("tcp://10.0.0.1:3000", MustReadRand(32))
+
+		switch err := routingTable.Update(id); err {
+		case nil:
+			added++
+		case ErrDiversityLimit:
+			rejected++
+		default:
+			t.Fatalf("Update() returned unexpected error %v", err)
+		}
+	}
+
+	if added != 2 || rejected != 2 {
+		t.Fatalf("expected 2 peers to be added and 2 rejected, got %d added and %d rejected", added, rejected)
+	}
+
+	// Removing a peer should free up room within its group.
+	routingTable.RemovePeer(routingTable.GetPeers()[0])
+
+	if err := routingTable.Update(peer.CreateID("tcp://10.0.0.2:3000", MustReadRand(32))); err != nil {
+		t.Fatalf("Update() expected no error after a peer was removed, got %v", err)
+	}
+
+	// Peers from other groups are unaffected.
+	if err := routingTable.Update(peer.CreateID("tcp://10.0.1.1:3000", MustReadRand(32))); err != nil {
+		t.Fatalf("Update() expected no error for a peer within a different group, got %v", err)
+	}
+}
@@ -0,0 +1,70 @@
+package dht
+
+import (
+	"github.com/perlin-network/noise/peer"
+)
+
+// Cursor marks a position within the routing table to resume paginating over
+// peers from. The zero value marks the start of the routing table.
+type Cursor struct {
+	Bucket int
+	Offset int
+}
+
+// snapshot copies the peers held by a bucket, such that they may be iterated
+// over without holding the buckets lock.
+func (b *Bucket) snapshot(self peer.ID) (peers []peer.ID) {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	peers = make([]peer.ID, 0, b.Len())
+	for e := b.Front(); e != nil; e = e.Next() {
+		if id := e.Value.(peer.ID); !id.Equals(self) {
+			peers = append(peers, id)
+		}
+	}
+	return
+}
+
+// ForEach calls fn for every peer within the routing table (excluding itself)
+// until fn returns false. No locks are held while fn is called, so fn may
+// freely modify the routing table.
+func (t *RoutingTable) ForEach(fn func(id peer.ID) bool) {
+	for _, bucket := range t.buckets {
+		for _, id := range bucket.snapshot(t.self) {
+			if !fn(id) {
+				return
+			}
+		}
+	}
+}
+
+// Page returns at most limit peers within the routing table (excluding itself)
+// starting from a cursor, alongside the cursor to retrieve the next page with.
+// The returned cursor is nil once all peers have been paged through.
+//
+// Pages reflect the routing table as it is when each page is retrieved; peers
+// added or removed in between pages may be skipped or repeated.
+func (t *RoutingTable) Page(cursor Cursor, limit int) (peers []peer.ID, next *Cursor) {
+	if limit <= 0 {
+		return nil, &cursor
+	}
+
+	for i := cursor.Bucket; i < len(t.buckets); i++ {
+		snapshot := t.buckets[i].snapshot(t.self)
+
+		offset := 0
+		if i == cursor.Bucket {
+			offset = cursor.Offset
+		}
+
+		for ; offset < len(snapshot); offset++ {
+			if len(peers) == limit {
+				return peers, &Cursor{Bucket: i, Offset: offset}
+			}
+			peers = append(peers, snapshot[offset])
+		}
+	}
+
+	return peers, nil
+}
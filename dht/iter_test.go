@@ -0,0 +1,66 @@
+package dht
+
+import (
+	"testing"
+
+	"github.com/perlin-network/noise/peer"
+)
+
+func TestForEach(t *testing.T) {
+	t.Parallel()
+
+	routingTable := CreateRoutingTable(id1)
+	routingTable.Update(id2)
+	routingTable.Update(id3)
+
+	count := 0
+	routingTable.ForEach(func(id peer.ID) bool {
+		if id.Equals(id1) {
+			t.Fatalf("ForEach() should not visit ourselves")
+		}
+
+		// Modifying the routing table within the callback should not deadlock.
+		routingTable.RemovePeer(id)
+
+		count++
+		return true
+	})
+
+	if count != 2 || len(routingTable.GetPeers()) != 0 {
+		t.Fatalf("expected ForEach() to visit and remove 2 peers, visited %d", count)
+	}
+}
+
+func TestPage(t *testing.T) {
+	t.Parallel()
+
+	routingTable := CreateRoutingTable(peer.CreateID("self", MustReadRand(32)))
+	for i := 0; i < 50; i++ {
+		routingTable.Update(peer.CreateID(string(MustReadRand(4)), MustReadRand(32)))
+	}
+
+	total := len(routingTable.GetPeers())
+	seen := make(map[string]struct{})
+
+	cursor := &Cursor{}
+	for pages := 0; cursor != nil; pages++ {
+		if pages > total {
+			t.Fatalf("Page() did not terminate")
+		}
+
+		var peers []peer.ID
+		peers, cursor = routingTable.Page(*cursor, 7)
+
+		if len(peers) > 7 {
+			t.Fatalf("Page() returned %d peers, expected at most 7", len(peers))
+		}
+
+		for _, id := range peers {
+			seen[id.PublicKeyHex()] = struct{}{}
+		}
+	}
+
+	if len(seen) != total {
+		t.Fatalf("expected pagination to visit all %d peers, visited %d", total, len(seen))
+	}
+}
@@ -2,45 +2,208 @@ package dht
 
 import (
 	"container/list"
-	"sort"
+	"crypto/rand"
+	"io"
 	"sync"
+	"time"
 
+	"github.com/perlin-network/noise/clock"
 	"github.com/perlin-network/noise/peer"
+
+	"github.com/pkg/errors"
 )
 
 // BucketSize defines the NodeID, Key, and routing table data structures.
 const BucketSize = 16
 
+// ReplacementCacheSize is the maximum number of candidates cached per bucket
+// to replace peers evicted from a full bucket.
+const ReplacementCacheSize = BucketSize
+
+var (
+	// ErrBucketFull is returned when a peer could not be added to a full bucket.
+	ErrBucketFull = errors.New("dht: bucket is full")
+)
+
 // RoutingTable contains one bucket list for lookups.
 type RoutingTable struct {
 	// Current node's ID.
 	self peer.ID
 
+	// Maximum number of peers held per bucket.
+	bucketSize int
+
+	// Number of closest peers records are replicated to.
+	replication int
+
+	// Limits on the number of peers held per address group.
+	diversity *diversity
+
+	// Peers closest to ourselves.
+	siblings *siblings
+
+	// Clock peers are timestamped by, and source of randomness of refresh IDs.
+	clock  clock.Clock
+	random io.Reader
+
 	buckets []*Bucket
 }
 
+// RoutingTableOption configures a routing table.
+type RoutingTableOption func(*RoutingTable)
+
+// WithBucketSize sets the maximum number of peers held per bucket (default: BucketSize).
+func WithBucketSize(size int) RoutingTableOption {
+	return func(t *RoutingTable) {
+		if size > 0 {
+			t.bucketSize = size
+		}
+	}
+}
+
+// WithReplication sets the number of closest peers records are replicated to,
+// independently of the bucket size (default: the bucket size).
+func WithReplication(k int) RoutingTableOption {
+	return func(t *RoutingTable) {
+		if k > 0 {
+			t.replication = k
+		}
+	}
+}
+
+// WithClock sets the clock peers and buckets are timestamped by, such that
+// staleness may be simulated (default: clock.Real()).
+func WithClock(c clock.Clock) RoutingTableOption {
+	return func(t *RoutingTable) {
+		if c != nil {
+			t.clock = c
+		}
+	}
+}
+
+// WithRandom sets the source of randomness IDs to refresh buckets with are
+// drawn from, such that refreshes may be reproduced (default: crypto/rand).
+func WithRandom(random io.Reader) RoutingTableOption {
+	return func(t *RoutingTable) {
+		if random != nil {
+			t.random = random
+		}
+	}
+}
+
 // Bucket holds a list of contacts of this node.
 type Bucket struct {
 	*list.List
 	mutex *sync.RWMutex
+
+	// lastUpdated is the last time a peer within the buckets range was seen.
+	lastUpdated time.Time
+
+	// lastSeen is the last time each peer held by the bucket was seen.
+	lastSeen map[string]time.Time
+
+	// Number of peers which have been added to and removed from the bucket.
+	added, removed uint64
+
+	// replacements caches candidates which could not be added while the bucket
+	// was full, freshest first.
+	replacements *list.List
+
+	clock clock.Clock
 }
 
 // NewBucket is a Factory method of Bucket, contains an empty list.
 func NewBucket() *Bucket {
+	return newBucket(clock.Real())
+}
+
+// newBucket returns an empty bucket timestamped by a clock.
+func newBucket(c clock.Clock) *Bucket {
 	return &Bucket{
-		List:  list.New(),
-		mutex: &sync.RWMutex{},
+		List:         list.New(),
+		mutex:        &sync.RWMutex{},
+		lastUpdated:  c.Now(),
+		lastSeen:     make(map[string]time.Time),
+		replacements: list.New(),
+		clock:        c,
+	}
+}
+
+// Peers returns the peers held by the bucket, most recently seen first.
+func (b *Bucket) Peers() (peers []peer.ID) {
+	b.mutex.RLock()
+	for e := b.Front(); e != nil; e = e.Next() {
+		peers = append(peers, e.Value.(peer.ID))
+	}
+	b.mutex.RUnlock()
+	return
+}
+
+// Replacements returns the candidates cached to replace peers evicted from
+// the bucket, freshest first.
+func (b *Bucket) Replacements() (peers []peer.ID) {
+	b.mutex.RLock()
+	for e := b.replacements.Front(); e != nil; e = e.Next() {
+		peers = append(peers, e.Value.(peer.ID))
 	}
+	b.mutex.RUnlock()
+	return
+}
+
+// cacheReplacement moves a candidate to the front of the replacement cache,
+// evicting the stalest candidate should the cache be full. Expects the bucket
+// to be locked.
+func (b *Bucket) cacheReplacement(target peer.ID) {
+	for e := b.replacements.Front(); e != nil; e = e.Next() {
+		if e.Value.(peer.ID).Equals(target) {
+			b.replacements.MoveToFront(e)
+			return
+		}
+	}
+
+	b.replacements.PushFront(target)
+
+	if b.replacements.Len() > ReplacementCacheSize {
+		b.replacements.Remove(b.replacements.Back())
+	}
+}
+
+// Touch marks the bucket as having been recently updated.
+func (b *Bucket) Touch() {
+	b.mutex.Lock()
+	b.lastUpdated = b.clock.Now()
+	b.mutex.Unlock()
+}
+
+// LastUpdated returns the last time a peer within the buckets range was seen,
+// or the bucket was otherwise touched.
+func (b *Bucket) LastUpdated() time.Time {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+	return b.lastUpdated
 }
 
 // CreateRoutingTable is a Factory method of RoutingTable containing empty buckets.
-func CreateRoutingTable(id peer.ID) *RoutingTable {
+func CreateRoutingTable(id peer.ID, opts ...RoutingTableOption) *RoutingTable {
 	table := &RoutingTable{
-		self:    id,
-		buckets: make([]*Bucket, len(id.Id)*8),
+		self:       id,
+		bucketSize: BucketSize,
+		diversity:  newDiversity(),
+		clock:      clock.Real(),
+		random:     rand.Reader,
+		buckets:    make([]*Bucket, len(id.Id)*8),
+	}
+
+	for _, opt := range opts {
+		opt(table)
+	}
+
+	if table.siblings == nil {
+		table.siblings = newSiblings(id, SiblingListFactor*table.Replication())
 	}
+
 	for i := 0; i < len(id.Id)*8; i++ {
-		table.buckets[i] = NewBucket()
+		table.buckets[i] = newBucket(table.clock)
 	}
 
 	table.Update(id)
@@ -48,13 +211,52 @@ func CreateRoutingTable(id peer.ID) *RoutingTable {
 	return table
 }
 
+// promoteReplacement moves the freshest cached candidate which does not exceed
+// diversity limits into a bucket. Expects the bucket to be locked.
+func (t *RoutingTable) promoteReplacement(bucket *Bucket) {
+	if bucket.Len() >= t.bucketSize {
+		return
+	}
+
+	for e := bucket.replacements.Front(); e != nil; e = e.Next() {
+		candidate := e.Value.(peer.ID)
+
+		if t.diversity.allows(bucket, candidate) {
+			bucket.replacements.Remove(e)
+			bucket.PushBack(candidate)
+			bucket.lastSeen[candidate.PublicKeyHex()] = t.clock.Now()
+			bucket.added++
+			t.diversity.added(candidate)
+			t.siblings.add(candidate)
+			return
+		}
+	}
+}
+
 // Self returns the ID of the node hosting the current routing table instance.
 func (t *RoutingTable) Self() peer.ID {
 	return t.self
 }
 
+// BucketSize returns the maximum number of peers held per bucket.
+func (t *RoutingTable) BucketSize() int {
+	return t.bucketSize
+}
+
+// Replication returns the number of closest peers records are replicated to.
+func (t *RoutingTable) Replication() int {
+	if t.replication > 0 {
+		return t.replication
+	}
+	return t.bucketSize
+}
+
 // Update moves a peer to the front of a bucket in the routing table.
-func (t *RoutingTable) Update(target peer.ID) {
+//
+// Should the bucket be full, the peer is instead cached as a replacement for
+// when a peer is evicted from the bucket, and ErrBucketFull is returned.
+// Should adding the peer exceed diversity limits, ErrDiversityLimit is returned.
+func (t *RoutingTable) Update(target peer.ID) (err error) {
 	if len(t.self.Id) != len(target.Id) {
 		return
 	}
@@ -76,62 +278,57 @@ func (t *RoutingTable) Update(target peer.ID) {
 
 	if element == nil {
 		// Populate bucket if its not full.
-		if bucket.Len() <= BucketSize {
+		if bucket.Len() >= t.bucketSize {
+			bucket.cacheReplacement(target)
+			err = ErrBucketFull
+		} else if !t.diversity.allows(bucket, target) {
+			err = ErrDiversityLimit
+		} else {
 			bucket.PushFront(target)
+			bucket.added++
+			t.diversity.added(target)
+			t.siblings.add(target)
 		}
 	} else {
 		bucket.MoveToFront(element)
 	}
 
+	if err == nil {
+		bucket.lastSeen[target.PublicKeyHex()] = t.clock.Now()
+	}
+
+	bucket.lastUpdated = t.clock.Now()
+
 	bucket.mutex.Unlock()
+
+	return
 }
 
 // GetPeers returns a randomly-ordered, unique list of all peers within the routing network (excluding itself).
 func (t *RoutingTable) GetPeers() (peers []peer.ID) {
-	visited := make(map[string]struct{})
-	visited[t.self.PublicKeyHex()] = struct{}{}
-
-	for _, bucket := range t.buckets {
-		bucket.mutex.RLock()
-
-		for e := bucket.Front(); e != nil; e = e.Next() {
-			id := e.Value.(peer.ID)
-			if _, seen := visited[id.PublicKeyHex()]; !seen {
-				peers = append(peers, id)
-				visited[id.PublicKeyHex()] = struct{}{}
-			}
-		}
-
-		bucket.mutex.RUnlock()
-	}
-
+	t.ForEach(func(id peer.ID) bool {
+		peers = append(peers, id)
+		return true
+	})
 	return
 }
 
 // GetPeerAddresses returns a unique list of all peer addresses within the routing network.
 func (t *RoutingTable) GetPeerAddresses() (peers []string) {
-	visited := make(map[string]struct{})
-	visited[t.self.PublicKeyHex()] = struct{}{}
-
-	for _, bucket := range t.buckets {
-		bucket.mutex.RLock()
-
-		for e := bucket.Front(); e != nil; e = e.Next() {
-			id := e.Value.(peer.ID)
-			if _, seen := visited[id.PublicKeyHex()]; !seen {
-				peers = append(peers, id.Address)
-				visited[id.PublicKeyHex()] = struct{}{}
-			}
-		}
-
-		bucket.mutex.RUnlock()
-	}
-
+	t.ForEach(func(id peer.ID) bool {
+		peers = append(peers, id.Address)
+		return true
+	})
 	return
 }
 
 // RemovePeer removes a peer from the routing table with O(bucket_size) time complexity.
+// The freshest candidate cached as a replacement takes the removed peer's place.
 func (t *RoutingTable) RemovePeer(target peer.ID) bool {
+	if len(t.self.Id) != len(target.Id) {
+		return false
+	}
+
 	bucketID := target.XorID(t.self).PrefixLen()
 	bucket := t.Bucket(bucketID)
 
@@ -140,12 +337,29 @@ func (t *RoutingTable) RemovePeer(target peer.ID) bool {
 	for e := bucket.Front(); e != nil; e = e.Next() {
 		if e.Value.(peer.ID).Equals(target) {
 			bucket.Remove(e)
+			delete(bucket.lastSeen, target.PublicKeyHex())
+			bucket.removed++
+			t.diversity.removed(target)
+			t.promoteReplacement(bucket)
 
 			bucket.mutex.Unlock()
+
+			if t.siblings.remove(target) {
+				t.refillSiblings()
+			}
+
 			return true
 		}
 	}
 
+	// Peer is no longer a viable replacement either.
+	for e := bucket.replacements.Front(); e != nil; e = e.Next() {
+		if e.Value.(peer.ID).Equals(target) {
+			bucket.replacements.Remove(e)
+			break
+		}
+	}
+
 	bucket.mutex.Unlock()
 
 	return false
@@ -153,6 +367,10 @@ func (t *RoutingTable) RemovePeer(target peer.ID) bool {
 
 // PeerExists checks if a peer exists in the routing table with O(bucket_size) time complexity.
 func (t *RoutingTable) PeerExists(target peer.ID) bool {
+	if len(t.self.Id) != len(target.Id) {
+		return false
+	}
+
 	bucketID := target.XorID(t.self).PrefixLen()
 	bucket := t.Bucket(bucketID)
 
@@ -169,61 +387,115 @@ func (t *RoutingTable) PeerExists(target peer.ID) bool {
 	return false
 }
 
-// FindClosestPeers returns a list of k(count) peers with smallest XorID distance.
-func (t *RoutingTable) FindClosestPeers(target peer.ID, count int) (peers []peer.ID) {
+// GetPeer returns the peer ID held within the routing table which is equal to
+// target, alongside whether or not it exists.
+func (t *RoutingTable) GetPeer(target peer.ID) (peer.ID, bool) {
 	if len(t.self.Id) != len(target.Id) {
-		return []peer.ID{}
+		return peer.ID{}, false
 	}
 
 	bucketID := target.XorID(t.self).PrefixLen()
 	bucket := t.Bucket(bucketID)
 
 	bucket.mutex.RLock()
+	defer bucket.mutex.RUnlock()
 
 	for e := bucket.Front(); e != nil; e = e.Next() {
-		peers = append(peers, e.Value.(peer.ID))
+		if id := e.Value.(peer.ID); id.Equals(target) {
+			return id, true
+		}
 	}
 
-	bucket.mutex.RUnlock()
+	return peer.ID{}, false
+}
 
-	for i := 1; len(peers) < count && (bucketID-i >= 0 || bucketID+i < len(t.self.Id)*8); i++ {
-		if bucketID-i >= 0 {
-			other := t.Bucket(bucketID - i)
-			other.mutex.RLock()
-			for e := other.Front(); e != nil; e = e.Next() {
-				peers = append(peers, e.Value.(peer.ID))
-			}
-			other.mutex.RUnlock()
+// Bucket returns a specific Bucket by ID.
+func (t *RoutingTable) Bucket(id int) *Bucket {
+	if id >= 0 && id < len(t.buckets) {
+		return t.buckets[id]
+	}
+	return nil
+}
+
+// StaleBuckets returns the IDs of buckets which have not been updated within a
+// given threshold. Only buckets up to the furthest non-empty bucket are
+// considered, as buckets beyond are all but guaranteed to remain empty.
+func (t *RoutingTable) StaleBuckets(threshold time.Duration) (ids []int) {
+	deepest := 0
+
+	for i, bucket := range t.buckets {
+		bucket.mutex.RLock()
+		if bucket.Len() > 0 {
+			deepest = i
 		}
+		bucket.mutex.RUnlock()
+	}
 
-		if bucketID+i < len(t.self.Id)*8 {
-			other := t.Bucket(bucketID + i)
-			other.mutex.RLock()
-			for e := other.Front(); e != nil; e = e.Next() {
-				peers = append(peers, e.Value.(peer.ID))
-			}
-			other.mutex.RUnlock()
+	cutoff := t.clock.Now().Add(-threshold)
+
+	for i := 0; i <= deepest && i < len(t.buckets); i++ {
+		if t.buckets[i].LastUpdated().Before(cutoff) {
+			ids = append(ids, i)
 		}
 	}
 
-	// Sort peers by XorID distance.
-	sort.Slice(peers, func(i, j int) bool {
-		left := peers[i].XorID(target)
-		right := peers[j].XorID(target)
-		return left.Less(right)
-	})
+	return
+}
+
+// RandomIDInBucket returns a random ID which falls within the range of a
+// specific bucket, for the purpose of refreshing the bucket via a lookup.
+func (t *RoutingTable) RandomIDInBucket(id int) peer.ID {
+	result := make([]byte, len(t.self.Id))
+	if _, err := io.ReadFull(t.random, result); err != nil {
+		panic(err)
+	}
+
+	// Share the first #id bits with our own ID, and differ on the bit after.
+	for i := 0; i < len(result)*8; i++ {
+		mask := byte(0x80) >> uint(i%8)
 
-	if len(peers) > count {
-		peers = peers[:count]
+		switch {
+		case i < id:
+			result[i/8] = result[i/8]&^mask | t.self.Id[i/8]&mask
+		case i == id:
+			result[i/8] = result[i/8]&^mask | ^t.self.Id[i/8]&mask
+		}
 	}
 
-	return peers
+	return peer.ID{Id: result}
 }
 
-// Bucket returns a specific Bucket by ID.
-func (t *RoutingTable) Bucket(id int) *Bucket {
-	if id >= 0 && id < len(t.buckets) {
-		return t.buckets[id]
+// LastSeen returns the last time a peer held by the routing table was seen.
+func (t *RoutingTable) LastSeen(target peer.ID) (time.Time, bool) {
+	bucket := t.Bucket(target.XorID(t.self).PrefixLen())
+	if bucket == nil {
+		return time.Time{}, false
 	}
-	return nil
+
+	bucket.mutex.RLock()
+	defer bucket.mutex.RUnlock()
+
+	lastSeen, exists := bucket.lastSeen[target.PublicKeyHex()]
+	return lastSeen, exists
+}
+
+// StalePeers returns all peers within the routing table (excluding itself)
+// which have not been seen within a given TTL.
+func (t *RoutingTable) StalePeers(ttl time.Duration) (peers []peer.ID) {
+	cutoff := t.clock.Now().Add(-ttl)
+
+	for _, bucket := range t.buckets {
+		bucket.mutex.RLock()
+
+		for e := bucket.Front(); e != nil; e = e.Next() {
+			id := e.Value.(peer.ID)
+			if !id.Equals(t.self) && bucket.lastSeen[id.PublicKeyHex()].Before(cutoff) {
+				peers = append(peers, id)
+			}
+		}
+
+		bucket.mutex.RUnlock()
+	}
+
+	return
 }
@@ -9,8 +9,10 @@ import (
 	"sync"
 	"sync/atomic"
 	"testing"
+	"time"
 	"unsafe"
 
+	"github.com/perlin-network/noise/clock"
 	"github.com/perlin-network/noise/crypto/blake2b"
 	"github.com/perlin-network/noise/peer"
 )
@@ -59,6 +61,20 @@ func TestSelf(t *testing.T) {
 	}
 }
 
+func TestReplication(t *testing.T) {
+	t.Parallel()
+
+	routingTable := CreateRoutingTable(id1, WithBucketSize(8))
+	if routingTable.Replication() != 8 {
+		t.Fatalf("expected replication to default to the bucket size, got %d", routingTable.Replication())
+	}
+
+	routingTable = CreateRoutingTable(id1, WithBucketSize(8), WithReplication(20))
+	if routingTable.BucketSize() != 8 || routingTable.Replication() != 20 {
+		t.Fatalf("expected bucket size 8 and replication 20, got %d and %d", routingTable.BucketSize(), routingTable.Replication())
+	}
+}
+
 func TestPeerExists(t *testing.T) {
 	t.Parallel()
 
@@ -73,6 +89,22 @@ func TestPeerExists(t *testing.T) {
 		t.Fatal("peerexists() targeting others failed")
 	}
 }
+
+func TestGetPeer(t *testing.T) {
+	t.Parallel()
+
+	routingTable := CreateRoutingTable(id1)
+	routingTable.Update(id2)
+
+	found, exists := routingTable.GetPeer(peer.CreateID("", id2.PublicKey))
+	if !exists || found.Address != id2.Address {
+		t.Fatalf("GetPeer() expected to resolve address %s, got %s", id2.Address, found.Address)
+	}
+
+	if _, exists := routingTable.GetPeer(id3); exists {
+		t.Fatal("GetPeer() expected peer not within the routing table to not exist")
+	}
+}
 func TestGetPeerAddresses(t *testing.T) {
 	t.Parallel()
 
@@ -147,7 +179,7 @@ func TestFindClosestPeers(t *testing.T) {
 	if len(testee) != 3 {
 		t.Fatalf("findclosestpeers() error, size of return should be 3, but found %d", len(testee))
 	}
-	answerKeys := []int{5, 2, 3}
+	answerKeys := []int{5, 1, 2}
 	for i := 0; i <= 2; i++ {
 		_answer := nodes[answerKeys[i]]
 		if testee[i].Address != _answer.Address || !bytes.Equal(testee[i].Id, _answer.Id) {
@@ -162,7 +194,7 @@ func TestFindClosestPeers(t *testing.T) {
 	if len(testee) != 2 {
 		t.Fatalf("findclosestpeers() error, size of return should be 2, but found %d", len(testee))
 	}
-	answerKeys = []int{4, 2}
+	answerKeys = []int{4, 1}
 	for i := 0; i <= 1; i++ {
 		_answer := nodes[answerKeys[i]]
 		if testee[i].Address != _answer.Address || !bytes.Equal(testee[i].Id, _answer.Id) {
@@ -234,3 +266,162 @@ func TestRoutingTable(t *testing.T) {
 
 	wg.Wait()
 }
+
+func TestRandomIDInBucket(t *testing.T) {
+	t.Parallel()
+
+	routingTable := CreateRoutingTable(id1)
+
+	for _, bucketID := range []int{0, 1, 7, 8, 100, len(id1.Id)*8 - 1} {
+		id := routingTable.RandomIDInBucket(bucketID)
+		if prefixLen := id.XorID(id1).PrefixLen(); prefixLen != bucketID {
+			t.Fatalf("RandomIDInBucket(%d) returned an ID within bucket %d", bucketID, prefixLen)
+		}
+	}
+}
+
+func TestRandomIDInBucketDeterministic(t *testing.T) {
+	t.Parallel()
+
+	seed := bytes.Repeat([]byte{0xAB}, len(id1.Id))
+
+	a := CreateRoutingTable(id1, WithRandom(bytes.NewReader(seed))).RandomIDInBucket(8)
+	b := CreateRoutingTable(id1, WithRandom(bytes.NewReader(seed))).RandomIDInBucket(8)
+
+	if !a.Equals(b) {
+		t.Fatalf("RandomIDInBucket() expected IDs drawn from the same source to be equal")
+	}
+}
+
+func TestStaleBuckets(t *testing.T) {
+	t.Parallel()
+
+	routingTable := CreateRoutingTable(id1)
+	routingTable.Update(id2)
+
+	if stale := routingTable.StaleBuckets(time.Hour); len(stale) != 0 {
+		t.Fatalf("expected no stale buckets, got %v", stale)
+	}
+
+	stale := routingTable.StaleBuckets(0)
+	if len(stale) != len(id1.Id)*8 {
+		t.Fatalf("expected all buckets up to the furthest non-empty bucket to be stale, got %d", len(stale))
+	}
+
+	bucketID := id2.XorID(id1).PrefixLen()
+	routingTable.Bucket(bucketID).Touch()
+
+	for _, id := range routingTable.StaleBuckets(10 * time.Millisecond) {
+		if id == bucketID {
+			t.Fatalf("expected touched bucket %d to not be stale", bucketID)
+		}
+	}
+}
+
+func TestReplacementCache(t *testing.T) {
+	t.Parallel()
+
+	self := peer.CreateID("self", MustReadRand(32))
+	routingTable := CreateRoutingTable(self)
+
+	// Generate peers which all fall within the same bucket.
+	var peers []peer.ID
+	for len(peers) < BucketSize+2 {
+		id := peer.CreateID(hex.EncodeToString(MustReadRand(4)), MustReadRand(32))
+		if id.XorID(self).PrefixLen() == 0 {
+			peers = append(peers, id)
+		}
+	}
+
+	for i, id := range peers {
+		err := routingTable.Update(id)
+		if i < BucketSize && err != nil {
+			t.Fatalf("Update() expected no error for peer %d, got %v", i, err)
+		}
+		if i >= BucketSize && err != ErrBucketFull {
+			t.Fatalf("Update() expected ErrBucketFull for peer %d, got %v", i, err)
+		}
+	}
+
+	bucket := routingTable.Bucket(0)
+	if bucket.Len() != BucketSize {
+		t.Fatalf("expected bucket to hold %d peers, got %d", BucketSize, bucket.Len())
+	}
+
+	replacements := bucket.Replacements()
+	if len(replacements) != 2 || !replacements[0].Equals(peers[BucketSize+1]) {
+		t.Fatalf("expected freshest candidate to be at the front of the replacement cache")
+	}
+
+	// Evicting a peer should promote the freshest candidate.
+	routingTable.RemovePeer(peers[0])
+
+	if !routingTable.PeerExists(peers[BucketSize+1]) {
+		t.Fatalf("expected freshest candidate to be promoted into the bucket")
+	}
+	if len(bucket.Replacements()) != 1 {
+		t.Fatalf("expected promoted candidate to be removed from the replacement cache")
+	}
+
+	// Removing a cached candidate should drop it from the replacement cache.
+	routingTable.RemovePeer(peers[BucketSize])
+
+	if len(bucket.Replacements()) != 0 {
+		t.Fatalf("expected removed candidate to be dropped from the replacement cache")
+	}
+}
+
+func TestStalePeers(t *testing.T) {
+	t.Parallel()
+
+	routingTable := CreateRoutingTable(id1)
+	routingTable.Update(id2)
+
+	if _, exists := routingTable.LastSeen(id3); exists {
+		t.Fatalf("LastSeen() expected peer not within the table to not have been seen")
+	}
+
+	lastSeen, exists := routingTable.LastSeen(id2)
+	if !exists || time.Since(lastSeen) > time.Second {
+		t.Fatalf("LastSeen() expected peer to have just been seen")
+	}
+
+	if stale := routingTable.StalePeers(time.Hour); len(stale) != 0 {
+		t.Fatalf("expected no stale peers, got %d", len(stale))
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	routingTable.Update(id3)
+
+	stale := routingTable.StalePeers(5 * time.Millisecond)
+	if len(stale) != 1 || !stale[0].Equals(id2) {
+		t.Fatalf("expected only peer 2 to be stale, got %d stale peers", len(stale))
+	}
+}
+
+func TestStaleVirtualClock(t *testing.T) {
+	t.Parallel()
+
+	c := clock.NewVirtual(time.Unix(0, 0))
+
+	routingTable := CreateRoutingTable(id1, WithClock(c))
+	routingTable.Update(id2)
+
+	if stale := routingTable.StalePeers(time.Minute); len(stale) != 0 {
+		t.Fatalf("expected no stale peers, got %d", len(stale))
+	}
+
+	if stale := routingTable.StaleBuckets(time.Minute); len(stale) != 0 {
+		t.Fatalf("expected no stale buckets, got %v", stale)
+	}
+
+	c.Advance(time.Hour)
+
+	if stale := routingTable.StalePeers(time.Minute); len(stale) != 1 || !stale[0].Equals(id2) {
+		t.Fatalf("expected peer 2 to be stale once the clock advanced, got %d stale peers", len(stale))
+	}
+
+	if stale := routingTable.StaleBuckets(time.Minute); len(stale) == 0 {
+		t.Fatalf("expected buckets to be stale once the clock advanced")
+	}
+}
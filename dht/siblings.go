@@ -0,0 +1,156 @@
+package dht
+
+import (
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/perlin-network/noise/peer"
+)
+
+// SiblingListFactor is how many times larger the sibling list is than the
+// replication parameter by default, as recommended by S/Kademlia.
+const SiblingListFactor = 5
+
+// siblings is the sibling list of a routing table: the peers closest to
+// ourselves, sorted by their XOR distance to ourselves.
+type siblings struct {
+	mutex sync.RWMutex
+
+	self  peer.ID
+	size  int
+	peers []peer.ID
+}
+
+func newSiblings(self peer.ID, size int) *siblings {
+	return &siblings{self: self, size: size}
+}
+
+// add inserts a peer into the sibling list, should it be closer to ourselves
+// than the furthest sibling or should the sibling list not be full.
+func (s *siblings) add(id peer.ID) {
+	if id.Equals(s.self) {
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	distance := id.XorID(s.self)
+	i := sort.Search(len(s.peers), func(i int) bool {
+		return !s.peers[i].XorID(s.self).Less(distance)
+	})
+
+	if i < len(s.peers) && s.peers[i].Equals(id) {
+		return
+	}
+
+	if i >= s.size {
+		return
+	}
+
+	s.peers = append(s.peers, peer.ID{})
+	copy(s.peers[i+1:], s.peers[i:])
+	s.peers[i] = id
+
+	if len(s.peers) > s.size {
+		s.peers = s.peers[:s.size]
+	}
+}
+
+// remove evicts a peer from the sibling list, and returns whether or not it was
+// a sibling.
+func (s *siblings) remove(id peer.ID) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for i, sibling := range s.peers {
+		if sibling.Equals(id) {
+			s.peers = append(s.peers[:i], s.peers[i+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
+
+// list returns a copy of the sibling list.
+func (s *siblings) list() []peer.ID {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return append([]peer.ID(nil), s.peers...)
+}
+
+// WithSiblingListSize sets the number of peers closest to ourselves held within
+// the sibling list (default: SiblingListFactor times the replication parameter).
+func WithSiblingListSize(size int) RoutingTableOption {
+	return func(t *RoutingTable) {
+		if size > 0 {
+			t.siblings = newSiblings(t.self, size)
+		}
+	}
+}
+
+// refillSiblings rebuilds the sibling list out of the peers within the routing
+// table after a sibling has been removed. Expects no bucket to be locked.
+func (t *RoutingTable) refillSiblings() {
+	for _, id := range t.FindClosestPeers(t.self, t.siblings.size) {
+		t.siblings.add(id)
+	}
+}
+
+// Siblings returns the sibling list: the peers within the routing table
+// closest to ourselves, sorted by their XOR distance to ourselves.
+func (t *RoutingTable) Siblings() []peer.ID {
+	return t.siblings.list()
+}
+
+// SiblingListSize returns the maximum number of peers held within the sibling list.
+func (t *RoutingTable) SiblingListSize() int {
+	return t.siblings.size
+}
+
+// ClosestSiblings returns the count closest peers to a target out of the
+// sibling list, sorted by their XOR distance to the target.
+//
+// Should the sibling list be full and the target lie close enough to ourselves,
+// no peer outside the sibling list may be closer to the target than the peers
+// returned, in which case complete is true and a lookup for the target may
+// terminate without querying the network.
+func (t *RoutingTable) ClosestSiblings(target peer.ID, count int) (peers []peer.ID, complete bool) {
+	siblings := t.siblings.list()
+	full := len(siblings) >= t.siblings.size
+
+	var furthest peer.ID
+	if len(siblings) > 0 {
+		furthest = siblings[len(siblings)-1]
+	}
+
+	sort.Slice(siblings, func(i, j int) bool {
+		return siblings[i].XorID(target).Less(siblings[j].XorID(target))
+	})
+
+	if count > len(siblings) {
+		return siblings, false
+	}
+
+	peers = siblings[:count]
+
+	if !full || count <= 0 {
+		return peers, false
+	}
+
+	// Every peer x outside of the sibling list lies at least as far from us as
+	// the furthest sibling. By the triangle inequality, d(x, target) is then at
+	// least d(furthest, self) - d(self, target).
+	bound := new(big.Int).Sub(distance(furthest, t.self), distance(t.self, target))
+	complete = distance(peers[count-1], target).Cmp(bound) < 0
+
+	return
+}
+
+// distance returns the XOR distance between two peer IDs as an integer.
+func distance(a peer.ID, b peer.ID) *big.Int {
+	return new(big.Int).SetBytes(a.XorID(b).Id)
+}
@@ -0,0 +1,72 @@
+package dht
+
+import (
+	"testing"
+
+	"github.com/perlin-network/noise/peer"
+)
+
+func idWithPrefix(prefix byte) peer.ID {
+	id := make([]byte, 32)
+	id[0] = prefix
+	return peer.ID{PublicKey: id, Id: id}
+}
+
+func TestSiblings(t *testing.T) {
+	t.Parallel()
+
+	self := idWithPrefix(0x00)
+	routingTable := CreateRoutingTable(self, WithSiblingListSize(3))
+
+	for _, prefix := range []byte{0x80, 0x08, 0x40, 0x01, 0x20} {
+		routingTable.Update(idWithPrefix(prefix))
+	}
+
+	expected := []byte{0x01, 0x08, 0x20}
+	assertSiblings := func(expected []byte) {
+		siblings := routingTable.Siblings()
+		if len(siblings) != len(expected) {
+			t.Fatalf("expected %d siblings, got %d", len(expected), len(siblings))
+		}
+		for i, prefix := range expected {
+			if siblings[i].Id[0] != prefix {
+				t.Fatalf("siblings[%d] = %x, expected %x", i, siblings[i].Id[0], prefix)
+			}
+		}
+	}
+
+	assertSiblings(expected)
+
+	// Removing a sibling should refill the sibling list out of the routing table.
+	routingTable.RemovePeer(idWithPrefix(0x08))
+	assertSiblings([]byte{0x01, 0x20, 0x40})
+
+	// Removing a peer which is not a sibling should not affect the sibling list.
+	routingTable.RemovePeer(idWithPrefix(0x80))
+	assertSiblings([]byte{0x01, 0x20, 0x40})
+}
+
+func TestClosestSiblings(t *testing.T) {
+	t.Parallel()
+
+	self := idWithPrefix(0x00)
+	routingTable := CreateRoutingTable(self, WithSiblingListSize(3))
+
+	routingTable.Update(idWithPrefix(0x01))
+	routingTable.Update(idWithPrefix(0x02))
+
+	if _, complete := routingTable.ClosestSiblings(idWithPrefix(0x01), 1); complete {
+		t.Fatal("expected a sibling list which is not full to never be complete")
+	}
+
+	routingTable.Update(idWithPrefix(0x40))
+
+	peers, complete := routingTable.ClosestSiblings(idWithPrefix(0x03), 2)
+	if !complete || len(peers) != 2 || peers[0].Id[0] != 0x02 || peers[1].Id[0] != 0x01 {
+		t.Fatalf("expected siblings 0x02 and 0x01 to be the complete closest peers to 0x03")
+	}
+
+	if _, complete := routingTable.ClosestSiblings(idWithPrefix(0xc0), 2); complete {
+		t.Fatal("expected a target far from ourselves to not be covered by the sibling list")
+	}
+}
@@ -0,0 +1,40 @@
+package dht
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+// EstimateNetworkSize estimates the number of peers within the network
+// (including ourselves) out of the XOR distances between ourselves and the
+// peers within our sibling list.
+//
+// In a network of N peers with uniformly distributed IDs, the i'th closest
+// peer to us lies at a normalized distance of roughly i/N. N is estimated by
+// a least-squares fit over the distances of the closest peers, being those
+// the routing table holds most completely. Returns 0 should the routing table
+// hold no peers.
+func (t *RoutingTable) EstimateNetworkSize() int {
+	var sumSquares, sumWeighted float64
+
+	for i, id := range t.Siblings() {
+		rank := float64(i + 1)
+
+		sumSquares += rank * rank
+		sumWeighted += rank * normalizedDistance(id.XorID(t.self).Id)
+	}
+
+	if sumWeighted == 0 {
+		return 0
+	}
+
+	return int(math.Round(sumSquares / sumWeighted))
+}
+
+// normalizedDistance maps an XOR distance onto [0, 1).
+func normalizedDistance(distance []byte) float64 {
+	var prefix [8]byte
+	copy(prefix[:], distance)
+
+	return float64(binary.BigEndian.Uint64(prefix[:])) / math.Exp2(64)
+}
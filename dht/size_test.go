@@ -0,0 +1,28 @@
+package dht
+
+import (
+	"testing"
+)
+
+func TestEstimateNetworkSize(t *testing.T) {
+	t.Parallel()
+
+	routingTable := CreateRoutingTable(idWithPrefix(0x00))
+
+	if size := routingTable.EstimateNetworkSize(); size != 0 {
+		t.Fatalf("expected an empty routing table to estimate a size of 0, got %d", size)
+	}
+
+	// Peers spaced evenly 1/16th of the ID space apart suggest a network of 16 peers.
+	for i := 1; i <= 8; i++ {
+		routingTable.Update(idWithPrefix(byte(i * 16)))
+	}
+
+	if size := routingTable.EstimateNetworkSize(); size != 16 {
+		t.Fatalf("expected an estimated network size of 16, got %d", size)
+	}
+
+	if stats := routingTable.Stats(); stats.EstimatedSize != 16 {
+		t.Fatalf("expected stats to report an estimated network size of 16, got %d", stats.EstimatedSize)
+	}
+}
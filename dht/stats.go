@@ -0,0 +1,108 @@
+package dht
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/perlin-network/noise/peer"
+)
+
+// BucketStats describes the state of a single bucket.
+type BucketStats struct {
+	ID           int       `json:"id"`
+	Peers        int       `json:"peers"`
+	Replacements int       `json:"replacements"`
+	LastUpdated  time.Time `json:"last_updated"`
+	// Added and Removed count peers which have churned through the bucket.
+	Added   uint64 `json:"added"`
+	Removed uint64 `json:"removed"`
+}
+
+// PeerDistance describes a peer alongside its XOR distance from ourselves.
+type PeerDistance struct {
+	Address  string `json:"address"`
+	Distance string `json:"distance"`
+}
+
+// Stats describes the state of a routing table.
+type Stats struct {
+	Peers        int    `json:"peers"`
+	Replacements int    `json:"replacements"`
+	Added        uint64 `json:"added"`
+	Removed      uint64 `json:"removed"`
+
+	// Nearest and Farthest are the peers closest and furthest away from
+	// ourselves. Nil should the routing table hold no peers.
+	Nearest  *PeerDistance `json:"nearest,omitempty"`
+	Farthest *PeerDistance `json:"farthest,omitempty"`
+
+	// EstimatedSize is the approximate number of peers within the network,
+	// derived from the density of peers closest to ourselves.
+	EstimatedSize int `json:"estimated_size"`
+
+	// Buckets holds the statistics of all buckets which hold peers or
+	// replacements, or which have seen churn.
+	Buckets []BucketStats `json:"buckets"`
+}
+
+// Stats returns a snapshot of the routing tables bucket occupancy, refresh
+// times, churn and distances, for monitoring purposes.
+func (t *RoutingTable) Stats() (stats Stats) {
+	var nearest, farthest *peer.ID
+	var nearestDistance, farthestDistance peer.ID
+
+	for i, bucket := range t.buckets {
+		bucket.mutex.RLock()
+
+		bs := BucketStats{
+			ID:           i,
+			Replacements: bucket.replacements.Len(),
+			LastUpdated:  bucket.lastUpdated,
+			Added:        bucket.added,
+			Removed:      bucket.removed,
+		}
+
+		for e := bucket.Front(); e != nil; e = e.Next() {
+			id := e.Value.(peer.ID)
+			if id.Equals(t.self) {
+				continue
+			}
+
+			bs.Peers++
+
+			distance := id.XorID(t.self)
+			if nearest == nil || distance.Less(nearestDistance) {
+				nearest, nearestDistance = &id, distance
+			}
+			if farthest == nil || farthestDistance.Less(distance) {
+				farthest, farthestDistance = &id, distance
+			}
+		}
+
+		bucket.mutex.RUnlock()
+
+		stats.Peers += bs.Peers
+		stats.Replacements += bs.Replacements
+		stats.Added += bs.Added
+		stats.Removed += bs.Removed
+
+		if bs.Peers > 0 || bs.Replacements > 0 || bs.Added > 0 || bs.Removed > 0 {
+			stats.Buckets = append(stats.Buckets, bs)
+		}
+	}
+
+	if nearest != nil {
+		stats.Nearest = &PeerDistance{Address: nearest.Address, Distance: hex.EncodeToString(nearestDistance.Id)}
+		stats.Farthest = &PeerDistance{Address: farthest.Address, Distance: hex.EncodeToString(farthestDistance.Id)}
+	}
+
+	stats.EstimatedSize = t.EstimateNetworkSize()
+
+	return
+}
+
+// StatsJSON returns a JSON export of the routing tables statistics.
+func (t *RoutingTable) StatsJSON() ([]byte, error) {
+	return json.Marshal(t.Stats())
+}
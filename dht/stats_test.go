@@ -0,0 +1,40 @@
+package dht
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestStats(t *testing.T) {
+	t.Parallel()
+
+	routingTable := CreateRoutingTable(id1)
+	routingTable.Update(id2)
+	routingTable.Update(id3)
+	routingTable.RemovePeer(id3)
+
+	stats := routingTable.Stats()
+
+	if stats.Peers != 1 {
+		t.Fatalf("expected 1 peer, got %d", stats.Peers)
+	}
+
+	// Our own ID counts as having been added to the table.
+	if stats.Added != 3 || stats.Removed != 1 {
+		t.Fatalf("expected 3 peers added and 1 removed, got %d added and %d removed", stats.Added, stats.Removed)
+	}
+
+	if stats.Nearest == nil || stats.Nearest.Address != id2.Address || stats.Farthest.Address != id2.Address {
+		t.Fatalf("expected peer 2 to be both the nearest and farthest peer")
+	}
+
+	raw, err := routingTable.StatsJSON()
+	if err != nil {
+		t.Fatalf("StatsJSON() = expected no error, got %v", err)
+	}
+
+	var decoded Stats
+	if err := json.Unmarshal(raw, &decoded); err != nil || decoded.Peers != stats.Peers {
+		t.Fatalf("expected StatsJSON() to round-trip, got %v", err)
+	}
+}
@@ -20,7 +20,7 @@ type ChatPlugin struct{ *network.Plugin }
 func (state *ChatPlugin) Receive(ctx *network.PluginContext) error {
 	switch msg := ctx.Message().(type) {
 	case *messages.ChatMessage:
-		log.Info().Msgf("<%s> %s", ctx.Client().ID.Address, msg.Message)
+		log.Info().Msgf("<%s> %s", ctx.Client().ID().Address, msg.Message)
 	}
 
 	return nil
@@ -16,6 +16,7 @@ import (
 	"github.com/perlin-network/noise/network"
 	"github.com/perlin-network/noise/network/backoff"
 	"github.com/perlin-network/noise/network/discovery"
+	"github.com/perlin-network/noise/network/fault"
 	"github.com/perlin-network/noise/types/opcode"
 )
 
@@ -83,6 +84,9 @@ func main() {
 	hostFlag := flag.String("host", "localhost", "host to listen to")
 	protocolFlag := flag.String("protocol", "tcp", "protocol to use (kcp/tcp)")
 	peersFlag := flag.String("peers", "", "peers to connect to")
+	dropFlag := flag.Int("drop-every", 0, "drop every nth frame sent (0 to disable)")
+	duplicateFlag := flag.Int("duplicate-every", 0, "duplicate every nth frame sent (0 to disable)")
+	delayFlag := flag.Duration("delay", 0, "delay every frame sent")
 	flag.Parse()
 
 	port := uint16(*portFlag)
@@ -98,7 +102,10 @@ func main() {
 	log.Info().Str("public_key", keys.PublicKeyHex()).Msg("")
 
 	opcode.RegisterMessageType(opcode.Opcode(1000), &messages.Empty{})
-	builder := network.NewBuilder()
+	// Inject faults into frames sent for soak runs.
+	policy := fault.Chain(fault.DropEvery(*dropFlag), fault.DuplicateEvery(*duplicateFlag), fault.Delay(*delayFlag))
+
+	builder := network.NewBuilderWithOptions(network.Faults(policy))
 	builder.SetKeys(keys)
 	builder.SetAddress(network.FormatAddress(protocol, host, port))
 
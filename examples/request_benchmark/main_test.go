@@ -1,12 +1,26 @@
 package main
 
 import (
+	"context"
 	"testing"
+	"time"
+
+	"github.com/perlin-network/noise/network/loadtest"
 )
 
 // Usage:
-//  vgo test -race .
+//
+//	vgo test -race .
 func TestClient(t *testing.T) {
 	t.Parallel()
-	t.Log(run())
+
+	report, err := loadtest.Run(context.Background(), defaultNumNodes,
+		loadtest.WithPattern(loadtest.RequestReply),
+		loadtest.WithDuration(time.Second),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Log(summary(report))
 }
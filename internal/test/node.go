@@ -0,0 +1,51 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/perlin-network/noise/crypto"
+	"github.com/perlin-network/noise/crypto/ed25519"
+	"github.com/perlin-network/noise/network"
+)
+
+// BuildNode builds a node under random keys listening on a random port with
+// the plugins given, and blocks until it is listening. Nodes write with a
+// timeout of 1 second unless opts say otherwise.
+func BuildNode(t testing.TB, opts []network.BuilderOption, plugins ...network.PluginInterface) *network.Network {
+	t.Helper()
+
+	return BuildNodeWithKeys(t, ed25519.RandomKeyPair(), opts, plugins...)
+}
+
+// BuildNodeWithKeys builds a node under the keys given listening on a random
+// port with the plugins given, and blocks until it is listening.
+func BuildNodeWithKeys(t testing.TB, keys *crypto.KeyPair, opts []network.BuilderOption, plugins ...network.PluginInterface) *network.Network {
+	t.Helper()
+
+	builder := network.NewBuilderWithOptions(append([]network.BuilderOption{network.WriteTimeout(1 * time.Second)}, opts...)...)
+	builder.SetKeys(keys)
+
+	listener, address, err := network.ListenOnRandomPort()
+	if err != nil {
+		t.Fatalf("ListenOnRandomPort() = expected no error, got %v", err)
+	}
+	builder.SetListener(listener)
+	builder.SetAddress(address)
+
+	for _, plugin := range plugins {
+		if err := builder.AddPlugin(plugin); err != nil {
+			t.Fatalf("AddPlugin() = expected no error, got %v", err)
+		}
+	}
+
+	net, err := builder.Build()
+	if err != nil {
+		t.Fatalf("Build() = expected no error, got %v", err)
+	}
+
+	go net.Listen()
+	net.BlockUntilListening()
+
+	return net
+}
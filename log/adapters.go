@@ -0,0 +1,160 @@
+package log
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// ModuleFieldName is the key of the field adapters log the module of an event
+// under.
+const ModuleFieldName = "module"
+
+type nopLogger struct{}
+
+func (nopLogger) Log(Level, string, string, []Field) {}
+
+// Nop returns a logger which discards all events.
+func Nop() Logger {
+	return nopLogger{}
+}
+
+type zerologLogger struct {
+	logger zerolog.Logger
+}
+
+// Zerolog returns a logger which writes events to a zerolog logger.
+func Zerolog(l zerolog.Logger) Logger {
+	return &zerologLogger{logger: l}
+}
+
+func (z *zerologLogger) Log(level Level, module string, msg string, fields []Field) {
+	var e *zerolog.Event
+
+	switch level {
+	case DebugLevel:
+		e = z.logger.Debug()
+	case InfoLevel:
+		e = z.logger.Info()
+	case WarnLevel:
+		e = z.logger.Warn()
+	case ErrorLevel:
+		e = z.logger.Error()
+	case FatalLevel:
+		e = z.logger.Fatal()
+	default:
+		return
+	}
+
+	if module != "" {
+		e = e.Str(ModuleFieldName, module)
+	}
+
+	for _, field := range fields {
+		switch value := field.Value.(type) {
+		case string:
+			e = e.Str(field.Key, value)
+		case []string:
+			e = e.Strs(field.Key, value)
+		case int:
+			e = e.Int(field.Key, value)
+		case int64:
+			e = e.Int64(field.Key, value)
+		case uint64:
+			e = e.Uint64(field.Key, value)
+		case bool:
+			e = e.Bool(field.Key, value)
+		case time.Duration:
+			e = e.Dur(field.Key, value)
+		case error:
+			e = e.AnErr(field.Key, value)
+		default:
+			e = e.Interface(field.Key, value)
+		}
+	}
+
+	e.Msg(msg)
+}
+
+type slogLogger struct {
+	logger *slog.Logger
+}
+
+// Slog returns a logger which writes events to a slog logger. Fatal events are
+// logged with a level above slog.LevelError.
+func Slog(l *slog.Logger) Logger {
+	return &slogLogger{logger: l}
+}
+
+func (s *slogLogger) Log(level Level, module string, msg string, fields []Field) {
+	var l slog.Level
+
+	switch level {
+	case DebugLevel:
+		l = slog.LevelDebug
+	case InfoLevel:
+		l = slog.LevelInfo
+	case WarnLevel:
+		l = slog.LevelWarn
+	case ErrorLevel:
+		l = slog.LevelError
+	case FatalLevel:
+		l = slog.LevelError + 4
+	default:
+		return
+	}
+
+	attrs := make([]slog.Attr, 0, len(fields)+1)
+	if module != "" {
+		attrs = append(attrs, slog.String(ModuleFieldName, module))
+	}
+
+	for _, field := range fields {
+		attrs = append(attrs, slog.Any(field.Key, field.Value))
+	}
+
+	s.logger.LogAttrs(context.Background(), l, msg, attrs...)
+}
+
+// SugaredLogger is the subset of the methods of *zap.SugaredLogger which
+// events are written through, such that noise need not depend on zap.
+type SugaredLogger interface {
+	Debugw(msg string, keysAndValues ...interface{})
+	Infow(msg string, keysAndValues ...interface{})
+	Warnw(msg string, keysAndValues ...interface{})
+	Errorw(msg string, keysAndValues ...interface{})
+}
+
+type zapLogger struct {
+	logger SugaredLogger
+}
+
+// Zap returns a logger which writes events to a zap sugared logger, as
+// returned by (*zap.Logger).Sugar(). Fatal events are logged with error level.
+func Zap(l SugaredLogger) Logger {
+	return &zapLogger{logger: l}
+}
+
+func (z *zapLogger) Log(level Level, module string, msg string, fields []Field) {
+	keysAndValues := make([]interface{}, 0, 2*len(fields)+2)
+	if module != "" {
+		keysAndValues = append(keysAndValues, ModuleFieldName, module)
+	}
+
+	for _, field := range fields {
+		keysAndValues = append(keysAndValues, field.Key, field.Value)
+	}
+
+	switch level {
+	case DebugLevel:
+		z.logger.Debugw(msg, keysAndValues...)
+	case InfoLevel:
+		z.logger.Infow(msg, keysAndValues...)
+	case WarnLevel:
+		z.logger.Warnw(msg, keysAndValues...)
+	case ErrorLevel, FatalLevel:
+		z.logger.Errorw(msg, keysAndValues...)
+	}
+}
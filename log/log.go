@@ -1,128 +1,146 @@
+// Package log is the logging facade of noise. Events are written to a Logger
+// which may be swapped out for an adapter to the logging library of an
+// embedding application, globally through SetLogger or per node through the
+// network.Logger builder option.
+//
+// By default, events are written to stderr through zerolog, and prettified
+// should stdout be a terminal.
 package log
 
 import (
-	"context"
-	"io"
+	"fmt"
 	"os"
+	"sync"
+	"sync/atomic"
 
 	"github.com/rs/zerolog"
-	"golang.org/x/crypto/ssh/terminal"
 )
 
+// holder wraps the global logger, as atomic.Value requires all values stored
+// to be of the same concrete type.
+type holder struct {
+	Logger
+}
+
 var (
-	logger = zerolog.New(os.Stderr).With().Timestamp().Logger()
+	logger atomic.Value
+
+	level int32 = int32(DebugLevel)
+
+	moduleLevelsMutex sync.RWMutex
+	moduleLevels      = make(map[string]Level)
 )
 
 func init() {
+	out := zerolog.New(os.Stderr).With().Timestamp().Logger()
+
 	// prettify if terminal is a console
-	if terminal.IsTerminal(int(os.Stdout.Fd())) {
-		logger = logger.Output(zerolog.ConsoleWriter{Out: os.Stderr})
+	if isTerminal(os.Stdout) {
+		out = out.Output(zerolog.ConsoleWriter{Out: os.Stderr})
 	}
+
+	SetLogger(Zerolog(out))
+}
+
+// global returns the global logger.
+func global() Logger {
+	return logger.Load().(holder).Logger
+}
+
+// SetLogger sets the global logger, which all events are written to unless a
+// node is built with a logger of its own. A nil logger disables logging.
+func SetLogger(l Logger) {
+	if l == nil {
+		l = Nop()
+	}
+	logger.Store(holder{l})
 }
 
 // Disable disables the noise logger
 func Disable() {
-	logger = zerolog.New(nil).Level(zerolog.Disabled)
+	SetLogger(Nop())
 }
 
-// Output duplicates the global logger and sets w as its output.
-func Output(w io.Writer) zerolog.Logger {
-	return logger.Output(w)
+// SetLevel sets the minimum level of events logged by modules whose level has
+// not been set through SetModuleLevel.
+func SetLevel(l Level) {
+	atomic.StoreInt32(&level, int32(l))
 }
 
-// With creates a child logger with the field added to its context.
-func With() zerolog.Context {
-	return logger.With()
+// SetModuleLevel sets the minimum level of events logged by a module of noise,
+// such as "network" or "discovery", overriding the level set through SetLevel.
+func SetModuleLevel(module string, l Level) {
+	moduleLevelsMutex.Lock()
+	moduleLevels[module] = l
+	moduleLevelsMutex.Unlock()
 }
 
-// Level creates a child logger with the minimum accepted level set to level.
-func Level(level zerolog.Level) zerolog.Logger {
-	return logger.Level(level)
+// ResetModuleLevel has a module of noise fall back to the level set through
+// SetLevel.
+func ResetModuleLevel(module string) {
+	moduleLevelsMutex.Lock()
+	delete(moduleLevels, module)
+	moduleLevelsMutex.Unlock()
 }
 
-// Sample returns a logger with the s sampler.
-func Sample(s zerolog.Sampler) zerolog.Logger {
-	return logger.Sample(s)
-}
+// Enabled returns whether or not events of a level logged by a module are to
+// be written.
+func Enabled(module string, l Level) bool {
+	min := Level(atomic.LoadInt32(&level))
+
+	moduleLevelsMutex.RLock()
+	if moduleLevel, ok := moduleLevels[module]; ok {
+		min = moduleLevel
+	}
+	moduleLevelsMutex.RUnlock()
 
-// Hook returns a logger with the h Hook.
-func Hook(h zerolog.Hook) zerolog.Logger {
-	return logger.Hook(h)
+	return l >= min && l != Disabled
 }
 
 // Debug starts a new message with debug level.
 //
 // You must call Msg on the returned event in order to send the event.
-func Debug() *zerolog.Event {
-	return logger.Debug()
+func Debug() *Event {
+	return Module{}.Debug()
 }
 
 // Info starts a new message with info level.
 //
 // You must call Msg on the returned event in order to send the event.
-func Info() *zerolog.Event {
-	return logger.Info()
+func Info() *Event {
+	return Module{}.Info()
 }
 
 // Warn starts a new message with warn level.
 //
 // You must call Msg on the returned event in order to send the event.
-func Warn() *zerolog.Event {
-	return logger.Warn()
+func Warn() *Event {
+	return Module{}.Warn()
 }
 
 // Error starts a new message with error level.
 //
 // You must call Msg on the returned event in order to send the event.
-func Error() *zerolog.Event {
-	return logger.Error()
+func Error() *Event {
+	return Module{}.Error()
 }
 
 // Fatal starts a new message with fatal level. The os.Exit(1) function
 // is called by the Msg method.
 //
 // You must call Msg on the returned event in order to send the event.
-func Fatal() *zerolog.Event {
-	return logger.Fatal()
-}
-
-// Panic starts a new message with panic level. The message is also sent
-// to the panic function.
-//
-// You must call Msg on the returned event in order to send the event.
-func Panic() *zerolog.Event {
-	return logger.Panic()
-}
-
-// WithLevel starts a new message with level.
-//
-// You must call Msg on the returned event in order to send the event.
-func WithLevel(level zerolog.Level) *zerolog.Event {
-	return logger.WithLevel(level)
-}
-
-// Log starts a new message with no level. Setting zerolog.GlobalLevel to
-// zerolog.Disabled will still disable events produced by this method.
-//
-// You must call Msg on the returned event in order to send the event.
-func Log() *zerolog.Event {
-	return logger.Log()
+func Fatal() *Event {
+	return Module{}.Fatal()
 }
 
 // Print sends a log event using debug level and no extra field.
 // Arguments are handled in the manner of fmt.Print.
 func Print(v ...interface{}) {
-	logger.Print(v...)
+	Debug().Msg(fmt.Sprint(v...))
 }
 
 // Printf sends a log event using debug level and no extra field.
 // Arguments are handled in the manner of fmt.Printf.
 func Printf(format string, v ...interface{}) {
-	logger.Printf(format, v...)
-}
-
-// Ctx returns the Logger associated with the ctx. If no logger
-// is associated, a disabled logger is returned.
-func Ctx(ctx context.Context) *zerolog.Logger {
-	return zerolog.Ctx(ctx)
+	Debug().Msgf(format, v...)
 }
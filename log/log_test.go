@@ -0,0 +1,179 @@
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+type event struct {
+	level  Level
+	module string
+	msg    string
+	fields []Field
+}
+
+// recorder is a logger which records all events written to it.
+type recorder struct {
+	sync.Mutex
+	events []event
+}
+
+func (r *recorder) Log(level Level, module string, msg string, fields []Field) {
+	r.Lock()
+	defer r.Unlock()
+	r.events = append(r.events, event{level: level, module: module, msg: msg, fields: fields})
+}
+
+// sugared is a stand-in for *zap.SugaredLogger.
+type sugared struct {
+	lines []string
+}
+
+func (s *sugared) Debugw(msg string, kv ...interface{}) { s.log("debug", msg, kv) }
+func (s *sugared) Infow(msg string, kv ...interface{})  { s.log("info", msg, kv) }
+func (s *sugared) Warnw(msg string, kv ...interface{})  { s.log("warn", msg, kv) }
+func (s *sugared) Errorw(msg string, kv ...interface{}) { s.log("error", msg, kv) }
+
+func (s *sugared) log(level string, msg string, kv []interface{}) {
+	s.lines = append(s.lines, strings.TrimSpace(fmt.Sprintln(append([]interface{}{level, msg}, kv...)...)))
+}
+
+func TestModuleLevels(t *testing.T) {
+	defer SetLevel(DebugLevel)
+	defer ResetModuleLevel("discovery")
+
+	r := new(recorder)
+	network, discovery := For("network", r), For("discovery", r)
+
+	SetLevel(WarnLevel)
+	SetModuleLevel("discovery", DebugLevel)
+
+	network.Info().Msg("dropped")
+	network.Warn().Msg("network")
+	discovery.Debug().Str("peer_address", "tcp://localhost:3000").Msg("discovery")
+
+	if len(r.events) != 2 {
+		t.Fatalf("expected 2 events to be logged, got %d", len(r.events))
+	}
+
+	if e := r.events[0]; e.level != WarnLevel || e.module != "network" || e.msg != "network" {
+		t.Fatalf("unexpected event %+v", e)
+	}
+
+	expected := []Field{{Key: "peer_address", Value: "tcp://localhost:3000"}}
+	if e := r.events[1]; e.level != DebugLevel || e.module != "discovery" || fmt.Sprint(e.fields) != fmt.Sprint(expected) {
+		t.Fatalf("unexpected event %+v", e)
+	}
+
+	ResetModuleLevel("discovery")
+	discovery.Debug().Msg("dropped")
+
+	if len(r.events) != 2 {
+		t.Fatalf("expected module to fall back to the global level, got %d events", len(r.events))
+	}
+}
+
+func TestSetLogger(t *testing.T) {
+	previous := global()
+	defer SetLogger(previous)
+
+	r := new(recorder)
+	SetLogger(r)
+
+	Info().Err(nil).Msgf("hello %s", "world")
+	For("network", nil).Error().Err(errors.New("boom")).Msg("failed")
+
+	if len(r.events) != 2 || r.events[0].msg != "hello world" || len(r.events[0].fields) != 0 {
+		t.Fatalf("unexpected events %+v", r.events)
+	}
+
+	if e := r.events[1]; e.module != "network" || e.fields[0].Key != ErrorFieldName {
+		t.Fatalf("unexpected event %+v", e)
+	}
+
+	Disable()
+	Info().Msg("dropped")
+
+	if len(r.events) != 2 {
+		t.Fatalf("expected no events once disabled, got %d", len(r.events))
+	}
+}
+
+func TestNilEvent(t *testing.T) {
+	var e *Event
+
+	// None of these may panic.
+	e.Str("a", "b").Strs("c", nil).Int("d", 1).Int64("e", 2).Uint64("f", 3).
+		Bool("g", true).Dur("h", time.Second).Interface("i", nil).Err(errors.New("j")).Msg("k")
+	e.Msgf("%d", 1)
+}
+
+func TestZerolog(t *testing.T) {
+	var buf bytes.Buffer
+
+	For("discovery", Zerolog(zerolog.New(&buf))).Info().
+		Str("peer_address", "tcp://localhost:3000").
+		Dur("timeout", time.Second).
+		Err(errors.New("boom")).
+		Msg("hello")
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &fields); err != nil {
+		t.Fatalf("expected JSON output, got %q", buf.String())
+	}
+
+	expected := map[string]interface{}{
+		"level":        "info",
+		"module":       "discovery",
+		"peer_address": "tcp://localhost:3000",
+		"timeout":      float64(1000),
+		"error":        "boom",
+		"message":      "hello",
+	}
+
+	if fmt.Sprint(fields) != fmt.Sprint(expected) {
+		t.Fatalf("expected %v, got %v", expected, fields)
+	}
+}
+
+func TestSlog(t *testing.T) {
+	var buf bytes.Buffer
+
+	handler := slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	})
+
+	For("nat", Slog(slog.New(handler))).Warn().Int("port", 3000).Msg("hello")
+
+	if expected := "level=WARN msg=hello module=nat port=3000\n"; buf.String() != expected {
+		t.Fatalf("expected %q, got %q", expected, buf.String())
+	}
+}
+
+func TestZap(t *testing.T) {
+	s := new(sugared)
+
+	l := For("backoff", Zap(s))
+	l.Debug().Str("address", "tcp://localhost:3000").Msg("hello")
+	l.Error().Msg("world")
+
+	expected := "debug hello module backoff address tcp://localhost:3000|error world module backoff"
+	if lines := strings.Join(s.lines, "|"); lines != expected {
+		t.Fatalf("expected %q, got %q", expected, lines)
+	}
+}
@@ -0,0 +1,223 @@
+package log
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// Level is the severity of a log event.
+type Level int8
+
+const (
+	// DebugLevel logs events useful while debugging.
+	DebugLevel Level = iota
+	// InfoLevel logs events describing the normal operation of a node.
+	InfoLevel
+	// WarnLevel logs events which may require attention.
+	WarnLevel
+	// ErrorLevel logs events which caused an operation to fail.
+	ErrorLevel
+	// FatalLevel logs events after which the process exits.
+	FatalLevel
+	// Disabled logs no events.
+	Disabled
+)
+
+// String returns the name of a level.
+func (l Level) String() string {
+	switch l {
+	case DebugLevel:
+		return "debug"
+	case InfoLevel:
+		return "info"
+	case WarnLevel:
+		return "warn"
+	case ErrorLevel:
+		return "error"
+	case FatalLevel:
+		return "fatal"
+	case Disabled:
+		return "disabled"
+	}
+	return fmt.Sprintf("Level(%d)", int8(l))
+}
+
+// ErrorFieldName is the key of the field errors are logged under.
+const ErrorFieldName = "error"
+
+// Field is a key-value pair attached to a log event.
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+// Logger is a backend log events are written to, such as an adapter to
+// zerolog, zap or slog.
+//
+// Events are filtered by level before reaching a logger. A logger must be safe
+// for concurrent use.
+type Logger interface {
+	// Log writes an event logged by a module of noise, being empty should the
+	// event not belong to any module.
+	Log(level Level, module string, msg string, fields []Field)
+}
+
+// Module logs events on behalf of a module of noise, such as "network" or
+// "discovery", whose level may be set independently of other modules through
+// SetModuleLevel.
+type Module struct {
+	name   string
+	logger Logger
+}
+
+// For returns a module which logs events to l, or to the global logger should
+// l be nil.
+func For(module string, l Logger) Module {
+	return Module{name: module, logger: l}
+}
+
+// Debug starts a new event with debug level.
+//
+// You must call Msg on the returned event in order to send the event.
+func (m Module) Debug() *Event {
+	return m.newEvent(DebugLevel)
+}
+
+// Info starts a new event with info level.
+//
+// You must call Msg on the returned event in order to send the event.
+func (m Module) Info() *Event {
+	return m.newEvent(InfoLevel)
+}
+
+// Warn starts a new event with warn level.
+//
+// You must call Msg on the returned event in order to send the event.
+func (m Module) Warn() *Event {
+	return m.newEvent(WarnLevel)
+}
+
+// Error starts a new event with error level.
+//
+// You must call Msg on the returned event in order to send the event.
+func (m Module) Error() *Event {
+	return m.newEvent(ErrorLevel)
+}
+
+// Fatal starts a new event with fatal level. The os.Exit(1) function is called
+// by the Msg method.
+//
+// You must call Msg on the returned event in order to send the event.
+func (m Module) Fatal() *Event {
+	return m.newEvent(FatalLevel)
+}
+
+// newEvent starts a new event, or returns nil should level be filtered out
+// for the module.
+func (m Module) newEvent(level Level) *Event {
+	if !Enabled(m.name, level) {
+		if level == FatalLevel {
+			// Fatal events must still exit even if they are not logged.
+			return &Event{level: level}
+		}
+		return nil
+	}
+
+	logger := m.logger
+	if logger == nil {
+		logger = global()
+	}
+
+	return &Event{logger: logger, module: m.name, level: level}
+}
+
+// Event is a log event under construction. All methods of a nil event are
+// no-ops, such that filtered out events cost next to nothing.
+type Event struct {
+	logger Logger
+	module string
+	level  Level
+	fields []Field
+}
+
+func (e *Event) add(key string, value interface{}) *Event {
+	if e == nil || e.logger == nil {
+		return e
+	}
+
+	e.fields = append(e.fields, Field{Key: key, Value: value})
+	return e
+}
+
+// Str adds a string field to the event.
+func (e *Event) Str(key, val string) *Event {
+	return e.add(key, val)
+}
+
+// Strs adds a string slice field to the event.
+func (e *Event) Strs(key string, vals []string) *Event {
+	return e.add(key, vals)
+}
+
+// Int adds an int field to the event.
+func (e *Event) Int(key string, i int) *Event {
+	return e.add(key, i)
+}
+
+// Int64 adds an int64 field to the event.
+func (e *Event) Int64(key string, i int64) *Event {
+	return e.add(key, i)
+}
+
+// Uint64 adds an uint64 field to the event.
+func (e *Event) Uint64(key string, i uint64) *Event {
+	return e.add(key, i)
+}
+
+// Bool adds a bool field to the event.
+func (e *Event) Bool(key string, b bool) *Event {
+	return e.add(key, b)
+}
+
+// Dur adds a duration field to the event.
+func (e *Event) Dur(key string, d time.Duration) *Event {
+	return e.add(key, d)
+}
+
+// Interface adds a field holding an arbitrary value to the event.
+func (e *Event) Interface(key string, i interface{}) *Event {
+	return e.add(key, i)
+}
+
+// Err adds an error field to the event under ErrorFieldName. Nil errors are
+// omitted.
+func (e *Event) Err(err error) *Event {
+	if err == nil {
+		return e
+	}
+	return e.add(ErrorFieldName, err)
+}
+
+// Msg sends the event with msg as its message.
+func (e *Event) Msg(msg string) {
+	if e == nil {
+		return
+	}
+
+	if e.logger != nil {
+		e.logger.Log(e.level, e.module, msg, e.fields)
+	}
+
+	if e.level == FatalLevel {
+		os.Exit(1)
+	}
+}
+
+// Msgf sends the event with a message formatted in the manner of fmt.Sprintf.
+func (e *Event) Msgf(format string, v ...interface{}) {
+	if e == nil {
+		return
+	}
+	e.Msg(fmt.Sprintf(format, v...))
+}
@@ -0,0 +1,8 @@
+package log
+
+import "os"
+
+// isTerminal returns false, as there are no terminals under js/wasm.
+func isTerminal(f *os.File) bool {
+	return false
+}
@@ -17,6 +17,10 @@ type AddressInfo struct {
 	Protocol string
 	Host     string
 	Port     uint16
+
+	// Name is the virtual host the address names, should several nodes be
+	// hosted on one listener through VirtualHosts. Empty otherwise.
+	Name string
 }
 
 const (
@@ -29,6 +33,11 @@ var (
 	ErrStrInvalidAddress       = "address: invalid address"
 	ErrStrAddressEmpty         = "address: cannot dial, address was empty"
 	ErrStrNoAvailableAddresses = "address: no available addresses"
+
+	// ErrAddressEmpty is returned upon an empty address being resolved.
+	ErrAddressEmpty = errors.New(ErrStrAddressEmpty)
+	// ErrNoAvailableAddresses is returned upon a host failing to resolve.
+	ErrNoAvailableAddresses = errors.New(ErrStrNoAvailableAddresses)
 )
 
 // NewAddressInfo creates a new AddressInfo instance.
@@ -52,6 +61,9 @@ func (info *AddressInfo) String() string {
 	if len(info.Protocol) > 0 {
 		address = info.Protocol + "://" + address
 	}
+	if len(info.Name) > 0 {
+		address += "/" + info.Name
+	}
 	return address
 }
 
@@ -65,8 +77,8 @@ func FormatAddress(protocol string, host string, port uint16) string {
 	return NewAddressInfo(protocol, host, port).String()
 }
 
-// ParseAddress derives a network scheme, host and port of a destinations
-// information. Errors should the provided destination address be malformed.
+// ParseAddress derives a network scheme, host, port and virtual host name of
+// a destinations information. Errors should the provided destination address be malformed.
 func ParseAddress(address string) (*AddressInfo, error) {
 	urlInfo, err := url.Parse(address)
 	if err != nil {
@@ -87,6 +99,7 @@ func ParseAddress(address string) (*AddressInfo, error) {
 		Protocol: urlInfo.Scheme,
 		Host:     host,
 		Port:     uint16(port),
+		Name:     strings.Trim(urlInfo.Path, "/"),
 	}, nil
 }
 
@@ -97,10 +110,10 @@ func ToUnifiedHost(host string) (string, error) {
 			// Probably a domain name is provided.
 			addresses, err := net.LookupHost(host)
 			if err != nil {
-				return "", errors.New(ErrStrNoAvailableAddresses)
+				return "", ErrNoAvailableAddresses
 			}
 			if len(addresses) == 0 {
-				return "", errors.New(ErrStrNoAvailableAddresses)
+				return "", ErrNoAvailableAddresses
 			}
 
 			host = addresses[0]
@@ -115,7 +128,7 @@ func ToUnifiedHost(host string) (string, error) {
 	})
 
 	if unifiedHost == nil {
-		return "", errors.New(ErrStrNoAvailableAddresses)
+		return "", ErrNoAvailableAddresses
 	}
 
 	return unifiedHost.(string), err
@@ -125,7 +138,7 @@ func ToUnifiedHost(host string) (string, error) {
 func ToUnifiedAddress(address string) (string, error) {
 	address = strings.TrimSpace(address)
 	if len(address) == 0 {
-		return "", errors.New(ErrStrAddressEmpty)
+		return "", ErrAddressEmpty
 	}
 
 	info, err := ParseAddress(address)
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net"
 	"net/url"
+	"strings"
 	"testing"
 
 	"github.com/pkg/errors"
@@ -127,6 +128,31 @@ func TestParseAddress(t *testing.T) {
 	}
 }
 
+func TestParseAddressName(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		address string
+		name    string
+	}{
+		{"tcp://127.0.0.1:3000", ""},
+		{"tcp://127.0.0.1:3000/", ""},
+		{"tcp://127.0.0.1:3000/alice", "alice"},
+	}
+	for _, tt := range testCases {
+		info, err := ParseAddress(tt.address)
+		if err != nil {
+			t.Fatalf("ParseAddress() = %+v, expected <nil>", err)
+		}
+		if info.Name != tt.name {
+			t.Errorf("ParseAddress().Name = %q, expected %q", info.Name, tt.name)
+		}
+		if expected := strings.TrimSuffix(tt.address, "/"); info.String() != expected {
+			t.Errorf("String() = %s, expected %s", info.String(), expected)
+		}
+	}
+}
+
 func BenchmarkParseAddress(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		_, err := ParseAddress("tcp://127.0.0.1:3000")
@@ -0,0 +1,153 @@
+package network
+
+import (
+	"net"
+	"sync"
+	"time"
+
+	"github.com/perlin-network/noise/crypto"
+	"github.com/perlin-network/noise/internal/protobuf"
+	"github.com/perlin-network/noise/peer"
+
+	"github.com/pkg/errors"
+)
+
+// attestationPrefix separates signatures over attestation evidence from
+// signatures over messages.
+const attestationPrefix = "noise/attestation"
+
+// attestationTimeout is how long nodes dialing peers await a challenge in reply
+// to their attestation request.
+const attestationTimeout = 10 * time.Second
+
+// Attestor produces evidence of the environment a node runs in, such as a TEE
+// quote, bound to the public key of the node such that it may not be presented
+// by nodes under other keys.
+type Attestor func(publicKey []byte) (evidence []byte, err error)
+
+// AttestationVerifier decides whether or not to admit a peer upon the
+// attestation evidence it presented. Peers are refused should it return an
+// error.
+type AttestationVerifier func(id peer.ID, evidence []byte) error
+
+// attestationPayload serializes attestation evidence presented by a node over
+// a connection, for signing purposes. The evidence is bound to the challenge
+// issued over the connection, such that it may not be replayed over others.
+func attestationPayload(sender *protobuf.ID, challenge []byte, evidence []byte) []byte {
+	payload := make([]byte, 0, len(attestationPrefix)+len(challenge)+len(evidence))
+	payload = append(payload, attestationPrefix...)
+	payload = append(payload, challenge...)
+	payload = append(payload, evidence...)
+
+	return SerializeMessage(sender, payload)
+}
+
+// attest presents attestation evidence over a connection dialed to a peer,
+// right after its greeting, should the node have been built with an attestor.
+// The peer is first asked for a challenge, which the evidence is signed
+// together with.
+func (n *Network) attest(conn net.Conn, address string) error {
+	if n.opts.attestor == nil {
+		return nil
+	}
+
+	evidence, err := n.opts.attestor(n.ID.PublicKey)
+	if err != nil {
+		return errors.Wrap(err, "network: failed to produce attestation evidence")
+	}
+	if len(evidence) == 0 {
+		return errors.New("network: attestor produced no evidence")
+	}
+
+	id := protobuf.ID(n.ID)
+	mutex := new(sync.Mutex)
+
+	conn.SetWriteDeadline(time.Now().Add(n.opts.writeTimeout))
+
+	if err := n.sendMessage(address, conn, &protobuf.Message{Sender: &id, AttestationRequest: true}, mutex); err != nil {
+		return err
+	}
+
+	conn.SetReadDeadline(time.Now().Add(attestationTimeout))
+
+	reply, _, err := n.receiveMessage(conn, nil)
+	if err != nil {
+		return errors.Wrap(err, "network: failed to receive attestation challenge")
+	}
+
+	conn.SetReadDeadline(time.Time{})
+
+	if !isAttestationChallenge(reply) || len(reply.AttestationChallenge) != challengeSize {
+		return errors.New("network: peer replied with no attestation challenge")
+	}
+
+	signature, err := n.Sign(attestationPayload(&id, reply.AttestationChallenge, evidence))
+	if err != nil {
+		return errors.Wrap(err, "network: failed to sign attestation evidence")
+	}
+
+	conn.SetWriteDeadline(time.Now().Add(n.opts.writeTimeout))
+
+	return n.sendMessage(address, conn, &protobuf.Message{Sender: &id, Attestation: evidence, Signature: signature}, mutex)
+}
+
+// challengeAttestation replies to an attestation request received over a
+// connection with a random challenge, which is returned.
+func (n *Network) challengeAttestation(conn net.Conn) ([]byte, error) {
+	challenge := make([]byte, challengeSize)
+	if _, err := n.Random().Read(challenge); err != nil {
+		return nil, errors.Wrap(err, "network: failed to generate attestation challenge")
+	}
+
+	id := protobuf.ID(n.ID)
+
+	conn.SetWriteDeadline(time.Now().Add(n.opts.writeTimeout))
+
+	err := n.sendMessage(conn.RemoteAddr().String(), conn, &protobuf.Message{Sender: &id, AttestationChallenge: challenge}, new(sync.Mutex))
+	if err != nil {
+		return nil, err
+	}
+
+	return challenge, nil
+}
+
+// isAttestation returns whether or not a message carries attestation evidence,
+// and nothing else.
+func isAttestation(msg *protobuf.Message) bool {
+	return msg.Opcode == 0 && len(msg.Attestation) > 0
+}
+
+// isAttestationRequest returns whether or not a message asks for a challenge
+// to bind attestation evidence to.
+func isAttestationRequest(msg *protobuf.Message) bool {
+	return msg.Opcode == 0 && msg.AttestationRequest
+}
+
+// isAttestationChallenge returns whether or not a message carries a challenge
+// in reply to an attestation request.
+func isAttestationChallenge(msg *protobuf.Message) bool {
+	return msg.Opcode == 0 && len(msg.AttestationChallenge) > 0
+}
+
+// verifyAttestation checks that attestation evidence was signed by the peer
+// presenting it together with the challenge issued over its connection, and
+// that the verifier of the node accepts it.
+func (n *Network) verifyAttestation(msg *protobuf.Message, challenge []byte) error {
+	if msg.Sender == nil || len(msg.Sender.Id) != len(n.ID.Id) {
+		return errors.New("network: attestation evidence carries no valid sender")
+	}
+
+	if len(challenge) == 0 {
+		return errors.New("network: attestation evidence was presented before a challenge was issued")
+	}
+
+	if !crypto.Verify(n.opts.signaturePolicy, n.opts.hashPolicy, msg.Sender.PublicKey, attestationPayload(msg.Sender, challenge, msg.Attestation), msg.Signature) {
+		return errors.New("network: attestation evidence is not signed by its sender")
+	}
+
+	if err := n.opts.attestationVerifier(peer.ID(*msg.Sender), msg.Attestation); err != nil {
+		return errors.Wrap(err, "network: attestation evidence was rejected")
+	}
+
+	return nil
+}
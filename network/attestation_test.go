@@ -0,0 +1,148 @@
+package network
+
+import (
+	"bytes"
+	"io"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/perlin-network/noise/crypto/ed25519"
+	"github.com/perlin-network/noise/internal/protobuf"
+	"github.com/perlin-network/noise/peer"
+	"github.com/perlin-network/noise/types/opcode"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func attestationQuote(publicKey []byte) ([]byte, error) {
+	return append([]byte("quote:"), publicKey...), nil
+}
+
+func verifyAttestationQuote(id peer.ID, evidence []byte) error {
+	if !bytes.Equal(evidence, append([]byte("quote:"), id.PublicKey...)) {
+		return errors.New("untrusted quote")
+	}
+	return nil
+}
+
+func buildAttestationNode(t *testing.T, opts ...BuilderOption) *Network {
+	builder := NewBuilderWithOptions(opts...)
+	builder.SetKeys(ed25519.RandomKeyPair())
+	listener, address, err := ListenOnRandomPort()
+	assert.Equal(t, nil, err)
+	builder.SetListener(listener)
+	builder.SetAddress(address)
+
+	node, err := builder.Build()
+	assert.Equal(t, nil, err)
+
+	go node.Listen()
+	node.BlockUntilListening()
+
+	return node
+}
+
+func TestAttestation(t *testing.T) {
+	t.Parallel()
+
+	quote, verifier := attestationQuote, verifyAttestationQuote
+
+	build := func(opts ...BuilderOption) *Network {
+		return buildAttestationNode(t, opts...)
+	}
+
+	verifying := build(Attestation(quote, verifier))
+	defer verifying.Close()
+
+	attested := build(Attestation(quote, nil))
+	defer attested.Close()
+
+	forged := build(Attestation(func([]byte) ([]byte, error) { return []byte("quote:someone else"), nil }, nil))
+	defer forged.Close()
+
+	plain := build()
+	defer plain.Close()
+
+	for _, node := range []*Network{attested, forged, plain} {
+		node.Bootstrap(verifying.Address)
+	}
+
+	admitted := false
+	for i := 0; i < 100 && !admitted; i++ {
+		admitted = verifying.ConnectionStateExists(attested.Address) && verifying.HandshakeFailures().Total[FailureAttestation] == 2
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	assert.True(t, admitted, "expected the attested peer to be admitted, and the others refused")
+
+	failures := verifying.HandshakeFailures().ByAddress
+	assert.Equal(t, uint64(1), failures[forged.Address][FailureAttestation])
+	assert.Equal(t, uint64(1), failures[plain.Address][FailureAttestation])
+	assert.False(t, verifying.ConnectionStateExists(forged.Address))
+	assert.False(t, verifying.ConnectionStateExists(plain.Address))
+}
+
+// requestChallenge dials a peer on behalf of a node, and asks for a challenge
+// to bind attestation evidence to.
+func requestChallenge(t *testing.T, node *Network, address string) (conn net.Conn, challenge []byte) {
+	c, err := node.Dial(address)
+	assert.Equal(t, nil, err)
+
+	id := protobuf.ID(node.ID)
+	assert.Equal(t, nil, node.sendMessage(address, c, &protobuf.Message{Sender: &id, AttestationRequest: true}, new(sync.Mutex)))
+
+	c.SetReadDeadline(time.Now().Add(3 * time.Second))
+
+	reply, _, err := node.receiveMessage(c, nil)
+	assert.Equal(t, nil, err)
+	assert.True(t, isAttestationChallenge(reply), "expected a challenge in reply to an attestation request")
+
+	return c, reply.AttestationChallenge
+}
+
+func TestAttestationReplay(t *testing.T) {
+	t.Parallel()
+
+	verifying := buildAttestationNode(t, Attestation(nil, verifyAttestationQuote))
+	defer verifying.Close()
+
+	attested := buildAttestationNode(t, Attestation(attestationQuote, nil))
+	defer attested.Close()
+
+	id := protobuf.ID(attested.ID)
+	evidence, _ := attestationQuote(attested.ID.PublicKey)
+
+	first, challenge := requestChallenge(t, attested, verifying.Address)
+	defer first.Close()
+
+	signature, err := attested.Sign(attestationPayload(&id, challenge, evidence))
+	assert.Equal(t, nil, err)
+
+	attestation := &protobuf.Message{Sender: &id, Attestation: evidence, Signature: signature}
+	assert.Equal(t, nil, attested.sendMessage(verifying.Address, first, attestation, new(sync.Mutex)))
+
+	// Messages past the evidence must be signed.
+	assert.Equal(t, nil, attested.sendMessage(verifying.Address, first, &protobuf.Message{Sender: &id, Opcode: uint32(opcode.PingCode)}, new(sync.Mutex)))
+
+	_, err = first.Read(make([]byte, 1))
+	assert.Equal(t, io.EOF, err, "expected an unsigned message to close a connection gated on attestation")
+
+	// Evidence signed together with the challenge of one connection is refused
+	// over others.
+	second, _ := requestChallenge(t, attested, verifying.Address)
+	defer second.Close()
+
+	assert.Equal(t, nil, attested.sendMessage(verifying.Address, second, attestation, new(sync.Mutex)))
+
+	refused := false
+	for i := 0; i < 100 && !refused; i++ {
+		refused = verifying.HandshakeFailures().ByAddress[attested.Address][FailureAttestation] == 1
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	assert.True(t, refused, "expected replayed attestation evidence to be refused")
+	assert.False(t, verifying.ConnectionStateExists(attested.Address))
+}
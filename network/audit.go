@@ -0,0 +1,79 @@
+package network
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/perlin-network/noise/audit"
+	"github.com/perlin-network/noise/internal/protobuf"
+	"github.com/perlin-network/noise/peer"
+	"github.com/perlin-network/noise/tracing"
+
+	"github.com/pkg/errors"
+)
+
+// Audit emits a security event to the audit sink of the node, should one be
+// set. The schema version, time and node address of the event are filled in
+// should they be left unspecified.
+func (n *Network) Audit(event audit.Event) {
+	if n.opts.auditSink == nil {
+		return
+	}
+
+	if event.Version == 0 {
+		event.Version = audit.SchemaVersion
+	}
+
+	if event.Time.IsZero() {
+		event.Time = n.Clock().Now()
+	}
+
+	if event.Node == "" {
+		event.Node = n.Address
+	}
+
+	n.opts.auditSink.Emit(event)
+}
+
+// AuditPeer emits a security event concerning a peer, and an optional error
+// which triggered it. The event is correlated under the trace of ctx, if any.
+func (n *Network) AuditPeer(ctx context.Context, kind audit.Kind, reason string, id peer.ID, err error) {
+	event := audit.Event{
+		Kind:        kind,
+		Reason:      reason,
+		PeerAddress: id.Address,
+	}
+
+	if traceID := tracing.TraceIDFromContext(ctx); !traceID.IsZero() {
+		event.TraceID = traceID.String()
+	}
+
+	if len(id.PublicKey) > 0 {
+		event.PeerPublicKey = hex.EncodeToString(id.PublicKey)
+	}
+
+	if err != nil {
+		event.Error = err.Error()
+	}
+
+	n.Audit(event)
+}
+
+// recordOutbound records a message about to be sent to a peer to the audit
+// trail of the node, should audit mode be enabled.
+func (n *Network) recordOutbound(address string, message *protobuf.Message) error {
+	if n.opts.trail == nil {
+		return nil
+	}
+
+	raw, err := message.Marshal()
+	if err != nil {
+		return errors.Wrap(err, "network: failed to marshal message for audit trail")
+	}
+
+	digest := sha256.Sum256(raw)
+
+	_, err = n.opts.trail.Append(n.Clock().Now(), address, message.Opcode, digest[:], message.Signature)
+	return err
+}
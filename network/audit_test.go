@@ -0,0 +1,109 @@
+package network
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/perlin-network/noise/audit"
+	"github.com/perlin-network/noise/audit/trail"
+	"github.com/perlin-network/noise/crypto/ed25519"
+	"github.com/perlin-network/noise/types/opcode"
+)
+
+func TestAuditBootstrapFailure(t *testing.T) {
+	t.Parallel()
+
+	events := make(chan audit.Event, 1)
+
+	builder := NewBuilderWithOptions(AuditSink(audit.Channel(events)), ConnectionTimeout(1*time.Second))
+	builder.SetKeys(ed25519.RandomKeyPair())
+	listener, address, err := ListenOnRandomPort()
+	if err != nil {
+		t.Fatal(err)
+	}
+	builder.SetListener(listener)
+	builder.SetAddress(address)
+
+	net, err := builder.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	go net.Listen()
+	defer net.Close()
+	net.BlockUntilListening()
+
+	// Nothing should be listening on a freshly picked unused port.
+	peerAddress := FormatAddress("tcp", "localhost", uint16(GetRandomUnusedPort()))
+	net.Bootstrap(peerAddress)
+
+	var event audit.Event
+
+	select {
+	case event = <-events:
+		if event.Kind != audit.HandshakeFailed || event.Reason != audit.ReasonDialFailed {
+			t.Fatalf("expected a failed dial to be audited, got %+v", event)
+		}
+
+		if event.Version != audit.SchemaVersion || event.Node != net.Address || event.Time.IsZero() || event.Error == "" {
+			t.Fatalf("expected event to be filled in, got %+v", event)
+		}
+	default:
+		t.Fatal("expected bootstrap failure to be audited")
+	}
+
+	if failures := net.HandshakeFailures(); failures.Total[FailureOther] != 1 || failures.ByAddress[event.PeerAddress][FailureOther] != 1 {
+		t.Fatalf("expected bootstrap failure to be counted, got %+v", failures)
+	}
+}
+
+func TestAuditOutbound(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	build := func(opts ...BuilderOption) *Network {
+		builder := NewBuilderWithOptions(opts...)
+		builder.SetKeys(ed25519.RandomKeyPair())
+		listener, address, err := ListenOnRandomPort()
+		if err != nil {
+			t.Fatal(err)
+		}
+		builder.SetListener(listener)
+		builder.SetAddress(address)
+
+		net, err := builder.Build()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		go net.Listen()
+		net.BlockUntilListening()
+
+		return net
+	}
+
+	auditing, peer := build(AuditOutbound(trail.New(&buf))), build()
+	defer peer.Close()
+
+	if result := auditing.Bootstrap(peer.Address); result.Succeeded() != 1 {
+		t.Fatal("expected bootstrapping to succeed")
+	}
+
+	auditing.Close()
+
+	if count, err := trail.Verify(bytes.NewReader(buf.Bytes())); err != nil || count == 0 {
+		t.Fatalf("expected sent messages to be recorded, got %d records (err: %v)", count, err)
+	}
+
+	var record trail.Record
+	if err := json.NewDecoder(&buf).Decode(&record); err != nil {
+		t.Fatal(err)
+	}
+
+	if record.Recipient != peer.Address || record.Opcode != uint32(opcode.PingCode) || len(record.Signature) == 0 {
+		t.Fatalf("expected a signed ping to the peer to be recorded, got %+v", record)
+	}
+}
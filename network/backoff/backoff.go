@@ -21,6 +21,10 @@ type Backoff struct {
 	MinInterval time.Duration
 	// MaxInterval specifies maximum time allowed for backoff interval
 	MaxInterval time.Duration
+
+	// Float64 returns random numbers within [0.0, 1.0) jitter is drawn from
+	// (default: math/rand)
+	Float64 func() float64
 }
 
 const (
@@ -75,8 +79,13 @@ func (b *Backoff) ForAttempt(attempt int) time.Duration {
 		backoffInterval = defaultBackoffInterval
 	}
 
+	random := b.Float64
+	if random == nil {
+		random = rand.Float64
+	}
+
 	// Calculate the new duration
-	jitter := b.Jitter * 2 * (rand.Float64() - 0.5)
+	jitter := b.Jitter * 2 * (random() - 0.5)
 	durf := float64(min) * math.Pow(backoffInterval*(1-jitter), float64(attempt))
 
 	// Check for overflow
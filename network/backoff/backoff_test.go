@@ -1,6 +1,7 @@
 package backoff
 
 import (
+	"math/rand"
 	"testing"
 	"time"
 
@@ -83,3 +84,21 @@ func TestEdgeCases(t *testing.T) {
 	b.NextDuration()
 	assert.Equal(t, b.MaxInterval, b.NextDuration())
 }
+
+func TestSeededJitter(t *testing.T) {
+	t.Parallel()
+
+	durations := func() []time.Duration {
+		b := createTestBackoff()
+		b.Jitter = 0.5
+		b.Float64 = rand.New(rand.NewSource(42)).Float64
+
+		var durations []time.Duration
+		for i := 0; i < 3; i++ {
+			durations = append(durations, b.NextDuration())
+		}
+		return durations
+	}
+
+	assert.Equal(t, durations(), durations())
+}
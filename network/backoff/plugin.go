@@ -6,7 +6,6 @@ import (
 	"time"
 
 	"github.com/perlin-network/noise/internal/protobuf"
-	"github.com/perlin-network/noise/log"
 	"github.com/perlin-network/noise/network"
 )
 
@@ -94,18 +93,20 @@ func (p *Plugin) PeerDisconnect(client *network.PeerClient) {
 
 // startBackoff uses an exponentially increasing timer to try to reconnect to a given address
 func (p *Plugin) startBackoff(addr string) {
-	time.Sleep(p.initialDelay)
+	p.net.Clock().Sleep(p.initialDelay)
 
 	if _, exists := p.backoffs.Load(addr); exists {
 		// don't activate if backoff is already active
-		log.Info().
+		p.net.Log("backoff").Info().
 			Str("address", addr).
 			Msg("backoff skipped, already active")
 		return
 	}
 	// reset the backoff counter
-	p.backoffs.Store(addr, DefaultBackoff())
-	startTime := time.Now()
+	initial := DefaultBackoff()
+	initial.Float64 = p.net.Random().Float64
+	p.backoffs.Store(addr, initial)
+	startTime := p.net.Clock().Now()
 	for i := 0; i < p.maxAttempts; i++ {
 		s, active := p.backoffs.Load(addr)
 		if !active {
@@ -114,19 +115,19 @@ func (p *Plugin) startBackoff(addr string) {
 		b := s.(*Backoff)
 		if b.TimeoutExceeded() {
 			// check if the backoff expired
-			log.Info().
+			p.net.Log("backoff").Info().
 				Str("address", addr).
-				Dur("timeout", time.Now().Sub(startTime)).
+				Dur("timeout", p.net.Clock().Now().Sub(startTime)).
 				Msg("backoff ended, timed out")
 			break
 		}
 		// sleep for a bit before connecting
 		d := b.NextDuration()
-		log.Info().
+		p.net.Log("backoff").Info().
 			Str("address", addr).
 			Int("iteration", i+1).
 			Msg("backoff reconnecting")
-		time.Sleep(d)
+		p.net.Clock().Sleep(d)
 		if p.net.ConnectionStateExists(addr) {
 			// check that the connection is still empty before dialing
 			break
@@ -140,7 +141,8 @@ func (p *Plugin) startBackoff(addr string) {
 			// check if successfully connected
 			continue
 		}
-		if err := c.Tell(context.Background(), &protobuf.Ping{}); err != nil {
+		nonce := c.NewChallenge()
+		if err := c.Tell(context.Background(), &protobuf.Ping{Nonce: nonce, Stamp: p.net.NewStamp(nonce)}); err != nil {
 			// ping failed, not really connected
 			continue
 		}
@@ -0,0 +1,185 @@
+package blockexchange
+
+import (
+	"context"
+
+	"github.com/perlin-network/noise/internal/protobuf"
+	"github.com/perlin-network/noise/network/discovery"
+	"github.com/perlin-network/noise/peer"
+	"github.com/perlin-network/noise/types/content"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrNoProviders is returned when no peer could be asked for a block.
+	ErrNoProviders = errors.New("blockexchange: no providers of block found")
+
+	// ErrNoDiscovery is returned when announcing blocks without the discovery
+	// plugin registered.
+	ErrNoDiscovery = errors.New("blockexchange: discovery plugin is not registered")
+)
+
+// Put stores a block, handing it to all peers and fetches which want it, and
+// returns its content ID.
+func (p *Plugin) Put(data []byte) (content.ID, error) {
+	id := content.Sum(data)
+
+	if err := p.Blocks.Put(id, data); err != nil {
+		return nil, errors.Wrap(err, "blockexchange: failed to store block")
+	}
+
+	p.mutex.Lock()
+	waiting := p.wants[string(id)]
+	delete(p.wants, string(id))
+	p.mutex.Unlock()
+
+	for _, ch := range waiting {
+		ch <- data
+	}
+
+	p.pushBlock(id, data)
+
+	return id, nil
+}
+
+// Announce announces this node as a provider of a block to the peers closest
+// to its content ID through the discovery plugin.
+func (p *Plugin) Announce(ctx context.Context, id content.ID) error {
+	if _, exists := p.net.Plugin(discovery.PluginID); !exists {
+		return ErrNoDiscovery
+	}
+	return discovery.ProvideContext(ctx, p.net, id.Key())
+}
+
+// Fetch returns the block addressed by id, requesting it from a number of
+// peers should it not be held locally. Should no peers be specified, providers
+// of the block are looked up through the discovery plugin.
+//
+// Peers which do not hold the block keep it on their want-lists, and push it
+// to us should they come to hold it. Fetch hence blocks until the block is
+// received, or ctx is cancelled.
+func (p *Plugin) Fetch(ctx context.Context, id content.ID, peers ...peer.ID) ([]byte, error) {
+	if data, exists := p.Blocks.Get(id); exists {
+		return data, nil
+	}
+
+	ch := p.want(id)
+	defer p.unwant(id, ch)
+
+	if len(peers) == 0 {
+		peers = p.findProviders(ctx, id)
+	}
+
+	var asked []peer.ID
+
+	for _, peerID := range peers {
+		if peerID.Equals(p.net.ID) {
+			continue
+		}
+
+		response, err := p.request(ctx, peerID, &protobuf.BlockRequest{Wants: [][]byte{id}})
+		if err != nil {
+			continue
+		}
+
+		asked = append(asked, peerID)
+
+		for _, block := range response.Blocks {
+			p.receiveBlock(peerID, block)
+		}
+
+		select {
+		case data := <-ch:
+			p.cancel(asked, id)
+			return data, nil
+		default:
+		}
+	}
+
+	if len(asked) == 0 {
+		return nil, ErrNoProviders
+	}
+
+	select {
+	case data := <-ch:
+		p.cancel(asked, id)
+		return data, nil
+	case <-ctx.Done():
+		p.cancel(asked, id)
+		return nil, ctx.Err()
+	}
+}
+
+// findProviders looks up providers of a block through the discovery plugin.
+func (p *Plugin) findProviders(ctx context.Context, id content.ID) []peer.ID {
+	plugin, exists := p.net.Plugin(discovery.PluginID)
+	if !exists {
+		return nil
+	}
+
+	return discovery.FindProvidersContext(ctx, p.net, id.Key(), plugin.(*discovery.Plugin).Alpha, p.MaxProviders)
+}
+
+// want registers a fetch as awaiting a block.
+func (p *Plugin) want(id content.ID) chan []byte {
+	ch := make(chan []byte, 1)
+
+	p.mutex.Lock()
+	p.wants[string(id)] = append(p.wants[string(id)], ch)
+	p.mutex.Unlock()
+
+	return ch
+}
+
+// unwant deregisters a fetch from awaiting a block.
+func (p *Plugin) unwant(id content.ID, ch chan []byte) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	waiting := p.wants[string(id)]
+	for i := range waiting {
+		if waiting[i] == ch {
+			waiting = append(waiting[:i], waiting[i+1:]...)
+			break
+		}
+	}
+
+	if len(waiting) == 0 {
+		delete(p.wants, string(id))
+	} else {
+		p.wants[string(id)] = waiting
+	}
+}
+
+// cancel tells peers to remove a block from our want-list.
+func (p *Plugin) cancel(peers []peer.ID, id content.ID) {
+	for _, peerID := range peers {
+		if client, err := p.net.Client(peerID.Address); err == nil {
+			client.Tell(context.Background(), &protobuf.BlockRequest{Cancels: [][]byte{id}})
+		}
+	}
+}
+
+// request sends a block request to a peer, and awaits its response.
+func (p *Plugin) request(ctx context.Context, peerID peer.ID, req *protobuf.BlockRequest) (*protobuf.BlockResponse, error) {
+	client, err := p.net.Client(peerID.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, p.RequestTimeout)
+	defer cancel()
+
+	res, err := client.Request(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	response, ok := res.(*protobuf.BlockResponse)
+	if !ok {
+		return nil, errors.Errorf("blockexchange: unexpected response %T from %s", res, peerID.Address)
+	}
+
+	return response, nil
+}
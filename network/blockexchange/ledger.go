@@ -0,0 +1,58 @@
+package blockexchange
+
+import (
+	"github.com/perlin-network/noise/peer"
+)
+
+// Ledger accounts for the blocks exchanged with a single peer, such that
+// applications may judge how much a peer gives back in return for what it
+// takes.
+type Ledger struct {
+	BlocksSent     uint64
+	BlocksReceived uint64
+	BytesSent      uint64
+	BytesReceived  uint64
+}
+
+// Ledger returns a copy of the ledger held for a peer.
+func (p *Plugin) Ledger(peerID peer.ID) Ledger {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if ledger, exists := p.ledgers[peerID.PublicKeyHex()]; exists {
+		return *ledger
+	}
+	return Ledger{}
+}
+
+// sent records a block of size bytes as sent to a peer.
+func (p *Plugin) sent(peerID peer.ID, size int) {
+	p.mutex.Lock()
+	ledger := p.ledger(peerID)
+	ledger.BlocksSent++
+	ledger.BytesSent += uint64(size)
+	p.mutex.Unlock()
+}
+
+// received records a block of size bytes as received from a peer.
+func (p *Plugin) received(peerID peer.ID, size int) {
+	p.mutex.Lock()
+	ledger := p.ledger(peerID)
+	ledger.BlocksReceived++
+	ledger.BytesReceived += uint64(size)
+	p.mutex.Unlock()
+}
+
+// ledger returns the ledger held for a peer, creating it should it not exist.
+// The mutex must be held.
+func (p *Plugin) ledger(peerID peer.ID) *Ledger {
+	key := peerID.PublicKeyHex()
+
+	ledger, exists := p.ledgers[key]
+	if !exists {
+		ledger = new(Ledger)
+		p.ledgers[key] = ledger
+	}
+
+	return ledger
+}
@@ -0,0 +1,293 @@
+package blockexchange
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/perlin-network/noise/internal/protobuf"
+	"github.com/perlin-network/noise/network"
+	"github.com/perlin-network/noise/peer"
+	"github.com/perlin-network/noise/types/content"
+)
+
+const (
+	// defaultRequestTimeout is how long a single peer is given to respond to a
+	// block request.
+	defaultRequestTimeout = 10 * time.Second
+	// defaultMaxProviders is how many providers are looked up per fetch.
+	defaultMaxProviders = 8
+	// defaultMaxWants is how many blocks a single peer may want from us at once.
+	defaultMaxWants = 256
+)
+
+// Plugin exchanges content blocks with peers. Peers request blocks by their
+// content IDs, and are served the blocks we hold. Blocks we lack are kept on
+// the peer's want-list, and pushed to the peer should we come to hold them.
+//
+// Providers of blocks are located through the discovery plugin, should it be
+// registered.
+type Plugin struct {
+	*network.Plugin
+
+	// Blocks holds the blocks served by this node (default: an in-memory
+	// store).
+	Blocks BlockStore
+
+	// RequestTimeout is how long a single peer is given to respond to a block
+	// request (default: 10 seconds).
+	RequestTimeout time.Duration
+	// MaxProviders is how many providers of a block are looked up per fetch
+	// (default: 8).
+	MaxProviders int
+	// MaxWants is how many blocks a single peer may want from us at once
+	// (default: 256).
+	MaxWants int
+
+	net *network.Network
+
+	mutex sync.Mutex
+
+	// Blocks wanted by peers: content ID -> peer public key hex -> peer ID.
+	peerWants map[string]map[string]peer.ID
+
+	// Blocks wanted by this node: content ID -> fetches awaiting the block.
+	wants map[string][]chan []byte
+
+	// Ledgers of blocks exchanged per peer: peer public key hex -> ledger.
+	ledgers map[string]*Ledger
+}
+
+var (
+	// PluginID is used to check existence of the block exchange plugin.
+	PluginID                         = (*Plugin)(nil)
+	_        network.PluginInterface = (*Plugin)(nil)
+)
+
+// PluginOption are configurable options for the block exchange plugin.
+type PluginOption func(*Plugin)
+
+// WithBlockStore sets the store blocks served by this node are held in.
+func WithBlockStore(store BlockStore) PluginOption {
+	return func(p *Plugin) {
+		p.Blocks = store
+	}
+}
+
+// WithRequestTimeout sets how long a single peer is given to respond to a
+// block request.
+func WithRequestTimeout(d time.Duration) PluginOption {
+	return func(p *Plugin) {
+		p.RequestTimeout = d
+	}
+}
+
+// WithMaxProviders sets how many providers of a block are looked up per fetch.
+func WithMaxProviders(n int) PluginOption {
+	return func(p *Plugin) {
+		p.MaxProviders = n
+	}
+}
+
+// WithMaxWants sets how many blocks a single peer may want from us at once.
+func WithMaxWants(n int) PluginOption {
+	return func(p *Plugin) {
+		p.MaxWants = n
+	}
+}
+
+// New returns a new block exchange plugin with specified options. Options
+// left unspecified take on their defaults once the plugin starts up, such that
+// new(Plugin) remains valid.
+func New(opts ...PluginOption) *Plugin {
+	p := new(Plugin)
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+// setDefaults fills in all options which have been left unspecified.
+func (p *Plugin) setDefaults() {
+	if p.Blocks == nil {
+		p.Blocks = NewMemoryBlockStore()
+	}
+
+	if p.RequestTimeout <= 0 {
+		p.RequestTimeout = defaultRequestTimeout
+	}
+
+	if p.MaxProviders <= 0 {
+		p.MaxProviders = defaultMaxProviders
+	}
+
+	if p.MaxWants <= 0 {
+		p.MaxWants = defaultMaxWants
+	}
+
+	p.peerWants = make(map[string]map[string]peer.ID)
+	p.wants = make(map[string][]chan []byte)
+	p.ledgers = make(map[string]*Ledger)
+}
+
+func (p *Plugin) Startup(net *network.Network) {
+	p.setDefaults()
+	p.net = net
+}
+
+func (p *Plugin) Receive(ctx *network.PluginContext) error {
+	switch msg := ctx.Message().(type) {
+	case *protobuf.BlockRequest:
+		sender := ctx.Sender()
+
+		for _, raw := range msg.Cancels {
+			p.cancelPeerWant(sender, content.ID(raw))
+		}
+
+		// Cancellations are told, and expect no response.
+		if len(msg.Wants) == 0 {
+			break
+		}
+
+		response := &protobuf.BlockResponse{}
+
+		for _, raw := range msg.Wants {
+			id, err := content.Parse(raw)
+			if err != nil {
+				continue
+			}
+
+			if data, exists := p.Blocks.Get(id); exists {
+				response.Blocks = append(response.Blocks, &protobuf.Block{Id: id, Data: data})
+				p.sent(sender, len(data))
+			} else {
+				p.addPeerWant(sender, id)
+			}
+		}
+
+		err := ctx.Reply(context.Background(), response)
+		if err != nil {
+			return err
+		}
+	case *protobuf.BlockResponse:
+		// Blocks pushed to us by peers which have come to hold blocks we want.
+		for _, block := range msg.Blocks {
+			p.receiveBlock(ctx.Sender(), block)
+		}
+	}
+
+	return nil
+}
+
+func (p *Plugin) PeerDisconnect(client *network.PeerClient) {
+	id := client.ID()
+	if id == nil {
+		return
+	}
+
+	// Forget the want-list of the disconnected peer.
+	p.mutex.Lock()
+	for key, peers := range p.peerWants {
+		delete(peers, id.PublicKeyHex())
+		if len(peers) == 0 {
+			delete(p.peerWants, key)
+		}
+	}
+	p.mutex.Unlock()
+}
+
+// addPeerWant places a block onto the want-list of a peer, should the peer not
+// already want too many blocks.
+func (p *Plugin) addPeerWant(peerID peer.ID, id content.ID) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	wanted := 0
+	for _, peers := range p.peerWants {
+		if _, exists := peers[peerID.PublicKeyHex()]; exists {
+			wanted++
+		}
+	}
+
+	if wanted >= p.MaxWants {
+		return
+	}
+
+	peers, exists := p.peerWants[string(id)]
+	if !exists {
+		peers = make(map[string]peer.ID)
+		p.peerWants[string(id)] = peers
+	}
+
+	peers[peerID.PublicKeyHex()] = peerID
+}
+
+// cancelPeerWant removes a block from the want-list of a peer.
+func (p *Plugin) cancelPeerWant(peerID peer.ID, id content.ID) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if peers, exists := p.peerWants[string(id)]; exists {
+		delete(peers, peerID.PublicKeyHex())
+		if len(peers) == 0 {
+			delete(p.peerWants, string(id))
+		}
+	}
+}
+
+// receiveBlock verifies and stores a block pushed by a peer, handing it to all
+// fetches awaiting it. Blocks we do not want are ignored.
+func (p *Plugin) receiveBlock(sender peer.ID, block *protobuf.Block) {
+	id := content.ID(block.Id)
+	if id.Verify(block.Data) != nil {
+		p.net.Log("blockexchange").Debug().
+			Str("peer_address", sender.Address).
+			Msg("Dropped block which does not match its content ID.")
+		return
+	}
+
+	p.mutex.Lock()
+	waiting, wanted := p.wants[string(id)]
+	delete(p.wants, string(id))
+	p.mutex.Unlock()
+
+	if !wanted {
+		return
+	}
+
+	p.received(sender, len(block.Data))
+
+	if err := p.Blocks.Put(id, block.Data); err != nil {
+		p.net.Log("blockexchange").Warn().Err(err).Msg("Failed to store block.")
+	}
+
+	for _, ch := range waiting {
+		ch <- block.Data
+	}
+
+	p.pushBlock(id, block.Data)
+}
+
+// pushBlock pushes a block to all peers which want it.
+func (p *Plugin) pushBlock(id content.ID, data []byte) {
+	p.mutex.Lock()
+	peers := p.peerWants[string(id)]
+	delete(p.peerWants, string(id))
+	p.mutex.Unlock()
+
+	for _, peerID := range peers {
+		client, err := p.net.Client(peerID.Address)
+		if err != nil {
+			continue
+		}
+
+		err = client.Tell(context.Background(), &protobuf.BlockResponse{Blocks: []*protobuf.Block{{Id: id, Data: data}}})
+		if err != nil {
+			continue
+		}
+
+		p.sent(peerID, len(data))
+	}
+}
@@ -0,0 +1,94 @@
+package blockexchange
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/perlin-network/noise/internal/test"
+	"github.com/perlin-network/noise/network"
+	"github.com/perlin-network/noise/network/discovery"
+	"github.com/perlin-network/noise/types/content"
+)
+
+func buildNode(t *testing.T) (*network.Network, *Plugin) {
+	plugin := New(WithRequestTimeout(1 * time.Second))
+
+	return test.BuildNode(t, nil, new(discovery.Plugin), plugin), plugin
+}
+
+func TestBlockExchange(t *testing.T) {
+	if testing.Short() {
+		t.Skipf("skipping %s in short mode", t.Name())
+	}
+
+	seed, seeder := buildNode(t)
+	defer seed.Close()
+
+	leech, leecher := buildNode(t)
+	defer leech.Close()
+
+	leech.Bootstrap(seed.Address)
+	time.Sleep(200 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	data := []byte("block")
+
+	id, err := seeder.Put(data)
+	if err != nil {
+		t.Fatalf("Put() = expected no error, got %v", err)
+	}
+
+	if err := seeder.Announce(ctx, id); err != nil {
+		t.Fatalf("Announce() = expected no error, got %v", err)
+	}
+
+	fetched, err := leecher.Fetch(ctx, id)
+	if err != nil || !bytes.Equal(data, fetched) {
+		t.Fatalf("Fetch() = expected %q, got %q (err: %v)", data, fetched, err)
+	}
+
+	if ledger := seeder.Ledger(leech.ID); ledger.BlocksSent != 1 || ledger.BytesSent != uint64(len(data)) {
+		t.Fatalf("expected seeders ledger to account for 1 block sent, got %+v", ledger)
+	}
+
+	if ledger := leecher.Ledger(seed.ID); ledger.BlocksReceived != 1 {
+		t.Fatalf("expected leechers ledger to account for 1 block received, got %+v", ledger)
+	}
+
+	// Blocks the seeder comes to hold later on are pushed to the leecher
+	// through its want-list.
+	wanted := []byte("wanted block")
+
+	result := make(chan []byte, 1)
+	go func() {
+		fetched, _ := leecher.Fetch(ctx, content.Sum(wanted), seed.ID)
+		result <- fetched
+	}()
+
+	time.Sleep(200 * time.Millisecond)
+
+	if _, err := seeder.Put(wanted); err != nil {
+		t.Fatalf("Put() = expected no error, got %v", err)
+	}
+
+	if fetched := <-result; !bytes.Equal(wanted, fetched) {
+		t.Fatalf("expected wanted block %q to be pushed, got %q", wanted, fetched)
+	}
+}
+
+func TestFetchNoProviders(t *testing.T) {
+	if testing.Short() {
+		t.Skipf("skipping %s in short mode", t.Name())
+	}
+
+	net, plugin := buildNode(t)
+	defer net.Close()
+
+	if _, err := plugin.Fetch(context.Background(), content.Sum([]byte("missing"))); err != ErrNoProviders {
+		t.Fatalf("Fetch() = expected ErrNoProviders, got %v", err)
+	}
+}
@@ -0,0 +1,42 @@
+package blockexchange
+
+import (
+	"sync"
+
+	"github.com/perlin-network/noise/types/content"
+)
+
+// BlockStore persists the blocks a node holds, keyed by their content IDs.
+type BlockStore interface {
+	// Get returns the block addressed by id, should it be held.
+	Get(id content.ID) ([]byte, bool)
+	// Put stores a block under its content ID.
+	Put(id content.ID, data []byte) error
+}
+
+// MemoryBlockStore is a BlockStore which keeps all blocks in memory.
+type MemoryBlockStore struct {
+	blocks sync.Map // string -> []byte
+}
+
+var _ BlockStore = (*MemoryBlockStore)(nil)
+
+// NewMemoryBlockStore returns a new empty in-memory block store.
+func NewMemoryBlockStore() *MemoryBlockStore {
+	return new(MemoryBlockStore)
+}
+
+// Get returns the block addressed by id, should it be held.
+func (s *MemoryBlockStore) Get(id content.ID) ([]byte, bool) {
+	data, exists := s.blocks.Load(string(id))
+	if !exists {
+		return nil, false
+	}
+	return data.([]byte), true
+}
+
+// Put stores a block under its content ID.
+func (s *MemoryBlockStore) Put(id content.ID, data []byte) error {
+	s.blocks.Store(string(id), data)
+	return nil
+}
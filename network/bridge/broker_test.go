@@ -0,0 +1,444 @@
+package bridge
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/perlin-network/noise/network/backoff"
+)
+
+func TestNATS(t *testing.T) {
+	t.Parallel()
+
+	client, server := net.Pipe()
+	defer server.Close()
+
+	reader := bufio.NewReader(server)
+	expect := func(prefix string) string {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.HasPrefix(line, prefix) {
+			t.Fatalf("expected line starting with %q, got %q", prefix, line)
+		}
+		return line
+	}
+
+	connected := make(chan *NATS)
+
+	go func() {
+		n, err := newNATS("", client, dialOptions{})
+		if err != nil {
+			t.Error(err)
+		}
+		connected <- n
+	}()
+
+	server.Write([]byte("INFO {\"server_id\":\"test\"}\r\n"))
+	if connect := expect("CONNECT "); !strings.Contains(connect, `"echo":false`) {
+		t.Fatalf("expected the client to opt out of echoes, got %q", connect)
+	}
+	expect("PING")
+	server.Write([]byte("PONG\r\n"))
+
+	n := <-connected
+	if n == nil {
+		t.FailNow()
+	}
+	defer n.Close()
+
+	received := make(chan string, 1)
+
+	go func() {
+		if err := n.Subscribe("noise.>", func(subject string, data []byte) {
+			received <- subject + "=" + string(data)
+		}); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	expect("SUB noise.> 1")
+
+	server.Write([]byte("PING\r\n"))
+	expect("PONG")
+
+	server.Write([]byte("MSG noise.alerts 1 4\r\nfire\r\n"))
+
+	select {
+	case msg := <-received:
+		if msg != "noise.alerts=fire" {
+			t.Fatalf("expected message to be handed to subscription, got %q", msg)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for message")
+	}
+
+	go n.Publish("noise.metrics", []byte("42"))
+
+	expect("PUB noise.metrics 2")
+	expect("42")
+
+	if err := n.Publish("bad subject", nil); err == nil {
+		t.Fatalf("expected publishing to a subject with spaces to fail")
+	}
+}
+
+func TestMQTT(t *testing.T) {
+	t.Parallel()
+
+	client, server := net.Pipe()
+	defer server.Close()
+
+	reader := bufio.NewReader(server)
+	expect := func(kind byte) []byte {
+		actual, body, err := readPacket(reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if actual != kind {
+			t.Fatalf("expected packet type %#x, got %#x", kind, actual)
+		}
+		return body
+	}
+
+	connected := make(chan *MQTT)
+
+	go func() {
+		m, err := newMQTT("", client, "noise", dialOptions{})
+		if err != nil {
+			t.Error(err)
+		}
+		connected <- m
+	}()
+
+	if body := expect(mqttConnect); !strings.HasPrefix(string(body), "\x00\x04MQTT\x04") {
+		t.Fatalf("expected an MQTT 3.1.1 connect packet, got %x", body)
+	}
+	server.Write([]byte{mqttConnAck, 2, 0, 0})
+
+	m := <-connected
+	if m == nil {
+		t.FailNow()
+	}
+
+	received := make(chan string, 1)
+
+	go m.Subscribe("noise.*.alerts", func(subject string, data []byte) {
+		received <- subject + "=" + string(data)
+	})
+
+	if body := expect(mqttSubscribe); string(body[4:len(body)-1]) != "noise/+/alerts" {
+		t.Fatalf("expected subscription to map onto an MQTT topic filter, got %q", body[4:len(body)-1])
+	}
+	server.Write([]byte{mqttSubAck, 3, 0, 1, 0})
+
+	publish := append([]byte{mqttPublish, 21, 0, 15}, "noise/eu/alerts"...)
+	server.Write(append(publish, "fire"...))
+
+	select {
+	case msg := <-received:
+		if msg != "noise.eu.alerts=fire" {
+			t.Fatalf("expected message to be handed to subscription, got %q", msg)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for message")
+	}
+
+	go m.Publish("noise.metrics", []byte("42"))
+
+	if body := expect(mqttPublish); string(body) != "\x00\x0dnoise/metrics42" {
+		t.Fatalf("expected message to be published under an MQTT topic, got %q", body)
+	}
+
+	if err := m.Publish("noise.>", nil); err == nil {
+		t.Fatalf("expected publishing to a wildcard subject to fail")
+	}
+
+	// Messages published under subscribed topics are echoed back by brokers,
+	// and the echoes dropped.
+	go m.Publish("noise.eu.alerts", []byte("smoke"))
+
+	echo := expect(mqttPublish)
+	server.Write(append([]byte{mqttPublish, byte(len(echo))}, echo...))
+	server.Write(append(publish, "fire"...))
+
+	select {
+	case msg := <-received:
+		if msg != "noise.eu.alerts=fire" {
+			t.Fatalf("expected the echo of a message published by the client to be dropped, got %q", msg)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for message")
+	}
+
+	server.Close()
+	m.Close()
+}
+
+func TestMatchMQTT(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		filter, topic string
+		match         bool
+	}{
+		{"a/b", "a/b", true},
+		{"a/b", "a/c", false},
+		{"a/+", "a/b", true},
+		{"a/+", "a/b/c", false},
+		{"a/#", "a/b/c", true},
+		{"#", "a", true},
+		{"a/b/c", "a/b", false},
+	}
+
+	for _, c := range cases {
+		if matchMQTT(c.filter, c.topic) != c.match {
+			t.Fatalf("matchMQTT(%q, %q) = expected %v", c.filter, c.topic, c.match)
+		}
+	}
+}
+
+// natsServer is a minimal NATS server, which delivers messages to all
+// subscriptions matching their subject, except to clients publishing them
+// which opted out of echoes.
+type natsServer struct {
+	listener net.Listener
+
+	username, password string
+
+	mutex sync.Mutex
+	conns map[*natsServerConn]struct{}
+}
+
+type natsServerConn struct {
+	conn net.Conn
+	echo bool
+
+	mutex sync.Mutex
+	subs  map[string]string // sid -> subject
+}
+
+func newNATSServer(t *testing.T, username, password string) *natsServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := &natsServer{
+		listener: listener,
+		username: username,
+		password: password,
+		conns:    make(map[*natsServerConn]struct{}),
+	}
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+
+	return s
+}
+
+func (s *natsServer) Addr() string {
+	return s.listener.Addr().String()
+}
+
+// drop closes the connections of all clients.
+func (s *natsServer) drop() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for c := range s.conns {
+		c.conn.Close()
+	}
+}
+
+func (s *natsServer) Close() {
+	s.listener.Close()
+	s.drop()
+}
+
+// matchNATS returns whether a subject matches a subject holding wildcards.
+func matchNATS(pattern string, subject string) bool {
+	patternTokens, subjectTokens := strings.Split(pattern, "."), strings.Split(subject, ".")
+
+	for i, token := range patternTokens {
+		if token == ">" {
+			return len(subjectTokens) > i
+		}
+
+		if i >= len(subjectTokens) || (token != "*" && token != subjectTokens[i]) {
+			return false
+		}
+	}
+
+	return len(patternTokens) == len(subjectTokens)
+}
+
+func (s *natsServer) serve(conn net.Conn) {
+	defer conn.Close()
+
+	c := &natsServerConn{conn: conn, subs: make(map[string]string)}
+
+	conn.Write([]byte("INFO {\"server_id\":\"test\"}\r\n"))
+
+	reader := bufio.NewReader(conn)
+
+	line, err := reader.ReadString('\n')
+	if err != nil || !strings.HasPrefix(line, "CONNECT ") {
+		return
+	}
+
+	var options natsConnect
+	if err := json.Unmarshal([]byte(strings.TrimPrefix(line, "CONNECT ")), &options); err != nil {
+		return
+	}
+
+	if options.User != s.username || options.Pass != s.password {
+		conn.Write([]byte("-ERR 'Authorization Violation'\r\n"))
+		return
+	}
+
+	c.echo = options.Echo
+
+	s.mutex.Lock()
+	s.conns[c] = struct{}{}
+	s.mutex.Unlock()
+
+	defer func() {
+		s.mutex.Lock()
+		delete(s.conns, c)
+		s.mutex.Unlock()
+	}()
+
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch fields[0] {
+		case "PING":
+			c.write("PONG\r\n")
+		case "SUB":
+			c.mutex.Lock()
+			c.subs[fields[2]] = fields[1]
+			c.mutex.Unlock()
+		case "PUB":
+			size, _ := strconv.Atoi(fields[2])
+
+			payload := make([]byte, size+2)
+			if _, err := io.ReadFull(reader, payload); err != nil {
+				return
+			}
+
+			s.publish(c, fields[1], payload[:size])
+		}
+	}
+}
+
+func (s *natsServer) publish(publisher *natsServerConn, subject string, data []byte) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for c := range s.conns {
+		if c == publisher && !c.echo {
+			continue
+		}
+
+		c.mutex.Lock()
+		for sid, pattern := range c.subs {
+			if matchNATS(pattern, subject) {
+				c.write("MSG " + subject + " " + sid + " " + strconv.Itoa(len(data)) + "\r\n" + string(data) + "\r\n")
+			}
+		}
+		c.mutex.Unlock()
+	}
+}
+
+func (c *natsServerConn) write(data string) {
+	c.conn.Write([]byte(data))
+}
+
+func TestNATSCredentials(t *testing.T) {
+	t.Parallel()
+
+	server := newNATSServer(t, "noise", "secret")
+	defer server.Close()
+
+	if _, err := DialNATS(server.Addr(), WithCredentials("noise", "wrong")); err == nil {
+		t.Fatalf("expected a client with wrong credentials to be refused")
+	}
+
+	n, err := DialNATS(server.Addr(), WithCredentials("noise", "secret"))
+	if err != nil {
+		t.Fatalf("expected a client with valid credentials to connect, got %v", err)
+	}
+	n.Close()
+}
+
+func TestNATSReconnect(t *testing.T) {
+	t.Parallel()
+
+	server := newNATSServer(t, "", "")
+	defer server.Close()
+
+	policy := backoff.Backoff{MaxAttempts: 50, MinInterval: 10 * time.Millisecond, MaxInterval: 50 * time.Millisecond}
+
+	subscriber, err := DialNATS(server.Addr(), WithReconnect(policy))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer subscriber.Close()
+
+	received := make(chan string, 16)
+	if err := subscriber.Subscribe("noise.>", func(subject string, data []byte) {
+		received <- string(data)
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	publisher, err := DialNATS(server.Addr(), WithReconnect(policy))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer publisher.Close()
+
+	server.drop()
+
+	// Subscriptions are restored once the connection is re-established.
+	deadline := time.After(5 * time.Second)
+
+	for {
+		publisher.Publish("noise.alerts", []byte("fire"))
+
+		select {
+		case data := <-received:
+			if data != "fire" {
+				t.Fatalf("expected message to be received past reconnecting, got %q", data)
+			}
+			return
+		case <-time.After(50 * time.Millisecond):
+		case <-deadline:
+			t.Fatalf("timed out waiting for the subscription to be restored")
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package bridge
+
+import (
+	"crypto/tls"
+	"net"
+	"time"
+
+	"github.com/perlin-network/noise/network/backoff"
+
+	"github.com/pkg/errors"
+)
+
+// DialOption configures how a client connects to a broker.
+type DialOption func(*dialOptions)
+
+type dialOptions struct {
+	tls *tls.Config
+
+	username string
+	password string
+	token    string
+
+	reconnect *backoff.Backoff
+}
+
+// WithTLS secures the connection to the broker with TLS (default: plaintext).
+func WithTLS(config *tls.Config) DialOption {
+	return func(o *dialOptions) {
+		o.tls = config
+	}
+}
+
+// WithCredentials authenticates to the broker with a username and password
+// (default: none).
+func WithCredentials(username, password string) DialOption {
+	return func(o *dialOptions) {
+		o.username = username
+		o.password = password
+	}
+}
+
+// WithToken authenticates to a NATS server with a token. MQTT brokers ignore
+// it (default: none).
+func WithToken(token string) DialOption {
+	return func(o *dialOptions) {
+		o.token = token
+	}
+}
+
+// WithReconnect has a client re-establish its connection to the broker should
+// it drop, and restore all subscriptions over it. Attempts are spaced out as
+// per a backoff policy, and given up on once it runs out of attempts (default:
+// connections are not re-established).
+func WithReconnect(policy backoff.Backoff) DialOption {
+	return func(o *dialOptions) {
+		o.reconnect = &policy
+	}
+}
+
+func newDialOptions(opts []DialOption) dialOptions {
+	var o dialOptions
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
+
+// secure performs a TLS handshake over a connection to a broker at a host:port
+// address.
+func (o dialOptions) secure(conn net.Conn, address string) (net.Conn, error) {
+	config := o.tls
+
+	if config.ServerName == "" && !config.InsecureSkipVerify {
+		host, _, err := net.SplitHostPort(address)
+		if err != nil {
+			return nil, errors.Wrap(err, "bridge: failed to derive tls server name")
+		}
+
+		config = config.Clone()
+		config.ServerName = host
+	}
+
+	secured := tls.Client(conn, config)
+	if err := secured.Handshake(); err != nil {
+		return nil, errors.Wrap(err, "bridge: tls handshake failed")
+	}
+
+	return secured, nil
+}
+
+// redial dials a broker at a host:port address and calls connect over the
+// connection until it succeeds, the reconnection policy runs out of attempts,
+// or done is closed. It returns whether or not connect succeeded.
+func (o dialOptions) redial(address string, done <-chan struct{}, connect func(conn net.Conn) error) bool {
+	if o.reconnect == nil || address == "" {
+		return false
+	}
+
+	policy := *o.reconnect
+
+	for !policy.TimeoutExceeded() {
+		select {
+		case <-done:
+			return false
+		case <-time.After(policy.NextDuration()):
+		}
+
+		conn, err := net.Dial("tcp", address)
+		if err != nil {
+			continue
+		}
+
+		if err := connect(conn); err != nil {
+			conn.Close()
+			continue
+		}
+
+		return true
+	}
+
+	return false
+}
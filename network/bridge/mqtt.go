@@ -0,0 +1,509 @@
+package bridge
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/binary"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// MQTT control packet types, held within the upper nibble of the first byte
+// of a packet alongside their flags.
+const (
+	mqttConnect    = 0x10
+	mqttConnAck    = 0x20
+	mqttPublish    = 0x30
+	mqttSubscribe  = 0x82
+	mqttSubAck     = 0x90
+	mqttPingReq    = 0xc0
+	mqttPingResp   = 0xd0
+	mqttDisconnect = 0xe0
+)
+
+const (
+	// mqttProtocolLevel denotes MQTT 3.1.1.
+	mqttProtocolLevel = 4
+	// mqttCleanSession asks the broker to not keep state across connections.
+	mqttCleanSession = 0x02
+	// mqttUsername and mqttPassword flag credentials within a connect packet.
+	mqttUsername = 0x80
+	mqttPassword = 0x40
+	// mqttKeepAlive is how long the broker keeps idle connections open.
+	mqttKeepAlive = 60 * time.Second
+	// mqttMaxLength is the largest remaining length of a packet.
+	mqttMaxLength = 268435455
+	// mqttMaxEchoes is the most messages published by a client whose echoes
+	// are awaited at once.
+	mqttMaxEchoes = 1024
+)
+
+// MQTT is a minimal MQTT 3.1.1 client, which publishes and subscribes at QoS 0.
+//
+// Subjects are given in the dot-separated form of NATS, and are mapped to MQTT
+// topics by replacing dots with slashes, and the wildcards * and > with + and
+// # respectively.
+//
+// As MQTT 3.1.1 brokers deliver messages to the client publishing them should
+// it be subscribed to their topic, the client drops the echoes of messages it
+// published itself.
+type MQTT struct {
+	address  string
+	clientID string
+	opts     dialOptions
+
+	mutex sync.Mutex
+	conn  net.Conn
+
+	subsMutex sync.RWMutex
+	subs      []mqttSubscription
+	packetID  uint16
+
+	// Digests of messages published by the client under topics it is
+	// subscribed to, whose echoes are yet to be dropped, oldest first.
+	echoesMutex sync.Mutex
+	echoes      map[[sha256.Size]byte]int
+	echoQueue   [][sha256.Size]byte
+
+	done chan struct{}
+	once sync.Once
+}
+
+type mqttSubscription struct {
+	filter  string
+	handler func(subject string, data []byte)
+}
+
+var _ Broker = (*MQTT)(nil)
+
+// DialMQTT connects to an MQTT broker at a host:port address under a client
+// ID.
+func DialMQTT(address string, clientID string, opts ...DialOption) (*MQTT, error) {
+	conn, err := net.Dial("tcp", address)
+	if err != nil {
+		return nil, errors.Wrap(err, "bridge: failed to dial mqtt broker")
+	}
+
+	m, err := newMQTT(address, conn, clientID, newDialOptions(opts))
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// newMQTT connects to an MQTT broker at a host:port address over a connection.
+func newMQTT(address string, conn net.Conn, clientID string, opts dialOptions) (*MQTT, error) {
+	m := &MQTT{
+		address:  address,
+		clientID: clientID,
+		opts:     opts,
+		echoes:   make(map[[sha256.Size]byte]int),
+		done:     make(chan struct{}),
+	}
+
+	reader, err := m.connect(conn)
+	if err != nil {
+		return nil, err
+	}
+
+	go m.readLoop(reader)
+	go m.keepAlive()
+
+	return m, nil
+}
+
+// connect connects to the broker over a connection, and restores all
+// subscriptions over it.
+func (m *MQTT) connect(conn net.Conn) (*bufio.Reader, error) {
+	if m.opts.tls != nil {
+		var err error
+		if conn, err = m.opts.secure(conn, m.address); err != nil {
+			return nil, err
+		}
+	}
+
+	flags := byte(mqttCleanSession)
+	if m.opts.username != "" {
+		flags |= mqttUsername | mqttPassword
+	}
+
+	var body []byte
+	body = appendString(body, "MQTT")
+	body = append(body, mqttProtocolLevel, flags)
+	body = appendUint16(body, uint16(mqttKeepAlive/time.Second))
+	body = appendString(body, m.clientID)
+
+	if m.opts.username != "" {
+		body = appendString(body, m.opts.username)
+		body = appendString(body, m.opts.password)
+	}
+
+	if err := writePacket(conn, mqttConnect, body); err != nil {
+		return nil, err
+	}
+
+	reader := bufio.NewReader(conn)
+
+	kind, ack, err := readPacket(reader)
+	if err != nil {
+		return nil, errors.Wrap(err, "bridge: failed to connect to mqtt broker")
+	}
+
+	if kind != mqttConnAck || len(ack) != 2 {
+		return nil, errors.Errorf("bridge: expected connack from mqtt broker, got packet type %#x", kind)
+	}
+
+	if ack[1] != 0 {
+		return nil, errors.Errorf("bridge: mqtt broker refused connection with code %d", ack[1])
+	}
+
+	// Subscriptions are held onto until the connection is in place, such
+	// that none are made in between being restored and the connection being
+	// swapped in.
+	m.subsMutex.Lock()
+	defer m.subsMutex.Unlock()
+
+	for _, sub := range m.subs {
+		if err := writePacket(conn, mqttSubscribe, m.subscribePacket(sub.filter)); err != nil {
+			return nil, err
+		}
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	select {
+	case <-m.done:
+		conn.Close()
+		return nil, errors.New("bridge: mqtt client closed")
+	default:
+	}
+
+	m.conn = conn
+
+	return reader, nil
+}
+
+// toMQTT maps a subject to an MQTT topic.
+func toMQTT(subject string) string {
+	return strings.NewReplacer(".", "/", "*", "+", ">", "#").Replace(subject)
+}
+
+// fromMQTT maps an MQTT topic to a subject.
+func fromMQTT(topic string) string {
+	return strings.Replace(topic, "/", ".", -1)
+}
+
+// matchMQTT returns whether a topic matches a topic filter.
+func matchMQTT(filter string, topic string) bool {
+	filterLevels, topicLevels := strings.Split(filter, "/"), strings.Split(topic, "/")
+
+	for i, level := range filterLevels {
+		if level == "#" {
+			return true
+		}
+
+		if i >= len(topicLevels) || (level != "+" && level != topicLevels[i]) {
+			return false
+		}
+	}
+
+	return len(filterLevels) == len(topicLevels)
+}
+
+func appendUint16(dst []byte, v uint16) []byte {
+	return append(dst, byte(v>>8), byte(v))
+}
+
+func appendString(dst []byte, s string) []byte {
+	return append(appendUint16(dst, uint16(len(s))), s...)
+}
+
+// write writes a packet of a given type to the broker.
+func (m *MQTT) write(kind byte, body []byte) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return writePacket(m.conn, kind, body)
+}
+
+// writePacket writes a packet of a given type.
+func writePacket(w io.Writer, kind byte, body []byte) error {
+	if len(body) > mqttMaxLength {
+		return errors.New("bridge: mqtt packet is too large")
+	}
+
+	header := []byte{kind}
+
+	// The remaining length is encoded 7 bits at a time.
+	length := len(body)
+	for {
+		b := byte(length % 128)
+		length /= 128
+		if length > 0 {
+			b |= 0x80
+		}
+		header = append(header, b)
+		if length == 0 {
+			break
+		}
+	}
+
+	if _, err := w.Write(append(header, body...)); err != nil {
+		return errors.Wrap(err, "bridge: failed to write to mqtt broker")
+	}
+
+	return nil
+}
+
+// readPacket reads a packet, and returns its type and body.
+func readPacket(reader *bufio.Reader) (byte, []byte, error) {
+	kind, err := reader.ReadByte()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	length, multiplier := 0, 1
+
+	for i := 0; ; i++ {
+		b, err := reader.ReadByte()
+		if err != nil {
+			return 0, nil, err
+		}
+
+		if i == 4 {
+			return 0, nil, errors.New("bridge: malformed mqtt packet length")
+		}
+
+		length += int(b&0x7f) * multiplier
+		multiplier *= 128
+
+		if b&0x80 == 0 {
+			break
+		}
+	}
+
+	body := make([]byte, length)
+	if _, err := io.ReadFull(reader, body); err != nil {
+		return 0, nil, err
+	}
+
+	return kind, body, nil
+}
+
+// Publish publishes data under a subject.
+func (m *MQTT) Publish(subject string, data []byte) error {
+	topic := toMQTT(subject)
+
+	if strings.ContainsAny(topic, "+#") {
+		return errors.Errorf("bridge: cannot publish to wildcard subject %q", subject)
+	}
+
+	m.expectEcho(topic, data)
+
+	return m.write(mqttPublish, append(appendString(nil, topic), data...))
+}
+
+// echoDigest returns the digest a message is recognized by when echoed.
+func echoDigest(topic string, data []byte) [sha256.Size]byte {
+	return sha256.Sum256(append(appendString(nil, topic), data...))
+}
+
+// expectEcho remembers a message published by the client, should the client
+// be subscribed to its topic, such that its echo is dropped.
+func (m *MQTT) expectEcho(topic string, data []byte) {
+	subscribed := false
+
+	m.subsMutex.RLock()
+	for _, sub := range m.subs {
+		if matchMQTT(sub.filter, topic) {
+			subscribed = true
+			break
+		}
+	}
+	m.subsMutex.RUnlock()
+
+	if !subscribed {
+		return
+	}
+
+	digest := echoDigest(topic, data)
+
+	m.echoesMutex.Lock()
+	defer m.echoesMutex.Unlock()
+
+	m.echoes[digest]++
+	m.echoQueue = append(m.echoQueue, digest)
+
+	// Echoes lost by the broker are eventually forgotten.
+	if len(m.echoQueue) > mqttMaxEchoes {
+		oldest := m.echoQueue[0]
+		m.echoQueue = m.echoQueue[1:]
+
+		if m.echoes[oldest] > 1 {
+			m.echoes[oldest]--
+		} else {
+			delete(m.echoes, oldest)
+		}
+	}
+}
+
+// isEcho returns whether or not a message received is the echo of a message
+// published by the client.
+func (m *MQTT) isEcho(topic string, data []byte) bool {
+	digest := echoDigest(topic, data)
+
+	m.echoesMutex.Lock()
+	defer m.echoesMutex.Unlock()
+
+	switch m.echoes[digest] {
+	case 0:
+		return false
+	case 1:
+		delete(m.echoes, digest)
+	default:
+		m.echoes[digest]--
+	}
+
+	return true
+}
+
+// Subscribe calls handler with all data published under subjects matching a
+// subject, which may hold the wildcards * and >.
+func (m *MQTT) Subscribe(subject string, handler func(subject string, data []byte)) error {
+	filter := toMQTT(subject)
+
+	m.subsMutex.Lock()
+	defer m.subsMutex.Unlock()
+
+	m.subs = append(m.subs, mqttSubscription{filter: filter, handler: handler})
+
+	return m.write(mqttSubscribe, m.subscribePacket(filter))
+}
+
+// subscribePacket returns the body of a packet subscribing to a topic filter.
+// It must be called with the subscriptions mutex held.
+func (m *MQTT) subscribePacket(filter string) []byte {
+	m.packetID++
+	if m.packetID == 0 {
+		m.packetID++
+	}
+
+	body := appendUint16(nil, m.packetID)
+	body = appendString(body, filter)
+	body = append(body, 0) // QoS 0
+
+	return body
+}
+
+// Close disconnects from the broker.
+func (m *MQTT) Close() error {
+	var err error
+
+	m.once.Do(func() {
+		m.mutex.Lock()
+		defer m.mutex.Unlock()
+
+		close(m.done)
+		writePacket(m.conn, mqttDisconnect, nil)
+		err = m.conn.Close()
+	})
+
+	return err
+}
+
+// keepAlive pings the broker such that the connection is not considered idle.
+// Pings failing while the connection is re-established are ignored.
+func (m *MQTT) keepAlive() {
+	ticker := time.NewTicker(mqttKeepAlive / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-m.done:
+			return
+		case <-ticker.C:
+			m.write(mqttPingReq, nil)
+		}
+	}
+}
+
+// readLoop handles packets sent by the broker, re-establishing the connection
+// should it drop and the client reconnect, until the client closes.
+func (m *MQTT) readLoop(reader *bufio.Reader) {
+	defer m.Close()
+
+	for {
+		m.serve(reader)
+
+		m.mutex.Lock()
+		m.conn.Close()
+		m.mutex.Unlock()
+
+		reconnected := m.opts.redial(m.address, m.done, func(conn net.Conn) (err error) {
+			reader, err = m.connect(conn)
+			return err
+		})
+
+		if !reconnected {
+			return
+		}
+	}
+}
+
+// serve handles packets sent by the broker until the connection drops or the
+// broker sends an unexpected packet.
+func (m *MQTT) serve(reader *bufio.Reader) {
+	for {
+		kind, body, err := readPacket(reader)
+		if err != nil {
+			return
+		}
+
+		switch kind & 0xf0 {
+		case mqttPublish:
+			if len(body) < 2 {
+				return
+			}
+
+			size := int(binary.BigEndian.Uint16(body))
+			if len(body) < 2+size {
+				return
+			}
+
+			topic, payload := string(body[2:2+size]), body[2+size:]
+
+			// Packets above QoS 0 carry a packet identifier.
+			if kind&0x06 != 0 {
+				if len(payload) < 2 {
+					return
+				}
+				payload = payload[2:]
+			}
+
+			if m.isEcho(topic, payload) {
+				continue
+			}
+
+			m.subsMutex.RLock()
+			subs := m.subs
+			m.subsMutex.RUnlock()
+
+			for _, sub := range subs {
+				if matchMQTT(sub.filter, topic) {
+					sub.handler(fromMQTT(topic), payload)
+				}
+			}
+		case mqttSubAck, mqttPingResp:
+		default:
+			return
+		}
+	}
+}
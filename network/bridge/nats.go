@@ -0,0 +1,296 @@
+package bridge
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// natsMaxPayload is the largest payload accepted from a NATS server.
+const natsMaxPayload = 64 << 20
+
+// NATS is a minimal client of a NATS server, which publishes and subscribes
+// over the core NATS text protocol without acknowledgements. Messages the
+// client publishes are not echoed back to its own subscriptions.
+type NATS struct {
+	address string
+	opts    dialOptions
+
+	mutex  sync.Mutex
+	conn   net.Conn
+	writer *bufio.Writer
+
+	subsMutex sync.RWMutex
+	subs      map[string]natsSubscription
+	nextSID   int
+
+	closed chan struct{}
+	once   sync.Once
+}
+
+type natsSubscription struct {
+	subject string
+	handler func(subject string, data []byte)
+}
+
+// natsConnect holds the options a client connects to a NATS server with.
+type natsConnect struct {
+	Verbose  bool   `json:"verbose"`
+	Pedantic bool   `json:"pedantic"`
+	Name     string `json:"name"`
+	// Echo is left unset, such that servers do not echo messages back to the
+	// client publishing them.
+	Echo      bool   `json:"echo"`
+	User      string `json:"user,omitempty"`
+	Pass      string `json:"pass,omitempty"`
+	AuthToken string `json:"auth_token,omitempty"`
+}
+
+var _ Broker = (*NATS)(nil)
+
+// DialNATS connects to a NATS server at a host:port address.
+func DialNATS(address string, opts ...DialOption) (*NATS, error) {
+	conn, err := net.Dial("tcp", address)
+	if err != nil {
+		return nil, errors.Wrap(err, "bridge: failed to dial nats server")
+	}
+
+	n, err := newNATS(address, conn, newDialOptions(opts))
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return n, nil
+}
+
+// newNATS connects to a NATS server at a host:port address over a connection.
+func newNATS(address string, conn net.Conn, opts dialOptions) (*NATS, error) {
+	n := &NATS{
+		address: address,
+		opts:    opts,
+		subs:    make(map[string]natsSubscription),
+		closed:  make(chan struct{}),
+	}
+
+	reader, err := n.connect(conn)
+	if err != nil {
+		return nil, err
+	}
+
+	go n.readLoop(reader)
+
+	return n, nil
+}
+
+// connect performs the NATS handshake over a connection, and restores all
+// subscriptions over it.
+func (n *NATS) connect(conn net.Conn) (*bufio.Reader, error) {
+	reader := bufio.NewReader(conn)
+
+	// The server greets clients with its info.
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, errors.Wrap(err, "bridge: failed to read nats server info")
+	}
+
+	if !strings.HasPrefix(line, "INFO ") {
+		return nil, errors.Errorf("bridge: expected nats server info, got %q", strings.TrimSpace(line))
+	}
+
+	// Connections are upgraded to TLS once the server info is read.
+	if n.opts.tls != nil {
+		if conn, err = n.opts.secure(conn, n.address); err != nil {
+			return nil, err
+		}
+		reader = bufio.NewReader(conn)
+	}
+
+	options, err := json.Marshal(natsConnect{
+		Name:      "noise",
+		User:      n.opts.username,
+		Pass:      n.opts.password,
+		AuthToken: n.opts.token,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "bridge: failed to encode nats connect options")
+	}
+
+	// Subscriptions are held onto until the connection is in place, such
+	// that none are made in between being restored and the connection being
+	// swapped in.
+	n.subsMutex.Lock()
+	defer n.subsMutex.Unlock()
+
+	writer := bufio.NewWriter(conn)
+	writer.WriteString("CONNECT " + string(options) + "\r\n")
+
+	for sid, sub := range n.subs {
+		writer.WriteString("SUB " + sub.subject + " " + sid + "\r\n")
+	}
+
+	writer.WriteString("PING\r\n")
+
+	if err := writer.Flush(); err != nil {
+		return nil, errors.Wrap(err, "bridge: failed to write to nats server")
+	}
+
+	// The server answers the ping once it accepts the connection.
+	line, err = reader.ReadString('\n')
+	if err != nil {
+		return nil, errors.Wrap(err, "bridge: failed to connect to nats server")
+	}
+
+	if strings.TrimSpace(line) != "PONG" {
+		return nil, errors.Errorf("bridge: nats server refused connection: %s", strings.TrimSpace(line))
+	}
+
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	select {
+	case <-n.closed:
+		conn.Close()
+		return nil, errors.New("bridge: nats client closed")
+	default:
+	}
+
+	n.conn, n.writer = conn, writer
+
+	return reader, nil
+}
+
+func (n *NATS) write(commands ...string) error {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	for _, command := range commands {
+		if _, err := n.writer.WriteString(command); err != nil {
+			return errors.Wrap(err, "bridge: failed to write to nats server")
+		}
+	}
+
+	if err := n.writer.Flush(); err != nil {
+		return errors.Wrap(err, "bridge: failed to write to nats server")
+	}
+
+	return nil
+}
+
+// Publish publishes data under a subject.
+func (n *NATS) Publish(subject string, data []byte) error {
+	if strings.ContainsAny(subject, " \t\r\n") {
+		return errors.Errorf("bridge: invalid nats subject %q", subject)
+	}
+
+	return n.write("PUB "+subject+" "+strconv.Itoa(len(data))+"\r\n", string(data), "\r\n")
+}
+
+// Subscribe calls handler with all data published under subjects matching a
+// subject, which may hold the wildcards * and >.
+func (n *NATS) Subscribe(subject string, handler func(subject string, data []byte)) error {
+	if strings.ContainsAny(subject, " \t\r\n") {
+		return errors.Errorf("bridge: invalid nats subject %q", subject)
+	}
+
+	n.subsMutex.Lock()
+	defer n.subsMutex.Unlock()
+
+	n.nextSID++
+	sid := strconv.Itoa(n.nextSID)
+	n.subs[sid] = natsSubscription{subject: subject, handler: handler}
+
+	return n.write("SUB " + subject + " " + sid + "\r\n")
+}
+
+// Close disconnects from the server.
+func (n *NATS) Close() error {
+	var err error
+
+	n.once.Do(func() {
+		n.mutex.Lock()
+		defer n.mutex.Unlock()
+
+		close(n.closed)
+		err = n.conn.Close()
+	})
+
+	return err
+}
+
+// readLoop handles messages and pings sent by the server, re-establishing the
+// connection should it drop and the client reconnect, until the client closes.
+func (n *NATS) readLoop(reader *bufio.Reader) {
+	defer n.Close()
+
+	for {
+		n.serve(reader)
+
+		n.mutex.Lock()
+		n.conn.Close()
+		n.mutex.Unlock()
+
+		reconnected := n.opts.redial(n.address, n.closed, func(conn net.Conn) (err error) {
+			reader, err = n.connect(conn)
+			return err
+		})
+
+		if !reconnected {
+			return
+		}
+	}
+}
+
+// serve handles messages and pings sent by the server until the connection
+// drops or the server reports an error.
+func (n *NATS) serve(reader *bufio.Reader) {
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch strings.ToUpper(fields[0]) {
+		case "PING":
+			if n.write("PONG\r\n") != nil {
+				return
+			}
+		case "MSG":
+			// MSG <subject> <sid> [reply-to] <size>
+			if len(fields) != 4 && len(fields) != 5 {
+				return
+			}
+
+			size, err := strconv.Atoi(fields[len(fields)-1])
+			if err != nil || size < 0 || size > natsMaxPayload {
+				return
+			}
+
+			payload := make([]byte, size+2)
+			if _, err := io.ReadFull(reader, payload); err != nil {
+				return
+			}
+
+			n.subsMutex.RLock()
+			sub, exists := n.subs[fields[2]]
+			n.subsMutex.RUnlock()
+
+			if exists {
+				sub.handler(fields[1], payload[:size])
+			}
+		case "-ERR":
+			return
+		}
+	}
+}
@@ -0,0 +1,232 @@
+// Package bridge bridges topic messages exchanged between peers to and from
+// external message brokers such as NATS or MQTT, such that overlay traffic may
+// feed existing messaging systems and vice versa.
+//
+// Messages published to the overlay under a topic are published to the broker
+// under the subject prefix+topic. Messages published to the broker under any
+// subscribed subject are broadcast to all peers under the subject stripped of
+// the prefix. Messages are bridged at most once, and never back to where they
+// came from. As every node running the plugin bridges messages published by
+// peers without a broker, usually one node per broker runs it.
+//
+//	broker, err := bridge.DialNATS("localhost:4222",
+//		bridge.WithCredentials("noise", "secret"),
+//		bridge.WithTLS(&tls.Config{}),
+//		bridge.WithReconnect(*backoff.DefaultBackoff()),
+//	)
+//	...
+//	builder.AddPlugin(bridge.New(broker,
+//		bridge.WithPrefix("noise."),
+//		bridge.WithSubscriptions("noise.>"),
+//	))
+package bridge
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/perlin-network/noise/internal/protobuf"
+	"github.com/perlin-network/noise/network"
+
+	"github.com/pkg/errors"
+)
+
+// Broker is a client of an external message broker.
+type Broker interface {
+	// Publish publishes data under a subject.
+	Publish(subject string, data []byte) error
+	// Subscribe calls handler with all data published under subjects
+	// matching a subject, which may hold the wildcards of the broker.
+	Subscribe(subject string, handler func(subject string, data []byte)) error
+	// Close disconnects from the broker.
+	Close() error
+}
+
+// Filter decides whether a message published under a topic is bridged.
+type Filter func(topic string, data []byte) bool
+
+// Plugin bridges topic messages between peers and an external broker.
+type Plugin struct {
+	*network.Plugin
+
+	// Broker messages are bridged to and from (default: none).
+	Broker Broker
+	// Prefix is prepended to topics to form broker subjects (default: none).
+	Prefix string
+	// Subscriptions are the broker subjects bridged to peers (default: none).
+	Subscriptions []string
+	// Inbound decides which messages from the broker are bridged to peers
+	// (default: all).
+	Inbound Filter
+	// Outbound decides which messages from peers are bridged to the broker
+	// (default: all).
+	Outbound Filter
+
+	// Handlers called upon messages published to the overlay: topic -> []func.
+	mutex    sync.RWMutex
+	handlers map[string][]func(topic string, data []byte)
+
+	net *network.Network
+}
+
+var (
+	// PluginID is used to check existence of the bridge plugin.
+	PluginID                         = (*Plugin)(nil)
+	_        network.PluginInterface = (*Plugin)(nil)
+)
+
+// PluginOption are configurable options for the bridge plugin.
+type PluginOption func(*Plugin)
+
+// WithPrefix sets the prefix prepended to topics to form broker subjects.
+func WithPrefix(prefix string) PluginOption {
+	return func(p *Plugin) {
+		p.Prefix = prefix
+	}
+}
+
+// WithSubscriptions sets the broker subjects bridged to peers.
+func WithSubscriptions(subjects ...string) PluginOption {
+	return func(p *Plugin) {
+		p.Subscriptions = append(p.Subscriptions, subjects...)
+	}
+}
+
+// WithInboundFilter sets which messages from the broker are bridged to peers.
+func WithInboundFilter(filter Filter) PluginOption {
+	return func(p *Plugin) {
+		p.Inbound = filter
+	}
+}
+
+// WithOutboundFilter sets which messages from peers are bridged to the broker.
+func WithOutboundFilter(filter Filter) PluginOption {
+	return func(p *Plugin) {
+		p.Outbound = filter
+	}
+}
+
+// New returns a new bridge plugin to a broker with specified options.
+func New(broker Broker, opts ...PluginOption) *Plugin {
+	p := &Plugin{Broker: broker}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+// TopicPrefix returns a filter passing messages under topics with a prefix.
+func TopicPrefix(prefix string) Filter {
+	return func(topic string, data []byte) bool {
+		return strings.HasPrefix(topic, prefix)
+	}
+}
+
+// Startup subscribes to the broker subjects bridged to peers.
+func (p *Plugin) Startup(net *network.Network) {
+	p.net = net
+
+	// Nodes without a broker have their messages bridged by peers.
+	if p.Broker == nil {
+		return
+	}
+
+	for _, subject := range p.Subscriptions {
+		if err := p.Broker.Subscribe(subject, p.bridgeInbound); err != nil {
+			net.Log("bridge").Error().Err(err).Str("subject", subject).Msg("Failed to subscribe to broker subject.")
+		}
+	}
+}
+
+// Cleanup disconnects from the broker.
+func (p *Plugin) Cleanup(net *network.Network) {
+	if p.Broker != nil {
+		p.Broker.Close()
+	}
+}
+
+// bridgeInbound broadcasts a message published to the broker to all peers.
+func (p *Plugin) bridgeInbound(subject string, data []byte) {
+	if !strings.HasPrefix(subject, p.Prefix) {
+		return
+	}
+
+	topic := strings.TrimPrefix(subject, p.Prefix)
+
+	if p.Inbound != nil && !p.Inbound(topic, data) {
+		return
+	}
+
+	p.deliver(topic, data)
+	p.net.Broadcast(network.WithSignMessage(context.Background(), true), &protobuf.BridgeMessage{Topic: topic, Data: data, Bridged: true})
+}
+
+// Receive bridges messages published by peers to the broker.
+func (p *Plugin) Receive(ctx *network.PluginContext) error {
+	msg, ok := ctx.Message().(*protobuf.BridgeMessage)
+	if !ok {
+		return nil
+	}
+
+	p.deliver(msg.Topic, msg.Data)
+
+	if msg.Bridged || p.Broker == nil {
+		return nil
+	}
+
+	return p.bridgeOutbound(msg.Topic, msg.Data)
+}
+
+// bridgeOutbound publishes a message published to the overlay to the broker.
+func (p *Plugin) bridgeOutbound(topic string, data []byte) error {
+	if p.Outbound != nil && !p.Outbound(topic, data) {
+		return nil
+	}
+
+	if err := p.Broker.Publish(p.Prefix+topic, data); err != nil {
+		return errors.Wrapf(err, "bridge: failed to publish to subject %s", p.Prefix+topic)
+	}
+
+	return nil
+}
+
+// Publish publishes a message under a topic to all peers and to the broker,
+// or should there be no broker, to all peers for them to bridge it.
+func (p *Plugin) Publish(ctx context.Context, topic string, data []byte) error {
+	bridged := p.Broker != nil
+
+	if bridged {
+		if err := p.bridgeOutbound(topic, data); err != nil {
+			return err
+		}
+	}
+
+	p.net.Broadcast(ctx, &protobuf.BridgeMessage{Topic: topic, Data: data, Bridged: bridged})
+	return nil
+}
+
+// Handle registers a handler called upon every message published to the
+// overlay under a topic, whether by a peer or through the broker.
+func (p *Plugin) Handle(topic string, handler func(topic string, data []byte)) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.handlers == nil {
+		p.handlers = make(map[string][]func(topic string, data []byte))
+	}
+
+	p.handlers[topic] = append(p.handlers[topic], handler)
+}
+
+func (p *Plugin) deliver(topic string, data []byte) {
+	p.mutex.RLock()
+	handlers := p.handlers[topic]
+	p.mutex.RUnlock()
+
+	for _, handler := range handlers {
+		handler(topic, data)
+	}
+}
@@ -0,0 +1,248 @@
+package bridge
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/perlin-network/noise/internal/test"
+	"github.com/perlin-network/noise/network"
+)
+
+// memoryBroker is an in-memory broker matching subjects exactly.
+type memoryBroker struct {
+	mutex     sync.Mutex
+	published map[string][][]byte
+	subs      map[string]func(subject string, data []byte)
+}
+
+func newMemoryBroker() *memoryBroker {
+	return &memoryBroker{
+		published: make(map[string][][]byte),
+		subs:      make(map[string]func(subject string, data []byte)),
+	}
+}
+
+func (b *memoryBroker) Publish(subject string, data []byte) error {
+	b.mutex.Lock()
+	b.published[subject] = append(b.published[subject], data)
+	handler := b.subs[subject]
+	b.mutex.Unlock()
+
+	if handler != nil {
+		handler(subject, data)
+	}
+	return nil
+}
+
+func (b *memoryBroker) Subscribe(subject string, handler func(subject string, data []byte)) error {
+	b.mutex.Lock()
+	b.subs[subject] = handler
+	b.mutex.Unlock()
+	return nil
+}
+
+func (b *memoryBroker) Close() error { return nil }
+
+func (b *memoryBroker) count(subject string) int {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return len(b.published[subject])
+}
+
+func waitFor(t *testing.T, description string, cond func() bool) {
+	deadline := time.Now().Add(5 * time.Second)
+
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", description)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+func TestBridge(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping bridge test in short mode")
+	}
+
+	broker := newMemoryBroker()
+
+	bridged := New(broker,
+		WithPrefix("noise."),
+		WithSubscriptions("noise.alerts", "noise.private"),
+		WithInboundFilter(TopicPrefix("alerts")),
+		WithOutboundFilter(TopicPrefix("metrics")),
+	)
+	plain := New(nil)
+
+	bridgeNode, plainNode := test.BuildNode(t, nil, bridged), test.BuildNode(t, nil, plain)
+	defer bridgeNode.Close()
+	defer plainNode.Close()
+
+	bridgeNode.Bootstrap(plainNode.Address)
+	plainNode.Bootstrap(bridgeNode.Address)
+
+	waitFor(t, "nodes to connect", func() bool {
+		return bridgeNode.ConnectionStateExists(plainNode.Address) && plainNode.ConnectionStateExists(bridgeNode.Address)
+	})
+
+	var mutex sync.Mutex
+	received := make(map[string]int)
+
+	plain.Handle("alerts", func(topic string, data []byte) {
+		mutex.Lock()
+		received[topic]++
+		mutex.Unlock()
+	})
+	plain.Handle("private", func(topic string, data []byte) {
+		mutex.Lock()
+		received[topic]++
+		mutex.Unlock()
+	})
+
+	// Messages published to the broker are broadcast to peers, should they
+	// pass the inbound filter.
+	broker.Publish("noise.alerts", []byte("fire"))
+	broker.Publish("noise.private", []byte("secret"))
+
+	waitFor(t, "alert to be bridged to peers", func() bool {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return received["alerts"] == 1
+	})
+
+	// Messages published by peers without a broker are bridged to the broker,
+	// should they pass the outbound filter.
+	ctx := network.WithSignMessage(context.Background(), true)
+
+	if err := plain.Publish(ctx, "metrics", []byte("42")); err != nil {
+		t.Fatal(err)
+	}
+	if err := plain.Publish(ctx, "chatter", []byte("hi")); err != nil {
+		t.Fatal(err)
+	}
+
+	waitFor(t, "metrics to be bridged to the broker", func() bool {
+		return broker.count("noise.metrics") == 1
+	})
+
+	// Messages published by a bridging node are published to its broker
+	// directly, and not bridged again by peers.
+	if err := bridged.Publish(ctx, "metrics", []byte("43")); err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(200 * time.Millisecond)
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	if received["private"] != 0 {
+		t.Fatalf("expected messages failing the inbound filter to not be bridged")
+	}
+
+	if broker.count("noise.chatter") != 0 {
+		t.Fatalf("expected messages failing the outbound filter to not be bridged")
+	}
+
+	if broker.count("noise.metrics") != 2 {
+		t.Fatalf("expected messages to be bridged exactly once, got %d", broker.count("noise.metrics"))
+	}
+
+	if broker.count("noise.alerts") != 1 {
+		t.Fatalf("expected messages from the broker to not be bridged back to it")
+	}
+}
+
+func TestBridgeRoundTrip(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping bridge test in short mode")
+	}
+
+	server := newNATSServer(t, "", "")
+	defer server.Close()
+
+	broker, err := DialNATS(server.Addr())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	external, err := DialNATS(server.Addr())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer external.Close()
+
+	bridged := New(broker, WithPrefix("noise."), WithSubscriptions("noise.>"))
+	plain := New(nil)
+
+	bridgeNode, plainNode := test.BuildNode(t, nil, bridged), test.BuildNode(t, nil, plain)
+	defer bridgeNode.Close()
+	defer plainNode.Close()
+
+	bridgeNode.Bootstrap(plainNode.Address)
+	plainNode.Bootstrap(bridgeNode.Address)
+
+	waitFor(t, "nodes to connect", func() bool {
+		return bridgeNode.ConnectionStateExists(plainNode.Address) && plainNode.ConnectionStateExists(bridgeNode.Address)
+	})
+
+	var mutex sync.Mutex
+	received := make(map[string]int)
+
+	count := func(node string) func(topic string, data []byte) {
+		return func(topic string, data []byte) {
+			mutex.Lock()
+			received[node+":"+topic]++
+			mutex.Unlock()
+		}
+	}
+
+	for _, topic := range []string{"alerts", "metrics"} {
+		bridged.Handle(topic, count("bridge"))
+		plain.Handle(topic, count("plain"))
+	}
+
+	if err := external.Subscribe("noise.>", count("external")); err != nil {
+		t.Fatal(err)
+	}
+
+	// Messages published to the broker reach every node exactly once.
+	if err := external.Publish("noise.alerts", []byte("fire")); err != nil {
+		t.Fatal(err)
+	}
+
+	// Messages published by peers reach the broker exactly once, and are not
+	// echoed back to the overlay.
+	if err := plain.Publish(network.WithSignMessage(context.Background(), true), "metrics", []byte("42")); err != nil {
+		t.Fatal(err)
+	}
+
+	waitFor(t, "messages to be bridged", func() bool {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return received["plain:alerts"] == 1 && received["external:noise.metrics"] == 1
+	})
+
+	time.Sleep(200 * time.Millisecond)
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	expected := map[string]int{
+		"bridge:alerts":          1,
+		"plain:alerts":           1,
+		"external:noise.alerts":  0,
+		"bridge:metrics":         1,
+		"plain:metrics":          0,
+		"external:noise.metrics": 1,
+	}
+
+	for key, count := range expected {
+		if received[key] != count {
+			t.Fatalf("expected %s to be received %d time(s), got %d", key, count, received[key])
+		}
+	}
+}
@@ -0,0 +1,309 @@
+package network
+
+import (
+	"context"
+	"math"
+	"sort"
+
+	"github.com/perlin-network/noise/internal/protobuf"
+	"github.com/perlin-network/noise/peer"
+)
+
+type targetKind int
+
+const (
+	targetConnected targetKind = iota
+	targetKnown
+	targetClosest
+)
+
+// TargetSet is the set of peers a broadcast is sent to.
+type TargetSet struct {
+	kind  targetKind
+	count int
+}
+
+var (
+	// Connected targets all peers connected to, such that no peer is dialed.
+	// It is the default target set.
+	Connected = TargetSet{kind: targetConnected}
+	// Known targets all peers known of through a plugin implementing
+	// PeerDirectory, such as the routing table of the discovery plugin,
+	// dialing those not connected to.
+	Known = TargetSet{kind: targetKnown}
+)
+
+// Closest targets the k known peers closest to this node, dialing those not
+// connected to.
+func Closest(k int) TargetSet {
+	return TargetSet{kind: targetClosest, count: k}
+}
+
+// BroadcastOption are configurable options for a single broadcast.
+type BroadcastOption func(*broadcastOptions)
+
+type broadcastOptions struct {
+	// Peers skipped: peer ID -> struct{}.
+	excluded map[string]struct{}
+
+	includeOrigin bool
+
+	weight func(id peer.ID) float64
+
+	// Whether the best scoring peers are preferred, and the fraction of
+	// peers sampled at random regardless of their score.
+	preferScored bool
+	explore      float64
+
+	targets TargetSet
+}
+
+// Targets sets the peers Broadcast is sent to. Broadcasts to peers not
+// connected to dial them in turn, which may take up to the dial timeout per
+// unreachable peer.
+func Targets(set TargetSet) BroadcastOption {
+	return func(o *broadcastOptions) {
+		o.targets = set
+	}
+}
+
+// ExcludePeers skips peers when broadcasting.
+func ExcludePeers(ids ...peer.ID) BroadcastOption {
+	return func(o *broadcastOptions) {
+		if o.excluded == nil {
+			o.excluded = make(map[string]struct{}, len(ids))
+		}
+		for _, id := range ids {
+			o.excluded[string(id.Id)] = struct{}{}
+		}
+	}
+}
+
+// IncludeOrigin broadcasts to the peer the message being relayed arrived from,
+// which is otherwise skipped.
+func IncludeOrigin() BroadcastOption {
+	return func(o *broadcastOptions) {
+		o.includeOrigin = true
+	}
+}
+
+// WeightPeers has BroadcastRandomly pick peers with probability proportional
+// to their weight, such as a score of how well they have behaved. Peers of a
+// weight of zero or less are never picked.
+func WeightPeers(weight func(id peer.ID) float64) BroadcastOption {
+	return func(o *broadcastOptions) {
+		o.weight = weight
+	}
+}
+
+// PreferScored has BroadcastRandomly pick the best scoring peers, as rated by
+// plugins implementing PeerScorer, for all but a fraction explore of the K
+// peers. The remaining peers are sampled at random, such that peers yet to be
+// rated, or rated poorly, still get a chance to prove themselves.
+func PreferScored(explore float64) BroadcastOption {
+	return func(o *broadcastOptions) {
+		o.preferScored = true
+		o.explore = math.Max(0, math.Min(1, explore))
+	}
+}
+
+// newBroadcastOptions applies options to a broadcast made under ctx, skipping
+// the origin of the message being relayed unless told otherwise.
+func newBroadcastOptions(ctx context.Context, opts []BroadcastOption) *broadcastOptions {
+	o := new(broadcastOptions)
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if origin, ok := GetOrigin(ctx); ok && !o.includeOrigin {
+		ExcludePeers(origin)(o)
+	}
+
+	return o
+}
+
+// skips returns whether or not a peer is to be skipped.
+func (o *broadcastOptions) skips(client *PeerClient) bool {
+	id := client.ID()
+	return id != nil && o.skipsID(*id)
+}
+
+func (o *broadcastOptions) skipsID(id peer.ID) bool {
+	_, excluded := o.excluded[string(id.Id)]
+	return excluded
+}
+
+// directory returns the first plugin implementing PeerDirectory.
+func (n *Network) directory() (PeerDirectory, bool) {
+	var directory PeerDirectory
+
+	n.plugins.Each(func(plugin PluginInterface) {
+		if d, ok := plugin.(PeerDirectory); ok && directory == nil {
+			directory = d
+		}
+	})
+
+	return directory, directory != nil
+}
+
+// broadcastDirectory writes a message to the known peers of a target set,
+// dialing those not connected to.
+func (n *Network) broadcastDirectory(signed *protobuf.Message, o *broadcastOptions) {
+	directory, ok := n.directory()
+	if !ok {
+		n.log().Warn().Msg("No plugin knows of peers to broadcast to.")
+		return
+	}
+
+	var ids []peer.ID
+	if o.targets.kind == targetClosest {
+		ids = directory.ClosestPeers(n.ID, o.targets.count)
+	} else {
+		ids = directory.KnownPeers()
+	}
+
+	for _, id := range ids {
+		if o.skipsID(id) || id.Equals(n.ID) {
+			continue
+		}
+
+		if _, err := n.Client(id.Address); err != nil {
+			n.log().Warn().Err(err).Str("peer_address", id.Address).Msg("Failed to dial peer to broadcast to.")
+			continue
+		}
+
+		if err := n.Write(id.Address, signed); err != nil {
+			n.log().Warn().Err(err).Str("peer_address", id.Address).Msg("Failed to send message to peer.")
+		}
+	}
+}
+
+// sample picks k peers at random, uniformly through reservoir sampling, or
+// should peers be weighted, with probability proportional to their weight
+// through weighted random sampling (Efraimidis-Spirakis).
+func (o *broadcastOptions) sample(clients []*PeerClient, k int, random *Random) []*PeerClient {
+	if k <= 0 {
+		return nil
+	}
+
+	if o.weight != nil {
+		return o.sampleWeighted(clients, k, random)
+	}
+
+	reservoir := make([]*PeerClient, 0, k)
+
+	for i, client := range clients {
+		if i < k {
+			reservoir = append(reservoir, client)
+		} else if j := random.Intn(i + 1); j < k {
+			reservoir[j] = client
+		}
+	}
+
+	return reservoir
+}
+
+// sampleWeighted keys every peer by u^(1/weight) for u drawn uniformly from
+// (0, 1], and picks the k peers of the greatest keys.
+func (o *broadcastOptions) sampleWeighted(clients []*PeerClient, k int, random *Random) []*PeerClient {
+	type keyed struct {
+		client *PeerClient
+		key    float64
+	}
+
+	candidates := make([]keyed, 0, len(clients))
+
+	for _, client := range clients {
+		id := client.ID()
+		if id == nil {
+			continue
+		}
+
+		weight := o.weight(*id)
+		if weight <= 0 || math.IsNaN(weight) {
+			continue
+		}
+
+		// Compare logarithms of keys, which would otherwise underflow for
+		// small weights.
+		candidates = append(candidates, keyed{client: client, key: math.Log(1-random.Float64()) / weight})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].key > candidates[j].key
+	})
+
+	if len(candidates) > k {
+		candidates = candidates[:k]
+	}
+
+	picked := make([]*PeerClient, len(candidates))
+	for i, candidate := range candidates {
+		picked[i] = candidate.client
+	}
+
+	return picked
+}
+
+// unratedScore is the score of peers not rated by any plugin, such that peers
+// known to be good are preferred over them.
+const unratedScore = 0.5
+
+// pick picks k peers to broadcast to, preferring the best scoring peers should
+// PreferScored be set.
+func (n *Network) pick(o *broadcastOptions, clients []*PeerClient, k int) []*PeerClient {
+	if !o.preferScored || k <= 0 {
+		return o.sample(clients, k, n.Random())
+	}
+
+	ranked := n.rank(clients)
+
+	best := int(math.Ceil(float64(k) * (1 - o.explore)))
+	if best > len(ranked) {
+		best = len(ranked)
+	}
+
+	picked := append([]*PeerClient(nil), ranked[:best]...)
+	return append(picked, o.sample(ranked[best:], k-best, n.Random())...)
+}
+
+// rank sorts peers by the mean of the scores given to them by all plugins
+// implementing PeerScorer, best first. Peers of equal scores keep their order.
+func (n *Network) rank(clients []*PeerClient) []*PeerClient {
+	var scorers []PeerScorer
+
+	n.plugins.Each(func(plugin PluginInterface) {
+		if scorer, ok := plugin.(PeerScorer); ok {
+			scorers = append(scorers, scorer)
+		}
+	})
+
+	scores := make(map[*PeerClient]float64, len(clients))
+
+	for _, client := range clients {
+		score, rated := 0.0, 0
+
+		if id := client.ID(); id != nil {
+			for _, scorer := range scorers {
+				if s, ok := scorer.PeerScore(*id); ok {
+					score += s
+					rated++
+				}
+			}
+		}
+
+		if rated > 0 {
+			scores[client] = score / float64(rated)
+		} else {
+			scores[client] = unratedScore
+		}
+	}
+
+	ranked := append([]*PeerClient(nil), clients...)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return scores[ranked[i]] > scores[ranked[j]]
+	})
+
+	return ranked
+}
@@ -0,0 +1,140 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/perlin-network/noise/crypto/ed25519"
+	"github.com/perlin-network/noise/peer"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func samplePeers(n int) []*PeerClient {
+	clients := make([]*PeerClient, n)
+	for i := range clients {
+		address := fmt.Sprintf("tcp://localhost:%d", 3000+i)
+		id := peer.CreateID(address, []byte(address))
+		clients[i] = &PeerClient{Address: address, id: &id}
+	}
+	return clients
+}
+
+func TestSampleUniformity(t *testing.T) {
+	t.Parallel()
+
+	const numPeers, k, trials = 10, 3, 30000
+
+	clients := samplePeers(numPeers)
+	random := NewRandom(1)
+	o := newBroadcastOptions(context.Background(), nil)
+
+	counts := make(map[string]int)
+	for i := 0; i < trials; i++ {
+		sampled := o.sample(clients, k, random)
+		assert.Len(t, sampled, k)
+
+		seen := make(map[string]bool)
+		for _, client := range sampled {
+			assert.False(t, seen[client.Address], "expected peers to be sampled at most once")
+			seen[client.Address] = true
+			counts[client.Address]++
+		}
+	}
+
+	// Every peer is expected to be sampled k/numPeers of the time.
+	expected := float64(trials) * k / numPeers
+	for _, client := range clients {
+		deviation := math.Abs(float64(counts[client.Address])-expected) / expected
+		assert.True(t, deviation < 0.05, "expected peer %s to be sampled %.0f times, got %d", client.Address, expected, counts[client.Address])
+	}
+
+	assert.Len(t, o.sample(clients[:2], k, random), 2, "expected all peers to be sampled should there be fewer than k")
+	assert.Empty(t, o.sample(clients, 0, random))
+}
+
+func TestSampleWeighted(t *testing.T) {
+	t.Parallel()
+
+	const trials = 20000
+
+	clients := samplePeers(3)
+	weights := map[string]float64{
+		clients[0].Address: 1,
+		clients[1].Address: 3,
+		clients[2].Address: 0,
+	}
+
+	o := newBroadcastOptions(context.Background(), []BroadcastOption{WeightPeers(func(id peer.ID) float64 {
+		return weights[id.Address]
+	})})
+	random := NewRandom(1)
+
+	counts := make(map[string]int)
+	for i := 0; i < trials; i++ {
+		for _, client := range o.sample(clients, 1, random) {
+			counts[client.Address]++
+		}
+	}
+
+	assert.Equal(t, 0, counts[clients[2].Address], "expected peers of no weight to never be sampled")
+
+	ratio := float64(counts[clients[1].Address]) / float64(counts[clients[0].Address])
+	assert.True(t, math.Abs(ratio-3) < 0.2, "expected peers to be sampled proportionally to their weight, got ratio %.2f", ratio)
+}
+
+// addressScorer scores peers by their address.
+type addressScorer struct {
+	*Plugin
+	scores map[string]float64
+}
+
+func (p *addressScorer) PeerScore(id peer.ID) (float64, bool) {
+	score, ok := p.scores[id.Address]
+	return score, ok
+}
+
+func TestPickPreferScored(t *testing.T) {
+	t.Parallel()
+
+	clients := samplePeers(6)
+
+	// The first three peers score best, followed by unrated peers.
+	scorer := &addressScorer{scores: map[string]float64{
+		clients[0].Address: 0.9,
+		clients[1].Address: 0.8,
+		clients[2].Address: 0.7,
+		clients[3].Address: 0.1,
+	}}
+
+	builder := NewBuilder()
+	builder.SetKeys(ed25519.RandomKeyPair())
+	assert.Equal(t, nil, builder.AddPlugin(scorer))
+
+	node, err := builder.Build()
+	assert.Equal(t, nil, err)
+	defer node.Close()
+
+	o := newBroadcastOptions(context.Background(), []BroadcastOption{PreferScored(0)})
+	assert.Equal(t, clients[:3], node.pick(o, clients, 3), "expected the best scoring peers to be picked")
+
+	ranked := node.rank(clients)
+	assert.Equal(t, []*PeerClient{clients[0], clients[1], clients[2], clients[4], clients[5], clients[3]}, ranked, "expected unrated peers to rank above poorly rated ones")
+
+	o = newBroadcastOptions(context.Background(), []BroadcastOption{PreferScored(0.5)})
+
+	explored := make(map[string]bool)
+	for i := 0; i < 100; i++ {
+		picked := node.pick(o, clients, 4)
+		assert.Len(t, picked, 4)
+		assert.Equal(t, clients[:2], picked[:2], "expected all but the explored fraction of peers to be the best scoring")
+
+		for _, client := range picked[2:] {
+			explored[client.Address] = true
+		}
+	}
+
+	assert.Len(t, explored, 4, "expected all remaining peers to be explored")
+}
@@ -1,15 +1,24 @@
 package network
 
 import (
+	"context"
+	"net"
 	"reflect"
 	"sync"
 	"time"
 
+	"github.com/perlin-network/noise/audit"
+	"github.com/perlin-network/noise/audit/trail"
+	"github.com/perlin-network/noise/clock"
 	"github.com/perlin-network/noise/crypto"
 	"github.com/perlin-network/noise/crypto/blake2b"
 	"github.com/perlin-network/noise/crypto/ed25519"
+	"github.com/perlin-network/noise/log"
+	"github.com/perlin-network/noise/network/capture"
+	"github.com/perlin-network/noise/network/fault"
 	"github.com/perlin-network/noise/network/transport"
 	"github.com/perlin-network/noise/peer"
+	"github.com/perlin-network/noise/tracing"
 	"github.com/pkg/errors"
 )
 
@@ -25,14 +34,20 @@ var (
 	ErrStrNoAddress = "builder: network requires public server IP for peers to connect to"
 	// ErrStrNoKeyPair returns if no keypair was given to the builder
 	ErrStrNoKeyPair = "builder: cryptography keys not provided to Network; cannot create node ID"
+
+	// ErrNoAddress is returned upon a network being built without an address.
+	ErrNoAddress = errors.New(ErrStrNoAddress)
+	// ErrNoKeyPair is returned upon a network being built without keys.
+	ErrNoKeyPair = errors.New(ErrStrNoKeyPair)
 )
 
 // Builder is a Address->processors struct
 type Builder struct {
 	opts options
 
-	keys    *crypto.KeyPair
-	address string
+	keys     *crypto.KeyPair
+	address  string
+	listener net.Listener
 
 	plugins     *PluginList
 	pluginCount int
@@ -49,6 +64,18 @@ var defaultBuilderOptions = options{
 	writeBufferSize:   defaultWriteBufferSize,
 	writeFlushLatency: defaultWriteFlushLatency,
 	writeTimeout:      defaultWriteTimeout,
+	shutdownTimeout:   defaultShutdownTimeout,
+	qos:               qosOptions{policies: defaultQoSPolicies},
+	maxHops:           defaultMaxHops,
+
+	maxInboundMemory:     defaultMaxInboundMemory,
+	maxPeerInboundMemory: defaultMaxPeerInboundMemory,
+
+	maxPeerPendingRequests: defaultMaxPeerPendingRequests,
+	maxPendingRequests:     defaultMaxPendingRequests,
+
+	maxSeeds:      defaultMaxSeeds,
+	seedsInterval: defaultSeedsInterval,
 }
 
 // A BuilderOption sets options such as connection timeout and cryptographic // policies for the network
@@ -118,6 +145,231 @@ func WriteTimeout(d time.Duration) BuilderOption {
 	}
 }
 
+// ShutdownTimeout returns a BuilderOption that sets how long Close waits for
+// plugins to shut down (default: 10 seconds).
+func ShutdownTimeout(d time.Duration) BuilderOption {
+	return func(o *options) {
+		o.shutdownTimeout = d
+	}
+}
+
+// MaxHops returns a BuilderOption that sets how many times a message may have
+// been relayed before this node refuses to relay it further, such that
+// messages caught in loops are eventually dropped (default: 16).
+func MaxHops(hops uint32) BuilderOption {
+	return func(o *options) {
+		o.maxHops = hops
+	}
+}
+
+// RecordPaths returns a BuilderOption that records the path of messages
+// originated or relayed by this node, such that receivers may tell through
+// PluginContext.Path which peers a message propagated through
+// (default: false).
+func RecordPaths() BuilderOption {
+	return func(o *options) {
+		o.recordPaths = true
+	}
+}
+
+// MaxInboundMemory returns a BuilderOption that sets how many bytes inbound
+// messages of all peers may hold whilst awaiting dispatch before reads from
+// peers are delayed, or zero for no limit (default: 256MiB).
+func MaxInboundMemory(bytes int64) BuilderOption {
+	return func(o *options) {
+		o.maxInboundMemory = bytes
+	}
+}
+
+// MaxPeerInboundMemory returns a BuilderOption that sets how many bytes
+// inbound messages of a single peer may hold whilst awaiting dispatch before
+// reads from the peer are delayed, or zero for no limit (default: 32MiB).
+func MaxPeerInboundMemory(bytes int64) BuilderOption {
+	return func(o *options) {
+		o.maxPeerInboundMemory = bytes
+	}
+}
+
+// MaxPendingRequests returns a BuilderOption that sets how many requests may
+// await a response from a single peer, and across all peers at once, or zero
+// for no limit. Requests past either limit fail with ErrTooManyRequests, such
+// that peers which never reply may not grow memory without bound (default:
+// 1024 per peer, and 16384 across all peers).
+func MaxPendingRequests(perPeer int, total int) BuilderOption {
+	return func(o *options) {
+		o.maxPeerPendingRequests = perPeer
+		o.maxPendingRequests = total
+	}
+}
+
+// ResourceLimits returns a BuilderOption that sets the soft and hard
+// watermarks of the usage of a resource, past which the node sheds load as
+// described by Pressure (default: no limits).
+func ResourceLimits(resource Resource, watermarks Watermarks) BuilderOption {
+	return func(o *options) {
+		if resource >= 0 && resource < numResources {
+			o.resourceLimits[resource] = watermarks
+		}
+	}
+}
+
+// MaxWorkers returns a BuilderOption that caps the number of live goroutines
+// of a worker pool or subsystem, named as keyed by WorkerCounts (default: no
+// caps). Spawning workers of the network past their cap blocks until one
+// exits, whereas Go refuses to spawn goroutines of subsystems past theirs.
+//
+// Example: network.MaxWorkers("dispatch", 1024)
+func MaxWorkers(subsystem string, max int) BuilderOption {
+	return func(o *options) {
+		// Copy the caps, as options are copied from defaults.
+		caps := make(map[string]int, len(o.maxWorkers)+1)
+		for name, max := range o.maxWorkers {
+			caps[name] = max
+		}
+		caps[subsystem] = max
+		o.maxWorkers = caps
+	}
+}
+
+// FairSend returns a BuilderOption that has messages to peers queued, and
+// written by a number of send workers which take turns across peers in
+// deficit round-robin order, such that a busy peer may not starve others of
+// their share of writes (default: messages are written by the goroutine
+// sending them, in whichever order goroutines acquire connections).
+func FairSend(workers int) BuilderOption {
+	return func(o *options) {
+		o.sendWorkers = workers
+	}
+}
+
+// AddressResolver returns a BuilderOption that sets the resolver consulted for
+// the addresses of peers known only by their public keys, such as a
+// StaticResolver or a DNSResolver bypassing the DHT (default: plugins
+// implementing Resolver, such as the discovery plugin).
+func AddressResolver(resolver Resolver) BuilderOption {
+	return func(o *options) {
+		o.resolver = resolver
+	}
+}
+
+// Attestation returns a BuilderOption that has the node present evidence
+// produced by an attestor, such as a TEE quote, upon dialing peers, and admit
+// peers dialing it only should a verifier accept the evidence they present.
+// Evidence is signed together with a challenge issued per connection, and
+// nodes which attest sign every message they send, such that connections of
+// admitted peers may not be hijacked. Either may be nil, such that nodes may
+// attest without verifying and vice versa (default: peers are admitted
+// without attestation).
+func Attestation(attestor Attestor, verifier AttestationVerifier) BuilderOption {
+	return func(o *options) {
+		o.attestor = attestor
+		o.attestationVerifier = verifier
+	}
+}
+
+// AuditOutbound returns a BuilderOption that enables audit mode, where every
+// message the node sends is signed and recorded to a tamper-evident log before
+// being sent. Messages which fail to be recorded are not sent (default: audit
+// mode disabled).
+func AuditOutbound(log *trail.Log) BuilderOption {
+	return func(o *options) {
+		o.trail = log
+	}
+}
+
+// SeedsFile returns a BuilderOption that has the node periodically persist
+// the addresses of the best peers it knows of to a file, preferring connected
+// peers with the highest scores, and contact them upon bootstrapping before
+// the bootstrap peers given. Restarted nodes thereby rejoin the network should
+// their bootstrap peers be unreachable (default: no seeds file).
+func SeedsFile(path string) BuilderOption {
+	return func(o *options) {
+		o.seedsFile = path
+	}
+}
+
+// MaxSeeds returns a BuilderOption that sets how many peers are persisted to
+// the seeds file, and how often they are persisted (default: 32 peers every
+// 5 minutes).
+func MaxSeeds(count int, interval time.Duration) BuilderOption {
+	return func(o *options) {
+		o.maxSeeds = count
+		o.seedsInterval = interval
+	}
+}
+
+// Tracer returns a BuilderOption that sets the tracer used to record spans
+// of message flows across nodes (default: tracing disabled).
+func Tracer(tracer *tracing.Tracer) BuilderOption {
+	return func(o *options) {
+		o.tracer = tracer
+	}
+}
+
+// StampDifficulty returns a BuilderOption that sets the number of leading zero
+// bits the proof-of-work stamps on pings and lookups must have (default: 0,
+// stamps are not required).
+func StampDifficulty(bits int) BuilderOption {
+	return func(o *options) {
+		o.stampDifficulty = bits
+	}
+}
+
+// AuditSink returns a BuilderOption that sets the sink security events, such
+// as failed handshakes or invalid signatures, are emitted to (default: events
+// are discarded).
+func AuditSink(sink audit.Sink) BuilderOption {
+	return func(o *options) {
+		o.auditSink = sink
+	}
+}
+
+// Capture returns a BuilderOption that hands every frame the node sends and
+// receives which passes all filters to a sink, such as for protocol debugging
+// (default: frames are not captured).
+func Capture(sink capture.Sink, filters ...capture.Filter) BuilderOption {
+	return func(o *options) {
+		o.capture = capture.Filtered(sink, filters...)
+	}
+}
+
+// Faults returns a BuilderOption that injects faults decided upon by a policy
+// into every frame the node sends, such as for tests and soak runs (default:
+// frames are sent untouched).
+func Faults(policy fault.Policy) BuilderOption {
+	return func(o *options) {
+		o.faults = policy
+	}
+}
+
+// Logger returns a BuilderOption that sets the logger the node and its plugins
+// write events to (default: the global logger of package log).
+func Logger(l log.Logger) BuilderOption {
+	return func(o *options) {
+		o.logger = l
+	}
+}
+
+// Clock returns a BuilderOption that sets the clock the node and its plugins
+// tell time and schedule timers against, such as a virtual clock for
+// reproducible simulations (default: the system clock). Connection deadlines
+// are always set against the system clock.
+func Clock(c clock.Clock) BuilderOption {
+	return func(o *options) {
+		o.clock = c
+	}
+}
+
+// Seed returns a BuilderOption that seeds all randomness the node and its
+// plugins draw upon, such that multi-node scenarios may be reproduced. Seeded
+// nonces are predictable, so it is only to be used for simulations and tests
+// (default: randomness is not seeded).
+func Seed(seed int64) BuilderOption {
+	return func(o *options) {
+		o.random = NewRandom(seed)
+	}
+}
+
 // NewBuilder returns a new builder with default options.
 func NewBuilder() *Builder {
 	builder := &Builder{
@@ -130,6 +382,7 @@ func NewBuilder() *Builder {
 	// Register default transport layers.
 	builder.RegisterTransportLayer("tcp", transport.NewTCP())
 	builder.RegisterTransportLayer("kcp", transport.NewKCP())
+	builder.RegisterTransportLayer("ws", transport.NewWebSocket())
 
 	return builder
 }
@@ -155,6 +408,14 @@ func (builder *Builder) SetAddress(address string) {
 	builder.address = address
 }
 
+// SetListener sets a listener bound ahead of time, such as through
+// ListenOnRandomPort, which the network serves peers from upon Listen rather
+// than binding to the port of its address. The address of the network should
+// be set to one the listener is reachable at.
+func (builder *Builder) SetListener(listener net.Listener) {
+	builder.listener = listener
+}
+
 // AddPluginWithPriority registers a new plugin onto the network with a set priority.
 func (builder *Builder) AddPluginWithPriority(priority int, plugin PluginInterface) error {
 	// Initialize plugin list if not exist.
@@ -178,6 +439,28 @@ func (builder *Builder) AddPlugin(plugin PluginInterface) error {
 	return err
 }
 
+// AddPluginWithLimits registers a new plugin onto the network, which is
+// handed messages by its own workers bounded by limits.
+//
+// Example: builder.AddPluginWithLimits(store, network.PluginLimits{MaxConcurrent: 4, QueueSize: 1024})
+func (builder *Builder) AddPluginWithLimits(plugin PluginInterface, limits PluginLimits) error {
+	limiter, err := newPluginLimiter(limits)
+	if err != nil {
+		return err
+	}
+
+	if builder.plugins == nil {
+		builder.plugins = NewPluginList()
+	}
+
+	if !builder.plugins.PutInfo(&PluginInfo{Priority: builder.pluginCount, Plugin: plugin, limiter: limiter}) {
+		return errors.Errorf(ErrStrDuplicatePlugin, reflect.TypeOf(plugin).String())
+	}
+
+	builder.pluginCount++
+	return nil
+}
+
 // RegisterTransportLayer registers a transport layer to the network keyed by its name.
 //
 // Example: builder.RegisterTransportLayer("kcp", transport.NewKCP())
@@ -194,11 +477,11 @@ func (builder *Builder) ClearTransportLayers() {
 // misconfiguration, or a *Network.
 func (builder *Builder) Build() (*Network, error) {
 	if builder.keys == nil {
-		return nil, errors.New(ErrStrNoKeyPair)
+		return nil, ErrNoKeyPair
 	}
 
 	if len(builder.address) == 0 {
-		return nil, errors.New(ErrStrNoAddress)
+		return nil, ErrNoAddress
 	}
 
 	// Initialize plugin list if not exist.
@@ -215,6 +498,8 @@ func (builder *Builder) Build() (*Network, error) {
 
 	id := peer.CreateID(unifiedAddress, builder.keys.PublicKey)
 
+	ctx, cancel := context.WithCancel(context.Background())
+
 	net := &Network{
 		opts:    builder.opts,
 		ID:      id,
@@ -223,12 +508,21 @@ func (builder *Builder) Build() (*Network, error) {
 
 		plugins:    builder.plugins,
 		transports: builder.transports,
+		listener:   builder.listener,
 
 		peers:       new(sync.Map),
 		connections: new(sync.Map),
 
 		listeningCh: make(chan struct{}),
 		kill:        make(chan struct{}),
+		stopped:     make(chan struct{}),
+
+		ctx:    ctx,
+		cancel: cancel,
+
+		workers: newWorkerPools(builder.opts.maxWorkers),
+		sender:  newSendScheduler(builder.opts.sendWorkers),
+		memory:  newMemoryBudget(builder.opts.maxInboundMemory),
 	}
 
 	net.Init()
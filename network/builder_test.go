@@ -6,8 +6,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/perlin-network/noise/clock"
 	"github.com/perlin-network/noise/crypto/blake2b"
 	"github.com/perlin-network/noise/crypto/ed25519"
+	"github.com/perlin-network/noise/log"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 )
@@ -184,6 +186,60 @@ func TestWriteTimeout(t *testing.T) {
 	assert.Equal(t, net.opts.writeTimeout, writeTimeout, "write timeout given should match found")
 }
 
+type recordingLogger struct {
+	modules []string
+}
+
+func (r *recordingLogger) Log(level log.Level, module string, msg string, fields []log.Field) {
+	r.modules = append(r.modules, module)
+}
+
+func TestLogger(t *testing.T) {
+	t.Parallel()
+
+	net, err := NewBuilder().Build()
+	assert.Equal(t, nil, err)
+	assert.Nil(t, net.Logger(), "nodes should log to the global logger by default")
+
+	logger := new(recordingLogger)
+	net, err = NewBuilderWithOptions(Logger(logger)).Build()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, logger, net.Logger(), "logger given should match found")
+
+	net.Log("discovery").Error().Msg("hello")
+	assert.Equal(t, []string{"discovery"}, logger.modules, "events should be written to the node's logger")
+}
+
+func TestClock(t *testing.T) {
+	t.Parallel()
+
+	net, err := NewBuilder().Build()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, clock.Real(), net.Clock(), "nodes should use the system clock by default")
+
+	virtual := clock.NewVirtual(time.Unix(0, 0))
+	net, err = NewBuilderWithOptions(Clock(virtual)).Build()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, virtual, net.Clock(), "clock given should match found")
+}
+
+func TestSeed(t *testing.T) {
+	t.Parallel()
+
+	draw := func() []int {
+		net, err := NewBuilderWithOptions(Seed(42)).Build()
+		assert.Equal(t, nil, err)
+
+		var draws []int
+		for i := 0; i < 10; i++ {
+			draws = append(draws, net.Random().Intn(1000))
+		}
+		return draws
+	}
+
+	assert.Equal(t, draw(), draw(), "identically seeded nodes should draw identical numbers")
+}
+
 func TestPeers(t *testing.T) {
 	var nodes []*Network
 	addresses := []string{"tcp://127.0.0.1:12345", "tcp://127.0.0.1:12346", "tcp://127.0.0.1:12347"}
@@ -0,0 +1,115 @@
+package capture_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/perlin-network/noise/internal/test"
+	"github.com/perlin-network/noise/network"
+	"github.com/perlin-network/noise/network/capture"
+	"github.com/perlin-network/noise/network/discovery"
+	"github.com/perlin-network/noise/types/opcode"
+)
+
+type recorder struct {
+	sync.Mutex
+	frames []*capture.Frame
+}
+
+func (r *recorder) Capture(frame *capture.Frame) {
+	r.Lock()
+	r.frames = append(r.frames, frame)
+	r.Unlock()
+}
+
+func TestFiltered(t *testing.T) {
+	t.Parallel()
+
+	r := new(recorder)
+	sink := capture.Filtered(r, capture.Only(capture.Outbound), capture.Opcodes(10, 11), capture.Peer("tcp://127.0.0.1:3000"))
+
+	sink.Capture(&capture.Frame{Direction: capture.Outbound, Opcode: 10, Remote: "tcp://127.0.0.1:3000"})
+	sink.Capture(&capture.Frame{Direction: capture.Inbound, Opcode: 10, Remote: "tcp://127.0.0.1:3000"})
+	sink.Capture(&capture.Frame{Direction: capture.Outbound, Opcode: 12, Remote: "tcp://127.0.0.1:3000"})
+	sink.Capture(&capture.Frame{Direction: capture.Outbound, Opcode: 11, Remote: "tcp://127.0.0.1:3001"})
+
+	if len(r.frames) != 1 || r.frames[0].Opcode != 10 {
+		t.Fatalf("expected only the first frame to pass all filters, got %d frames", len(r.frames))
+	}
+}
+
+func TestPCAP(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	sink, err := capture.PCAP(&buf)
+	if err != nil {
+		t.Fatalf("PCAP() = expected no error, got %v", err)
+	}
+
+	sink.Capture(&capture.Frame{Direction: capture.Outbound, Time: time.Unix(5, 6000), Raw: []byte{0, 0, 0, 1, 42}})
+
+	out := buf.Bytes()
+	if len(out) != 24+16+6 {
+		t.Fatalf("expected a 24 byte header and a 22 byte record, got %d bytes", len(out))
+	}
+
+	if magic := binary.LittleEndian.Uint32(out[0:4]); magic != 0xa1b2c3d4 {
+		t.Fatalf("expected pcap magic number, got %x", magic)
+	}
+
+	record := out[24:]
+	if sec, usec := binary.LittleEndian.Uint32(record[0:4]), binary.LittleEndian.Uint32(record[4:8]); sec != 5 || usec != 6 {
+		t.Fatalf("expected record timestamp 5s 6us, got %ds %dus", sec, usec)
+	}
+
+	if length := binary.LittleEndian.Uint32(record[8:12]); length != 6 {
+		t.Fatalf("expected record of 6 bytes, got %d", length)
+	}
+
+	if !bytes.Equal(record[16:], []byte{byte(capture.Outbound), 0, 0, 0, 1, 42}) {
+		t.Fatalf("unexpected record data %v", record[16:])
+	}
+}
+
+func TestCapture(t *testing.T) {
+	if testing.Short() {
+		t.Skipf("skipping %s in short mode", t.Name())
+	}
+
+	r := new(recorder)
+
+	net := test.BuildNode(t, []network.BuilderOption{network.Capture(r, capture.Opcodes(uint32(opcode.PingCode), uint32(opcode.PongCode)))}, new(discovery.Plugin))
+	defer net.Close()
+
+	peer := test.BuildNode(t, nil, new(discovery.Plugin))
+	defer peer.Close()
+
+	net.Bootstrap(peer.Address)
+	time.Sleep(200 * time.Millisecond)
+
+	r.Lock()
+	defer r.Unlock()
+
+	var ping, pong bool
+	for _, frame := range r.frames {
+		if frame.Local != net.Address || frame.Remote != peer.Address {
+			t.Fatalf("expected frame between %s and %s, got %+v", net.Address, peer.Address, frame)
+		}
+
+		if length := binary.BigEndian.Uint32(frame.Raw[:4]); int(length) != len(frame.Raw)-4 {
+			t.Fatalf("expected frame to be length-prefixed, got prefix %d for %d bytes", length, len(frame.Raw)-4)
+		}
+
+		ping = ping || (frame.Direction == capture.Outbound && frame.Opcode == uint32(opcode.PingCode))
+		pong = pong || (frame.Direction == capture.Inbound && frame.Opcode == uint32(opcode.PongCode))
+	}
+
+	if !ping || !pong {
+		t.Fatalf("expected an outbound ping and inbound pong to be captured, got %d frames", len(r.frames))
+	}
+}
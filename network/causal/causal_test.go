@@ -0,0 +1,59 @@
+package causal
+
+import (
+	"context"
+	"testing"
+
+	"github.com/perlin-network/noise/network"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLamport(t *testing.T) {
+	a, b := NewLamport(), NewLamport()
+
+	headers := make(network.Headers)
+	a.Send(context.Background(), headers)
+	a.Send(context.Background(), headers)
+
+	assert.Equal(t, uint64(2), a.Time())
+
+	// Receiving a message advances the clock past its timestamp.
+	b.Receive(nil, headers)
+	assert.Equal(t, uint64(3), b.Time())
+
+	headers = make(network.Headers)
+	b.Send(context.Background(), headers)
+
+	a.Receive(nil, headers)
+	assert.Equal(t, uint64(5), a.Time())
+
+	// Messages without or with malformed timestamps are ignored.
+	a.Receive(nil, network.Headers{})
+	a.Receive(nil, network.Headers{LamportHeader: []byte{0xff}})
+	assert.Equal(t, uint64(5), a.Time())
+}
+
+func TestVector(t *testing.T) {
+	a, b, c := NewVector("a"), NewVector("b"), NewVector("c")
+
+	headers := make(network.Headers)
+	a.Send(context.Background(), headers)
+	sent := a.Clock()
+
+	b.Receive(nil, headers)
+	assert.Equal(t, VectorClock{"a": 1, "b": 1}, b.Clock())
+	assert.Equal(t, Before, sent.Compare(b.Clock()), "expected a message to precede its receipt")
+	assert.Equal(t, After, b.Clock().Compare(sent))
+
+	c.Tick()
+	assert.Equal(t, Concurrent, c.Clock().Compare(b.Clock()), "expected unrelated events to be concurrent")
+	assert.Equal(t, Equal, b.Clock().Compare(b.Clock()))
+
+	decoded, ok := decodeVector(b.Clock().encode())
+	assert.True(t, ok)
+	assert.Equal(t, b.Clock(), decoded)
+
+	_, ok = decodeVector([]byte{0x05, 'a'})
+	assert.False(t, ok, "expected truncated clocks to not decode")
+}
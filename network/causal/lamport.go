@@ -0,0 +1,97 @@
+// Package causal provides middleware maintaining causal clocks across the
+// messages nodes exchange, such that applications may order events causally
+// without carrying clocks within their own messages.
+//
+// Register either clock with the network builder, and read the clock of
+// incoming messages from within plugins:
+//
+//	clock := causal.NewVector(keys.PublicKeyHex())
+//	builder := network.NewBuilderWithOptions(network.Middlewares(clock))
+//
+//	func (p *Plugin) Receive(ctx *network.PluginContext) error {
+//		if sent, ok := causal.VectorOf(ctx); ok && sent.Compare(p.last) == causal.After {
+//			...
+//		}
+//	}
+package causal
+
+import (
+	"context"
+	"encoding/binary"
+	"sync"
+
+	"github.com/perlin-network/noise/network"
+)
+
+// LamportHeader is the name of the header Lamport timestamps are carried
+// under.
+const LamportHeader = "causal.lamport"
+
+// Lamport is middleware maintaining a Lamport clock, which timestamps every
+// message sent such that a message sent in response to another is always
+// timestamped later.
+type Lamport struct {
+	mutex sync.Mutex
+	time  uint64
+}
+
+var _ network.Middleware = (*Lamport)(nil)
+
+// NewLamport returns a Lamport clock starting at zero.
+func NewLamport() *Lamport {
+	return new(Lamport)
+}
+
+// Time returns the current time of the clock.
+func (l *Lamport) Time() uint64 {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	return l.time
+}
+
+// Tick advances the clock for a local event, and returns its time.
+func (l *Lamport) Tick() uint64 {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.time++
+	return l.time
+}
+
+// Send timestamps a message about to be sent.
+func (l *Lamport) Send(ctx context.Context, headers network.Headers) {
+	buf := make([]byte, binary.MaxVarintLen64)
+	headers[LamportHeader] = buf[:binary.PutUvarint(buf, l.Tick())]
+}
+
+// Receive advances the clock past the timestamp of a received message.
+func (l *Lamport) Receive(client *network.PeerClient, headers network.Headers) {
+	received, ok := decodeLamport(headers[LamportHeader])
+	if !ok {
+		return
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if received > l.time {
+		l.time = received
+	}
+	l.time++
+}
+
+// LamportOf returns the Lamport timestamp of an incoming message, and false
+// should the sender not have timestamped it.
+func LamportOf(ctx *network.PluginContext) (uint64, bool) {
+	value, ok := ctx.Header(LamportHeader)
+	if !ok {
+		return 0, false
+	}
+	return decodeLamport(value)
+}
+
+func decodeLamport(value []byte) (uint64, bool) {
+	time, n := binary.Uvarint(value)
+	return time, n > 0 && n == len(value)
+}
@@ -0,0 +1,191 @@
+package causal
+
+import (
+	"context"
+	"encoding/binary"
+	"sort"
+	"sync"
+
+	"github.com/perlin-network/noise/network"
+)
+
+// VectorHeader is the name of the header vector clocks are carried under.
+const VectorHeader = "causal.vector"
+
+// Ordering is the causal relation between two vector clocks.
+type Ordering int
+
+const (
+	// Equal clocks denote the same event.
+	Equal Ordering = iota
+	// Before denotes a clock which causally precedes another.
+	Before
+	// After denotes a clock which causally succeeds another.
+	After
+	// Concurrent clocks denote events neither of which causally precedes the
+	// other.
+	Concurrent
+)
+
+// VectorClock counts the events of every node observed, keyed by node.
+type VectorClock map[string]uint64
+
+// Compare returns how the clock is causally related to another.
+func (v VectorClock) Compare(other VectorClock) Ordering {
+	before, after := false, false
+
+	for node, count := range v {
+		if count > other[node] {
+			after = true
+		} else if count < other[node] {
+			before = true
+		}
+	}
+
+	for node, count := range other {
+		if _, exists := v[node]; !exists && count > 0 {
+			before = true
+		}
+	}
+
+	switch {
+	case before && after:
+		return Concurrent
+	case before:
+		return Before
+	case after:
+		return After
+	default:
+		return Equal
+	}
+}
+
+// Copy returns a copy of the clock.
+func (v VectorClock) Copy() VectorClock {
+	copied := make(VectorClock, len(v))
+	for node, count := range v {
+		copied[node] = count
+	}
+	return copied
+}
+
+// merge advances the clock to the counts of another where they are greater.
+func (v VectorClock) merge(other VectorClock) {
+	for node, count := range other {
+		if count > v[node] {
+			v[node] = count
+		}
+	}
+}
+
+// encode encodes the clock as its entries sorted by node, each a
+// length-prefixed node followed by its count as varints.
+func (v VectorClock) encode() []byte {
+	nodes := make([]string, 0, len(v))
+	for node := range v {
+		nodes = append(nodes, node)
+	}
+	sort.Strings(nodes)
+
+	var encoded []byte
+	buf := make([]byte, binary.MaxVarintLen64)
+
+	for _, node := range nodes {
+		encoded = append(encoded, buf[:binary.PutUvarint(buf, uint64(len(node)))]...)
+		encoded = append(encoded, node...)
+		encoded = append(encoded, buf[:binary.PutUvarint(buf, v[node])]...)
+	}
+
+	return encoded
+}
+
+func decodeVector(encoded []byte) (VectorClock, bool) {
+	v := make(VectorClock)
+
+	for len(encoded) > 0 {
+		size, n := binary.Uvarint(encoded)
+		if n <= 0 || uint64(len(encoded)-n) < size {
+			return nil, false
+		}
+		node := string(encoded[n : n+int(size)])
+		encoded = encoded[n+int(size):]
+
+		count, n := binary.Uvarint(encoded)
+		if n <= 0 {
+			return nil, false
+		}
+		encoded = encoded[n:]
+
+		v[node] = count
+	}
+
+	return v, true
+}
+
+// Vector is middleware maintaining a vector clock, which stamps every message
+// sent such that the causal relation between any two messages may be told.
+type Vector struct {
+	self string
+
+	mutex sync.Mutex
+	clock VectorClock
+}
+
+var _ network.Middleware = (*Vector)(nil)
+
+// NewVector returns a vector clock of a node identified by self, such as the
+// hex-encoded public key of the node.
+func NewVector(self string) *Vector {
+	return &Vector{self: self, clock: make(VectorClock)}
+}
+
+// Clock returns a copy of the current clock.
+func (v *Vector) Clock() VectorClock {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	return v.clock.Copy()
+}
+
+// Tick advances the clock for a local event, and returns a copy of it.
+func (v *Vector) Tick() VectorClock {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	v.clock[v.self]++
+	return v.clock.Copy()
+}
+
+// Send stamps a message about to be sent.
+func (v *Vector) Send(ctx context.Context, headers network.Headers) {
+	headers[VectorHeader] = v.Tick().encode()
+}
+
+// Receive merges the clock of a received message.
+func (v *Vector) Receive(client *network.PeerClient, headers network.Headers) {
+	value, ok := headers[VectorHeader]
+	if !ok {
+		return
+	}
+
+	received, ok := decodeVector(value)
+	if !ok {
+		return
+	}
+
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	v.clock.merge(received)
+	v.clock[v.self]++
+}
+
+// VectorOf returns the vector clock of an incoming message, and false should
+// the sender not have stamped it.
+func VectorOf(ctx *network.PluginContext) (VectorClock, bool) {
+	value, ok := ctx.Header(VectorHeader)
+	if !ok {
+		return nil, false
+	}
+	return decodeVector(value)
+}
@@ -0,0 +1,41 @@
+package network
+
+// challengeSize is the number of random bytes within a ping challenge.
+const challengeSize = 16
+
+// NewChallenge generates a random nonce to be sent within a ping, which the peer
+// is expected to echo back within its pong. The nonce is remembered as
+// outstanding until it is answered or withdrawn.
+func (c *PeerClient) NewChallenge() []byte {
+	var random *Random
+	if c.Network != nil {
+		random = c.Network.Random()
+	}
+
+	nonce := make([]byte, challengeSize)
+	if _, err := random.Read(nonce); err != nil {
+		return nil
+	}
+
+	c.challenges.Store(string(nonce), struct{}{})
+	return nonce
+}
+
+// VerifyChallenge checks whether a nonce echoed back by the peer answers an
+// outstanding challenge. A challenge may only be answered once.
+func (c *PeerClient) VerifyChallenge(nonce []byte) bool {
+	if len(nonce) != challengeSize {
+		return false
+	}
+
+	_, outstanding := c.challenges.Load(string(nonce))
+	c.challenges.Delete(string(nonce))
+
+	return outstanding
+}
+
+// WithdrawChallenge forgets an outstanding challenge which will no longer be
+// answered, such as should the ping carrying it have failed or timed out.
+func (c *PeerClient) WithdrawChallenge(nonce []byte) {
+	c.challenges.Delete(string(nonce))
+}
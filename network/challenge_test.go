@@ -0,0 +1,47 @@
+package network
+
+import (
+	"testing"
+)
+
+func TestChallenge(t *testing.T) {
+	t.Parallel()
+
+	client, err := createPeerClient(nil, "tcp://localhost:3000")
+	if err != nil {
+		t.Fatalf("createPeerClient() = expected no error, got %v", err)
+	}
+
+	nonce := client.NewChallenge()
+	if len(nonce) != challengeSize {
+		t.Fatalf("expected a %d byte challenge, got %d bytes", challengeSize, len(nonce))
+	}
+
+	if client.VerifyChallenge(make([]byte, challengeSize)) {
+		t.Fatal("VerifyChallenge() expected an unknown nonce to not verify")
+	}
+
+	if !client.VerifyChallenge(nonce) {
+		t.Fatal("VerifyChallenge() expected an outstanding challenge to verify")
+	}
+
+	if client.VerifyChallenge(nonce) {
+		t.Fatal("VerifyChallenge() expected a challenge to only be answered once")
+	}
+}
+
+func TestWithdrawChallenge(t *testing.T) {
+	t.Parallel()
+
+	client, err := createPeerClient(nil, "tcp://localhost:3000")
+	if err != nil {
+		t.Fatalf("createPeerClient() = expected no error, got %v", err)
+	}
+
+	nonce := client.NewChallenge()
+	client.WithdrawChallenge(nonce)
+
+	if client.VerifyChallenge(nonce) {
+		t.Fatal("VerifyChallenge() expected a withdrawn challenge to not verify")
+	}
+}
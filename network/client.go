@@ -14,27 +14,52 @@ import (
 	"github.com/pkg/errors"
 )
 
+// errPeerDisconnected is the cause of requests failing as the peer disconnects
+// before responding.
+var errPeerDisconnected = errors.New("peer disconnected")
+
 // PeerClient represents a single incoming peers client.
 type PeerClient struct {
 	sync.Once
 
 	Network *Network
 
-	ID      *peer.ID
 	Address string
 
+	// ID of the peer, which is set once the peer identifies itself.
+	idMutex sync.RWMutex
+	id      *peer.ID
+
 	Requests     sync.Map // uint64 -> *RequestState
 	RequestNonce uint64
 
+	// Number of requests awaiting a response from the peer.
+	pendingRequests int64
+
+	// Ping challenges which have yet to be answered by the peer.
+	challenges sync.Map // string -> struct{}
+
 	stream StreamState
 
 	outgoingReady chan struct{}
 	incomingReady chan struct{}
 
 	jobs chan func()
+	// Jobs of messages of QoS classes with priority.
+	priorityJobs chan func()
+
+	// Rate limits of messages sent by the peer per QoS class.
+	qosMutex   sync.Mutex
+	qosBuckets [numQoSClasses]*tokenBucket
 
 	closed      uint32 // for atomic ops
 	closeSignal chan struct{}
+
+	// Context of the peer, which is cancelled once the peer disconnects or
+	// the node shuts down. Handlers of messages sent by the peer, and requests
+	// awaiting its responses are bound to it.
+	ctx    context.Context
+	cancel context.CancelFunc
 }
 
 // StreamState represents a stream.
@@ -73,9 +98,18 @@ func createPeerClient(network *Network, address string) (*PeerClient, error) {
 			buffered: make(chan struct{}),
 		},
 
-		jobs:        make(chan func(), 128),
-		closeSignal: make(chan struct{}),
+		jobs:         make(chan func(), 128),
+		priorityJobs: make(chan func(), 128),
+		closeSignal:  make(chan struct{}),
+	}
+
+	// The context is created before the client is published to the network,
+	// such that closing the client may never race with its creation.
+	parent := context.Background()
+	if network != nil && network.ctx != nil {
+		parent = network.ctx
 	}
+	client.ctx, client.cancel = context.WithCancel(parent)
 
 	return client, nil
 }
@@ -83,14 +117,31 @@ func createPeerClient(network *Network, address string) (*PeerClient, error) {
 // Init initialize a client's pluging and starts executing a jobs.
 func (c *PeerClient) Init() {
 	c.Network.plugins.Each(func(plugin PluginInterface) {
-		plugin.PeerConnect(c)
+		c.Network.safely(plugin, "PeerConnect", c, func() { plugin.PeerConnect(c) })
 	})
-	go c.executeJobs()
+	c.Network.goWorker(workerJobs, c.executeJobs)
 }
 
 func (c *PeerClient) executeJobs() {
 	for {
+		// Drop jobs still queued once the peer disconnects.
+		select {
+		case <-c.closeSignal:
+			return
+		default:
+		}
+
+		// Execute jobs with priority first.
 		select {
+		case job := <-c.priorityJobs:
+			job()
+			continue
+		default:
+		}
+
+		select {
+		case job := <-c.priorityJobs:
+			job()
 		case job := <-c.jobs:
 			job()
 		case <-c.closeSignal:
@@ -107,6 +158,39 @@ func (c *PeerClient) Submit(job func()) {
 	}
 }
 
+// submitPriority adds a job to the execution queue of jobs with priority.
+func (c *PeerClient) submitPriority(job func()) {
+	select {
+	case c.priorityJobs <- job:
+	case <-c.closeSignal:
+	}
+}
+
+// Context returns a context which is cancelled once the peer disconnects,
+// which happens to all peers as the node shuts down.
+func (c *PeerClient) Context() context.Context {
+	if c.ctx == nil {
+		return context.Background()
+	}
+	return c.ctx
+}
+
+// ID returns the ID of the peer, or nil should the peer have yet to identify
+// itself.
+func (c *PeerClient) ID() *peer.ID {
+	c.idMutex.RLock()
+	defer c.idMutex.RUnlock()
+
+	return c.id
+}
+
+// setID sets the ID the peer identified itself with.
+func (c *PeerClient) setID(id *peer.ID) {
+	c.idMutex.Lock()
+	c.id = id
+	c.idMutex.Unlock()
+}
+
 // Close stops all sessions/streams and cleans up the nodes in routing table.
 func (c *PeerClient) Close() error {
 	if atomic.SwapUint32(&c.closed, 1) == 1 {
@@ -115,30 +199,50 @@ func (c *PeerClient) Close() error {
 
 	close(c.closeSignal)
 
+	if c.cancel != nil {
+		c.cancel()
+	}
+
 	c.stream.Lock()
 	c.stream.isClosed = true
 	c.stream.Unlock()
 
 	c.Network.plugins.Each(func(plugin PluginInterface) {
-		plugin.PeerDisconnect(c)
+		c.Network.safely(plugin, "PeerDisconnect", c, func() { plugin.PeerDisconnect(c) })
 	})
 
-	// Remove entries from node's network.
-	if c.ID != nil {
-		// close out connections
-		if state, ok := c.Network.ConnectionState(c.ID.Address); ok {
-			state.conn.Close()
-		}
+	// Remove entries from node's network. Peers dialed which have yet to
+	// identify themselves are keyed by the address they were dialed at.
+	address := c.Address
+	if id := c.ID(); id != nil {
+		address = id.Address
+	}
 
-		c.Network.peers.Delete(c.ID.Address)
-		c.Network.connections.Delete(c.ID.Address)
+	// Close out the connection, which stops its receive worker, and drop all
+	// frames still queued for it.
+	if state, ok := c.Network.ConnectionState(address); ok {
+		state.conn.Close()
+
+		if state.queue != nil {
+			state.queue.clear()
+		}
 	}
 
+	c.Network.peers.Delete(address)
+	c.Network.connections.Delete(address)
+
 	return nil
 }
 
 // Tell will asynchronously emit a message to a given peer.
-func (c *PeerClient) Tell(ctx context.Context, message proto.Message) error {
+func (c *PeerClient) Tell(ctx context.Context, message proto.Message) (err error) {
+	ctx, span := c.Network.opts.tracer.StartSpan(ctx, "noise.Tell")
+	span.SetTag("peer_address", c.Address)
+	defer func() {
+		span.SetError(err)
+		span.Finish()
+	}()
+
 	signed, err := c.Network.PrepareMessage(ctx, message)
 	if err != nil {
 		return errors.Wrap(err, "failed to sign message")
@@ -146,14 +250,14 @@ func (c *PeerClient) Tell(ctx context.Context, message proto.Message) error {
 
 	err = c.Network.Write(c.Address, signed)
 	if err != nil {
-		return errors.Wrapf(err, "failed to send message to %s", c.Address)
+		return err
 	}
 
 	return nil
 }
 
 // Request requests for a response for a request sent to a given peer.
-func (c *PeerClient) Request(ctx context.Context, req proto.Message) (proto.Message, error) {
+func (c *PeerClient) Request(ctx context.Context, req proto.Message) (res proto.Message, err error) {
 	if ctx == nil {
 		return nil, errors.New("network: invalid context")
 	}
@@ -162,6 +266,19 @@ func (c *PeerClient) Request(ctx context.Context, req proto.Message) (proto.Mess
 		return nil, ctx.Err()
 	}
 
+	ctx, span := c.Network.opts.tracer.StartSpan(ctx, "noise.Request")
+	span.SetTag("peer_address", c.Address)
+	defer func() {
+		span.SetError(err)
+		span.Finish()
+	}()
+
+	release, err := c.reserveRequest()
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
 	signed, err := c.Network.PrepareMessage(ctx, req)
 	if err != nil {
 		return nil, err
@@ -169,12 +286,13 @@ func (c *PeerClient) Request(ctx context.Context, req proto.Message) (proto.Mess
 
 	signed.RequestNonce = atomic.AddUint64(&c.RequestNonce, 1)
 
-	err = c.Network.Write(c.Address, signed)
-	if err != nil {
-		return nil, err
+	// Have the peer give up on serving the request once we stop awaiting it.
+	if deadline, ok := ctx.Deadline(); ok {
+		signed.Timeout = int64(time.Until(deadline))
 	}
 
-	// Start tracking the request.
+	// Start tracking the request before it is sent, such that its response
+	// may not arrive before it is tracked.
 	channel := make(chan proto.Message, 1)
 	closeSignal := make(chan struct{})
 
@@ -187,17 +305,35 @@ func (c *PeerClient) Request(ctx context.Context, req proto.Message) (proto.Mess
 	defer close(closeSignal)
 	defer c.Requests.Delete(signed.RequestNonce)
 
+	err = c.Network.Write(c.Address, signed)
+	if err != nil {
+		return nil, err
+	}
+
 	select {
-	case res := <-channel:
+	case res = <-channel:
+		if reply, ok := res.(*protobuf.ErrorReply); ok {
+			return nil, remoteError(c.Address, reply)
+		}
 		return res, nil
 	case <-ctx.Done():
 		return nil, ctx.Err()
+	case <-c.Context().Done():
+		return nil, peerError(ErrPeerUnreachable, c.Address, errPeerDisconnected)
 	}
 }
 
 // Reply is equivalent to Write() with an appended nonce to signal a reply.
-func (c *PeerClient) Reply(ctx context.Context, nonce uint64, message proto.Message) error {
-	msg, err := c.Network.PrepareMessage(ctx, message)
+func (c *PeerClient) Reply(ctx context.Context, nonce uint64, message proto.Message) (err error) {
+	ctx, span := c.Network.opts.tracer.StartSpan(ctx, "noise.Reply")
+	span.SetTag("peer_address", c.Address)
+	defer func() {
+		span.SetError(err)
+		span.Finish()
+	}()
+
+	// Replies go straight back to the requester rather than being relayed.
+	msg, err := c.Network.PrepareMessage(withRelay(ctx, nil), message)
 	if err != nil {
 		return err
 	}
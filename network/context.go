@@ -2,6 +2,8 @@ package network
 
 import (
 	"context"
+
+	"github.com/perlin-network/noise/peer"
 )
 
 type (
@@ -25,3 +27,38 @@ func GetSignMessage(ctx context.Context) bool {
 	}
 	return sign
 }
+
+type originCtxKeyType struct{}
+
+// WithOrigin marks the peer a message being relayed arrived from, such that
+// broadcasts made under ctx skip it.
+func WithOrigin(ctx context.Context, origin peer.ID) context.Context {
+	return context.WithValue(ctx, originCtxKeyType{}, origin)
+}
+
+// GetOrigin returns the peer a message being relayed arrived from, and false
+// should ctx not be derived from an incoming message.
+func GetOrigin(ctx context.Context) (peer.ID, bool) {
+	origin, ok := ctx.Value(originCtxKeyType{}).(peer.ID)
+	return origin, ok
+}
+
+type relayCtxKeyType struct{}
+
+// relay is how far a message being relayed has propagated.
+type relay struct {
+	hops uint32
+	path [][]byte
+}
+
+// withRelay marks ctx as relaying a message which was relayed hops times
+// along path, such that messages prepared under ctx count one more hop. A nil
+// relay marks ctx as not relaying any message.
+func withRelay(ctx context.Context, r *relay) context.Context {
+	return context.WithValue(ctx, relayCtxKeyType{}, r)
+}
+
+func getRelay(ctx context.Context) *relay {
+	r, _ := ctx.Value(relayCtxKeyType{}).(*relay)
+	return r
+}
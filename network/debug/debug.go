@@ -0,0 +1,171 @@
+// Package debug exposes opt-in runtime introspection endpoints for a node:
+// pprof profiles, expvar variables, queue depths, per-pool worker counts, a
+// snapshot of the node's state, a snapshot of goroutines spawned by noise, and
+// liveness and readiness probes.
+//
+// Nothing is registered on http.DefaultServeMux; mount Handler wherever
+// suits, or call ListenAndServe on a dedicated (ideally loopback) address.
+package debug
+
+import (
+	"bytes"
+	"encoding/json"
+	"expvar"
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+
+	"github.com/perlin-network/noise/network"
+	"github.com/perlin-network/noise/network/health"
+)
+
+const (
+	// noisePackagePrefix identifies goroutines whose stacks pass through noise.
+	noisePackagePrefix = "github.com/perlin-network/noise/"
+)
+
+// Option configures the debug endpoints of a node.
+type Option func(*options)
+
+type options struct {
+	health *health.Health
+}
+
+// WithHealth sets the health prober serving liveness and readiness probes
+// (default: a prober with default options and no subsystems).
+func WithHealth(h *health.Health) Option {
+	return func(o *options) {
+		o.health = h
+	}
+}
+
+// Handler returns a http.Handler serving debug endpoints for a node:
+//
+//	/debug/pprof/           net/http/pprof profiles
+//	/debug/vars             process-wide expvar variables
+//	/debug/noise/vars       the node's queue depths, worker counts and handshake failures
+//	/debug/noise/snapshot   the node's identity, uptime, peers and plugin statuses
+//	/debug/noise/goroutines per-pool worker counts, and stacks of noise goroutines
+//	/healthz                liveness probe
+//	/readyz                 readiness probe
+func Handler(net *network.Network, opts ...Option) http.Handler {
+	o := options{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if o.health == nil {
+		o.health = health.New(net)
+	}
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+
+	mux.Handle("/debug/vars", expvar.Handler())
+
+	vars := Vars(net)
+	mux.HandleFunc("/debug/noise/vars", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.Write([]byte(vars.String()))
+	})
+
+	mux.HandleFunc("/debug/noise/snapshot", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		json.NewEncoder(w).Encode(net.Snapshot())
+	})
+
+	mux.HandleFunc("/debug/noise/goroutines", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.Write(Goroutines(net))
+	})
+
+	probes := o.health.Handler()
+	mux.Handle("/healthz", probes)
+	mux.Handle("/readyz", probes)
+
+	return mux
+}
+
+// ListenAndServe serves the debug endpoints of a node on address.
+func ListenAndServe(address string, net *network.Network, opts ...Option) error {
+	return http.ListenAndServe(address, Handler(net, opts...))
+}
+
+// Vars returns an expvar.Map reporting a node's queue depths, worker counts,
+// handshake failures by cause and resource usage. The map is not published globally so that several nodes may live
+// within the same process; callers may expvar.Publish it themselves.
+func Vars(net *network.Network) *expvar.Map {
+	vars := new(expvar.Map).Init()
+
+	vars.Set("address", expvarString(net.Address))
+	vars.Set("queues", expvar.Func(func() interface{} {
+		return net.QueueStats()
+	}))
+	vars.Set("workers", expvar.Func(func() interface{} {
+		return net.WorkerCounts()
+	}))
+	vars.Set("handshake_failures", expvar.Func(func() interface{} {
+		return net.HandshakeFailures()
+	}))
+	vars.Set("resources", expvar.Func(func() interface{} {
+		return net.ResourceUsage()
+	}))
+	vars.Set("goroutines", expvar.Func(func() interface{} {
+		return runtime.NumGoroutine()
+	}))
+
+	return vars
+}
+
+// Goroutines returns a human-readable snapshot of the node's worker pools,
+// followed by the stacks of every goroutine currently executing noise code.
+// Diffing two snapshots taken some time apart helps pinpoint leaks.
+func Goroutines(net *network.Network) []byte {
+	var buf bytes.Buffer
+
+	counts, _ := json.Marshal(net.WorkerCounts())
+	buf.WriteString("workers: ")
+	buf.Write(counts)
+	buf.WriteString("\n\n")
+
+	for _, stack := range noiseStacks() {
+		buf.Write(stack)
+		buf.WriteString("\n\n")
+	}
+
+	return buf.Bytes()
+}
+
+// noiseStacks returns the stacks of all goroutines which pass through noise.
+func noiseStacks() [][]byte {
+	size := 1 << 16
+	var all []byte
+	for {
+		all = make([]byte, size)
+		if n := runtime.Stack(all, true); n < size {
+			all = all[:n]
+			break
+		}
+		size *= 2
+	}
+
+	var stacks [][]byte
+	for _, stack := range bytes.Split(all, []byte("\n\n")) {
+		if bytes.Contains(stack, []byte(noisePackagePrefix)) {
+			stacks = append(stacks, stack)
+		}
+	}
+	return stacks
+}
+
+type expvarString string
+
+func (s expvarString) String() string {
+	b, _ := json.Marshal(string(s))
+	return string(b)
+}
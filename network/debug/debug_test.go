@@ -0,0 +1,65 @@
+package debug
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/perlin-network/noise/crypto/ed25519"
+	"github.com/perlin-network/noise/network"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler(t *testing.T) {
+	builder := network.NewBuilder()
+	builder.SetKeys(ed25519.RandomKeyPair())
+	builder.SetAddress(network.FormatAddress("tcp", "localhost", 0))
+
+	net, err := builder.Build()
+	assert.Equal(t, nil, err)
+
+	server := httptest.NewServer(Handler(net))
+	defer server.Close()
+
+	res, err := server.Client().Get(server.URL + "/debug/noise/vars")
+	assert.Equal(t, nil, err)
+	defer res.Body.Close()
+
+	var vars struct {
+		Address   string                  `json:"address"`
+		Queues    network.QueueStats      `json:"queues"`
+		Workers   map[string]int64        `json:"workers"`
+		Resources []network.ResourceUsage `json:"resources"`
+	}
+	assert.Equal(t, nil, json.NewDecoder(res.Body).Decode(&vars))
+	assert.Equal(t, net.Address, vars.Address)
+	assert.Equal(t, 10, len(vars.Workers))
+	assert.NotEmpty(t, vars.Resources)
+
+	res, err = server.Client().Get(server.URL + "/debug/noise/snapshot")
+	assert.Equal(t, nil, err)
+	defer res.Body.Close()
+
+	var snapshot network.Snapshot
+	assert.Equal(t, nil, json.NewDecoder(res.Body).Decode(&snapshot))
+	assert.Equal(t, net.ID.PublicKeyHex(), snapshot.PublicKey)
+	assert.Equal(t, 0, len(snapshot.Peers))
+
+	res, err = server.Client().Get(server.URL + "/debug/noise/goroutines")
+	assert.Equal(t, nil, err)
+	defer res.Body.Close()
+
+	body, err := ioutil.ReadAll(res.Body)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, strings.HasPrefix(string(body), "workers: "))
+
+	res, err = server.Client().Get(server.URL + "/healthz")
+	assert.Equal(t, nil, err)
+	defer res.Body.Close()
+
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+}
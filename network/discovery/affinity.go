@@ -0,0 +1,54 @@
+package discovery
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/perlin-network/noise/peer"
+)
+
+// Responsible returns up to count peers responsible for an application key:
+// the peers within the routing table, ourselves included, closest to its
+// KeyID by XOR distance, closest first.
+//
+// Services may shard work across the overlay by handing every key to its
+// responsible peers. As distances between keys and peers never change, a peer
+// joining only takes over keys it is now among the closest to, and a peer
+// leaving only hands its keys over to the next closest peers.
+func (state *Plugin) Responsible(key []byte, count int) []peer.ID {
+	if state.Routes == nil || count <= 0 {
+		return nil
+	}
+
+	target := KeyID(key)
+
+	peers := append(state.Routes.FindClosestPeers(target, count), state.Routes.Self())
+
+	sort.Slice(peers, func(i, j int) bool {
+		return bytes.Compare(peers[i].XorID(target).Id, peers[j].XorID(target).Id) < 0
+	})
+
+	if len(peers) > count {
+		peers = peers[:count]
+	}
+
+	return peers
+}
+
+// IsResponsible returns whether or not we are among the count peers
+// responsible for an application key.
+func (state *Plugin) IsResponsible(key []byte, count int) bool {
+	if state.Routes == nil {
+		return false
+	}
+
+	self := state.Routes.Self()
+
+	for _, id := range state.Responsible(key, count) {
+		if id.Equals(self) {
+			return true
+		}
+	}
+
+	return false
+}
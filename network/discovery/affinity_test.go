@@ -0,0 +1,94 @@
+package discovery
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/perlin-network/noise/crypto/ed25519"
+	"github.com/perlin-network/noise/dht"
+	"github.com/perlin-network/noise/peer"
+)
+
+func randomID(port int) peer.ID {
+	return peer.CreateID(fmt.Sprintf("tcp://localhost:%d", port), ed25519.RandomKeyPair().PublicKey)
+}
+
+// owners maps keys to the peer responsible for them.
+func owners(state *Plugin, keys [][]byte) []string {
+	owners := make([]string, len(keys))
+	for i, key := range keys {
+		owners[i] = state.Responsible(key, 1)[0].Address
+	}
+	return owners
+}
+
+func TestResponsible(t *testing.T) {
+	t.Parallel()
+
+	self := randomID(3000)
+
+	state := New()
+	state.setDefaults()
+	state.Routes = dht.CreateRoutingTable(self)
+
+	all := []peer.ID{self}
+	for i := 1; i <= 8; i++ {
+		id := randomID(3000 + i)
+		state.Routes.Update(id)
+		all = append(all, id)
+	}
+
+	key := []byte("shard")
+	target := KeyID(key)
+
+	sort.Slice(all, func(i, j int) bool {
+		return bytes.Compare(all[i].XorID(target).Id, all[j].XorID(target).Id) < 0
+	})
+
+	responsible := state.Responsible(key, 3)
+	if len(responsible) != 3 {
+		t.Fatalf("Responsible() expected 3 peers, got %d", len(responsible))
+	}
+	for i, id := range responsible {
+		if !id.Equals(all[i]) {
+			t.Fatalf("Responsible() expected peer %d to be %s, got %s", i, all[i].Address, id.Address)
+		}
+	}
+
+	if got := len(state.Responsible(key, 100)); got != len(all) {
+		t.Fatalf("Responsible() expected all %d peers, got %d", len(all), got)
+	}
+
+	if !state.IsResponsible(key, len(all)) {
+		t.Fatal("IsResponsible() expected ourselves to be responsible given every peer is")
+	}
+
+	keys := make([][]byte, 256)
+	for i := range keys {
+		keys[i] = []byte(fmt.Sprintf("key-%d", i))
+	}
+
+	// Only keys the joining peer is closest to are reassigned, and only to it.
+	before := owners(state, keys)
+
+	joining := randomID(4000)
+	state.Routes.Update(joining)
+
+	after := owners(state, keys)
+	for i := range keys {
+		if before[i] != after[i] && after[i] != joining.Address {
+			t.Fatalf("expected key %q to only be reassigned to the joining peer, got %s", keys[i], after[i])
+		}
+	}
+
+	// Keys of a leaving peer are handed back, leaving others in place.
+	state.Routes.RemovePeer(joining)
+
+	for i, owner := range owners(state, keys) {
+		if owner != before[i] {
+			t.Fatalf("expected key %q to be reassigned back to %s, got %s", keys[i], before[i], owner)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package discovery
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/perlin-network/noise/peer"
+)
+
+// QueryEventType denotes the kind of a query event.
+type QueryEventType int
+
+const (
+	// QueryStarted is emitted once a query commences.
+	QueryStarted QueryEventType = iota
+	// PeerQueried is emitted whenever a peer responds to a query.
+	PeerQueried
+	// PeerFailed is emitted whenever a peer fails to respond to a query.
+	PeerFailed
+	// QueryFinished is emitted once a query completes.
+	QueryFinished
+)
+
+// String returns the name of the query event type.
+func (t QueryEventType) String() string {
+	switch t {
+	case QueryStarted:
+		return "QueryStarted"
+	case PeerQueried:
+		return "PeerQueried"
+	case PeerFailed:
+		return "PeerFailed"
+	case QueryFinished:
+		return "QueryFinished"
+	}
+	return "Unknown"
+}
+
+// QueryEvent describes progress made by a DHT query, such as a lookup.
+type QueryEvent struct {
+	Type QueryEventType
+	Time time.Time
+
+	// QueryID uniquely identifies the query the event belongs to.
+	QueryID uint64
+	// Target is the ID the query is directed towards.
+	Target peer.ID
+
+	// Path is the index of the disjoint lookup path the peer was queried on.
+	Path int
+	// Peer is the peer which was queried (PeerQueried and PeerFailed).
+	Peer peer.ID
+	// Peers holds the closer peers the queried peer responded with
+	// (PeerQueried), or the results of the query (QueryFinished).
+	Peers []peer.ID
+	// Err is the reason the queried peer failed to respond (PeerFailed).
+	Err error
+
+	// Latency is how long the peer took to respond (PeerQueried and
+	// PeerFailed), or how long the query took to complete (QueryFinished).
+	Latency time.Duration
+}
+
+// QueryObserver receives events for every query made by the discovery plugin,
+// such that queries may be visualized and slow resolutions debugged.
+//
+// Events may be emitted concurrently from multiple goroutines.
+type QueryObserver interface {
+	ObserveQuery(event QueryEvent)
+}
+
+// QueryObserverFunc is an adapter to allow the use of ordinary functions as query observers.
+type QueryObserverFunc func(event QueryEvent)
+
+// ObserveQuery calls f(event).
+func (f QueryObserverFunc) ObserveQuery(event QueryEvent) {
+	f(event)
+}
+
+var queryIDs uint64
+
+// query emits the events of a single query to an observer.
+//
+// A nil *query is valid, and emits no events.
+type query struct {
+	observer QueryObserver
+	id       uint64
+	target   peer.ID
+	start    time.Time
+}
+
+// startQuery emits a QueryStarted event should a query observer be registered.
+func (state *Plugin) startQuery(target peer.ID) *query {
+	if state.Observer == nil {
+		return nil
+	}
+
+	q := &query{
+		observer: state.Observer,
+		id:       atomic.AddUint64(&queryIDs, 1),
+		target:   target,
+		start:    time.Now(),
+	}
+	q.emit(QueryEvent{Type: QueryStarted})
+
+	return q
+}
+
+func (q *query) emit(event QueryEvent) {
+	event.Time = time.Now()
+	event.QueryID = q.id
+	event.Target = q.target
+
+	q.observer.ObserveQuery(event)
+}
+
+// peerQueried emits a PeerQueried event.
+func (q *query) peerQueried(path int, peerID peer.ID, closer []peer.ID, start time.Time) {
+	if q == nil {
+		return
+	}
+	q.emit(QueryEvent{Type: PeerQueried, Path: path, Peer: peerID, Peers: closer, Latency: time.Since(start)})
+}
+
+// peerFailed emits a PeerFailed event.
+func (q *query) peerFailed(path int, peerID peer.ID, err error, start time.Time) {
+	if q == nil {
+		return
+	}
+	q.emit(QueryEvent{Type: PeerFailed, Path: path, Peer: peerID, Err: err, Latency: time.Since(start)})
+}
+
+// finish emits a QueryFinished event.
+func (q *query) finish(results []peer.ID) {
+	if q == nil {
+		return
+	}
+	q.emit(QueryEvent{Type: QueryFinished, Peers: results, Latency: time.Since(q.start)})
+}
@@ -0,0 +1,47 @@
+package discovery
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestQueryEvents(t *testing.T) {
+	t.Parallel()
+
+	var events []QueryEvent
+
+	state := New(WithQueryObserver(QueryObserverFunc(func(event QueryEvent) {
+		events = append(events, event)
+	})))
+
+	target := idWithPrefix(0x01)
+
+	q := state.startQuery(target)
+	q.peerQueried(1, idWithPrefix(0x02), nil, time.Now())
+	q.peerFailed(0, idWithPrefix(0x03), errors.New("timed out"), time.Now())
+	q.finish(nil)
+
+	expected := []QueryEventType{QueryStarted, PeerQueried, PeerFailed, QueryFinished}
+	if len(events) != len(expected) {
+		t.Fatalf("expected %d events, got %d", len(expected), len(events))
+	}
+
+	for i, event := range events {
+		if event.Type != expected[i] {
+			t.Fatalf("events[%d] = %s, expected %s", i, event.Type, expected[i])
+		}
+		if event.QueryID != events[0].QueryID || !event.Target.Equals(target) {
+			t.Fatalf("expected all events to belong to the same query")
+		}
+	}
+
+	if events[1].Path != 1 || events[2].Err == nil {
+		t.Fatalf("expected peer events to carry their path and error")
+	}
+
+	// Queries made without an observer emit nothing.
+	unobserved := New().startQuery(target)
+	unobserved.peerQueried(0, idWithPrefix(0x02), nil, time.Now())
+	unobserved.finish(nil)
+}
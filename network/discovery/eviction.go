@@ -0,0 +1,229 @@
+package discovery
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/perlin-network/noise/network"
+	"github.com/perlin-network/noise/peer"
+)
+
+// EvictionPolicy decides which peer, if any, should be evicted from a full
+// routing table bucket in favour of a candidate peer.
+type EvictionPolicy interface {
+	// Evict is handed the peers held by a full bucket, most recently seen first,
+	// and returns the peer to evict in favour of candidate. Should no peer be
+	// evicted, false is returned.
+	//
+	// Evict is called outside of the message processing path, and may block
+	// until ctx is cancelled once the plugin is cleaned up.
+	Evict(ctx context.Context, net *network.Network, peers []peer.ID, candidate peer.ID) (peer.ID, bool)
+}
+
+// PingLeastRecentlySeen is the eviction policy described in the Kademlia paper:
+// the least recently seen peer is pinged, and is only evicted should it fail to
+// respond within Timeout (default: 3 seconds).
+type PingLeastRecentlySeen struct {
+	Timeout time.Duration
+}
+
+// Evict implements EvictionPolicy.
+func (p PingLeastRecentlySeen) Evict(ctx context.Context, net *network.Network, peers []peer.ID, candidate peer.ID) (peer.ID, bool) {
+	if len(peers) == 0 {
+		return peer.ID{}, false
+	}
+
+	lastSeen := peers[len(peers)-1]
+
+	client, err := net.Client(lastSeen.Address)
+	if err != nil {
+		return lastSeen, true
+	}
+
+	timeout := p.Timeout
+	if timeout <= 0 {
+		timeout = defaultQueryTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if err := ping(ctx, client); err != nil {
+		// Do not punish peers for our own shutdown.
+		if ctx.Err() == context.Canceled {
+			return peer.ID{}, false
+		}
+		return lastSeen, true
+	}
+
+	return peer.ID{}, false
+}
+
+// LeastRecentlySeen unconditionally evicts the least recently seen peer,
+// favouring fresh peers over long-lived ones.
+type LeastRecentlySeen struct{}
+
+// Evict implements EvictionPolicy.
+func (LeastRecentlySeen) Evict(ctx context.Context, net *network.Network, peers []peer.ID, candidate peer.ID) (peer.ID, bool) {
+	if len(peers) == 0 {
+		return peer.ID{}, false
+	}
+	return peers[len(peers)-1], true
+}
+
+// OldestConnection unconditionally evicts the peer whose connection is the
+// oldest, rotating long-lived connections out in favour of fresh peers. Peers
+// we hold no connection to are evicted first.
+type OldestConnection struct{}
+
+// Evict implements EvictionPolicy.
+func (OldestConnection) Evict(ctx context.Context, net *network.Network, peers []peer.ID, candidate peer.ID) (peer.ID, bool) {
+	if len(peers) == 0 {
+		return peer.ID{}, false
+	}
+
+	var oldest peer.ID
+	var oldestAt time.Time
+
+	for i, id := range peers {
+		conn, connected := net.ConnectionState(id.Address)
+		if !connected {
+			return id, true
+		}
+
+		if i == 0 || conn.ConnectedAt().Before(oldestAt) {
+			oldest, oldestAt = id, conn.ConnectedAt()
+		}
+	}
+
+	return oldest, true
+}
+
+// LowestScore evicts the lowest scoring peer, should the candidate score higher.
+type LowestScore struct {
+	Score func(id peer.ID) float64
+}
+
+// Evict implements EvictionPolicy.
+func (p LowestScore) Evict(ctx context.Context, net *network.Network, peers []peer.ID, candidate peer.ID) (peer.ID, bool) {
+	if len(peers) == 0 || p.Score == nil {
+		return peer.ID{}, false
+	}
+
+	lowest, lowestScore := peers[0], p.Score(peers[0])
+	for _, id := range peers[1:] {
+		if score := p.Score(id); score < lowestScore {
+			lowest, lowestScore = id, score
+		}
+	}
+
+	if p.Score(candidate) <= lowestScore {
+		return peer.ID{}, false
+	}
+
+	return lowest, true
+}
+
+// evictionQueue holds the candidates of full buckets awaiting an eviction
+// decision. Candidates are coalesced per bucket, such that a burst of peers
+// contending for one bucket costs at most a single eviction.
+type evictionQueue struct {
+	mutex   sync.Mutex
+	pending map[int]peer.ID // bucket -> latest candidate
+	order   []int
+
+	wake chan struct{}
+}
+
+func newEvictionQueue() *evictionQueue {
+	return &evictionQueue{
+		pending: make(map[int]peer.ID),
+		wake:    make(chan struct{}, 1),
+	}
+}
+
+// push queues a candidate for the bucket it belongs to, replacing any
+// candidate for the bucket already queued.
+func (q *evictionQueue) push(bucketID int, candidate peer.ID) {
+	q.mutex.Lock()
+	if _, queued := q.pending[bucketID]; !queued {
+		q.order = append(q.order, bucketID)
+	}
+	q.pending[bucketID] = candidate
+	q.mutex.Unlock()
+
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+}
+
+// take dequeues up to max buckets in the order they were queued.
+func (q *evictionQueue) take(max int) (buckets []int, candidates []peer.ID) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	if max > len(q.order) {
+		max = len(q.order)
+	}
+
+	buckets, q.order = q.order[:max:max], q.order[max:]
+
+	for _, bucketID := range buckets {
+		candidates = append(candidates, q.pending[bucketID])
+		delete(q.pending, bucketID)
+	}
+
+	return buckets, candidates
+}
+
+// evict queues a candidate belonging to a full bucket for the eviction loop to
+// decide upon, without blocking the message processing path.
+func (state *Plugin) evict(net *network.Network, candidate peer.ID) {
+	state.evictions.push(candidate.XorID(net.ID).PrefixLen(), candidate)
+}
+
+// evictLoop asks the eviction policy whether peers should be evicted from the
+// buckets queued for eviction, deciding upon up to EvictionBatchSize buckets at
+// once. Evicting a peer promotes the freshest candidate cached as its
+// replacement. At most one eviction runs per bucket at a time.
+func (state *Plugin) evictLoop(ctx context.Context, net *network.Network) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-state.evictions.wake:
+		}
+
+		for ctx.Err() == nil {
+			buckets, candidates := state.evictions.take(state.EvictionBatchSize)
+			if len(buckets) == 0 {
+				break
+			}
+
+			var wg sync.WaitGroup
+			wg.Add(len(buckets))
+
+			for i := range buckets {
+				bucketID, candidate := buckets[i], candidates[i]
+
+				spawn(net, func() {
+					defer wg.Done()
+
+					// The bucket may have made room for the candidate since.
+					peers := state.Routes.Bucket(bucketID).Peers()
+					if len(peers) < state.Routes.BucketSize() {
+						return
+					}
+
+					if victim, ok := state.Eviction.Evict(ctx, net, peers, candidate); ok {
+						state.removePeer(victim)
+					}
+				})
+			}
+
+			wg.Wait()
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package discovery
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/perlin-network/noise/clock"
+	"github.com/perlin-network/noise/internal/test"
+	"github.com/perlin-network/noise/network"
+	"github.com/perlin-network/noise/peer"
+)
+
+func TestLowestScoreEviction(t *testing.T) {
+	t.Parallel()
+
+	peers := []peer.ID{idWithPrefix(0x01), idWithPrefix(0x02), idWithPrefix(0x03)}
+	scores := map[byte]float64{0x01: 5, 0x02: 1, 0x03: 3, 0x04: 2, 0x05: 0}
+
+	policy := LowestScore{Score: func(id peer.ID) float64 {
+		return scores[id.Id[0]]
+	}}
+
+	victim, ok := policy.Evict(context.Background(), nil, peers, idWithPrefix(0x04))
+	if !ok || victim.Id[0] != 0x02 {
+		t.Fatalf("expected lowest scoring peer to be evicted in favour of a higher scoring candidate")
+	}
+
+	if _, ok := policy.Evict(context.Background(), nil, peers, idWithPrefix(0x05)); ok {
+		t.Fatalf("expected no peer to be evicted in favour of a lower scoring candidate")
+	}
+}
+
+func TestLeastRecentlySeenEviction(t *testing.T) {
+	t.Parallel()
+
+	peers := []peer.ID{idWithPrefix(0x01), idWithPrefix(0x02), idWithPrefix(0x03)}
+
+	victim, ok := LeastRecentlySeen{}.Evict(context.Background(), nil, peers, idWithPrefix(0x04))
+	if !ok || victim.Id[0] != 0x03 {
+		t.Fatalf("expected least recently seen peer to be evicted")
+	}
+}
+
+func TestOldestConnectionEviction(t *testing.T) {
+	t.Parallel()
+
+	virtual := clock.NewVirtual(time.Now())
+
+	net := test.BuildNode(t, []network.BuilderOption{network.Clock(virtual)})
+	defer net.Close()
+
+	older, newer := test.BuildNode(t, nil), test.BuildNode(t, nil)
+	defer older.Close()
+	defer newer.Close()
+
+	if _, err := net.Client(older.Address); err != nil {
+		t.Fatalf("Client() = expected no error, got %v", err)
+	}
+
+	virtual.Advance(time.Minute)
+
+	if _, err := net.Client(newer.Address); err != nil {
+		t.Fatalf("Client() = expected no error, got %v", err)
+	}
+
+	peers := []peer.ID{newer.ID, older.ID}
+
+	victim, ok := OldestConnection{}.Evict(context.Background(), net, peers, idWithPrefix(0x01))
+	if !ok || !victim.Equals(older.ID) {
+		t.Fatalf("expected peer with the oldest connection to be evicted")
+	}
+
+	unconnected := peer.CreateID("tcp://localhost:1", idWithPrefix(0x02).PublicKey)
+
+	victim, ok = OldestConnection{}.Evict(context.Background(), net, append(peers, unconnected), idWithPrefix(0x01))
+	if !ok || !victim.Equals(unconnected) {
+		t.Fatalf("expected peer with no connection to be evicted first")
+	}
+}
+
+func TestEvictionQueue(t *testing.T) {
+	t.Parallel()
+
+	q := newEvictionQueue()
+
+	q.push(1, idWithPrefix(0x01))
+	q.push(2, idWithPrefix(0x02))
+	q.push(1, idWithPrefix(0x03))
+	q.push(3, idWithPrefix(0x04))
+
+	buckets, candidates := q.take(2)
+	if len(buckets) != 2 || buckets[0] != 1 || buckets[1] != 2 {
+		t.Fatalf("expected buckets to be taken in the order they were queued, got %v", buckets)
+	}
+
+	if candidates[0].Id[0] != 0x03 {
+		t.Fatalf("expected the latest candidate of a bucket to be kept")
+	}
+
+	buckets, _ = q.take(2)
+	if len(buckets) != 1 || buckets[0] != 3 {
+		t.Fatalf("expected the remaining bucket to be taken, got %v", buckets)
+	}
+
+	if buckets, _ = q.take(2); len(buckets) != 0 {
+		t.Fatalf("expected the queue to be drained")
+	}
+}
@@ -0,0 +1,56 @@
+package discovery
+
+import (
+	"testing"
+
+	"github.com/perlin-network/noise/internal/protobuf"
+	"github.com/perlin-network/noise/peer"
+
+	"github.com/gogo/protobuf/proto"
+)
+
+func FuzzFilterPeers(f *testing.F) {
+	net, remote := buildNetwork(f, 3060), buildNetwork(f, 3061)
+
+	state := New()
+	state.Startup(net)
+	defer state.Cleanup(net)
+
+	record, err := NewPeerRecord(remote, 1)
+	if err != nil {
+		f.Fatal(err)
+	}
+
+	id := protobuf.ID(remote.ID)
+
+	valid, err := proto.Marshal(&protobuf.LookupNodeResponse{Peers: []*protobuf.ID{&id}, Records: []*protobuf.PeerRecord{record}})
+	if err != nil {
+		f.Fatal(err)
+	}
+
+	malformed, err := proto.Marshal(&protobuf.LookupNodeResponse{Peers: []*protobuf.ID{{Address: remote.Address}}})
+	if err != nil {
+		f.Fatal(err)
+	}
+
+	f.Add(valid)
+	f.Add(malformed)
+	f.Add([]byte{0x0a, 0x00})
+	f.Add([]byte{})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		response := new(protobuf.LookupNodeResponse)
+		if err := proto.Unmarshal(data, response); err != nil {
+			return
+		}
+
+		for _, id := range state.filterPeers(net, response.Peers, response.Records) {
+			target := peer.ID(*id)
+
+			state.Routes.Update(target)
+			state.Routes.PeerExists(target)
+			state.Routes.LastSeen(target)
+			state.Routes.RemovePeer(target)
+		}
+	})
+}
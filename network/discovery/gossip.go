@@ -0,0 +1,88 @@
+package discovery
+
+import (
+	"context"
+	"sort"
+
+	"github.com/perlin-network/noise/internal/protobuf"
+	"github.com/perlin-network/noise/network"
+	"github.com/perlin-network/noise/peer"
+	"github.com/perlin-network/noise/types/bloom"
+
+	"github.com/pkg/errors"
+)
+
+// summarize builds a compact summary of the routing table: a Bloom filter over
+// the IDs of ourselves and all peers within the routing table.
+func (state *Plugin) summarize(net *network.Network) *protobuf.RoutingSummaryRequest {
+	peers := state.Routes.GetPeers()
+
+	filter := bloom.New(len(peers) + 1)
+	filter.Add(net.ID.Id)
+
+	for _, peerID := range peers {
+		filter.Add(peerID.Id)
+	}
+
+	return &protobuf.RoutingSummaryRequest{Filter: filter.Bytes(), Hashes: filter.Hashes()}
+}
+
+// missingPeers returns the #K peers within our routing table closest to the
+// sender of a routing table summary which are absent from the summary.
+func (state *Plugin) missingPeers(sender peer.ID, summary *protobuf.RoutingSummaryRequest) ([]peer.ID, error) {
+	filter, err := bloom.FromBytes(summary.Filter, summary.Hashes)
+	if err != nil {
+		return nil, err
+	}
+
+	var missing []peer.ID
+
+	state.Routes.ForEach(func(peerID peer.ID) bool {
+		if !peerID.Equals(sender) && !filter.Has(peerID.Id) {
+			missing = append(missing, peerID)
+		}
+		return true
+	})
+
+	sort.Slice(missing, func(i, j int) bool {
+		return missing[i].XorID(sender).Less(missing[j].XorID(sender))
+	})
+
+	if len(missing) > state.BucketSize {
+		missing = missing[:state.BucketSize]
+	}
+
+	return missing, nil
+}
+
+// gossip exchanges a summary of our routing table with a random peer, and
+// pulls in the peers it holds which we lack. Routing tables thus converge
+// after partitions without requiring full lookups.
+func (state *Plugin) gossip(ctx context.Context, net *network.Network) error {
+	peers := state.Routes.GetPeers()
+	if len(peers) == 0 {
+		return nil
+	}
+
+	target := peers[net.Random().Intn(len(peers))]
+
+	response, err := requestPeerByID(ctx, net, target, state.summarize(net))
+	if err != nil {
+		return err
+	}
+
+	res, ok := response.(*protobuf.RoutingSummaryResponse)
+	if !ok {
+		return errors.Errorf("discovery: unexpected response %T from %s", response, target.Address)
+	}
+
+	learned := toPeerIDs(state.filterPeers(net, res.Peers, res.Records))
+	state.learnPeers(net, learned)
+
+	net.Log("discovery").Debug().
+		Str("peer_address", target.Address).
+		Int("num_peers", len(learned)).
+		Msg("Exchanged routing table summary.")
+
+	return nil
+}
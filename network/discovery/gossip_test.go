@@ -0,0 +1,51 @@
+package discovery
+
+import (
+	"testing"
+
+	"github.com/perlin-network/noise/dht"
+	"github.com/perlin-network/noise/internal/protobuf"
+	"github.com/perlin-network/noise/types/bloom"
+)
+
+func TestMissingPeers(t *testing.T) {
+	t.Parallel()
+
+	state := New(WithBucketSize(2))
+	state.setDefaults()
+	state.Routes = dht.CreateRoutingTable(idWithPrefix(0xff))
+
+	for _, prefix := range []byte{0x01, 0x02, 0x20, 0x40, 0x80} {
+		state.Routes.Update(idWithPrefix(prefix))
+	}
+
+	// The sender holds 0x02 and itself within its routing table.
+	sender := idWithPrefix(0x01)
+
+	filter := bloom.New(2)
+	filter.Add(sender.Id)
+	filter.Add(idWithPrefix(0x02).Id)
+
+	summary := &protobuf.RoutingSummaryRequest{Filter: filter.Bytes(), Hashes: filter.Hashes()}
+
+	missing, err := state.missingPeers(sender, summary)
+	if err != nil {
+		t.Fatalf("missingPeers() = expected no error, got %v", err)
+	}
+
+	// Only the #K missing peers closest to the sender are returned.
+	expected := []byte{0x20, 0x40}
+	if len(missing) != len(expected) {
+		t.Fatalf("expected %d missing peers, got %d", len(expected), len(missing))
+	}
+
+	for i, prefix := range expected {
+		if missing[i].Id[0] != prefix {
+			t.Fatalf("missing[%d] = %x, expected prefix %x", i, missing[i].Id[0], prefix)
+		}
+	}
+
+	if _, err := state.missingPeers(sender, &protobuf.RoutingSummaryRequest{}); err == nil {
+		t.Fatalf("missingPeers() expected an error given an empty summary")
+	}
+}
@@ -0,0 +1,156 @@
+package discovery
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"time"
+
+	"github.com/perlin-network/noise/internal/protobuf"
+	"github.com/perlin-network/noise/network"
+	"github.com/perlin-network/noise/peer"
+
+	"github.com/pkg/errors"
+)
+
+// identityLinkPayload deterministically serializes all fields of an identity
+// link except for its signatures, for signing purposes.
+func identityLinkPayload(link *protobuf.IdentityLink) []byte {
+	var buf bytes.Buffer
+
+	writeBytes := func(b []byte) {
+		binary.Write(&buf, binary.LittleEndian, uint32(len(b)))
+		buf.Write(b)
+	}
+
+	writeBytes(link.OldPublicKey)
+
+	if link.NewId != nil {
+		writeBytes(link.NewId.PublicKey)
+		writeBytes([]byte(link.NewId.Address))
+		writeBytes(link.NewId.Id)
+	}
+
+	return buf.Bytes()
+}
+
+// NewIdentityLink returns an identity link announcing that the identity of a
+// node being retired is replaced by the identity of another node, signed by
+// both identities.
+func NewIdentityLink(oldNet *network.Network, newNet *network.Network) (*protobuf.IdentityLink, error) {
+	newID := protobuf.ID(newNet.ID)
+
+	link := &protobuf.IdentityLink{
+		OldPublicKey: oldNet.ID.PublicKey,
+		NewId:        &newID,
+	}
+
+	payload := identityLinkPayload(link)
+
+	oldSignature, err := oldNet.Sign(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	newSignature, err := newNet.Sign(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	link.OldSignature, link.NewSignature = oldSignature, newSignature
+
+	return link, nil
+}
+
+// VerifyIdentityLink checks that an identity link was signed by both of the
+// identities it links under the signature and hash policies of a network, and
+// that the new identities ID matches its public key.
+func VerifyIdentityLink(net *network.Network, link *protobuf.IdentityLink) bool {
+	if link == nil || link.NewId == nil || len(link.OldSignature) == 0 || len(link.NewSignature) == 0 {
+		return false
+	}
+
+	newID := peer.ID(*link.NewId)
+	if !peer.CreateID(newID.Address, newID.PublicKey).Equals(newID) {
+		return false
+	}
+
+	payload := identityLinkPayload(link)
+
+	return net.Verify(link.OldPublicKey, payload, link.OldSignature) &&
+		net.Verify(newID.PublicKey, payload, link.NewSignature)
+}
+
+// receiveIdentityLink migrates the routing table entry of a retired identity
+// over to the identity replacing it. Links for identities not within our
+// routing table are ignored.
+func (state *Plugin) receiveIdentityLink(net *network.Network, link *protobuf.IdentityLink) {
+	if !VerifyIdentityLink(net, link) {
+		return
+	}
+
+	oldID, newID := peer.CreateID("", link.OldPublicKey), peer.ID(*link.NewId)
+
+	if !state.Routes.PeerExists(oldID) {
+		return
+	}
+
+	state.removePeer(oldID)
+
+	state.learnPeers(net, []peer.ID{newID})
+
+	net.Log("discovery").Debug().
+		Str("peer_address", newID.Address).
+		Msg("Migrated peer to its new identity.")
+}
+
+// RotateIdentity rotates the identity of a node over to a new node holding a
+// freshly generated keypair, which must already be listening.
+//
+// The routing table of the retiring node is migrated over to the new node,
+// which then bootstraps with all of the retiring node's peers. The retiring
+// node then announces a link to the new identity signed by both identities,
+// such that peers migrate their routing table entries.
+//
+// Both identities run side by side for a grace period, after which the
+// retiring node is closed. Should ctx be cancelled, the retiring node is
+// closed immediately.
+func RotateIdentity(ctx context.Context, oldNet *network.Network, newNet *network.Network, grace time.Duration) error {
+	oldPlugin, exists := oldNet.Plugin(PluginID)
+	if !exists {
+		return errors.New("discovery: plugin is not registered on the retiring node")
+	}
+
+	newPlugin, exists := newNet.Plugin(PluginID)
+	if !exists {
+		return errors.New("discovery: plugin is not registered on the new node")
+	}
+
+	newNet.BlockUntilListening()
+
+	// Migrate the routing table of the retiring identity over to the new identity.
+	snapshot := oldPlugin.(*Plugin).Export()
+	newPlugin.(*Plugin).Import(newNet, snapshot)
+
+	addresses := make([]string, 0, len(snapshot.Peers))
+	for _, id := range snapshot.Peers {
+		addresses = append(addresses, id.Address)
+	}
+	newNet.Bootstrap(addresses...)
+
+	link, err := NewIdentityLink(oldNet, newNet)
+	if err != nil {
+		return errors.Wrap(err, "discovery: failed to sign identity link")
+	}
+
+	oldNet.Broadcast(ctx, link)
+
+	select {
+	case <-oldNet.Clock().After(grace):
+	case <-ctx.Done():
+	}
+
+	oldNet.Close()
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package discovery
+
+import (
+	"testing"
+
+	"github.com/perlin-network/noise/dht"
+)
+
+func TestIdentityLink(t *testing.T) {
+	t.Parallel()
+
+	local, retiring, replacement := buildNetwork(t, 3050), buildNetwork(t, 3051), buildNetwork(t, 3052)
+
+	link, err := NewIdentityLink(retiring, replacement)
+	if err != nil {
+		t.Fatalf("NewIdentityLink() = expected no error, got %v", err)
+	}
+
+	if !VerifyIdentityLink(local, link) {
+		t.Fatalf("VerifyIdentityLink() expected link signed by both identities to verify")
+	}
+
+	tampered := *link
+	tampered.OldPublicKey = local.ID.PublicKey
+	if VerifyIdentityLink(local, &tampered) {
+		t.Fatalf("VerifyIdentityLink() expected link to an unrelated identity to not verify")
+	}
+
+	state := New()
+	state.setDefaults()
+	state.Routes = dht.CreateRoutingTable(local.ID)
+	state.Routes.Update(retiring.ID)
+
+	state.receiveIdentityLink(local, &tampered)
+	if !state.Routes.PeerExists(retiring.ID) {
+		t.Fatalf("expected an invalid identity link to be ignored")
+	}
+
+	state.receiveIdentityLink(local, link)
+	if state.Routes.PeerExists(retiring.ID) || !state.Routes.PeerExists(replacement.ID) {
+		t.Fatalf("expected the retiring identity to be replaced within the routing table")
+	}
+}
@@ -0,0 +1,239 @@
+package discovery
+
+import (
+	"context"
+	"time"
+
+	"github.com/perlin-network/noise/internal/protobuf"
+	"github.com/perlin-network/noise/log"
+	"github.com/perlin-network/noise/network"
+	"github.com/perlin-network/noise/peer"
+)
+
+const (
+	// defaultRecordTTL is how long a stored record lives unless republished (tExpire).
+	defaultRecordTTL = 24 * time.Hour
+	// defaultProviderTTL is how long a provider announcement lives unless republished.
+	defaultProviderTTL = 24 * time.Hour
+	// defaultRepublishInterval is how often locally originated records are republished (tRepublish).
+	defaultRepublishInterval = 1 * time.Hour
+	// defaultRefreshInterval is how long a bucket may go without being updated
+	// before it is refreshed (tRefresh).
+	defaultRefreshInterval = 1 * time.Hour
+	// defaultSelfLookupInterval is how often a node looks up its own ID once bootstrapped.
+	defaultSelfLookupInterval = 10 * time.Minute
+	// defaultGossipInterval is how often routing table summaries are exchanged with a random peer.
+	defaultGossipInterval = 5 * time.Minute
+	// defaultPeerTTL is how long a peer may go unseen before its liveness is re-verified.
+	defaultPeerTTL = 1 * time.Hour
+	// transferBatchSize is roughly how many bytes of records are handed to a
+	// peer per request, well within the message size limit.
+	transferBatchSize = 256 * 1024
+	// expireInterval is how often stale records and peers are swept.
+	expireInterval = 1 * time.Minute
+)
+
+// maintain periodically looks up our own ID, refreshes stale buckets,
+// republishes locally originated records, gossips routing table summaries,
+// expires stale records and re-verifies stale peers until ctx is cancelled once
+// the plugin is cleaned up.
+func (state *Plugin) maintain(ctx context.Context, net *network.Network) {
+	selfLookup := net.Clock().NewTicker(state.SelfLookupInterval)
+	defer selfLookup.Stop()
+
+	refresh := net.Clock().NewTicker(state.RefreshInterval)
+	defer refresh.Stop()
+
+	republish := net.Clock().NewTicker(state.RepublishInterval)
+	defer republish.Stop()
+
+	gossip := net.Clock().NewTicker(state.GossipInterval)
+	defer gossip.Stop()
+
+	expire := net.Clock().NewTicker(expireInterval)
+	defer expire.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-selfLookup.C():
+			state.lookupSelf(ctx, net)
+		case <-refresh.C():
+			state.refreshBuckets(ctx, net)
+		case <-republish.C():
+			state.republishRecords(ctx, net)
+		case <-gossip.C():
+			if err := state.gossip(ctx, net); err != nil {
+				net.Log("discovery").Debug().Err(err).Msg("Failed to exchange routing table summary.")
+			}
+		case now := <-expire.C():
+			expireRecords(net.Log("discovery"), state.Records, now)
+			state.Providers.Expire(now)
+			state.reverifyStalePeers(net)
+			state.persistPeers(net)
+		}
+	}
+}
+
+// lookupSelf looks up our own ID so that we keep learning about peers closest to
+// us as the network changes. Does nothing should we not have bootstrapped yet.
+func (state *Plugin) lookupSelf(ctx context.Context, net *network.Network) {
+	if len(state.Routes.GetPeers()) == 0 {
+		return
+	}
+
+	state.learnPeers(net, FindNodeContext(ctx, net, net.ID, state.Alpha, state.DisjointPaths))
+}
+
+// refreshBuckets looks up a random ID within the range of every bucket which
+// has not been updated within the refresh interval, so that the routing table
+// stays healthy in networks with little inbound traffic.
+func (state *Plugin) refreshBuckets(ctx context.Context, net *network.Network) {
+	for _, bucketID := range state.Routes.StaleBuckets(state.RefreshInterval) {
+		if ctx.Err() != nil {
+			return
+		}
+
+		state.learnPeers(net, FindNodeContext(ctx, net, state.Routes.RandomIDInBucket(bucketID), state.Alpha, state.DisjointPaths))
+
+		// A lookup within the buckets range counts as an update, regardless of its results.
+		state.Routes.Bucket(bucketID).Touch()
+	}
+}
+
+// republishRecords stores every locally originated record at the peers
+// currently closest to its key, and announces this node as a provider of every
+// key it provides to them, refreshing their expiry.
+func (state *Plugin) republishRecords(ctx context.Context, net *network.Network) {
+	state.published.Range(func(key, value interface{}) bool {
+		if err := StoreValueContext(ctx, net, []byte(key.(string)), value.([]byte)); err != nil {
+			net.Log("discovery").Warn().Err(err).Msg("Failed to republish record.")
+		}
+		return ctx.Err() == nil
+	})
+
+	state.provided.Range(func(key, _ interface{}) bool {
+		if err := ProvideContext(ctx, net, []byte(key.(string))); err != nil {
+			net.Log("discovery").Warn().Err(err).Msg("Failed to republish provider announcement.")
+		}
+		return ctx.Err() == nil
+	})
+}
+
+// expireRecords deletes all records from a store which expired before now.
+func expireRecords(logger log.Module, store RecordStore, now time.Time) {
+	var expired [][]byte
+
+	store.Range(func(key []byte, value []byte, expiresAt time.Time) bool {
+		if now.After(expiresAt) {
+			expired = append(expired, key)
+		}
+		return true
+	})
+
+	for _, key := range expired {
+		if err := store.Delete(key); err != nil {
+			logger.Warn().Err(err).Msg("Failed to expire record.")
+		}
+	}
+}
+
+// transferRecords hands a peer newly inserted into the routing table all
+// records whose keys it is closer to than we are, and for which it is among the
+// #K closest peers we know of, as it is now responsible for holding them.
+// Records are handed over in batches alongside how long they are left to live,
+// such that they expire just as they would have had they not been handed over.
+func (state *Plugin) transferRecords(net *network.Network, target peer.ID) {
+	now := net.Clock().Now()
+
+	var batches []*protobuf.StoreBatchRequest
+	batch, batchSize := new(protobuf.StoreBatchRequest), 0
+
+	state.Records.Range(func(key []byte, value []byte, expiresAt time.Time) bool {
+		keyID := KeyID(key)
+
+		if !expiresAt.After(now) || !target.XorID(keyID).Less(net.ID.XorID(keyID)) || !state.amongClosest(target, keyID) {
+			return true
+		}
+
+		if len(batch.Records) > 0 && batchSize+len(key)+len(value) > transferBatchSize {
+			batches = append(batches, batch)
+			batch, batchSize = new(protobuf.StoreBatchRequest), 0
+		}
+
+		batch.Records = append(batch.Records, &protobuf.StoreRequest{Key: key, Value: value, Ttl: int64(expiresAt.Sub(now))})
+		batchSize += len(key) + len(value)
+
+		return true
+	})
+
+	if len(batch.Records) > 0 {
+		batches = append(batches, batch)
+	}
+
+	if len(batches) == 0 {
+		return
+	}
+
+	transferring := net.Go(Subsystem, func() {
+		for _, batch := range batches {
+			if _, err := requestPeerByID(state.context(), net, target, batch); err != nil {
+				net.Log("discovery").Warn().Err(err).Str("peer_address", target.Address).Msg("Failed to transfer records.")
+				return
+			}
+		}
+	})
+
+	if !transferring {
+		net.Log("discovery").Warn().Str("peer_address", target.Address).Msg("Skipped transferring records as discovery is at its goroutine cap.")
+	}
+}
+
+// amongClosest returns whether or not a peer is among the #K peers closest to
+// a key within the routing table, itself included.
+func (state *Plugin) amongClosest(target peer.ID, keyID peer.ID) bool {
+	distance := target.XorID(keyID)
+	closer := 0
+
+	for _, peerID := range state.Routes.FindClosestPeers(keyID, state.Replication) {
+		if !peerID.Equals(target) && peerID.XorID(keyID).Less(distance) {
+			closer++
+		}
+	}
+
+	return closer < state.Replication
+}
+
+// reverifyStalePeers pings every peer within the routing table which has not
+// been seen within the peer TTL. Peers which fail to respond are dropped, so
+// that long-gone peers do not linger within the routing table.
+func (state *Plugin) reverifyStalePeers(net *network.Network) {
+	for _, peerID := range state.Routes.StalePeers(state.PeerTTL) {
+		if _, verifying := state.verifying.LoadOrStore(peerID.PublicKeyHex(), struct{}{}); verifying {
+			continue
+		}
+
+		verifying := net.Go(Subsystem, func() {
+			defer state.verifying.Delete(peerID.PublicKeyHex())
+
+			if err := verifyPeer(state.context(), net, peerID, state.QueryTimeout); err != nil {
+				state.removePeer(peerID)
+
+				net.Log("discovery").Debug().
+					Err(err).
+					Str("peer_address", peerID.Address).
+					Msg("Dropped stale peer.")
+				return
+			}
+
+			state.Routes.Update(peerID)
+		})
+
+		// Stale peers left unverified whilst discovery is at its goroutine cap
+		// are verified upon the next round of maintenance.
+		if !verifying {
+			state.verifying.Delete(peerID.PublicKeyHex())
+		}
+	}
+}
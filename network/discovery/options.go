@@ -0,0 +1,353 @@
+package discovery
+
+import (
+	"time"
+
+	"github.com/perlin-network/noise/dht"
+	"github.com/perlin-network/noise/network/peerstore"
+)
+
+const (
+	defaultAlpha         = dht.BucketSize
+	defaultDisjointPaths = 8
+	// defaultQueryTimeout is how long a single peer is given to respond to a query.
+	defaultQueryTimeout = 3 * time.Second
+	// defaultEvictionBatchSize is how many full buckets have their evictions decided upon at once.
+	defaultEvictionBatchSize = 16
+	// defaultMaxRecords is how many records are stored on behalf of other peers at most.
+	defaultMaxRecords = 1 << 16
+	// defaultMaxRecordSize is the largest record value stored on behalf of other peers.
+	defaultMaxRecordSize = 64 << 10
+	// defaultMaxProviderKeys is how many keys providers are held for at most.
+	defaultMaxProviderKeys = 1 << 16
+	// defaultMaxProvidersPerKey is how many providers are held per key at most.
+	defaultMaxProvidersPerKey = 20
+	// defaultMaxPeerRecords is how many signed peer records of other peers are held at most.
+	defaultMaxPeerRecords = 1 << 14
+)
+
+// PluginOption are configurable options for the discovery plugin.
+type PluginOption func(*Plugin)
+
+// WithAlpha sets the number of peers queried in parallel per lookup path.
+func WithAlpha(alpha int) PluginOption {
+	return func(p *Plugin) {
+		p.Alpha = alpha
+	}
+}
+
+// WithDisjointPaths sets the number of disjoint paths lookups are split across.
+func WithDisjointPaths(paths int) PluginOption {
+	return func(p *Plugin) {
+		p.DisjointPaths = paths
+	}
+}
+
+// WithBucketSize sets the number of peers held per routing table bucket, which
+// is also the number of closest peers lookups converge on.
+func WithBucketSize(size int) PluginOption {
+	return func(p *Plugin) {
+		p.BucketSize = size
+	}
+}
+
+// WithReplication sets the number of closest peers records and provider
+// announcements are stored at, independently of the bucket size.
+func WithReplication(k int) PluginOption {
+	return func(p *Plugin) {
+		p.Replication = k
+	}
+}
+
+// WithQueryTimeout sets how long a single peer is given to respond to a query.
+func WithQueryTimeout(d time.Duration) PluginOption {
+	return func(p *Plugin) {
+		p.QueryTimeout = d
+	}
+}
+
+// WithRecursiveLookups has queried peers perform lookups on behalf of this node,
+// trading trust in the peers along the way for fewer round trips.
+func WithRecursiveLookups(recursive bool) PluginOption {
+	return func(p *Plugin) {
+		p.RecursiveLookups = recursive
+	}
+}
+
+// WithDiversityLimits limits how many peers sharing an address group may be held
+// per bucket and throughout the routing table, mitigating eclipse attacks. A
+// limit of zero leaves it unbounded.
+func WithDiversityLimits(perBucket int, perTable int) PluginOption {
+	return func(p *Plugin) {
+		p.MaxPeersPerGroup = perBucket
+		p.MaxTablePeersPerGroup = perTable
+	}
+}
+
+// WithGroupResolver sets how peer addresses are mapped to address groups for
+// enforcing diversity limits, such as by subnet or autonomous system.
+func WithGroupResolver(resolver dht.GroupResolver) PluginOption {
+	return func(p *Plugin) {
+		p.GroupResolver = resolver
+	}
+}
+
+// WithPeerVerification requires peers learned from lookups to be dialed back
+// and answer a ping under their advertised ID before being routed to.
+func WithPeerVerification(verify bool) PluginOption {
+	return func(p *Plugin) {
+		p.VerifyPeers = verify
+	}
+}
+
+// WithRequireSignedRecords discards peers learned from lookups for which no
+// signed peer record is held.
+func WithRequireSignedRecords(require bool) PluginOption {
+	return func(p *Plugin) {
+		p.RequireSignedRecords = require
+	}
+}
+
+// WithPingTimeout evicts the least recently seen peer of a full bucket should
+// it fail to respond to a ping within a given timeout.
+func WithPingTimeout(d time.Duration) PluginOption {
+	return func(p *Plugin) {
+		p.Eviction = PingLeastRecentlySeen{Timeout: d}
+	}
+}
+
+// WithEvictionPolicy sets the policy deciding which peer to evict from a full bucket.
+func WithEvictionPolicy(policy EvictionPolicy) PluginOption {
+	return func(p *Plugin) {
+		p.Eviction = policy
+	}
+}
+
+// WithEvictionBatchSize sets how many full buckets may have their evictions decided upon at once.
+func WithEvictionBatchSize(size int) PluginOption {
+	return func(p *Plugin) {
+		p.EvictionBatchSize = size
+	}
+}
+
+// WithQueryObserver sets the observer receiving events for every lookup made.
+func WithQueryObserver(observer QueryObserver) PluginOption {
+	return func(p *Plugin) {
+		p.Observer = observer
+	}
+}
+
+// WithRecordStore sets the store holding records on behalf of the DHT.
+func WithRecordStore(store RecordStore) PluginOption {
+	return func(p *Plugin) {
+		p.Records = store
+	}
+}
+
+// WithProviderStore sets the store holding provider records on behalf of the DHT.
+func WithProviderStore(store ProviderStore) PluginOption {
+	return func(p *Plugin) {
+		p.Providers = store
+	}
+}
+
+// WithMaxPeerRecords sets how many signed peer records of other peers are held at most.
+func WithMaxPeerRecords(count int) PluginOption {
+	return func(p *Plugin) {
+		p.MaxPeerRecords = count
+	}
+}
+
+// WithPeerStore sets the store the routing table is persisted to, and banned
+// peers are held within.
+func WithPeerStore(store peerstore.PeerStore) PluginOption {
+	return func(p *Plugin) {
+		p.PeerStore = store
+	}
+}
+
+// WithMaxRecords sets how many records are stored on behalf of other peers at
+// most. It only applies to the default record store.
+func WithMaxRecords(count int) PluginOption {
+	return func(p *Plugin) {
+		p.MaxRecords = count
+	}
+}
+
+// WithMaxRecordSize sets the largest record value stored on behalf of other peers.
+func WithMaxRecordSize(size int) PluginOption {
+	return func(p *Plugin) {
+		p.MaxRecordSize = size
+	}
+}
+
+// WithMaxProviderKeys sets how many keys providers are held for at most. It
+// only applies to the default provider store.
+func WithMaxProviderKeys(count int) PluginOption {
+	return func(p *Plugin) {
+		p.MaxProviderKeys = count
+	}
+}
+
+// WithMaxProvidersPerKey sets how many providers are held per key at most. It
+// only applies to the default provider store.
+func WithMaxProvidersPerKey(count int) PluginOption {
+	return func(p *Plugin) {
+		p.MaxProvidersPerKey = count
+	}
+}
+
+// WithProviderTTL sets how long providers announced by other peers are held onto.
+func WithProviderTTL(d time.Duration) PluginOption {
+	return func(p *Plugin) {
+		p.ProviderTTL = d
+	}
+}
+
+// WithRecordTTL sets how long records stored on behalf of other peers live.
+func WithRecordTTL(d time.Duration) PluginOption {
+	return func(p *Plugin) {
+		p.RecordTTL = d
+	}
+}
+
+// WithRepublishInterval sets how often locally originated records are republished.
+func WithRepublishInterval(d time.Duration) PluginOption {
+	return func(p *Plugin) {
+		p.RepublishInterval = d
+	}
+}
+
+// WithRefreshInterval sets how long a bucket may go without being updated before
+// it is refreshed.
+func WithRefreshInterval(d time.Duration) PluginOption {
+	return func(p *Plugin) {
+		p.RefreshInterval = d
+	}
+}
+
+// WithSelfLookupInterval sets how often this node looks up its own ID.
+func WithSelfLookupInterval(d time.Duration) PluginOption {
+	return func(p *Plugin) {
+		p.SelfLookupInterval = d
+	}
+}
+
+// WithGossipInterval sets how often a summary of the routing table is exchanged
+// with a random peer.
+func WithGossipInterval(d time.Duration) PluginOption {
+	return func(p *Plugin) {
+		p.GossipInterval = d
+	}
+}
+
+// WithPeerTTL sets how long a peer may go unseen before its liveness is re-verified.
+func WithPeerTTL(d time.Duration) PluginOption {
+	return func(p *Plugin) {
+		p.PeerTTL = d
+	}
+}
+
+// New returns a new discovery plugin with specified options. Options left
+// unspecified take on their defaults once the plugin starts up, such that
+// new(Plugin) remains valid.
+func New(opts ...PluginOption) *Plugin {
+	p := new(Plugin)
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+// setDefaults fills in all options which have been left unspecified.
+func (state *Plugin) setDefaults() {
+	if state.Alpha <= 0 {
+		state.Alpha = defaultAlpha
+	}
+
+	if state.DisjointPaths <= 0 {
+		state.DisjointPaths = defaultDisjointPaths
+	}
+
+	if state.BucketSize <= 0 {
+		state.BucketSize = dht.BucketSize
+	}
+
+	if state.Replication <= 0 {
+		state.Replication = state.BucketSize
+	}
+
+	if state.QueryTimeout <= 0 {
+		state.QueryTimeout = defaultQueryTimeout
+	}
+
+	if state.Eviction == nil {
+		state.Eviction = PingLeastRecentlySeen{}
+	}
+
+	if state.EvictionBatchSize <= 0 {
+		state.EvictionBatchSize = defaultEvictionBatchSize
+	}
+
+	if state.evictions == nil {
+		state.evictions = newEvictionQueue()
+	}
+
+	if state.MaxRecords <= 0 {
+		state.MaxRecords = defaultMaxRecords
+	}
+
+	if state.MaxRecordSize <= 0 {
+		state.MaxRecordSize = defaultMaxRecordSize
+	}
+
+	if state.MaxPeerRecords <= 0 {
+		state.MaxPeerRecords = defaultMaxPeerRecords
+	}
+
+	if state.Records == nil {
+		state.Records = NewMemoryStoreWithLimits(state.MaxRecords, state.MaxRecordSize, state.clock())
+	}
+
+	if state.MaxProviderKeys <= 0 {
+		state.MaxProviderKeys = defaultMaxProviderKeys
+	}
+
+	if state.MaxProvidersPerKey <= 0 {
+		state.MaxProvidersPerKey = defaultMaxProvidersPerKey
+	}
+
+	if state.Providers == nil {
+		state.Providers = NewMemoryProviderStoreWithLimits(state.MaxProviderKeys, state.MaxProvidersPerKey, state.clock())
+	}
+
+	if state.ProviderTTL <= 0 {
+		state.ProviderTTL = defaultProviderTTL
+	}
+
+	if state.RecordTTL <= 0 {
+		state.RecordTTL = defaultRecordTTL
+	}
+
+	if state.RepublishInterval <= 0 {
+		state.RepublishInterval = defaultRepublishInterval
+	}
+
+	if state.RefreshInterval <= 0 {
+		state.RefreshInterval = defaultRefreshInterval
+	}
+
+	if state.SelfLookupInterval <= 0 {
+		state.SelfLookupInterval = defaultSelfLookupInterval
+	}
+
+	if state.GossipInterval <= 0 {
+		state.GossipInterval = defaultGossipInterval
+	}
+
+	if state.PeerTTL <= 0 {
+		state.PeerTTL = defaultPeerTTL
+	}
+}
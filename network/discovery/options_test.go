@@ -0,0 +1,61 @@
+package discovery
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/perlin-network/noise/dht"
+)
+
+func TestOptions(t *testing.T) {
+	t.Parallel()
+
+	p := New(WithAlpha(3), WithBucketSize(20), WithPingTimeout(time.Second))
+	p.setDefaults()
+
+	if p.Alpha != 3 || p.BucketSize != 20 {
+		t.Fatalf("expected options to be applied, got alpha = %d, bucket size = %d", p.Alpha, p.BucketSize)
+	}
+
+	if policy, ok := p.Eviction.(PingLeastRecentlySeen); !ok || policy.Timeout != time.Second {
+		t.Fatalf("expected ping timeout to configure the eviction policy")
+	}
+
+	if p.Replication != p.BucketSize {
+		t.Fatalf("expected replication to default to the bucket size, got %d", p.Replication)
+	}
+
+	if p.DisjointPaths != defaultDisjointPaths || p.QueryTimeout != defaultQueryTimeout {
+		t.Fatalf("expected unspecified options to take on their defaults")
+	}
+
+	zero := new(Plugin)
+	zero.setDefaults()
+
+	if zero.BucketSize != dht.BucketSize || zero.Records == nil || zero.Providers == nil {
+		t.Fatalf("expected a zero-valued plugin to take on defaults")
+	}
+}
+
+func TestCleanupCancelsLookups(t *testing.T) {
+	t.Parallel()
+
+	net := buildNetwork(t, 3030)
+
+	state := New()
+	if state.context().Err() != nil {
+		t.Fatalf("expected the context of a plugin which has not started up to be usable")
+	}
+
+	state.Startup(net)
+	state.Cleanup(net)
+
+	if state.context().Err() != context.Canceled {
+		t.Fatalf("expected cleaning up the plugin to cancel its context")
+	}
+
+	// Lookups bound to the plugins context must not block once it is cancelled.
+	state.Routes.Update(idWithPrefix(0x01))
+	state.lookupSelf(state.context(), net)
+}
@@ -0,0 +1,248 @@
+package discovery
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+
+	"github.com/perlin-network/noise/internal/protobuf"
+	"github.com/perlin-network/noise/network"
+	"github.com/perlin-network/noise/peer"
+)
+
+const (
+	peerRecordNonceSize = 16
+)
+
+// peerRecordPayload deterministically serializes all fields of a peer record
+// except for its signature, for signing purposes.
+func peerRecordPayload(record *protobuf.PeerRecord) []byte {
+	var buf bytes.Buffer
+
+	writeBytes := func(b []byte) {
+		binary.Write(&buf, binary.LittleEndian, uint32(len(b)))
+		buf.Write(b)
+	}
+
+	writeBytes(record.PublicKey)
+
+	binary.Write(&buf, binary.LittleEndian, uint32(len(record.Addresses)))
+	for _, address := range record.Addresses {
+		writeBytes([]byte(address))
+	}
+
+	writeBytes(record.Nonce)
+	binary.Write(&buf, binary.LittleEndian, record.Sequence)
+
+	return buf.Bytes()
+}
+
+// NewPeerRecord returns a peer record advertising the address of a node, signed
+// by the node itself. Records with a higher sequence number supersede others.
+func NewPeerRecord(net *network.Network, sequence uint64) (*protobuf.PeerRecord, error) {
+	nonce := make([]byte, peerRecordNonceSize)
+	if _, err := net.Random().Read(nonce); err != nil {
+		return nil, err
+	}
+
+	record := &protobuf.PeerRecord{
+		PublicKey: net.ID.PublicKey,
+		Addresses: []string{net.Address},
+		Nonce:     nonce,
+		Sequence:  sequence,
+	}
+
+	signature, err := net.Sign(peerRecordPayload(record))
+	if err != nil {
+		return nil, err
+	}
+	record.Signature = signature
+
+	return record, nil
+}
+
+// VerifyPeerRecord checks that a peer record was signed by the public key it
+// holds under the signature and hash policies of a network.
+func VerifyPeerRecord(net *network.Network, record *protobuf.PeerRecord) bool {
+	if record == nil || len(record.Signature) == 0 {
+		return false
+	}
+	return net.Verify(record.PublicKey, peerRecordPayload(record), record.Signature)
+}
+
+// vouchesFor returns whether a peer record maps a peer IDs public key to its address.
+func vouchesFor(record *protobuf.PeerRecord, id peer.ID) bool {
+	if !bytes.Equal(record.PublicKey, id.PublicKey) {
+		return false
+	}
+
+	for _, address := range record.Addresses {
+		if address == id.Address {
+			return true
+		}
+	}
+
+	return false
+}
+
+// signSelfRecord creates this nodes own signed peer record.
+func (state *Plugin) signSelfRecord(net *network.Network) {
+	record, err := NewPeerRecord(net, uint64(net.Clock().Now().UnixNano()))
+	if err != nil {
+		net.Log("discovery").Error().Err(err).Msg("Failed to sign peer record.")
+		return
+	}
+	state.selfRecord = record
+}
+
+// storePeerRecord keeps a verified peer record, should it supersede any record
+// already held for the same public key. Should MaxPeerRecords records already
+// be held, a record of a peer outside of the routing table is dropped to make
+// room for it, or the record is refused otherwise.
+func (state *Plugin) storePeerRecord(net *network.Network, record *protobuf.PeerRecord) bool {
+	if !VerifyPeerRecord(net, record) {
+		return false
+	}
+
+	key := hex.EncodeToString(record.PublicKey)
+
+	state.peerRecordsMutex.Lock()
+	defer state.peerRecordsMutex.Unlock()
+
+	existing, exists := state.peerRecords.Load(key)
+	if exists && existing.(*protobuf.PeerRecord).Sequence >= record.Sequence {
+		return false
+	}
+
+	if !exists && state.MaxPeerRecords > 0 && state.peerRecordCount >= state.MaxPeerRecords && !state.dropUnroutedPeerRecord() {
+		return false
+	}
+
+	if !exists {
+		state.peerRecordCount++
+	}
+
+	state.peerRecords.Store(key, record)
+	return true
+}
+
+// dropUnroutedPeerRecord drops the record of a peer outside of the routing
+// table, returning whether or not one was dropped. Expects peer records to be
+// locked.
+func (state *Plugin) dropUnroutedPeerRecord() (dropped bool) {
+	state.peerRecords.Range(func(key, value interface{}) bool {
+		publicKey := value.(*protobuf.PeerRecord).PublicKey
+
+		if state.Routes != nil && state.Routes.PeerExists(peer.CreateID("", publicKey)) {
+			return true
+		}
+
+		state.peerRecords.Delete(key)
+		state.peerRecordCount--
+		dropped = true
+
+		return false
+	})
+
+	return
+}
+
+// deletePeerRecord drops the record held for a public key, should one be held.
+func (state *Plugin) deletePeerRecord(publicKey []byte) {
+	state.peerRecordsMutex.Lock()
+	defer state.peerRecordsMutex.Unlock()
+
+	if _, exists := state.peerRecords.Load(hex.EncodeToString(publicKey)); exists {
+		state.peerRecords.Delete(hex.EncodeToString(publicKey))
+		state.peerRecordCount--
+	}
+}
+
+// removePeer removes a peer from the routing table, alongside its peer record.
+func (state *Plugin) removePeer(id peer.ID) {
+	state.Routes.RemovePeer(id)
+	state.deletePeerRecord(id.PublicKey)
+}
+
+// peerRecord returns the peer record held for a peer, should one be held.
+func (state *Plugin) peerRecord(id peer.ID) (*protobuf.PeerRecord, bool) {
+	record, exists := state.peerRecords.Load(id.PublicKeyHex())
+	if !exists {
+		return nil, false
+	}
+	return record.(*protobuf.PeerRecord), true
+}
+
+// filterPeers stores all valid peer records relayed by another peer which vouch
+// for a peer relayed alongside them, and discards peers whose addresses contradict the records held for them, or
+// whose IDs are malformed or do not hash from their public keys. Duplicates,
+// ourselves, and peers claiming our own address are discarded as well. Should
+// signed records be required, peers with no records held are discarded too.
+func (state *Plugin) filterPeers(net *network.Network, ids []*protobuf.ID, records []*protobuf.PeerRecord) (filtered []*protobuf.ID) {
+	// Records which vouch for none of the peers relayed alongside them are
+	// ignored, such that responders may not fill our records with throwaway keys.
+	for _, record := range records {
+		for _, id := range ids {
+			if record != nil && id != nil && vouchesFor(record, peer.ID(*id)) {
+				state.storePeerRecord(net, record)
+				break
+			}
+		}
+	}
+
+	seen := make(map[string]struct{}, len(ids))
+
+	for _, id := range ids {
+		if id == nil || id.Address == "" || len(id.Id) != len(net.ID.Id) {
+			continue
+		}
+
+		if !bytes.Equal(peer.CreateID(id.Address, id.PublicKey).Id, id.Id) {
+			continue
+		}
+
+		if peer.ID(*id).Equals(net.ID) || id.Address == net.ID.Address {
+			continue
+		}
+
+		if _, duplicate := seen[peer.ID(*id).PublicKeyHex()]; duplicate {
+			continue
+		}
+		seen[peer.ID(*id).PublicKeyHex()] = struct{}{}
+
+		record, exists := state.peerRecord(peer.ID(*id))
+
+		if exists && !vouchesFor(record, peer.ID(*id)) {
+			continue
+		}
+
+		if !exists && state.RequireSignedRecords {
+			continue
+		}
+
+		if state.banned(peer.ID(*id)) {
+			continue
+		}
+
+		filtered = append(filtered, id)
+	}
+
+	return
+}
+
+// receivePeerRecord stores a peer record sent directly by a peer, should the
+// record belong to the peer itself.
+func (state *Plugin) receivePeerRecord(ctx *network.PluginContext, record *protobuf.PeerRecord) {
+	if record != nil && bytes.Equal(record.PublicKey, ctx.Sender().PublicKey) {
+		state.storePeerRecord(ctx.Network(), record)
+	}
+}
+
+// selfRecord returns the signed peer record of a node, should the discovery
+// plugin be registered.
+func selfRecord(net *network.Network) *protobuf.PeerRecord {
+	if plugin, exists := net.Plugin(PluginID); exists {
+		return plugin.(*Plugin).selfRecord
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+package discovery
+
+import (
+	"testing"
+
+	"github.com/perlin-network/noise/crypto/ed25519"
+	"github.com/perlin-network/noise/dht"
+	"github.com/perlin-network/noise/internal/protobuf"
+	"github.com/perlin-network/noise/network"
+	"github.com/perlin-network/noise/peer"
+)
+
+func buildNetwork(t testing.TB, port uint16) *network.Network {
+	builder := network.NewBuilder()
+	builder.SetKeys(ed25519.RandomKeyPair())
+	builder.SetAddress(network.FormatAddress("tcp", "localhost", port))
+
+	net, err := builder.Build()
+	if err != nil {
+		t.Fatalf("Build() = expected no error, got %v", err)
+	}
+	return net
+}
+
+func TestPeerRecord(t *testing.T) {
+	t.Parallel()
+
+	net := buildNetwork(t, 3000)
+
+	record, err := NewPeerRecord(net, 1)
+	if err != nil {
+		t.Fatalf("NewPeerRecord() = expected no error, got %v", err)
+	}
+
+	if !VerifyPeerRecord(net, record) {
+		t.Fatalf("VerifyPeerRecord() expected signed record to verify")
+	}
+
+	if !vouchesFor(record, net.ID) {
+		t.Fatalf("expected record to vouch for the nodes own ID")
+	}
+
+	tampered := *record
+	tampered.Addresses = []string{"tcp://localhost:4000"}
+	if VerifyPeerRecord(net, &tampered) {
+		t.Fatalf("VerifyPeerRecord() expected tampered record to not verify")
+	}
+}
+
+func TestFilterPeers(t *testing.T) {
+	t.Parallel()
+
+	local, remote, other := buildNetwork(t, 3001), buildNetwork(t, 3002), buildNetwork(t, 3057)
+
+	record, err := NewPeerRecord(remote, 1)
+	if err != nil {
+		t.Fatalf("NewPeerRecord() = expected no error, got %v", err)
+	}
+
+	honest := protobuf.ID(remote.ID)
+	spoofed := protobuf.ID(peer.CreateID("tcp://localhost:6666", remote.ID.PublicKey))
+	unknown := protobuf.ID(other.ID)
+
+	state := new(Plugin)
+	filtered := state.filterPeers(local, []*protobuf.ID{&honest, &spoofed, &unknown}, []*protobuf.PeerRecord{record})
+
+	if len(filtered) != 2 || filtered[0] != &honest || filtered[1] != &unknown {
+		t.Fatalf("expected only the spoofed peer to be discarded, got %d peers", len(filtered))
+	}
+
+	state.RequireSignedRecords = true
+	filtered = state.filterPeers(local, []*protobuf.ID{&honest, &unknown}, nil)
+
+	if len(filtered) != 1 || filtered[0] != &honest {
+		t.Fatalf("expected peers without signed records to be discarded")
+	}
+}
+
+func TestFilterMalformedPeers(t *testing.T) {
+	t.Parallel()
+
+	local, remote := buildNetwork(t, 3058), buildNetwork(t, 3059)
+
+	honest := protobuf.ID(remote.ID)
+	duplicate := protobuf.ID(remote.ID)
+	self := protobuf.ID(local.ID)
+	loopback := protobuf.ID(peer.CreateID(local.Address, remote.ID.PublicKey))
+
+	forged := protobuf.ID(remote.ID)
+	forged.Id = append([]byte(nil), remote.ID.Id...)
+	forged.Id[0] ^= 0xff
+
+	state := new(Plugin)
+	filtered := state.filterPeers(local, []*protobuf.ID{&forged, &self, &loopback, &honest, &duplicate}, nil)
+
+	if len(filtered) != 1 || filtered[0] != &honest {
+		t.Fatalf("expected forged, duplicate, self and loopback peers to be discarded, got %d peers", len(filtered))
+	}
+}
+
+func TestPeerRecordRetention(t *testing.T) {
+	t.Parallel()
+
+	local, routed, other, junk := buildNetwork(t, 3062), buildNetwork(t, 3063), buildNetwork(t, 3064), buildNetwork(t, 3065)
+
+	records := make(map[*network.Network]*protobuf.PeerRecord)
+	for _, net := range []*network.Network{routed, other, junk} {
+		record, err := NewPeerRecord(net, 1)
+		if err != nil {
+			t.Fatalf("NewPeerRecord() = expected no error, got %v", err)
+		}
+		records[net] = record
+	}
+
+	state := new(Plugin)
+	state.Routes = dht.CreateRoutingTable(local.ID)
+	state.MaxPeerRecords = 2
+
+	// Records relayed for peers not relayed alongside them are not stored.
+	routedID := protobuf.ID(routed.ID)
+	state.filterPeers(local, []*protobuf.ID{&routedID}, []*protobuf.PeerRecord{records[routed], records[junk]})
+
+	if _, exists := state.peerRecord(junk.ID); exists {
+		t.Fatalf("expected record vouching for no relayed peer to not be stored")
+	}
+	if _, exists := state.peerRecord(routed.ID); !exists {
+		t.Fatalf("expected record vouching for a relayed peer to be stored")
+	}
+
+	state.Routes.Update(routed.ID)
+
+	// Records of peers outside of the routing table make room for others once
+	// the cap is reached.
+	if !state.storePeerRecord(local, records[other]) || !state.storePeerRecord(local, records[junk]) {
+		t.Fatalf("expected records to be stored up to the cap")
+	}
+	if _, exists := state.peerRecord(other.ID); exists {
+		t.Fatalf("expected record of a peer outside of the routing table to be dropped past the cap")
+	}
+	if _, exists := state.peerRecord(routed.ID); !exists {
+		t.Fatalf("expected record of a peer within the routing table to be kept past the cap")
+	}
+
+	// Records are dropped alongside their peers leaving the routing table.
+	state.removePeer(routed.ID)
+
+	if _, exists := state.peerRecord(routed.ID); exists {
+		t.Fatalf("expected record to be dropped once its peer left the routing table")
+	}
+	if state.peerRecordCount != 1 {
+		t.Fatalf("expected 1 record to remain held, got %d", state.peerRecordCount)
+	}
+}
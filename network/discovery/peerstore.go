@@ -0,0 +1,86 @@
+package discovery
+
+import (
+	"time"
+
+	"github.com/perlin-network/noise/internal/protobuf"
+	"github.com/perlin-network/noise/network"
+	"github.com/perlin-network/noise/network/peerstore"
+	"github.com/perlin-network/noise/peer"
+
+	"github.com/gogo/protobuf/proto"
+)
+
+// restorePeers imports all peers persisted within the peer store into the
+// routing table, such that a restarted node need not bootstrap from scratch.
+func (state *Plugin) restorePeers(net *network.Network) {
+	if state.PeerStore == nil {
+		return
+	}
+
+	snapshot := &protobuf.RoutingTableSnapshot{}
+
+	err := state.PeerStore.Range(func(p peerstore.Peer) bool {
+		if len(p.Addresses) == 0 || p.Banned(time.Now()) {
+			return true
+		}
+
+		id := protobuf.ID(peer.CreateID(p.Addresses[0], p.PublicKey))
+		snapshot.Peers = append(snapshot.Peers, &id)
+
+		if len(p.Record) > 0 {
+			record := &protobuf.PeerRecord{}
+			if proto.Unmarshal(p.Record, record) == nil {
+				snapshot.Records = append(snapshot.Records, record)
+			}
+		}
+
+		return true
+	})
+
+	if err != nil {
+		net.Log("discovery").Warn().Err(err).Msg("Failed to restore peers from the peer store.")
+	}
+
+	imported := state.Import(net, snapshot)
+
+	net.Log("discovery").Debug().
+		Int("num_peers", imported).
+		Msg("Restored peers from the peer store.")
+}
+
+// persistPeers saves all peers within the routing table alongside their signed
+// peer records into the peer store.
+func (state *Plugin) persistPeers(net *network.Network) {
+	if state.PeerStore == nil || state.Routes == nil {
+		return
+	}
+
+	state.Routes.ForEach(func(peerID peer.ID) bool {
+		var record []byte
+		if r, exists := state.peerRecord(peerID); exists {
+			record, _ = proto.Marshal(r)
+		}
+
+		err := state.PeerStore.Update(peerID.PublicKey, func(p *peerstore.Peer) {
+			p.AddAddress(peerID.Address)
+			if record != nil {
+				p.Record = record
+			}
+		})
+
+		if err != nil {
+			net.Log("discovery").Warn().
+				Err(err).
+				Str("peer_address", peerID.Address).
+				Msg("Failed to persist peer.")
+		}
+
+		return true
+	})
+}
+
+// banned returns whether or not a peer is banned within the peer store.
+func (state *Plugin) banned(peerID peer.ID) bool {
+	return state.PeerStore != nil && peerstore.Banned(state.PeerStore, peerID.PublicKey)
+}
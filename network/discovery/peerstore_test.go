@@ -0,0 +1,58 @@
+package discovery
+
+import (
+	"testing"
+	"time"
+
+	"github.com/perlin-network/noise/dht"
+	"github.com/perlin-network/noise/internal/protobuf"
+	"github.com/perlin-network/noise/network/peerstore"
+)
+
+func TestPeerStore(t *testing.T) {
+	t.Parallel()
+
+	local, remote, other, banned := buildNetwork(t, 3053), buildNetwork(t, 3054), buildNetwork(t, 3055), buildNetwork(t, 3056)
+
+	store := peerstore.NewMemory()
+
+	persister := New(WithPeerStore(store))
+	persister.setDefaults()
+	persister.Routes = dht.CreateRoutingTable(remote.ID)
+	persister.Routes.Update(other.ID)
+	persister.Routes.Update(banned.ID)
+
+	record, err := NewPeerRecord(other, 1)
+	if err != nil {
+		t.Fatalf("NewPeerRecord() = expected no error, got %v", err)
+	}
+	persister.storePeerRecord(remote, record)
+
+	persister.persistPeers(remote)
+
+	if err := peerstore.Ban(store, banned.ID.PublicKey, time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("Ban() = expected no error, got %v", err)
+	}
+
+	restorer := New(WithPeerStore(store))
+	restorer.setDefaults()
+	restorer.Routes = dht.CreateRoutingTable(local.ID)
+	restorer.restorePeers(local)
+
+	if !restorer.Routes.PeerExists(other.ID) {
+		t.Fatalf("expected persisted peer to be restored into the routing table")
+	}
+
+	if _, exists := restorer.peerRecord(other.ID); !exists {
+		t.Fatalf("expected persisted peer record to be restored")
+	}
+
+	if restorer.Routes.PeerExists(banned.ID) {
+		t.Fatalf("expected banned peer not to be restored")
+	}
+
+	id := protobuf.ID(banned.ID)
+	if filtered := restorer.filterPeers(local, []*protobuf.ID{&id}, nil); len(filtered) != 0 {
+		t.Fatalf("expected banned peer to be filtered out of lookup results")
+	}
+}
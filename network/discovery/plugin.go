@@ -2,68 +2,307 @@ package discovery
 
 import (
 	"context"
+	"sync"
+	"time"
 
+	"github.com/perlin-network/noise/audit"
+	"github.com/perlin-network/noise/clock"
 	"github.com/perlin-network/noise/dht"
 	"github.com/perlin-network/noise/internal/protobuf"
-	"github.com/perlin-network/noise/log"
 	"github.com/perlin-network/noise/network"
+	"github.com/perlin-network/noise/network/peerstore"
 	"github.com/perlin-network/noise/peer"
 )
 
+// Subsystem is the name goroutines spawned by the plugin are accounted for
+// under, whose number may be capped through network.MaxWorkers.
+const Subsystem = "discovery"
+
 type Plugin struct {
 	*network.Plugin
 
-	DisablePing   bool
-	DisablePong   bool
-	DisableLookup bool
+	DisablePing      bool
+	DisablePong      bool
+	DisableLookup    bool
+	DisableStore     bool
+	DisableFindValue bool
+	DisableProviders bool
+	DisableGossip    bool
 
 	Routes *dht.RoutingTable
+
+	// Alpha is the number of peers queried in parallel per lookup path
+	// (default: 16).
+	Alpha int
+	// DisjointPaths is the number of disjoint paths lookups are split across
+	// (default: 8).
+	DisjointPaths int
+	// BucketSize is the number of peers held per routing table bucket, and the
+	// number of closest peers lookups converge on (default: dht.BucketSize).
+	BucketSize int
+	// Replication is the number of closest peers records and provider
+	// announcements are stored at (default: BucketSize).
+	Replication int
+	// QueryTimeout is how long a single peer is given to respond to a query
+	// (default: 3 seconds).
+	QueryTimeout time.Duration
+
+	// RecursiveLookups has lookups be performed recursively, where every
+	// queried peer forwards the lookup to the next closest peer on behalf of
+	// the requester rather than handing back closer peers to query. Lookups
+	// complete in fewer round trips at the expense of trusting the peers along
+	// the way. Recursive lookups are only forwarded by peers which have this
+	// enabled as well.
+	RecursiveLookups bool
+
+	// VerifyPeers requires peers learned from lookups to be dialed back and
+	// answer a ping under their advertised ID before being inserted into the
+	// routing table, preventing spoofed addresses from polluting routes.
+	VerifyPeers bool
+
+	// RequireSignedRecords discards peers learned from lookups for which no
+	// signed peer record is held. Peers whose addresses contradict a signed
+	// peer record are always discarded.
+	RequireSignedRecords bool
+
+	// MaxPeersPerGroup limits how many peers sharing an address group may be
+	// held per bucket (default: unlimited).
+	MaxPeersPerGroup int
+	// MaxTablePeersPerGroup limits how many peers sharing an address group may
+	// be held throughout the routing table (default: unlimited).
+	MaxTablePeersPerGroup int
+	// GroupResolver maps peer addresses to address groups for enforcing
+	// diversity limits (default: dht.SubnetGroup).
+	GroupResolver dht.GroupResolver
+
+	// Records holds the key/value records stored at this node on behalf of
+	// the DHT (default: an in-memory store).
+	Records RecordStore
+	// MaxRecords is how many records the default record store holds on behalf
+	// of other peers at most (default: 65536).
+	MaxRecords int
+	// MaxRecordSize is the largest record value stored on behalf of other
+	// peers, in bytes (default: 64KiB).
+	MaxRecordSize int
+
+	// Providers holds the peers known to provide content on behalf of the DHT
+	// (default: an in-memory store).
+	Providers ProviderStore
+	// MaxProviderKeys is how many keys the default provider store holds
+	// providers for at most (default: 65536).
+	MaxProviderKeys int
+	// MaxProvidersPerKey is how many providers the default provider store
+	// holds per key at most (default: 20).
+	MaxProvidersPerKey int
+	// ProviderTTL is how long providers announced by other peers are held
+	// onto before expiring (default: 24 hours).
+	ProviderTTL time.Duration
+
+	// RecordTTL is how long records stored on behalf of other peers live
+	// before expiring (default: 24 hours).
+	RecordTTL time.Duration
+	// RepublishInterval is how often records stored through this node, and
+	// announcements of content it provides, are republished to the peers
+	// closest to their keys (default: 1 hour).
+	RepublishInterval time.Duration
+	// RefreshInterval is how long a routing table bucket may go without being
+	// updated before it is refreshed by a lookup (default: 1 hour).
+	RefreshInterval time.Duration
+	// SelfLookupInterval is how often this node looks up its own ID once
+	// bootstrapped, to learn about peers closest to it (default: 10 minutes).
+	SelfLookupInterval time.Duration
+	// GossipInterval is how often a summary of the routing table is exchanged
+	// with a random peer to pull in peers we lack (default: 5 minutes).
+	GossipInterval time.Duration
+	// PeerTTL is how long a peer within the routing table may go unseen before
+	// it is pinged, and dropped should it fail to respond (default: 1 hour).
+	PeerTTL time.Duration
+
+	// Observer receives events for every lookup made, such that lookups may
+	// be visualized and slow resolutions debugged (default: none).
+	Observer QueryObserver
+
+	// Eviction decides which peer to evict from a full bucket in favour of a
+	// newly seen peer (default: PingLeastRecentlySeen).
+	Eviction EvictionPolicy
+	// EvictionBatchSize is how many full buckets may have their evictions
+	// decided upon at once, such as by pinging their least recently seen
+	// peers in parallel (default: 16).
+	EvictionBatchSize int
+
+	// MaxPeerRecords is how many signed peer records of other peers are held
+	// at most (default: 16384).
+	MaxPeerRecords int
+
+	// PeerStore persists the routing table across restarts, and holds the
+	// peers banned from it (default: none).
+	PeerStore peerstore.PeerStore
+
+	// Records originally stored through this node, which are to be republished.
+	published sync.Map // string -> []byte
+
+	// Keys this node announced itself as a provider of, which are to be
+	// republished.
+	provided sync.Map // string -> struct{}
+
+	// Buckets awaiting an eviction decision.
+	evictions *evictionQueue
+
+	// Peers which are currently being verified.
+	verifying sync.Map // string -> struct{}
+
+	// This nodes own signed peer record.
+	selfRecord *protobuf.PeerRecord
+
+	// Latest verified peer records of other peers.
+	peerRecords      sync.Map // string -> *protobuf.PeerRecord
+	peerRecordsMutex sync.Mutex
+	peerRecordCount  int
+
+	// Cancelled once the plugin is cleaned up, aborting all outstanding
+	// lookups and requests made by the plugin.
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	// The network the plugin was started up on.
+	net *network.Network
 }
 
 var (
 	PluginID                         = (*Plugin)(nil)
 	_        network.PluginInterface = (*Plugin)(nil)
+	_        network.PluginStatus    = (*Plugin)(nil)
+	_        network.PeerDirectory   = (*Plugin)(nil)
+	_        network.Resolver        = (*Plugin)(nil)
 )
 
 func (state *Plugin) Startup(net *network.Network) {
+	state.net = net
+	state.setDefaults()
+
 	// Create routing table.
-	state.Routes = dht.CreateRoutingTable(net.ID)
+	state.Routes = dht.CreateRoutingTable(net.ID,
+		dht.WithBucketSize(state.BucketSize),
+		dht.WithReplication(state.Replication),
+		dht.WithDiversityLimits(state.MaxPeersPerGroup, state.MaxTablePeersPerGroup),
+		dht.WithGroupResolver(state.GroupResolver),
+		dht.WithClock(net.Clock()),
+		dht.WithRandom(net.Random()),
+	)
+
+	state.signSelfRecord(net)
+	state.restorePeers(net)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	state.ctx, state.cancel = ctx, cancel
+
+	if !net.Go(Subsystem, func() { state.maintain(ctx, net) }) {
+		net.Log("discovery").Error().Msg("Unable to start maintaining the routing table as discovery is at its goroutine cap.")
+	}
+
+	if !net.Go(Subsystem, func() { state.evictLoop(ctx, net) }) {
+		net.Log("discovery").Error().Msg("Unable to start evicting peers from full buckets as discovery is at its goroutine cap.")
+	}
+}
+
+// context returns the context outstanding lookups and requests made by the
+// plugin are bound to.
+func (state *Plugin) context() context.Context {
+	if state.ctx == nil {
+		return context.Background()
+	}
+	return state.ctx
+}
+
+// spawn runs fn in a goroutine accounted for under Subsystem, or on the
+// calling goroutine should the subsystem be at its cap.
+// clock returns the clock of the network the plugin was started up on, or the
+// real clock should it not have been started up yet.
+func (state *Plugin) clock() clock.Clock {
+	if state.net == nil {
+		return clock.Real()
+	}
+	return state.net.Clock()
+}
+
+func spawn(net *network.Network, fn func()) {
+	if !net.Go(Subsystem, fn) {
+		fn()
+	}
 }
 
 func (state *Plugin) Receive(ctx *network.PluginContext) error {
-	// Update routing for every incoming message.
-	state.Routes.Update(ctx.Sender())
+	// Drop pongs which do not answer a ping we have sent, such that unsolicited
+	// pongs may not keep peers alive within our routing table.
+	if pong, ok := ctx.Message().(*protobuf.Pong); ok && !ctx.Client().VerifyChallenge(pong.Nonce) {
+		ctx.Network().Log("discovery").Debug().
+			Str("peer_address", ctx.Sender().Address).
+			Msg("Dropped unsolicited pong.")
+		ctx.Network().AuditPeer(ctx.Context(), audit.ReplayDetected, audit.ReasonUnsolicitedPong, ctx.Sender(), nil)
+		return nil
+	}
+
+	// Drop pings and lookups lacking a valid proof-of-work stamp before they
+	// may touch our routing table, should the network require stamps.
+	if !checkStamp(ctx) {
+		ctx.Network().Log("discovery").Debug().
+			Str("peer_address", ctx.Sender().Address).
+			Msg("Dropped message with an invalid proof-of-work stamp.")
+		ctx.Network().RecordHandshakeFailure(network.FailureBadPuzzle, ctx.Sender().Address)
+		return nil
+	}
+
+	// Update routing for every incoming message, handing peers newly inserted
+	// into the routing table the records they are now responsible for. Banned
+	// peers are ignored.
+	if state.banned(ctx.Sender()) {
+		ctx.Network().Log("discovery").Debug().
+			Str("peer_address", ctx.Sender().Address).
+			Msg("Dropped message from banned peer.")
+		return nil
+	}
+	known := state.Routes.PeerExists(ctx.Sender())
+
+	if err := state.Routes.Update(ctx.Sender()); err == dht.ErrBucketFull {
+		state.evict(ctx.Network(), ctx.Sender())
+	} else if err == nil && !known {
+		state.transferRecords(ctx.Network(), ctx.Sender())
+	}
 	gCtx := network.WithSignMessage(context.Background(), true)
 
 	// Handle RPC.
 	switch msg := ctx.Message().(type) {
 	case *protobuf.Ping:
+		state.receivePeerRecord(ctx, msg.Record)
+
 		if state.DisablePing {
 			break
 		}
 
 		// Send pong to peer.
-		err := ctx.Reply(gCtx, &protobuf.Pong{})
+		err := ctx.Reply(gCtx, &protobuf.Pong{Record: state.selfRecord, Nonce: msg.Nonce})
 
 		if err != nil {
 			return err
 		}
 	case *protobuf.Pong:
+		state.receivePeerRecord(ctx, msg.Record)
+
 		if state.DisablePong {
 			break
 		}
 
-		peers := FindNode(ctx.Network(), ctx.Sender(), dht.BucketSize, 8)
+		peers := FindNodeContext(state.context(), ctx.Network(), ctx.Sender(), state.Alpha, state.DisjointPaths)
 
 		// Update routing table w/ closest peers to self.
-		for _, peerID := range peers {
-			state.Routes.Update(peerID)
-		}
+		state.learnPeers(ctx.Network(), peers)
 
-		log.Info().
+		ctx.Network().Log("discovery").Info().
 			Strs("peers", state.Routes.GetPeerAddresses()).
 			Msg("Bootstrapped w/ peer(s).")
 	case *protobuf.LookupNodeRequest:
+		state.receivePeerRecord(ctx, msg.Record)
+
 		if state.DisableLookup {
 			break
 		}
@@ -71,10 +310,21 @@ func (state *Plugin) Receive(ctx *network.PluginContext) error {
 		// Prepare response.
 		response := &protobuf.LookupNodeResponse{}
 
-		// Respond back with closest peers to a provided target.
-		for _, peerID := range state.Routes.FindClosestPeers(peer.ID(*msg.Target), dht.BucketSize) {
+		var closest []peer.ID
+		if msg.Recursive {
+			closest = state.serveRecursiveLookup(ctx.Network(), ctx.Sender(), peer.ID(*msg.Target), msg.Hops)
+		} else {
+			closest = state.Routes.FindClosestPeers(peer.ID(*msg.Target), state.BucketSize)
+		}
+
+		// Respond back with closest peers to a provided target, alongside their signed peer records.
+		for _, peerID := range closest {
 			id := protobuf.ID(peerID)
 			response.Peers = append(response.Peers, &id)
+
+			if record, exists := state.peerRecord(peerID); exists {
+				response.Records = append(response.Records, record)
+			}
 		}
 
 		err := ctx.Reply(gCtx, response)
@@ -82,27 +332,195 @@ func (state *Plugin) Receive(ctx *network.PluginContext) error {
 			return err
 		}
 
-		log.Info().
+		ctx.Network().Log("discovery").Info().
 			Strs("peers", state.Routes.GetPeerAddresses()).
 			Msg("Connected to peer(s).")
+	case *protobuf.IdentityLink:
+		state.receiveIdentityLink(ctx.Network(), msg)
+	case *protobuf.RoutingSummaryRequest:
+		if state.DisableGossip {
+			break
+		}
+
+		missing, err := state.missingPeers(ctx.Sender(), msg)
+		if err != nil {
+			return err
+		}
+
+		response := &protobuf.RoutingSummaryResponse{}
+		for _, peerID := range missing {
+			id := protobuf.ID(peerID)
+			response.Peers = append(response.Peers, &id)
+
+			if record, exists := state.peerRecord(peerID); exists {
+				response.Records = append(response.Records, record)
+			}
+		}
+
+		err = ctx.Reply(gCtx, response)
+		if err != nil {
+			return err
+		}
+	case *protobuf.StoreRequest:
+		if state.DisableStore {
+			break
+		}
+
+		// Records which are too large, or which do not fit into the store, are
+		// left unacknowledged.
+		if !state.storeRecord(ctx, msg) {
+			break
+		}
+
+		err := ctx.Reply(gCtx, &protobuf.StoreResponse{})
+		if err != nil {
+			return err
+		}
+	case *protobuf.StoreBatchRequest:
+		if state.DisableStore {
+			break
+		}
+
+		// Batches are acknowledged should any of their records be stored.
+		stored := false
+		for _, record := range msg.Records {
+			if record != nil && state.storeRecord(ctx, record) {
+				stored = true
+			}
+		}
+
+		if !stored {
+			break
+		}
+
+		err := ctx.Reply(gCtx, &protobuf.StoreResponse{})
+		if err != nil {
+			return err
+		}
+	case *protobuf.FindValueRequest:
+		if state.DisableFindValue {
+			break
+		}
+
+		// Prepare response.
+		response := &protobuf.FindValueResponse{}
+
+		// Respond back with the value if we hold it, or otherwise the closest
+		// peers to its key alongside their signed peer records.
+		if value, found := state.Records.Get(msg.Key); found {
+			response.Value = value
+			response.Found = true
+		} else {
+			for _, peerID := range state.Routes.FindClosestPeers(KeyID(msg.Key), state.BucketSize) {
+				id := protobuf.ID(peerID)
+				response.Peers = append(response.Peers, &id)
+
+				if record, exists := state.peerRecord(peerID); exists {
+					response.Records = append(response.Records, record)
+				}
+			}
+		}
+
+		err := ctx.Reply(gCtx, response)
+		if err != nil {
+			return err
+		}
+	case *protobuf.AddProviderRequest:
+		if state.DisableProviders {
+			break
+		}
+
+		// Peers may only announce themselves as providers.
+		if err := state.Providers.AddProvider(msg.Key, ctx.Sender(), ctx.Network().Clock().Now().Add(state.ProviderTTL)); err != nil {
+			ctx.Network().Log("discovery").Debug().Err(err).
+				Str("peer_address", ctx.Sender().Address).
+				Msg("Failed to add provider.")
+			break
+		}
+
+		err := ctx.Reply(gCtx, &protobuf.AddProviderResponse{})
+		if err != nil {
+			return err
+		}
+	case *protobuf.GetProvidersRequest:
+		if state.DisableProviders {
+			break
+		}
+
+		// Prepare response.
+		response := &protobuf.GetProvidersResponse{}
+
+		// Respond back with known providers, alongside the closest peers to the
+		// key and the signed peer records of both.
+		for _, peerID := range state.Providers.Providers(msg.Key) {
+			id := protobuf.ID(peerID)
+			response.Providers = append(response.Providers, &id)
+
+			if record, exists := state.peerRecord(peerID); exists {
+				response.Records = append(response.Records, record)
+			}
+		}
+
+		for _, peerID := range state.Routes.FindClosestPeers(KeyID(msg.Key), state.BucketSize) {
+			id := protobuf.ID(peerID)
+			response.Peers = append(response.Peers, &id)
+
+			if record, exists := state.peerRecord(peerID); exists {
+				response.Records = append(response.Records, record)
+			}
+		}
+
+		err := ctx.Reply(gCtx, response)
+		if err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
+// storeRecord stores a record on behalf of a peer for as long as it is left to
+// live, up to RecordTTL, and returns whether or not it was stored.
+func (state *Plugin) storeRecord(ctx *network.PluginContext, req *protobuf.StoreRequest) bool {
+	if len(req.Value) > state.MaxRecordSize {
+		return false
+	}
+
+	ttl := state.RecordTTL
+	if req.Ttl > 0 && time.Duration(req.Ttl) < ttl {
+		ttl = time.Duration(req.Ttl)
+	}
+
+	if err := state.Records.Put(req.Key, req.Value, ctx.Network().Clock().Now().Add(ttl)); err != nil {
+		ctx.Network().Log("discovery").Debug().Err(err).
+			Str("peer_address", ctx.Sender().Address).
+			Msg("Failed to store record.")
+		return false
+	}
+
+	return true
+}
+
 func (state *Plugin) Cleanup(net *network.Network) {
-	// TODO: Save routing table?
+	// Save the routing table, such that it may be restored upon restarting.
+	state.persistPeers(net)
+
+	// Stop maintaining the routing table and records, aborting all outstanding
+	// lookups and requests.
+	if state.cancel != nil {
+		state.cancel()
+	}
 }
 
 func (state *Plugin) PeerDisconnect(client *network.PeerClient) {
 	// Delete peer if in routing table.
-	if client.ID != nil {
-		if state.Routes.PeerExists(*client.ID) {
-			state.Routes.RemovePeer(*client.ID)
+	if id := client.ID(); id != nil {
+		if state.Routes.PeerExists(*id) {
+			state.removePeer(*id)
 
-			log.Debug().
+			client.Network.Log("discovery").Debug().
 				Str("address", client.Network.ID.Address).
-				Str("peer_address", client.ID.Address).
+				Str("peer_address", id.Address).
 				Msg("Peer has disconnected.")
 		}
 	}
@@ -0,0 +1,241 @@
+package discovery
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/perlin-network/noise/clock"
+	"github.com/perlin-network/noise/internal/protobuf"
+	"github.com/perlin-network/noise/network"
+	"github.com/perlin-network/noise/peer"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/pkg/errors"
+)
+
+// ProviderStore keeps track of which peers provide content identified by a key.
+type ProviderStore interface {
+	// AddProvider records a peer as a provider of content identified by key
+	// until a given expiry, refreshing the expiry should it already be held.
+	AddProvider(key []byte, provider peer.ID, expiresAt time.Time) error
+	// Providers returns all known providers of content identified by key.
+	// Expired providers are not returned.
+	Providers(key []byte) []peer.ID
+	// Expire deletes all providers which expired before now.
+	Expire(now time.Time)
+}
+
+type providerRecord struct {
+	id        peer.ID
+	expiresAt time.Time
+}
+
+// MemoryProviderStore is a ProviderStore which keeps all provider records in
+// memory, up to a maximum number of keys and providers per key.
+type MemoryProviderStore struct {
+	sync.RWMutex
+	providers map[string][]providerRecord
+
+	maxKeys            int
+	maxProvidersPerKey int
+
+	clock clock.Clock
+}
+
+var _ ProviderStore = (*MemoryProviderStore)(nil)
+
+// NewMemoryProviderStore returns a new empty in-memory provider store, holding
+// providers of at most 65536 keys, and at most 20 providers per key.
+func NewMemoryProviderStore() *MemoryProviderStore {
+	return NewMemoryProviderStoreWithLimits(defaultMaxProviderKeys, defaultMaxProvidersPerKey, clock.Real())
+}
+
+// NewMemoryProviderStoreWithLimits returns a new empty in-memory provider
+// store holding providers of at most maxKeys keys, and at most
+// maxProvidersPerKey providers per key, which expires providers by c. Limits
+// which are not positive are not enforced.
+func NewMemoryProviderStoreWithLimits(maxKeys int, maxProvidersPerKey int, c clock.Clock) *MemoryProviderStore {
+	return &MemoryProviderStore{
+		providers:          make(map[string][]providerRecord),
+		maxKeys:            maxKeys,
+		maxProvidersPerKey: maxProvidersPerKey,
+		clock:              c,
+	}
+}
+
+// AddProvider records a peer as a provider of content identified by key until
+// a given expiry, refreshing the expiry should it already be held. Should the
+// store or the providers of key be full, expired providers are evicted to make
+// room before the provider is refused.
+func (s *MemoryProviderStore) AddProvider(key []byte, provider peer.ID, expiresAt time.Time) error {
+	s.Lock()
+	defer s.Unlock()
+
+	providers, exists := s.providers[string(key)]
+
+	for i, existing := range providers {
+		if existing.id.Equals(provider) {
+			providers[i].expiresAt = expiresAt
+			return nil
+		}
+	}
+
+	if !exists && s.maxKeys > 0 && len(s.providers) >= s.maxKeys {
+		s.expire(s.clock.Now())
+
+		if len(s.providers) >= s.maxKeys {
+			return ErrProvidersFull
+		}
+	}
+
+	if s.maxProvidersPerKey > 0 && len(providers) >= s.maxProvidersPerKey {
+		providers = unexpiredProviders(providers, s.clock.Now())
+
+		if len(providers) >= s.maxProvidersPerKey {
+			s.providers[string(key)] = providers
+			return ErrProvidersFull
+		}
+	}
+
+	s.providers[string(key)] = append(providers, providerRecord{id: provider, expiresAt: expiresAt})
+	return nil
+}
+
+// Providers returns all known providers of content identified by key. Expired
+// providers are not returned.
+func (s *MemoryProviderStore) Providers(key []byte) (providers []peer.ID) {
+	s.RLock()
+	defer s.RUnlock()
+
+	now := s.clock.Now()
+
+	for _, provider := range s.providers[string(key)] {
+		if !now.After(provider.expiresAt) {
+			providers = append(providers, provider.id)
+		}
+	}
+
+	return
+}
+
+// Expire deletes all providers which expired before now.
+func (s *MemoryProviderStore) Expire(now time.Time) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.expire(now)
+}
+
+// Len returns the number of keys providers are held for.
+func (s *MemoryProviderStore) Len() int {
+	s.RLock()
+	defer s.RUnlock()
+
+	return len(s.providers)
+}
+
+// expire deletes all providers which expired before now, alongside keys left
+// with no providers. The store must be locked.
+func (s *MemoryProviderStore) expire(now time.Time) {
+	for key, providers := range s.providers {
+		if providers = unexpiredProviders(providers, now); len(providers) > 0 {
+			s.providers[key] = providers
+		} else {
+			delete(s.providers, key)
+		}
+	}
+}
+
+// unexpiredProviders filters out providers which expired before now in place.
+func unexpiredProviders(providers []providerRecord, now time.Time) []providerRecord {
+	filtered := providers[:0]
+
+	for _, provider := range providers {
+		if !now.After(provider.expiresAt) {
+			filtered = append(filtered, provider)
+		}
+	}
+
+	return filtered
+}
+
+// Provide announces to the #K peers closest to key that this node
+// provides content identified by key.
+//
+// An error is returned should no peer have acknowledged the announcement.
+//
+// The announcement is periodically republished for as long as this node lives,
+// as peers only hold onto providers for a limited time.
+func Provide(net *network.Network, key []byte) error {
+	return ProvideContext(context.Background(), net, key)
+}
+
+// ProvideContext is Provide with support for cancellation.
+func ProvideContext(ctx context.Context, net *network.Network, key []byte) error {
+	if plugin, exists := net.Plugin(PluginID); exists {
+		plugin.(*Plugin).provided.Store(string(key), struct{}{})
+	}
+
+	_, err := requestClosestPeers(ctx, net, KeyID(key), &protobuf.AddProviderRequest{Key: key}, &protobuf.AddProviderResponse{})
+	if err != nil {
+		return errors.Wrap(err, "discovery: failed to announce provider to any peer")
+	}
+	return nil
+}
+
+// FindProviders looks up at most count providers of content identified by key,
+// first within the local provider store and then by iteratively querying peers
+// closest to the key.
+//
+// Queries at most #ALPHA peers at a time.
+func FindProviders(net *network.Network, key []byte, alpha int, count int) []peer.ID {
+	return FindProvidersContext(context.Background(), net, key, alpha, count)
+}
+
+// FindProvidersContext is FindProviders with support for cancellation.
+func FindProvidersContext(ctx context.Context, net *network.Network, key []byte, alpha int, count int) (providers []peer.ID) {
+	plugin, exists := net.Plugin(PluginID)
+
+	// Discovery plugin was not registered. Fail.
+	if !exists {
+		return
+	}
+
+	seen := make(map[string]struct{})
+
+	add := func(provider peer.ID) bool {
+		if _, exists := seen[provider.PublicKeyHex()]; !exists {
+			seen[provider.PublicKeyHex()] = struct{}{}
+			providers = append(providers, provider)
+		}
+		return len(providers) >= count
+	}
+
+	state := plugin.(*Plugin)
+
+	for _, provider := range state.Providers.Providers(key) {
+		if add(provider) {
+			return
+		}
+	}
+
+	walkClosestPeers(ctx, net, KeyID(key), alpha, &protobuf.GetProvidersRequest{Key: key}, func(response proto.Message) ([]*protobuf.ID, []*protobuf.PeerRecord, bool) {
+		res, ok := response.(*protobuf.GetProvidersResponse)
+		if !ok {
+			return nil, nil, false
+		}
+
+		// Providers relayed by other peers are filtered just as closer peers
+		// are, as they are dialed once looked up.
+		for _, id := range state.filterPeers(net, res.Providers, res.Records) {
+			if add(peer.ID(*id)) {
+				return nil, nil, true
+			}
+		}
+
+		return res.Peers, res.Records, false
+	})
+
+	return
+}
@@ -0,0 +1,65 @@
+package discovery
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/perlin-network/noise/clock"
+)
+
+func TestMemoryProviderStoreLimits(t *testing.T) {
+	t.Parallel()
+
+	store := NewMemoryProviderStoreWithLimits(4, 2, clock.Real())
+	live, expired := time.Now().Add(time.Hour), time.Now().Add(-time.Second)
+
+	// Flood the providers of a single key.
+	for i := byte(1); i <= 8; i++ {
+		err := store.AddProvider([]byte("key"), idWithPrefix(i), live)
+
+		if i <= 2 && err != nil {
+			t.Fatalf("AddProvider() = expected no error, got %v", err)
+		}
+		if i > 2 && err != ErrProvidersFull {
+			t.Fatalf("AddProvider() = expected ErrProvidersFull past the per-key cap, got %v", err)
+		}
+	}
+
+	if providers := store.Providers([]byte("key")); len(providers) != 2 {
+		t.Fatalf("expected 2 providers to be held for key, got %d", len(providers))
+	}
+
+	// Re-announcing a held provider refreshes it regardless of the cap.
+	if err := store.AddProvider([]byte("key"), idWithPrefix(1), live); err != nil {
+		t.Fatalf("AddProvider() = expected held provider to be refreshed, got %v", err)
+	}
+
+	// Flood the store with keys.
+	for i := 0; i < 16; i++ {
+		err := store.AddProvider([]byte("flood"+strconv.Itoa(i)), idWithPrefix(1), expired)
+
+		if i < 3 && err != nil {
+			t.Fatalf("AddProvider() = expected no error, got %v", err)
+		}
+	}
+
+	if store.Len() > 4 {
+		t.Fatalf("expected providers of at most 4 keys to be held, got %d", store.Len())
+	}
+
+	// Expired providers are neither returned, nor held onto once swept.
+	if providers := store.Providers([]byte("flood0")); len(providers) != 0 {
+		t.Fatalf("expected expired providers to not be returned, got %d", len(providers))
+	}
+
+	store.Expire(time.Now())
+
+	if store.Len() != 1 {
+		t.Fatalf("expected only the key with live providers to remain after expiry, got %d", store.Len())
+	}
+
+	if err := store.AddProvider([]byte("new"), idWithPrefix(1), live); err != nil {
+		t.Fatalf("AddProvider() = expected room for new keys once expired providers are swept, got %v", err)
+	}
+}
@@ -0,0 +1,168 @@
+package discovery
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/perlin-network/noise/internal/protobuf"
+	"github.com/perlin-network/noise/network"
+	"github.com/perlin-network/noise/peer"
+
+	"github.com/pkg/errors"
+)
+
+// defaultRecursionHops is the number of peers a recursive lookup may be
+// forwarded through beyond the first peer queried.
+const defaultRecursionHops = 8
+
+// queryPeerRecursive asks a peer to look up the closest peers to a target ID
+// on our behalf, allowing the lookup to be forwarded through at most hops
+// further peers.
+//
+// Every hop is given QueryTimeout to respond, such that a peer forwarding a
+// lookup always times out after the peers it forwarded the lookup to.
+func queryPeerRecursive(ctx context.Context, net *network.Network, state *Plugin, peerID peer.ID, targetID peer.ID, hops uint32) ([]*protobuf.ID, error) {
+	client, err := net.Client(peerID.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, state.QueryTimeout*time.Duration(hops+1))
+	defer cancel()
+
+	targetProtoID := protobuf.ID(targetID)
+
+	response, err := client.Request(ctx, &protobuf.LookupNodeRequest{
+		Target:    &targetProtoID,
+		Record:    state.selfRecord,
+		Recursive: true,
+		Hops:      hops,
+		Stamp:     net.NewStamp(targetID.Id),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if response, ok := response.(*protobuf.LookupNodeResponse); ok {
+		return state.filterPeers(net, response.Peers, response.Records), nil
+	}
+
+	return nil, errors.Errorf("discovery: unexpected response %T from %s", response, peerID.Address)
+}
+
+// serveRecursiveLookup looks up the closest peers to a target ID on behalf of
+// a sender by forwarding the lookup to the closest peer we know of which is
+// closer to the target than ourselves.
+//
+// Should recursive lookups be disabled, no hops remain, or no closer peer
+// respond, the closest peers within our routing table are returned instead.
+func (state *Plugin) serveRecursiveLookup(net *network.Network, sender peer.ID, targetID peer.ID, hops uint32) []peer.ID {
+	closest := state.Routes.FindClosestPeers(targetID, state.BucketSize)
+
+	if !state.RecursiveLookups || hops == 0 {
+		return closest
+	}
+
+	if hops > defaultRecursionHops {
+		hops = defaultRecursionHops
+	}
+
+	// Only ever forward lookups towards the target, such that they may not loop.
+	distance := net.ID.XorID(targetID)
+
+	for _, peerID := range closest {
+		if !peerID.XorID(targetID).Less(distance) {
+			break
+		}
+
+		if peerID.Equals(sender) {
+			continue
+		}
+
+		peers, err := queryPeerRecursive(state.context(), net, state, peerID, targetID, hops-1)
+		if err != nil {
+			continue
+		}
+
+		found := []peer.ID{peerID}
+		for _, id := range peers {
+			found = append(found, peer.ID(*id))
+		}
+
+		return mergeClosest(net.ID, targetID, state.BucketSize, closest, found)
+	}
+
+	return closest
+}
+
+// findNodeRecursive looks up the closest peers to a target ID by having each
+// of the closest peers within our routing table recursively perform the lookup
+// on our behalf, along a number of disjoint paths in parallel.
+func findNodeRecursive(ctx context.Context, net *network.Network, state *Plugin, q *query, targetID peer.ID, disjointPaths int) []peer.ID {
+	seeds := state.Routes.FindClosestPeers(targetID, disjointPaths)
+
+	wait, mutex := &sync.WaitGroup{}, &sync.Mutex{}
+	var results []peer.ID
+
+	for path, peerID := range seeds {
+		wait.Add(1)
+
+		spawn(net, func() {
+			defer wait.Done()
+
+			start := time.Now()
+
+			peers, err := queryPeerRecursive(ctx, net, state, peerID, targetID, defaultRecursionHops)
+			if err != nil {
+				q.peerFailed(path, peerID, err, start)
+				return
+			}
+
+			if q != nil {
+				q.peerQueried(path, peerID, toPeerIDs(peers), start)
+			}
+
+			mutex.Lock()
+			results = append(results, peerID)
+			for _, id := range peers {
+				results = append(results, peer.ID(*id))
+			}
+			mutex.Unlock()
+		})
+	}
+
+	wait.Wait()
+
+	return mergeClosest(net.ID, targetID, state.Routes.BucketSize(), results)
+}
+
+// mergeClosest merges lists of peers into a single list of the #K unique peers
+// closest to a target ID, sorted by XOR distance, excluding self.
+func mergeClosest(self peer.ID, targetID peer.ID, k int, lists ...[]peer.ID) (merged []peer.ID) {
+	seen := map[string]struct{}{self.PublicKeyHex(): {}}
+
+	for _, list := range lists {
+		for _, peerID := range list {
+			if _, exists := seen[peerID.PublicKeyHex()]; exists {
+				continue
+			}
+			seen[peerID.PublicKeyHex()] = struct{}{}
+
+			merged = append(merged, peerID)
+		}
+	}
+
+	sort.Slice(merged, func(i, j int) bool {
+		left := merged[i].XorID(targetID)
+		right := merged[j].XorID(targetID)
+		return left.Less(right)
+	})
+
+	if len(merged) > k {
+		merged = merged[:k]
+	}
+
+	return
+}
@@ -0,0 +1,50 @@
+package discovery
+
+import (
+	"testing"
+
+	"github.com/perlin-network/noise/dht"
+	"github.com/perlin-network/noise/peer"
+)
+
+func TestMergeClosest(t *testing.T) {
+	t.Parallel()
+
+	self, target := idWithPrefix(0x01), idWithPrefix(0x00)
+
+	merged := mergeClosest(self, target, 3,
+		[]peer.ID{idWithPrefix(0x40), idWithPrefix(0x10)},
+		[]peer.ID{self, idWithPrefix(0x10), idWithPrefix(0x04), idWithPrefix(0x80)},
+	)
+
+	expected := []byte{0x04, 0x10, 0x40}
+	if len(merged) != len(expected) {
+		t.Fatalf("expected %d merged peers, got %d", len(expected), len(merged))
+	}
+
+	for i, prefix := range expected {
+		if merged[i].PublicKey[0] != prefix {
+			t.Fatalf("merged[%d] = %x, expected %x", i, merged[i].PublicKey[0], prefix)
+		}
+	}
+}
+
+func TestServeRecursiveLookupDisabled(t *testing.T) {
+	t.Parallel()
+
+	net := buildNetwork(t, 3010)
+
+	state := New(WithBucketSize(4))
+	state.setDefaults()
+	state.Routes = dht.CreateRoutingTable(net.ID, dht.WithBucketSize(state.BucketSize))
+
+	for _, prefix := range []byte{0x01, 0x02, 0x03} {
+		state.Routes.Update(idWithPrefix(prefix))
+	}
+
+	// With recursive lookups disabled, the lookup must not be forwarded.
+	closest := state.serveRecursiveLookup(net, idWithPrefix(0x01), idWithPrefix(0x00), defaultRecursionHops)
+	if len(closest) != 3 {
+		t.Fatalf("expected the 3 closest peers in the routing table, got %d", len(closest))
+	}
+}
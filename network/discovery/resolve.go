@@ -0,0 +1,90 @@
+package discovery
+
+import (
+	"context"
+
+	"github.com/perlin-network/noise/network"
+	"github.com/perlin-network/noise/peer"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/pkg/errors"
+)
+
+// ErrPeerNotFound is returned when no address could be resolved for a public key.
+var ErrPeerNotFound = errors.New("discovery: no address found for peer")
+
+// Resolve resolves the address of the peer owning a public key.
+//
+// The routing table and the signed peer records held by this node are checked
+// first. Should neither know of the peer, a lookup is performed through the
+// DHT for the peers ID.
+func Resolve(ctx context.Context, net *network.Network, publicKey []byte) (peer.ID, error) {
+	plugin, exists := net.Plugin(PluginID)
+
+	// Discovery plugin was not registered. Fail.
+	if !exists {
+		return peer.ID{}, ErrPeerNotFound
+	}
+
+	state := plugin.(*Plugin)
+	target := peer.CreateID("", publicKey)
+
+	if id, exists := state.Routes.GetPeer(target); exists {
+		return id, nil
+	}
+
+	if record, exists := state.peerRecord(target); exists && len(record.Addresses) > 0 {
+		return peer.CreateID(record.Addresses[0], publicKey), nil
+	}
+
+	for _, id := range FindNodeContext(ctx, net, target, state.Alpha, state.DisjointPaths) {
+		if id.Equals(target) {
+			return id, nil
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return peer.ID{}, err
+	}
+
+	return peer.ID{}, ErrPeerNotFound
+}
+
+// Resolve resolves the addresses of the peer owning a public key as the
+// package-level Resolve does, such that the plugin serves as the address
+// resolver of the network unless another is set through
+// network.AddressResolver.
+func (state *Plugin) Resolve(ctx context.Context, publicKey []byte) ([]string, error) {
+	if state.net == nil {
+		return nil, network.ErrUnresolved
+	}
+
+	id, err := Resolve(ctx, state.net, publicKey)
+	if err == ErrPeerNotFound {
+		return nil, network.ErrUnresolved
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return []string{id.Address}, nil
+}
+
+// SendTo sends a message to the peer owning a public key, dialing the peer
+// should no connection to it exist yet.
+//
+// Should the peers address not be known, it is resolved through the DHT,
+// allowing peers to be addressed by their IDs alone.
+func SendTo(ctx context.Context, net *network.Network, publicKey []byte, message proto.Message) error {
+	id, err := Resolve(ctx, net, publicKey)
+	if err != nil {
+		return err
+	}
+
+	client, err := net.Client(id.Address)
+	if err != nil {
+		return errors.Wrapf(err, "discovery: failed to dial peer at %s", id.Address)
+	}
+
+	return client.Tell(ctx, message)
+}
@@ -0,0 +1,55 @@
+package discovery
+
+import (
+	"context"
+	"testing"
+
+	"github.com/perlin-network/noise/crypto/ed25519"
+	"github.com/perlin-network/noise/dht"
+	"github.com/perlin-network/noise/network"
+	"github.com/perlin-network/noise/peer"
+)
+
+func TestResolve(t *testing.T) {
+	t.Parallel()
+
+	state := New()
+	state.setDefaults()
+
+	builder := network.NewBuilder()
+	builder.SetKeys(ed25519.RandomKeyPair())
+	builder.SetAddress(network.FormatAddress("tcp", "localhost", 3020))
+	builder.AddPlugin(state)
+
+	local, err := builder.Build()
+	if err != nil {
+		t.Fatalf("Build() = expected no error, got %v", err)
+	}
+	remote := buildNetwork(t, 3021)
+
+	state.Routes = dht.CreateRoutingTable(local.ID)
+
+	if _, err := Resolve(context.Background(), local, remote.ID.PublicKey); err != ErrPeerNotFound {
+		t.Fatalf("Resolve() expected unknown peer to not be found, got %v", err)
+	}
+
+	state.Routes.Update(remote.ID)
+
+	id, err := Resolve(context.Background(), local, remote.ID.PublicKey)
+	if err != nil || id.Address != remote.Address {
+		t.Fatalf("Resolve() expected address %s, got %s (err: %v)", remote.Address, id.Address, err)
+	}
+
+	state.Routes.RemovePeer(remote.ID)
+
+	record, err := NewPeerRecord(remote, 1)
+	if err != nil {
+		t.Fatalf("NewPeerRecord() = expected no error, got %v", err)
+	}
+	state.storePeerRecord(local, record)
+
+	id, err = Resolve(context.Background(), local, remote.ID.PublicKey)
+	if err != nil || !id.Equals(peer.CreateID("", remote.ID.PublicKey)) || id.Address != remote.Address {
+		t.Fatalf("Resolve() expected address %s from peer record, got %s (err: %v)", remote.Address, id.Address, err)
+	}
+}
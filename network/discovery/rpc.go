@@ -2,91 +2,180 @@ package discovery
 
 import (
 	"context"
+	"reflect"
 	"sort"
 	"sync"
 	"time"
 
-	"github.com/perlin-network/noise/dht"
 	"github.com/perlin-network/noise/internal/protobuf"
 	"github.com/perlin-network/noise/network"
 	"github.com/perlin-network/noise/peer"
-)
 
-func queryPeerByID(net *network.Network, peerID peer.ID, targetID peer.ID, responses chan []*protobuf.ID) {
-	client, err := net.Client(peerID.Address)
-	if err != nil {
-		responses <- []*protobuf.ID{}
-		return
-	}
+	"github.com/gogo/protobuf/proto"
+	"github.com/pkg/errors"
+)
 
+func queryPeerByID(ctx context.Context, net *network.Network, state *Plugin, peerID peer.ID, targetID peer.ID) ([]*protobuf.ID, error) {
 	targetProtoID := protobuf.ID(targetID)
 
-	msg := &protobuf.LookupNodeRequest{Target: &targetProtoID}
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
-	defer cancel()
-	response, err := client.Request(ctx, msg)
-
+	response, err := requestPeerByID(ctx, net, peerID, &protobuf.LookupNodeRequest{
+		Target: &targetProtoID,
+		Record: state.selfRecord,
+		Stamp:  net.NewStamp(targetID.Id),
+	})
 	if err != nil {
-		responses <- []*protobuf.ID{}
-		return
+		return nil, err
 	}
 
 	if response, ok := response.(*protobuf.LookupNodeResponse); ok {
-		responses <- response.Peers
-	} else {
-		responses <- []*protobuf.ID{}
+		return state.filterPeers(net, response.Peers, response.Records), nil
 	}
+
+	return nil, errors.Errorf("discovery: unexpected response %T from %s", response, peerID.Address)
 }
 
-type lookupBucket struct {
-	pending int
-	queue   []peer.ID
+// lookup is a single iterative lookup path towards a target ID.
+type lookup struct {
+	self     peer.ID
+	targetID peer.ID
+
+	// k is the number of closest peers the lookup converges on.
+	k int
+
+	// shortlist holds candidate peers sorted by their distance to the target.
+	shortlist []peer.ID
+	seen      map[string]struct{}
+	queried   map[string]struct{}
+
+	// query emits events for every peer queried along the path.
+	query *query
+	path  int
 }
 
-func (lookup *lookupBucket) performLookup(net *network.Network, targetID peer.ID, alpha int, visited *sync.Map) (results []peer.ID) {
-	responses := make(chan []*protobuf.ID)
+func newLookup(self peer.ID, targetID peer.ID, k int) *lookup {
+	return &lookup{
+		self:     self,
+		targetID: targetID,
+		k:        k,
+		seen:     map[string]struct{}{self.PublicKeyHex(): {}},
+		queried:  make(map[string]struct{}),
+	}
+}
 
-	// Go through every peer in the entire queue and queue up what peers believe
-	// is closest to a target ID.
+// add inserts a peer into the shortlist, should it not have been seen before.
+func (l *lookup) add(peerID peer.ID) {
+	if _, seen := l.seen[peerID.PublicKeyHex()]; seen {
+		return
+	}
+	l.seen[peerID.PublicKeyHex()] = struct{}{}
 
-	for ; lookup.pending < alpha && len(lookup.queue) > 0; lookup.pending++ {
-		go queryPeerByID(net, lookup.queue[0], targetID, responses)
+	distance := peerID.XorID(l.targetID)
+	i := sort.Search(len(l.shortlist), func(i int) bool {
+		return distance.Less(l.shortlist[i].XorID(l.targetID))
+	})
 
-		results = append(results, lookup.queue[0])
-		lookup.queue = lookup.queue[1:]
+	l.shortlist = append(l.shortlist, peer.ID{})
+	copy(l.shortlist[i+1:], l.shortlist[i:])
+	l.shortlist[i] = peerID
+}
+
+// remove evicts a peer from the shortlist.
+func (l *lookup) remove(peerID peer.ID) {
+	for i, id := range l.shortlist {
+		if id.Equals(peerID) {
+			l.shortlist = append(l.shortlist[:i], l.shortlist[i+1:]...)
+			return
+		}
 	}
+}
 
-	// Empty queue.
-	lookup.queue = lookup.queue[:0]
+// run queries at most #ALPHA peers at a time out of the #K closest peers in the
+// shortlist, incorporating their responses into the shortlist, until all of the
+// #K closest peers have been queried.
+//
+// Peers which fail to respond are evicted from the shortlist. Peers claimed by
+// other disjoint lookups through visited are evicted as well.
+func (l *lookup) run(ctx context.Context, net *network.Network, state *Plugin, alpha int, visited *sync.Map) []peer.ID {
+	type response struct {
+		peerID peer.ID
+		peers  []*protobuf.ID
+		err    error
+	}
 
-	// Asynchronous breadth-first search.
-	for lookup.pending > 0 {
-		response := <-responses
+	responses := make(chan response, alpha)
+	pending := 0
 
-		lookup.pending--
+	for {
+		for i := 0; pending < alpha && i < len(l.shortlist) && i < l.k; i++ {
+			peerID := l.shortlist[i]
 
-		// Expand responses containing a peer's belief on the closest peers to target ID.
-		for _, id := range response {
-			peerID := peer.ID(*id)
+			if _, queried := l.queried[peerID.PublicKeyHex()]; queried {
+				continue
+			}
+			l.queried[peerID.PublicKeyHex()] = struct{}{}
 
-			if _, seen := visited.LoadOrStore(peerID.PublicKeyHex(), struct{}{}); !seen {
-				// Append new peer to be queued by the routing table.
-				results = append(results, peerID)
-				lookup.queue = append(lookup.queue, peerID)
+			if _, claimed := visited.LoadOrStore(peerID.PublicKeyHex(), struct{}{}); claimed {
+				l.remove(peerID)
+				i--
+				continue
 			}
+
+			pending++
+
+			spawn(net, func() {
+				start := time.Now()
+				peers, err := queryPeerByID(ctx, net, state, peerID, l.targetID)
+
+				if err != nil {
+					l.query.peerFailed(l.path, peerID, err, start)
+				} else if l.query != nil {
+					l.query.peerQueried(l.path, peerID, toPeerIDs(peers), start)
+				}
+
+				responses <- response{peerID: peerID, peers: peers, err: err}
+			})
 		}
 
-		// Queue and request for #ALPHA closest peers to target ID from expanded results.
-		for ; lookup.pending < alpha && len(lookup.queue) > 0; lookup.pending++ {
-			go queryPeerByID(net, lookup.queue[0], targetID, responses)
-			lookup.queue = lookup.queue[1:]
+		// All of the closest peers have been queried.
+		if pending == 0 {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return l.closest()
+		case res := <-responses:
+			pending--
+
+			if res.err != nil {
+				l.remove(res.peerID)
+				continue
+			}
+
+			for _, id := range res.peers {
+				l.add(peer.ID(*id))
+			}
 		}
+	}
+
+	return l.closest()
+}
 
-		// Empty queue.
-		lookup.queue = lookup.queue[:0]
+// toPeerIDs converts a list of protobuf IDs into peer IDs.
+func toPeerIDs(ids []*protobuf.ID) []peer.ID {
+	peers := make([]peer.ID, 0, len(ids))
+	for _, id := range ids {
+		peers = append(peers, peer.ID(*id))
 	}
+	return peers
+}
 
-	return
+// closest returns the #K closest peers in the shortlist.
+func (l *lookup) closest() []peer.ID {
+	if len(l.shortlist) > l.k {
+		return l.shortlist[:l.k]
+	}
+	return l.shortlist
 }
 
 // FindNode queries all peers this current node acknowledges for the closest peers
@@ -95,7 +184,21 @@ func (lookup *lookupBucket) performLookup(net *network.Network, targetID peer.ID
 // All lookups are done under a number of disjoint lookups in parallel.
 //
 // Queries at most #ALPHA nodes at a time per lookup, and returns all peer IDs closest to a target peer ID.
-func FindNode(net *network.Network, targetID peer.ID, alpha int, disjointPaths int) (results []peer.ID) {
+func FindNode(net *network.Network, targetID peer.ID, alpha int, disjointPaths int) []peer.ID {
+	return FindNodeContext(context.Background(), net, targetID, alpha, disjointPaths)
+}
+
+// FindNodeContext is FindNode with support for cancellation. Should ctx be
+// cancelled, the closest peers found so far are returned.
+//
+// Every lookup path maintains a shortlist of the peers closest to the target,
+// and terminates once the #K closest peers within its shortlist have all been
+// queried, where #K is the bucket size of the routing table. No peer is
+// queried by more than one path.
+//
+// Should recursive lookups be enabled, every path is instead handed off to a
+// peer which performs the remainder of the lookup on our behalf.
+func FindNodeContext(ctx context.Context, net *network.Network, targetID peer.ID, alpha int, disjointPaths int) (results []peer.ID) {
 	plugin, exists := net.Plugin(PluginID)
 
 	// Discovery plugin was not registered. Fail.
@@ -103,37 +206,59 @@ func FindNode(net *network.Network, targetID peer.ID, alpha int, disjointPaths i
 		return
 	}
 
-	visited := new(sync.Map)
+	if alpha < 1 {
+		alpha = 1
+	}
+
+	if disjointPaths < 1 {
+		disjointPaths = 1
+	}
+
+	q := plugin.(*Plugin).startQuery(targetID)
+	defer func() { q.finish(results) }()
+
+	if plugin.(*Plugin).RecursiveLookups {
+		return findNodeRecursive(ctx, net, plugin.(*Plugin), q, targetID, disjointPaths)
+	}
+
+	// Converge on enough peers to both fill a bucket and replicate records to.
+	k := plugin.(*Plugin).Routes.BucketSize()
+	if replication := plugin.(*Plugin).Routes.Replication(); replication > k {
+		k = replication
+	}
 
-	var lookups []*lookupBucket
+	visited := new(sync.Map)
+	visited.Store(net.ID.PublicKeyHex(), struct{}{})
 
-	// Start searching for target from #ALPHA peers closest to target by queuing
-	// them up and marking them as visited.
-	for i, peerID := range plugin.(*Plugin).Routes.FindClosestPeers(targetID, alpha) {
-		visited.Store(peerID.PublicKeyHex(), struct{}{})
+	var lookups []*lookup
 
+	// Seed every lookup path with a disjoint portion of the peers closest to
+	// target in our routing table.
+	for i, peerID := range plugin.(*Plugin).Routes.FindClosestPeers(targetID, k) {
 		if len(lookups) < disjointPaths {
-			lookups = append(lookups, new(lookupBucket))
-		}
+			l := newLookup(net.ID, targetID, k)
+			l.query, l.path = q, len(lookups)
 
-		lookup := lookups[i%disjointPaths]
-		lookup.queue = append(lookup.queue, peerID)
+			lookups = append(lookups, l)
+		}
 
-		results = append(results, peerID)
+		lookups[i%disjointPaths].add(peerID)
 	}
 
 	wait, mutex := &sync.WaitGroup{}, &sync.Mutex{}
 
-	for _, lookup := range lookups {
-		go func(lookup *lookupBucket) {
-			mutex.Lock()
-			results = append(results, lookup.performLookup(net, targetID, alpha, visited)...)
-			mutex.Unlock()
+	for _, l := range lookups {
+		wait.Add(1)
 
-			wait.Done()
-		}(lookup)
+		spawn(net, func() {
+			defer wait.Done()
 
-		wait.Add(1)
+			found := l.run(ctx, net, plugin.(*Plugin), alpha, visited)
+
+			mutex.Lock()
+			results = append(results, found...)
+			mutex.Unlock()
+		})
 	}
 
 	// Wait until all #D parallel lookups have been completed.
@@ -141,16 +266,257 @@ func FindNode(net *network.Network, targetID peer.ID, alpha int, disjointPaths i
 
 	// Sort resulting peers by XOR distance.
 	sort.Slice(results, func(i, j int) bool {
-		left := results[i].Xor(targetID)
-		right := results[j].Xor(targetID)
+		left := results[i].XorID(targetID)
+		right := results[j].XorID(targetID)
 		return left.Less(right)
 	})
 
 	// Cut off list of results to only have the routing table focus on the
-	// #dht.BucketSize closest peers to the current node.
-	if len(results) > dht.BucketSize {
-		results = results[:dht.BucketSize]
+	// #K closest peers to the current node.
+	if len(results) > k {
+		results = results[:k]
 	}
 
 	return
 }
+
+func requestPeerByID(ctx context.Context, net *network.Network, peerID peer.ID, req proto.Message) (proto.Message, error) {
+	client, err := net.Client(peerID.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := defaultQueryTimeout
+	if plugin, exists := net.Plugin(PluginID); exists {
+		timeout = plugin.(*Plugin).QueryTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	return client.Request(ctx, req)
+}
+
+// closestPeers looks up the #K peers within the network whose IDs are closest
+// to a target ID, where #K is the replication parameter of the routing table.
+func closestPeers(ctx context.Context, net *network.Network, targetID peer.ID) []peer.ID {
+	plugin, exists := net.Plugin(PluginID)
+
+	// Discovery plugin was not registered. Fail.
+	if !exists {
+		return nil
+	}
+
+	state := plugin.(*Plugin)
+
+	// Should the target lie well within our sibling list, the peers closest to
+	// it are already known and no lookup is necessary.
+	if peers, complete := state.Routes.ClosestSiblings(targetID, state.Routes.Replication()); complete {
+		return peers
+	}
+
+	peers := FindNodeContext(ctx, net, targetID, state.Alpha, state.DisjointPaths)
+
+	sort.Slice(peers, func(i, j int) bool {
+		left := peers[i].XorID(targetID)
+		right := peers[j].XorID(targetID)
+		return left.Less(right)
+	})
+
+	if k := state.Routes.Replication(); len(peers) > k {
+		peers = peers[:k]
+	}
+
+	return peers
+}
+
+// requestClosestPeers sends a request to the #K peers closest to a
+// target ID, and returns the number of peers which successfully responded with
+// a response of the same type as expected.
+func requestClosestPeers(ctx context.Context, net *network.Network, targetID peer.ID, req proto.Message, expected proto.Message) (int, error) {
+	peers := closestPeers(ctx, net, targetID)
+
+	if len(peers) == 0 {
+		return 0, errors.New("discovery: no peers known to send request to")
+	}
+
+	results := make(chan error, len(peers))
+	for _, peerID := range peers {
+		spawn(net, func() {
+			response, err := requestPeerByID(ctx, net, peerID, req)
+			if err == nil && reflect.TypeOf(response) != reflect.TypeOf(expected) {
+				err = errors.Errorf("discovery: unexpected response %T from %s", response, peerID.Address)
+			}
+			results <- err
+		})
+	}
+
+	var lastErr error
+	succeeded := 0
+
+	for range peers {
+		if err := <-results; err != nil {
+			lastErr = err
+		} else {
+			succeeded++
+		}
+	}
+
+	if succeeded == 0 {
+		return 0, lastErr
+	}
+
+	return succeeded, nil
+}
+
+// walkClosestPeers iteratively queries peers closest to a target ID, #ALPHA at a
+// time out of the #K closest peers known, starting from those held in our
+// routing table. The walk terminates once the #K closest peers known have all
+// been queried.
+//
+// Every response is handed to handle, which returns peers believed to be closer
+// to the target alongside their signed peer records, and whether or not the
+// walk is complete. Closer peers are filtered as per filterPeers before being
+// queried. Peers which fail to respond are dropped from the walk. The walk is abandoned should ctx be
+// cancelled.
+func walkClosestPeers(ctx context.Context, net *network.Network, targetID peer.ID, alpha int, req proto.Message, handle func(response proto.Message) (closer []*protobuf.ID, records []*protobuf.PeerRecord, done bool)) {
+	plugin, exists := net.Plugin(PluginID)
+
+	// Discovery plugin was not registered. Fail.
+	if !exists {
+		return
+	}
+
+	if alpha < 1 {
+		alpha = 1
+	}
+
+	state := plugin.(*Plugin)
+
+	q := state.startQuery(targetID)
+	defer q.finish(nil)
+
+	l := newLookup(net.ID, targetID, state.Routes.BucketSize())
+
+	for _, peerID := range state.Routes.FindClosestPeers(targetID, l.k) {
+		l.add(peerID)
+	}
+
+	type result struct {
+		peerID   peer.ID
+		response proto.Message
+		err      error
+		start    time.Time
+	}
+
+	for ctx.Err() == nil {
+		var batch []peer.ID
+
+		for i := 0; len(batch) < alpha && i < len(l.shortlist) && i < l.k; i++ {
+			peerID := l.shortlist[i]
+
+			if _, queried := l.queried[peerID.PublicKeyHex()]; !queried {
+				l.queried[peerID.PublicKeyHex()] = struct{}{}
+				batch = append(batch, peerID)
+			}
+		}
+
+		// All of the closest peers have been queried.
+		if len(batch) == 0 {
+			return
+		}
+
+		results := make(chan result, len(batch))
+		for _, peerID := range batch {
+			spawn(net, func() {
+				start := time.Now()
+				response, err := requestPeerByID(ctx, net, peerID, req)
+				results <- result{peerID: peerID, response: response, err: err, start: start}
+			})
+		}
+
+		for range batch {
+			res := <-results
+			if res.err != nil {
+				q.peerFailed(0, res.peerID, res.err, res.start)
+				l.remove(res.peerID)
+				continue
+			}
+
+			closer, records, done := handle(res.response)
+			closer = state.filterPeers(net, closer, records)
+
+			if q != nil {
+				q.peerQueried(0, res.peerID, toPeerIDs(closer), res.start)
+			}
+
+			if done {
+				return
+			}
+
+			for _, id := range closer {
+				l.add(peer.ID(*id))
+			}
+		}
+	}
+}
+
+// StoreValue stores a value under key at the #K peers closest to
+// the key within the network.
+//
+// An error is returned should the value not have been stored at any peer.
+//
+// The value is periodically republished for as long as this node lives.
+func StoreValue(net *network.Network, key []byte, value []byte) error {
+	return StoreValueContext(context.Background(), net, key, value)
+}
+
+// StoreValueContext is StoreValue with support for cancellation.
+func StoreValueContext(ctx context.Context, net *network.Network, key []byte, value []byte) error {
+	if plugin, exists := net.Plugin(PluginID); exists {
+		plugin.(*Plugin).published.Store(string(key), append([]byte(nil), value...))
+	}
+
+	_, err := requestClosestPeers(ctx, net, KeyID(key), &protobuf.StoreRequest{Key: key, Value: value}, &protobuf.StoreResponse{})
+	if err != nil {
+		return errors.Wrap(err, "discovery: failed to store value at any peer")
+	}
+	return nil
+}
+
+// FindValue looks up the value stored under key, first within the local record
+// store and then by iteratively querying peers closest to the key.
+//
+// Queries at most #ALPHA peers at a time, and returns whether or not a value was found.
+func FindValue(net *network.Network, key []byte, alpha int) (value []byte, found bool) {
+	return FindValueContext(context.Background(), net, key, alpha)
+}
+
+// FindValueContext is FindValue with support for cancellation.
+func FindValueContext(ctx context.Context, net *network.Network, key []byte, alpha int) (value []byte, found bool) {
+	plugin, exists := net.Plugin(PluginID)
+
+	// Discovery plugin was not registered. Fail.
+	if !exists {
+		return nil, false
+	}
+
+	if value, found := plugin.(*Plugin).Records.Get(key); found {
+		return value, true
+	}
+
+	walkClosestPeers(ctx, net, KeyID(key), alpha, &protobuf.FindValueRequest{Key: key}, func(response proto.Message) ([]*protobuf.ID, []*protobuf.PeerRecord, bool) {
+		res, ok := response.(*protobuf.FindValueResponse)
+		if !ok {
+			return nil, nil, false
+		}
+
+		if res.Found {
+			value, found = res.Value, true
+			return nil, nil, true
+		}
+
+		return res.Peers, res.Records, false
+	})
+
+	return
+}
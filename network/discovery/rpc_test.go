@@ -0,0 +1,41 @@
+package discovery
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/perlin-network/noise/peer"
+)
+
+func idWithPrefix(prefix byte) peer.ID {
+	id := make([]byte, 32)
+	id[0] = prefix
+	return peer.ID{PublicKey: id, Id: id}
+}
+
+func TestLookupShortlist(t *testing.T) {
+	t.Parallel()
+
+	self, target := idWithPrefix(0xff), idWithPrefix(0x00)
+	l := newLookup(self, target, 4)
+
+	for _, prefix := range []byte{0x40, 0x01, 0x10, 0x01, 0xff, 0x04} {
+		l.add(idWithPrefix(prefix))
+	}
+
+	expected := []byte{0x01, 0x04, 0x10, 0x40}
+	if len(l.shortlist) != len(expected) {
+		t.Fatalf("expected shortlist to hold %d peers without duplicates or self, got %d", len(expected), len(l.shortlist))
+	}
+
+	for i, prefix := range expected {
+		if !bytes.Equal(l.shortlist[i].Id, idWithPrefix(prefix).Id) {
+			t.Fatalf("shortlist[%d] = %x, expected prefix %x", i, l.shortlist[i].Id[0], prefix)
+		}
+	}
+
+	l.remove(idWithPrefix(0x04))
+	if len(l.shortlist) != 3 || l.shortlist[1].Id[0] != 0x10 {
+		t.Fatalf("expected peer to be evicted from the shortlist")
+	}
+}
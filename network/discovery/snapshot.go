@@ -0,0 +1,135 @@
+package discovery
+
+import (
+	"encoding/json"
+
+	"github.com/perlin-network/noise/internal/protobuf"
+	"github.com/perlin-network/noise/network"
+	"github.com/perlin-network/noise/peer"
+
+	"github.com/pkg/errors"
+)
+
+// Export takes a snapshot of all peers within the routing table alongside
+// their signed peer records, such that new nodes may be seeded from it or its
+// contents debugged offline.
+func (state *Plugin) Export() *protobuf.RoutingTableSnapshot {
+	snapshot := &protobuf.RoutingTableSnapshot{}
+
+	state.Routes.ForEach(func(peerID peer.ID) bool {
+		id := protobuf.ID(peerID)
+		snapshot.Peers = append(snapshot.Peers, &id)
+
+		if record, exists := state.peerRecord(peerID); exists {
+			snapshot.Records = append(snapshot.Records, record)
+		}
+
+		return true
+	})
+
+	return snapshot
+}
+
+// ExportJSON takes a snapshot of the routing table, and encodes it as JSON.
+func (state *Plugin) ExportJSON() ([]byte, error) {
+	return json.Marshal(state.Export())
+}
+
+// Status summarizes the routing table for snapshots of the network, or
+// returns nil should the plugin have yet to start.
+func (state *Plugin) Status() interface{} {
+	if state.Routes == nil {
+		return nil
+	}
+
+	return state.Routes.Stats()
+}
+
+// KnownPeers returns the IDs of all peers within the routing table.
+func (state *Plugin) KnownPeers() []peer.ID {
+	if state.Routes == nil {
+		return nil
+	}
+
+	return state.Routes.GetPeers()
+}
+
+// ClosestPeers returns the IDs of up to count peers within the routing table
+// closest to a target ID.
+func (state *Plugin) ClosestPeers(target peer.ID, count int) []peer.ID {
+	if state.Routes == nil {
+		return nil
+	}
+
+	return state.Routes.FindClosestPeers(target, count)
+}
+
+// Import inserts all valid peers from a routing table snapshot into the routing
+// table, and returns the number of peers imported.
+//
+// Peers whose IDs do not match their public keys, or whose addresses
+// contradict their signed peer records are discarded. Should signed records be
+// required, peers without a valid signed peer record are discarded as well.
+func (state *Plugin) Import(net *network.Network, snapshot *protobuf.RoutingTableSnapshot) (imported int) {
+	for _, record := range snapshot.Records {
+		state.storePeerRecord(net, record)
+	}
+
+	for _, id := range snapshot.Peers {
+		peerID := peer.ID(*id)
+
+		if err := state.validateSnapshotPeer(net, peerID); err != nil {
+			net.Log("discovery").Debug().
+				Err(err).
+				Str("peer_address", peerID.Address).
+				Msg("Discarded peer from routing table snapshot.")
+			continue
+		}
+
+		if state.Routes.Update(peerID) == nil {
+			imported++
+		}
+	}
+
+	return
+}
+
+// ImportJSON decodes a JSON-encoded routing table snapshot, and imports it into
+// the routing table.
+func (state *Plugin) ImportJSON(net *network.Network, data []byte) (int, error) {
+	snapshot := &protobuf.RoutingTableSnapshot{}
+
+	if err := json.Unmarshal(data, snapshot); err != nil {
+		return 0, errors.Wrap(err, "discovery: failed to decode routing table snapshot")
+	}
+
+	return state.Import(net, snapshot), nil
+}
+
+// validateSnapshotPeer checks that a peer from a routing table snapshot may be
+// inserted into the routing table.
+func (state *Plugin) validateSnapshotPeer(net *network.Network, peerID peer.ID) error {
+	if peerID.Equals(net.ID) {
+		return errors.New("discovery: snapshot peer is ourselves")
+	}
+
+	if !peer.CreateID(peerID.Address, peerID.PublicKey).Equals(peerID) {
+		return errors.New("discovery: snapshot peer ID does not match its public key")
+	}
+
+	if state.banned(peerID) {
+		return errors.New("discovery: snapshot peer is banned")
+	}
+
+	record, exists := state.peerRecord(peerID)
+
+	if exists && !vouchesFor(record, peerID) {
+		return errors.New("discovery: snapshot peer address contradicts its signed peer record")
+	}
+
+	if !exists && state.RequireSignedRecords {
+		return errors.New("discovery: snapshot peer has no signed peer record")
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package discovery
+
+import (
+	"testing"
+
+	"github.com/perlin-network/noise/dht"
+	"github.com/perlin-network/noise/internal/protobuf"
+	"github.com/perlin-network/noise/peer"
+)
+
+func TestSnapshot(t *testing.T) {
+	t.Parallel()
+
+	local, remote, other := buildNetwork(t, 3040), buildNetwork(t, 3041), buildNetwork(t, 3042)
+
+	exporter := New()
+	exporter.setDefaults()
+	exporter.Routes = dht.CreateRoutingTable(remote.ID)
+	exporter.Routes.Update(other.ID)
+
+	record, err := NewPeerRecord(other, 1)
+	if err != nil {
+		t.Fatalf("NewPeerRecord() = expected no error, got %v", err)
+	}
+	exporter.storePeerRecord(remote, record)
+
+	data, err := exporter.ExportJSON()
+	if err != nil {
+		t.Fatalf("ExportJSON() = expected no error, got %v", err)
+	}
+
+	importer := New()
+	importer.setDefaults()
+	importer.Routes = dht.CreateRoutingTable(local.ID)
+
+	imported, err := importer.ImportJSON(local, data)
+	if err != nil || imported != 1 {
+		t.Fatalf("ImportJSON() expected 1 peer to be imported, got %d (err: %v)", imported, err)
+	}
+
+	if !importer.Routes.PeerExists(other.ID) {
+		t.Fatalf("expected exported peer to be within the routing table")
+	}
+
+	if _, exists := importer.peerRecord(other.ID); !exists {
+		t.Fatalf("expected exported peer record to be imported")
+	}
+
+	if _, err := importer.ImportJSON(local, []byte("not json")); err == nil {
+		t.Fatalf("ImportJSON() expected malformed snapshot to fail to decode")
+	}
+}
+
+func TestSnapshotValidation(t *testing.T) {
+	t.Parallel()
+
+	local, remote := buildNetwork(t, 3043), buildNetwork(t, 3044)
+
+	record, err := NewPeerRecord(remote, 1)
+	if err != nil {
+		t.Fatalf("NewPeerRecord() = expected no error, got %v", err)
+	}
+
+	spoofed := protobuf.ID(peer.CreateID("tcp://localhost:6666", remote.ID.PublicKey))
+	mismatched := protobuf.ID(peer.ID{Address: remote.Address, PublicKey: remote.ID.PublicKey, Id: local.ID.Id})
+	unsigned := protobuf.ID(peer.CreateID("tcp://localhost:6667", local.ID.PublicKey[:16]))
+	self := protobuf.ID(local.ID)
+
+	state := New(WithRequireSignedRecords(true))
+	state.setDefaults()
+	state.Routes = dht.CreateRoutingTable(local.ID)
+
+	snapshot := &protobuf.RoutingTableSnapshot{
+		Peers:   []*protobuf.ID{&spoofed, &mismatched, &unsigned, &self},
+		Records: []*protobuf.PeerRecord{record},
+	}
+
+	if imported := state.Import(local, snapshot); imported != 0 {
+		t.Fatalf("Import() expected all invalid peers to be discarded, imported %d", imported)
+	}
+}
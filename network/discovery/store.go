@@ -0,0 +1,164 @@
+package discovery
+
+import (
+	"sync"
+	"time"
+
+	"github.com/perlin-network/noise/clock"
+	"github.com/perlin-network/noise/crypto/blake2b"
+	"github.com/perlin-network/noise/peer"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrRecordTooLarge is returned when storing a record whose value exceeds
+	// the largest size a store accepts.
+	ErrRecordTooLarge = errors.New("discovery: record too large")
+	// ErrStoreFull is returned when storing a new record into a store which
+	// already holds as many live records as it accepts.
+	ErrStoreFull = errors.New("discovery: record store full")
+	// ErrProvidersFull is returned when adding a new provider to a store which
+	// already holds as many live providers as it accepts, either in total or
+	// for the key provided.
+	ErrProvidersFull = errors.New("discovery: provider store full")
+)
+
+// RecordStore persists the key/value records a node is responsible for
+// holding on behalf of the DHT.
+type RecordStore interface {
+	// Get returns the value stored under key, and whether or not it exists.
+	// Expired records are not returned.
+	Get(key []byte) ([]byte, bool)
+	// Put stores a value under key until a given expiry, overwriting any
+	// existing value.
+	Put(key []byte, value []byte, expiresAt time.Time) error
+	// Delete removes the value stored under key.
+	Delete(key []byte) error
+	// Range calls fn for every stored record until fn returns false.
+	Range(fn func(key []byte, value []byte, expiresAt time.Time) bool)
+}
+
+type record struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+// MemoryStore is a RecordStore which keeps all records in memory, up to a
+// maximum number of records and record size.
+type MemoryStore struct {
+	sync.RWMutex
+	records map[string]record
+
+	maxRecords    int
+	maxRecordSize int
+
+	clock clock.Clock
+}
+
+var _ RecordStore = (*MemoryStore)(nil)
+
+// NewMemoryStore returns a new empty in-memory record store, holding at most
+// 65536 records of at most 64KiB each.
+func NewMemoryStore() *MemoryStore {
+	return NewMemoryStoreWithLimits(defaultMaxRecords, defaultMaxRecordSize, clock.Real())
+}
+
+// NewMemoryStoreWithLimits returns a new empty in-memory record store holding
+// at most maxRecords records, whose values are at most maxRecordSize bytes,
+// which expires records by c. Limits which are not positive are not enforced.
+func NewMemoryStoreWithLimits(maxRecords int, maxRecordSize int, c clock.Clock) *MemoryStore {
+	return &MemoryStore{
+		records:       make(map[string]record),
+		maxRecords:    maxRecords,
+		maxRecordSize: maxRecordSize,
+		clock:         c,
+	}
+}
+
+// Get returns the value stored under key, and whether or not it exists.
+// Expired records are not returned.
+func (s *MemoryStore) Get(key []byte) ([]byte, bool) {
+	s.RLock()
+	defer s.RUnlock()
+
+	r, ok := s.records[string(key)]
+	if !ok || s.clock.Now().After(r.expiresAt) {
+		return nil, false
+	}
+	return r.value, true
+}
+
+// Put stores a value under key until a given expiry, overwriting any existing
+// value. Should the store be full, expired records are evicted to make room for
+// a new record before it is refused.
+func (s *MemoryStore) Put(key []byte, value []byte, expiresAt time.Time) error {
+	if s.maxRecordSize > 0 && len(value) > s.maxRecordSize {
+		return ErrRecordTooLarge
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	if _, exists := s.records[string(key)]; !exists && s.maxRecords > 0 && len(s.records) >= s.maxRecords {
+		s.expire(s.clock.Now())
+
+		if len(s.records) >= s.maxRecords {
+			return ErrStoreFull
+		}
+	}
+
+	s.records[string(key)] = record{value: append([]byte(nil), value...), expiresAt: expiresAt}
+	return nil
+}
+
+// Delete removes the value stored under key.
+func (s *MemoryStore) Delete(key []byte) error {
+	s.Lock()
+	defer s.Unlock()
+
+	delete(s.records, string(key))
+	return nil
+}
+
+// Range calls fn for every stored record until fn returns false.
+func (s *MemoryStore) Range(fn func(key []byte, value []byte, expiresAt time.Time) bool) {
+	s.RLock()
+	records := make(map[string]record, len(s.records))
+	for key, r := range s.records {
+		records[key] = r
+	}
+	s.RUnlock()
+
+	// Records are ranged over outside of the lock, such that fn may modify the store.
+	for key, r := range records {
+		if !fn([]byte(key), r.value, r.expiresAt) {
+			return
+		}
+	}
+}
+
+// Len returns the number of records held, including those which have expired
+// but have yet to be swept.
+func (s *MemoryStore) Len() int {
+	s.RLock()
+	defer s.RUnlock()
+
+	return len(s.records)
+}
+
+// expire deletes all records which expired before now. The store must be locked.
+func (s *MemoryStore) expire(now time.Time) {
+	for key, r := range s.records {
+		if now.After(r.expiresAt) {
+			delete(s.records, key)
+		}
+	}
+}
+
+// KeyID returns the position of a record key within the DHT keyspace, such that
+// it may be compared by XOR distance against peer IDs.
+func KeyID(key []byte) peer.ID {
+	hash := blake2b.New().HashBytes(key)
+	return peer.ID{PublicKey: hash, Id: hash}
+}
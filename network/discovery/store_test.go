@@ -0,0 +1,126 @@
+package discovery
+
+import (
+	"testing"
+	"time"
+
+	"github.com/perlin-network/noise/clock"
+	"github.com/perlin-network/noise/dht"
+	"github.com/perlin-network/noise/log"
+)
+
+func TestMemoryStoreVirtualClock(t *testing.T) {
+	t.Parallel()
+
+	c := clock.NewVirtual(time.Unix(0, 0))
+	store := NewMemoryStoreWithLimits(1, 0, c)
+
+	if err := store.Put([]byte("a"), []byte("value"), c.Now().Add(time.Minute)); err != nil {
+		t.Fatalf("Put() expected record to be stored, got %v", err)
+	}
+
+	if err := store.Put([]byte("b"), []byte("value"), c.Now().Add(time.Minute)); err != ErrStoreFull {
+		t.Fatalf("Put() expected store to be full, got %v", err)
+	}
+
+	c.Advance(time.Hour)
+
+	if _, found := store.Get([]byte("a")); found {
+		t.Fatalf("Get() expected record to expire once the clock advanced")
+	}
+
+	if err := store.Put([]byte("b"), []byte("value"), c.Now().Add(time.Minute)); err != nil {
+		t.Fatalf("Put() expected expired record to be evicted, got %v", err)
+	}
+}
+
+func TestMemoryStoreExpiry(t *testing.T) {
+	t.Parallel()
+
+	store := NewMemoryStore()
+
+	store.Put([]byte("live"), []byte("value"), time.Now().Add(time.Hour))
+	store.Put([]byte("expired"), []byte("value"), time.Now().Add(-time.Second))
+
+	if value, found := store.Get([]byte("live")); !found || string(value) != "value" {
+		t.Fatalf("Get() expected live record to be found, got %q (found = %v)", value, found)
+	}
+
+	if _, found := store.Get([]byte("expired")); found {
+		t.Fatalf("Get() expected expired record to not be found")
+	}
+
+	expireRecords(log.For("discovery", nil), store, time.Now())
+
+	count := 0
+	store.Range(func(key []byte, value []byte, expiresAt time.Time) bool {
+		count++
+		return true
+	})
+
+	if count != 1 {
+		t.Fatalf("expected 1 record to remain after expiry, got %d", count)
+	}
+}
+
+func TestMemoryStoreLimits(t *testing.T) {
+	t.Parallel()
+
+	store := NewMemoryStoreWithLimits(2, 4, clock.Real())
+
+	if err := store.Put([]byte("large"), []byte("value"), time.Now().Add(time.Hour)); err != ErrRecordTooLarge {
+		t.Fatalf("Put() = expected ErrRecordTooLarge, got %v", err)
+	}
+
+	store.Put([]byte("live"), []byte("a"), time.Now().Add(time.Hour))
+	store.Put([]byte("expired"), []byte("b"), time.Now().Add(-time.Second))
+
+	// Expired records are evicted to make room for new records.
+	if err := store.Put([]byte("new"), []byte("c"), time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("Put() = expected expired record to be evicted, got %v", err)
+	}
+
+	if err := store.Put([]byte("full"), []byte("d"), time.Now().Add(time.Hour)); err != ErrStoreFull {
+		t.Fatalf("Put() = expected ErrStoreFull, got %v", err)
+	}
+
+	// Existing records may still be overwritten once the store is full.
+	if err := store.Put([]byte("live"), []byte("e"), time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("Put() = expected existing record to be overwritten, got %v", err)
+	}
+
+	if store.Len() != 2 {
+		t.Fatalf("expected store to hold 2 records, got %d", store.Len())
+	}
+}
+
+func TestTransferAmongClosest(t *testing.T) {
+	t.Parallel()
+
+	state := new(Plugin)
+	state.Routes = dht.CreateRoutingTable(idWithPrefix(0xff))
+	state.Replication = 2
+
+	keyID := idWithPrefix(0x00)
+
+	for _, prefix := range []byte{0x01, 0x02} {
+		state.Routes.Update(idWithPrefix(prefix))
+	}
+
+	// Records are only handed to peers among the closest to their keys.
+	far, near := idWithPrefix(0x40), idWithPrefix(0x03)
+
+	state.Routes.Update(far)
+	if state.amongClosest(far, keyID) {
+		t.Fatalf("expected peer with 2 closer peers to not be among the 2 closest")
+	}
+
+	state.Routes.Update(near)
+	if state.amongClosest(near, keyID) {
+		t.Fatalf("expected peer with 2 closer peers to not be among the 2 closest")
+	}
+
+	if !state.amongClosest(idWithPrefix(0x02), keyID) {
+		t.Fatalf("expected peer with 1 closer peer to be among the 2 closest")
+	}
+}
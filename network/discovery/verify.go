@@ -0,0 +1,110 @@
+package discovery
+
+import (
+	"context"
+	"time"
+
+	"github.com/perlin-network/noise/internal/protobuf"
+	"github.com/perlin-network/noise/network"
+	"github.com/perlin-network/noise/peer"
+
+	"github.com/pkg/errors"
+)
+
+// learnPeers inserts peers learned from lookups into the routing table.
+//
+// Should peer verification be enabled, peers not already within the routing
+// table are only inserted once they have been dialed back on their advertised
+// address and have answered a ping under their advertised ID.
+func (state *Plugin) learnPeers(net *network.Network, peers []peer.ID) {
+	for _, peerID := range peers {
+		if !state.VerifyPeers || state.Routes.PeerExists(peerID) {
+			state.Routes.Update(peerID)
+			continue
+		}
+
+		if _, verifying := state.verifying.LoadOrStore(peerID.PublicKeyHex(), struct{}{}); verifying {
+			continue
+		}
+
+		verifying := net.Go(Subsystem, func() {
+			defer state.verifying.Delete(peerID.PublicKeyHex())
+
+			if err := verifyPeer(state.context(), net, peerID, state.QueryTimeout); err != nil {
+				net.Log("discovery").Debug().
+					Err(err).
+					Str("peer_address", peerID.Address).
+					Msg("Discarded unverifiable peer.")
+				return
+			}
+
+			state.Routes.Update(peerID)
+		})
+
+		// Peers are discarded unverified whilst discovery is at its goroutine
+		// cap, until learned of again.
+		if !verifying {
+			state.verifying.Delete(peerID.PublicKeyHex())
+		}
+	}
+}
+
+// verifyPeer dials back a peer on its advertised address, and checks that it
+// answers a ping under its advertised ID.
+func verifyPeer(ctx context.Context, net *network.Network, peerID peer.ID, timeout time.Duration) error {
+	client, err := net.Client(peerID.Address)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if err := ping(ctx, client); err != nil {
+		return err
+	}
+
+	if id := client.ID(); id == nil || !id.Equals(peerID) {
+		return errors.Errorf("discovery: peer at %s does not hold its advertised ID", peerID.Address)
+	}
+
+	return nil
+}
+
+// ping pings a peer, and checks that it answers with a pong echoing the ping's
+// challenge. The challenge is withdrawn should the peer not answer it, so that
+// neither failed pings pile up challenges nor late pongs answer them.
+func ping(ctx context.Context, client *network.PeerClient) error {
+	nonce := client.NewChallenge()
+	defer client.WithdrawChallenge(nonce)
+
+	res, err := client.Request(ctx, &protobuf.Ping{
+		Record: selfRecord(client.Network),
+		Nonce:  nonce,
+		Stamp:  client.Network.NewStamp(nonce),
+	})
+	if err != nil {
+		return err
+	}
+
+	if pong, ok := res.(*protobuf.Pong); !ok || !client.VerifyChallenge(pong.Nonce) {
+		return errors.Errorf("discovery: peer at %s did not answer ping challenge", client.Address)
+	}
+
+	return nil
+}
+
+// checkStamp verifies the proof-of-work stamp of an incoming ping or lookup.
+// All other messages do not carry stamps.
+func checkStamp(ctx *network.PluginContext) bool {
+	switch msg := ctx.Message().(type) {
+	case *protobuf.Ping:
+		return ctx.Network().CheckStamp(ctx.Sender(), msg.Nonce, msg.Stamp)
+	case *protobuf.LookupNodeRequest:
+		if msg.Target == nil {
+			return false
+		}
+		return ctx.Network().CheckStamp(ctx.Sender(), msg.Target.Id, msg.Stamp)
+	}
+	return true
+}
@@ -0,0 +1,145 @@
+package network
+
+import (
+	"github.com/perlin-network/noise/internal/protobuf"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrSelfSend is returned upon a node dialing or sending to itself.
+	ErrSelfSend = errors.New("network: peer should not dial itself")
+	// ErrPeerUnreachable is returned upon a peer failing to be dialed, or a
+	// message failing to be written to a peer.
+	ErrPeerUnreachable = errors.New("network: peer is unreachable")
+	// ErrHandshakeFailed is returned upon a handshake with a peer failing,
+	// such as whilst bootstrapping.
+	ErrHandshakeFailed = errors.New("network: handshake with peer failed")
+	// ErrHopLimit is returned upon relaying a message which was already
+	// relayed as many times as permitted by MaxHops.
+	ErrHopLimit = errors.New("network: message exceeded its hop limit")
+	// ErrNoQuorum is returned upon too few peers agreeing on the response to
+	// a request issued through QuorumRequest.
+	ErrNoQuorum = errors.New("network: too few peers agreed on a response")
+	// ErrUnresolved is returned upon no address being resolved for the peer
+	// owning a public key.
+	ErrUnresolved = errors.New("network: no address resolved for peer")
+	// ErrPeerRefused is returned upon a peer being refused by a plugin
+	// implementing PeerFilter, such as for its key having been revoked.
+	ErrPeerRefused = errors.New("network: peer was refused")
+	// ErrAttestationFailed is returned upon a peer presenting no attestation
+	// evidence, or evidence which failed to be verified.
+	ErrAttestationFailed = errors.New("network: peer failed attestation")
+	// ErrTooManyRequests is returned upon issuing a request whilst too many
+	// requests await a response from the peer, or across all peers, as
+	// limited by MaxPendingRequests.
+	ErrTooManyRequests = errors.New("network: too many requests await a response")
+)
+
+// PeerError is an error which occurred communicating with a peer. It matches
+// its Kind under errors.Is, and unwraps to the error which caused it.
+//
+//	if errors.Is(err, network.ErrPeerUnreachable) {
+//		...
+//	}
+type PeerError struct {
+	// Kind is the sentinel error classifying the error, such as
+	// ErrPeerUnreachable.
+	Kind error
+	// Address is the address of the peer.
+	Address string
+	// Err is the error which caused it, if any.
+	Err error
+}
+
+func peerError(kind error, address string, err error) *PeerError {
+	return &PeerError{Kind: kind, Address: address, Err: err}
+}
+
+func (e *PeerError) Error() string {
+	if e.Err == nil {
+		return e.Kind.Error() + " (" + e.Address + ")"
+	}
+	return e.Kind.Error() + " (" + e.Address + "): " + e.Err.Error()
+}
+
+// Is reports whether target is the Kind of the error.
+func (e *PeerError) Is(target error) bool {
+	return target == e.Kind
+}
+
+// Unwrap returns the error which caused it.
+func (e *PeerError) Unwrap() error {
+	return e.Err
+}
+
+// Cause returns the error which caused it, or its Kind should there be none,
+// such that errors.Cause of github.com/pkg/errors resolves the root cause.
+func (e *PeerError) Cause() error {
+	if e.Err == nil {
+		return e.Kind
+	}
+	return e.Err
+}
+
+// ErrorCode is a protocol error code a node may reply to a request with, such
+// that the requester fails fast rather than timing out.
+type ErrorCode uint32
+
+const (
+	// CodeUnknownService is replied to requests no plugin serves, such as
+	// requests of an unregistered opcode.
+	CodeUnknownService ErrorCode = 1 + iota
+	// CodePayloadTooLarge is replied to requests too large to be served.
+	CodePayloadTooLarge
+	// CodeRateLimited is replied to requests refused for the requester
+	// exceeding a rate limit.
+	CodeRateLimited
+	// CodeShuttingDown is replied to requests received whilst the node shuts
+	// down.
+	CodeShuttingDown
+)
+
+var (
+	// ErrUnknownService is returned by Request upon the peer replying with
+	// CodeUnknownService.
+	ErrUnknownService = errors.New("network: peer does not serve the request")
+	// ErrPayloadTooLarge is returned by Request upon the peer replying with
+	// CodePayloadTooLarge.
+	ErrPayloadTooLarge = errors.New("network: request is too large for peer")
+	// ErrRateLimited is returned by Request upon the peer replying with
+	// CodeRateLimited.
+	ErrRateLimited = errors.New("network: peer rate limited the request")
+	// ErrShuttingDown is returned by Request upon the peer replying with
+	// CodeShuttingDown.
+	ErrShuttingDown = errors.New("network: peer is shutting down")
+	// ErrRemote is returned by Request upon the peer replying with an error
+	// code not known to this node.
+	ErrRemote = errors.New("network: peer failed to serve the request")
+)
+
+// errorCodes maps error codes to the sentinel errors returned by Request.
+var errorCodes = map[ErrorCode]error{
+	CodeUnknownService:  ErrUnknownService,
+	CodePayloadTooLarge: ErrPayloadTooLarge,
+	CodeRateLimited:     ErrRateLimited,
+	CodeShuttingDown:    ErrShuttingDown,
+}
+
+// Err returns the sentinel error of an error code.
+func (c ErrorCode) Err() error {
+	if err, exists := errorCodes[c]; exists {
+		return err
+	}
+	return ErrRemote
+}
+
+// remoteError converts an error reply from a peer into a *PeerError.
+func remoteError(address string, reply *protobuf.ErrorReply) error {
+	var err error
+	if reply.Message != "" {
+		err = errors.New(reply.Message)
+	}
+
+	return peerError(ErrorCode(reply.Code).Err(), address, err)
+}
@@ -0,0 +1,56 @@
+package network
+
+import (
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/perlin-network/noise/internal/protobuf"
+)
+
+func TestPeerError(t *testing.T) {
+	t.Parallel()
+
+	timeout := &net.OpError{Op: "dial", Net: "tcp", Err: &net.DNSError{IsTimeout: true}}
+	err := peerError(ErrHandshakeFailed, "tcp://localhost:3000", peerError(ErrPeerUnreachable, "tcp://localhost:3000", timeout))
+
+	if !errors.Is(err, ErrHandshakeFailed) || !errors.Is(err, ErrPeerUnreachable) {
+		t.Fatalf("expected %v to match all kinds it wraps", err)
+	}
+
+	if errors.Is(err, ErrSelfSend) {
+		t.Fatalf("expected %v to not match kinds it does not wrap", err)
+	}
+
+	var opErr *net.OpError
+	if !errors.As(err, &opErr) {
+		t.Fatalf("expected %v to unwrap to its cause", err)
+	}
+
+	if cause := classifyHandshakeFailure(err); cause != FailureTimeout {
+		t.Fatalf("expected %v to be classified as a timeout, got %s", err, cause)
+	}
+
+	expected := "network: handshake with peer failed (tcp://localhost:3000): network: peer is unreachable (tcp://localhost:3000): " + timeout.Error()
+	if err.Error() != expected {
+		t.Fatalf("expected %q, got %q", expected, err.Error())
+	}
+}
+
+func TestRemoteError(t *testing.T) {
+	t.Parallel()
+
+	for code, expected := range map[ErrorCode]error{
+		CodeUnknownService:  ErrUnknownService,
+		CodePayloadTooLarge: ErrPayloadTooLarge,
+		CodeRateLimited:     ErrRateLimited,
+		CodeShuttingDown:    ErrShuttingDown,
+		ErrorCode(1 << 16):  ErrRemote,
+	} {
+		err := remoteError("tcp://localhost:3000", &protobuf.ErrorReply{Code: uint32(code)})
+
+		if !errors.Is(err, expected) {
+			t.Errorf("remoteError(%d) = expected %v, got %v", code, expected, err)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+// Package fault injects faults into the frames a node sends, such as dropping,
+// duplicating, truncating or delaying them, such that tests and soak runs may
+// exercise how protocols cope with misbehaving links.
+//
+// A policy is set on a node through network.Faults:
+//
+//	policy := fault.Chain(fault.DropEvery(10), fault.Delay(50*time.Millisecond))
+//	builder := network.NewBuilderWithOptions(network.Faults(policy))
+package fault
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/perlin-network/noise/network/capture"
+)
+
+// Fault describes the faults to inject into a frame about to be sent. The
+// zero value sends the frame untouched.
+type Fault struct {
+	// Drop silently discards the frame.
+	Drop bool
+	// Duplicates is how many extra copies of the frame are sent.
+	Duplicates int
+	// Truncate, if positive, cuts the frame down to its first Truncate bytes,
+	// corrupting the stream the frame is sent over.
+	Truncate int
+	// Delay is how long sending the frame is held back for.
+	Delay time.Duration
+}
+
+// Policy decides upon the faults to inject into frames about to be sent.
+type Policy interface {
+	Inject(frame *capture.Frame) Fault
+}
+
+// PolicyFunc adapts a function into a policy.
+type PolicyFunc func(frame *capture.Frame) Fault
+
+// Inject calls f(frame).
+func (f PolicyFunc) Inject(frame *capture.Frame) Fault {
+	return f(frame)
+}
+
+// every returns a function which returns true for every nth call.
+func every(n int) func() bool {
+	var count uint64
+
+	return func() bool {
+		return n > 0 && atomic.AddUint64(&count, 1)%uint64(n) == 0
+	}
+}
+
+// DropEvery drops every nth frame.
+func DropEvery(n int) Policy {
+	due := every(n)
+
+	return PolicyFunc(func(*capture.Frame) Fault {
+		return Fault{Drop: due()}
+	})
+}
+
+// DuplicateEvery sends every nth frame twice.
+func DuplicateEvery(n int) Policy {
+	due := every(n)
+
+	return PolicyFunc(func(*capture.Frame) Fault {
+		if due() {
+			return Fault{Duplicates: 1}
+		}
+		return Fault{}
+	})
+}
+
+// TruncateEvery cuts every nth frame down to its first size bytes.
+func TruncateEvery(n int, size int) Policy {
+	due := every(n)
+
+	return PolicyFunc(func(*capture.Frame) Fault {
+		if due() {
+			return Fault{Truncate: size}
+		}
+		return Fault{}
+	})
+}
+
+// Delay holds back sending every frame by d.
+func Delay(d time.Duration) Policy {
+	return PolicyFunc(func(*capture.Frame) Fault {
+		return Fault{Delay: d}
+	})
+}
+
+// Chain combines the faults of several policies. A frame is dropped should
+// any policy drop it, duplicates and delays add up, and the shortest
+// truncation wins.
+func Chain(policies ...Policy) Policy {
+	return PolicyFunc(func(frame *capture.Frame) Fault {
+		var combined Fault
+
+		for _, policy := range policies {
+			f := policy.Inject(frame)
+
+			combined.Drop = combined.Drop || f.Drop
+			combined.Duplicates += f.Duplicates
+			combined.Delay += f.Delay
+
+			if f.Truncate > 0 && (combined.Truncate == 0 || f.Truncate < combined.Truncate) {
+				combined.Truncate = f.Truncate
+			}
+		}
+
+		return combined
+	})
+}
+
+// Only injects the faults of a policy into frames passing all filters, such
+// as to only disrupt a particular opcode or peer.
+func Only(policy Policy, filters ...capture.Filter) Policy {
+	return PolicyFunc(func(frame *capture.Frame) Fault {
+		for _, filter := range filters {
+			if !filter(frame) {
+				return Fault{}
+			}
+		}
+		return policy.Inject(frame)
+	})
+}
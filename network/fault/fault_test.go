@@ -0,0 +1,116 @@
+package fault_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/perlin-network/noise/internal/test"
+	"github.com/perlin-network/noise/internal/test/protobuf"
+	"github.com/perlin-network/noise/network"
+	"github.com/perlin-network/noise/network/capture"
+	"github.com/perlin-network/noise/network/fault"
+	"github.com/perlin-network/noise/types/opcode"
+)
+
+func init() {
+	opcode.RegisterMessageType(opcode.Opcode(1000), &protobuf.TestMessage{})
+}
+
+// inbox records the test messages it receives in order.
+type inbox struct {
+	*network.Plugin
+
+	mutex    sync.Mutex
+	messages []string
+}
+
+func (p *inbox) Receive(ctx *network.PluginContext) error {
+	if msg, ok := ctx.Message().(*protobuf.TestMessage); ok {
+		p.mutex.Lock()
+		p.messages = append(p.messages, msg.Message)
+		p.mutex.Unlock()
+	}
+	return nil
+}
+
+func (p *inbox) received() []string {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return append([]string(nil), p.messages...)
+}
+
+func TestPolicies(t *testing.T) {
+	t.Parallel()
+
+	policy := fault.Chain(fault.DropEvery(2), fault.DuplicateEvery(3), fault.TruncateEvery(4, 8), fault.TruncateEvery(2, 6), fault.Delay(time.Millisecond))
+
+	var faults []fault.Fault
+	for i := 0; i < 4; i++ {
+		faults = append(faults, policy.Inject(&capture.Frame{}))
+	}
+
+	expected := []fault.Fault{
+		{Delay: time.Millisecond},
+		{Drop: true, Truncate: 6, Delay: time.Millisecond},
+		{Duplicates: 1, Delay: time.Millisecond},
+		{Drop: true, Truncate: 6, Delay: time.Millisecond},
+	}
+
+	if fmt.Sprint(faults) != fmt.Sprint(expected) {
+		t.Fatalf("expected faults %v, got %v", expected, faults)
+	}
+}
+
+func TestOnly(t *testing.T) {
+	t.Parallel()
+
+	policy := fault.Only(fault.DropEvery(1), capture.Opcodes(1000))
+
+	if policy.Inject(&capture.Frame{Opcode: 1}).Drop {
+		t.Fatal("expected frames not passing all filters to be left untouched")
+	}
+
+	if !policy.Inject(&capture.Frame{Opcode: 1000}).Drop {
+		t.Fatal("expected frames passing all filters to be dropped")
+	}
+}
+
+func TestDropEvery(t *testing.T) {
+	if testing.Short() {
+		t.Skipf("skipping %s in short mode", t.Name())
+	}
+
+	receiver := new(inbox)
+	net := test.BuildNode(t, nil, receiver)
+	defer net.Close()
+
+	sender := test.BuildNode(t, []network.BuilderOption{network.Faults(fault.Only(fault.DropEvery(2), capture.Opcodes(1000)))}, new(inbox))
+	defer sender.Close()
+
+	client, err := sender.Client(net.Address)
+	if err != nil {
+		t.Fatalf("Client() = expected no error, got %v", err)
+	}
+
+	for i := 0; i < 4; i++ {
+		if err := client.Tell(context.Background(), &protobuf.TestMessage{Message: fmt.Sprint(i)}); err != nil {
+			t.Fatalf("Tell() = expected no error, got %v", err)
+		}
+	}
+
+	time.Sleep(300 * time.Millisecond)
+
+	received := receiver.received()
+	if len(received) == 0 {
+		t.Fatal("expected messages which were not dropped to be received")
+	}
+
+	for _, message := range received {
+		if message != "0" && message != "2" {
+			t.Fatalf("expected every second message to be dropped, got %v", received)
+		}
+	}
+}
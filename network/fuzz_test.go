@@ -0,0 +1,84 @@
+package network
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"testing"
+
+	"github.com/perlin-network/noise/internal/protobuf"
+	"github.com/perlin-network/noise/types/opcode"
+
+	"github.com/gogo/protobuf/proto"
+)
+
+// frame prefixes a body with its length, as it is written to a stream.
+func frame(body []byte) []byte {
+	buffer := make([]byte, 4, 4+len(body))
+	binary.BigEndian.PutUint32(buffer, uint32(len(body)))
+	return append(buffer, body...)
+}
+
+func FuzzReadFrame(f *testing.F) {
+	net, err := buildNetwork(port)
+	if err != nil {
+		f.Fatal(err)
+	}
+
+	msg, err := net.PrepareMessage(WithSignMessage(context.Background(), true), &protobuf.Ping{})
+	if err != nil {
+		f.Fatal(err)
+	}
+
+	raw, err := proto.Marshal(msg)
+	if err != nil {
+		f.Fatal(err)
+	}
+
+	f.Add(frame(raw))
+	f.Add(frame(raw)[:len(raw)/2])
+	f.Add([]byte{0, 0, 0, 0})
+	f.Add([]byte{0xff, 0xff, 0xff, 0xff})
+	f.Add([]byte{})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		_, body, err := readFrame(bytes.NewReader(data))
+		if err != nil {
+			return
+		}
+
+		if len(body) == 0 || len(body) > maxMessageSize {
+			t.Fatalf("readFrame() returned a body of invalid length %d", len(body))
+		}
+
+		msg := new(protobuf.Message)
+		if err := proto.Unmarshal(body, msg); err != nil {
+			return
+		}
+
+		if err := net.verifyMessage(msg); err != nil {
+			return
+		}
+
+		decodeMessageBody(opcode.Opcode(msg.Opcode), msg.Message)
+	})
+}
+
+func FuzzDecodeMessageBody(f *testing.F) {
+	ping, err := proto.Marshal(&protobuf.Ping{})
+	if err != nil {
+		f.Fatal(err)
+	}
+
+	f.Add(uint32(opcode.PingCode), ping)
+	f.Add(uint32(opcode.PingCode), []byte{0xff})
+	f.Add(uint32(opcode.UnregisteredCode), []byte{})
+	f.Add(uint32(0xffffffff), []byte{0x0a, 0x00})
+
+	f.Fuzz(func(t *testing.T, code uint32, body []byte) {
+		ptr, err := decodeMessageBody(opcode.Opcode(code), body)
+		if err == nil && ptr == nil {
+			t.Fatalf("decodeMessageBody() returned neither a message nor an error")
+		}
+	})
+}
@@ -0,0 +1,185 @@
+package group
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+
+	"github.com/perlin-network/noise/crypto/ed25519"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/nacl/box"
+	"golang.org/x/crypto/nacl/secretbox"
+)
+
+// ID identifies a group by the hex-encoded public key of its owner and its
+// name, which is unique to its owner.
+type ID struct {
+	Owner string
+	Name  string
+}
+
+func (id ID) String() string {
+	return id.Owner + "/" + id.Name
+}
+
+// group is the state of a group held by one of its members or its owner.
+type group struct {
+	id    ID
+	owned bool
+
+	// Members of the group: public key hex -> public key.
+	members map[string][]byte
+
+	// Keys of the current and previous epoch, such that messages encrypted
+	// just before a rotation may still be decrypted.
+	epoch uint64
+	keys  map[uint64]*[32]byte
+
+	// Epoch of the key last delivered to every member, for groups owned.
+	delivered map[string]uint64
+}
+
+// rotate generates a new key for the group, and forgets all keys but those of
+// the new and previous epoch.
+func (g *group) rotate() error {
+	key := new([32]byte)
+	if _, err := io.ReadFull(rand.Reader, key[:]); err != nil {
+		return errors.Wrap(err, "group: failed to generate key")
+	}
+
+	g.setKey(g.epoch+1, key)
+	return nil
+}
+
+// setKey sets the key of an epoch as the current one.
+func (g *group) setKey(epoch uint64, key *[32]byte) {
+	for held := range g.keys {
+		if held+1 < epoch {
+			delete(g.keys, held)
+		}
+	}
+
+	g.epoch = epoch
+	g.keys[epoch] = key
+}
+
+// memberList returns the public keys of all members.
+func (g *group) memberList() [][]byte {
+	members := make([][]byte, 0, len(g.members))
+	for _, member := range g.members {
+		members = append(members, member)
+	}
+	return members
+}
+
+func newGroup(id ID, members [][]byte) *group {
+	g := &group{
+		id:        id,
+		members:   make(map[string][]byte),
+		keys:      make(map[uint64]*[32]byte),
+		delivered: make(map[string]uint64),
+	}
+
+	for _, member := range members {
+		g.members[hex.EncodeToString(member)] = member
+	}
+
+	return g
+}
+
+func randomNonce() (*[24]byte, error) {
+	nonce := new([24]byte)
+	if _, err := io.ReadFull(rand.Reader, nonce[:]); err != nil {
+		return nil, errors.Wrap(err, "group: failed to generate nonce")
+	}
+	return nonce, nil
+}
+
+// curveKeys converts the public key of a peer and the private key of this node
+// into their X25519 counterparts.
+func curveKeys(publicKey []byte, privateKey []byte) (*[32]byte, *[32]byte, error) {
+	converted, err := ed25519.PublicKeyToCurve25519(publicKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var peerKey, selfKey [32]byte
+	copy(peerKey[:], converted)
+
+	converted, err = ed25519.PrivateKeyToCurve25519(privateKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	copy(selfKey[:], converted)
+
+	return &peerKey, &selfKey, nil
+}
+
+// sealKey seals a group key to a member, such that only the member may open it
+// and tell it was sealed by this node.
+func sealKey(key *[32]byte, member []byte, privateKey []byte) (sealed []byte, nonce []byte, err error) {
+	peerKey, selfKey, err := curveKeys(member, privateKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	n, err := randomNonce()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return box.Seal(nil, key[:], n, peerKey, selfKey), n[:], nil
+}
+
+// openKey opens a group key sealed to this node by the owner of a group.
+func openKey(sealed []byte, nonce []byte, owner []byte, privateKey []byte) (*[32]byte, error) {
+	if len(nonce) != 24 {
+		return nil, errors.New("group: malformed nonce")
+	}
+
+	peerKey, selfKey, err := curveKeys(owner, privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	var n [24]byte
+	copy(n[:], nonce)
+
+	opened, ok := box.Open(nil, sealed, &n, peerKey, selfKey)
+	if !ok || len(opened) != 32 {
+		return nil, errors.New("group: failed to open sealed key")
+	}
+
+	key := new([32]byte)
+	copy(key[:], opened)
+
+	return key, nil
+}
+
+// encrypt encrypts data with a group key.
+func encrypt(data []byte, key *[32]byte) (ciphertext []byte, nonce []byte, err error) {
+	n, err := randomNonce()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return secretbox.Seal(nil, data, n, key), n[:], nil
+}
+
+// decrypt decrypts data encrypted with a group key.
+func decrypt(ciphertext []byte, nonce []byte, key *[32]byte) ([]byte, error) {
+	if len(nonce) != 24 {
+		return nil, errors.New("group: malformed nonce")
+	}
+
+	var n [24]byte
+	copy(n[:], nonce)
+
+	data, ok := secretbox.Open(nil, ciphertext, &n, key)
+	if !ok {
+		return nil, errors.New("group: failed to decrypt message")
+	}
+
+	return data, nil
+}
@@ -0,0 +1,464 @@
+// Package group provides private multicast among groups of nodes.
+//
+// A group is created by its owner, who adds and removes members by their
+// public key. Every member holds a key shared by the group, which the owner
+// seals to each member individually using X25519 keys derived from the ed25519
+// keys of the owner and the member. Messages sent to the group are encrypted
+// once with the group key, and the same ciphertext is fanned out to every
+// member connected to.
+//
+// The owner rotates the group key whenever members are added or removed, such
+// that removed members may not read messages sent thereafter, and new members
+// may not read messages sent before they joined. Members not connected to the
+// owner upon a rotation receive the new key once they next message the owner.
+package group
+
+import (
+	"context"
+	"encoding/hex"
+	"sync"
+
+	"github.com/perlin-network/noise/internal/protobuf"
+	"github.com/perlin-network/noise/network"
+	"github.com/perlin-network/noise/peer"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrNotStarted is returned upon using groups of a plugin which has yet to
+	// start up.
+	ErrNotStarted = errors.New("group: plugin has not started")
+	// ErrGroupExists is returned upon creating a group under a name already
+	// taken.
+	ErrGroupExists = errors.New("group: group already exists")
+	// ErrUnknownGroup is returned upon using a group this node is not a member
+	// of.
+	ErrUnknownGroup = errors.New("group: not a member of the group")
+	// ErrNotOwner is returned upon managing the members of a group this node
+	// does not own.
+	ErrNotOwner = errors.New("group: not the owner of the group")
+)
+
+// Plugin manages the groups a node owns or is a member of.
+type Plugin struct {
+	*network.Plugin
+
+	// OnMessage is called with every message sent to a group by one of its
+	// members, alongside the public key of the member.
+	OnMessage func(id ID, sender []byte, data []byte)
+
+	// Connected peers: address -> *network.PeerClient.
+	clients sync.Map
+
+	mutex  sync.Mutex
+	net    *network.Network
+	groups map[ID]*group
+}
+
+var (
+	// PluginID is used to check existence of the group plugin.
+	PluginID                         = (*Plugin)(nil)
+	_        network.PluginInterface = (*Plugin)(nil)
+)
+
+// PluginOption are configurable options for the group plugin.
+type PluginOption func(*Plugin)
+
+// WithOnMessage sets the callback called with every message sent to a group.
+func WithOnMessage(fn func(id ID, sender []byte, data []byte)) PluginOption {
+	return func(p *Plugin) {
+		p.OnMessage = fn
+	}
+}
+
+// New returns a new group plugin with specified options.
+func New(opts ...PluginOption) *Plugin {
+	p := new(Plugin)
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+func (p *Plugin) Startup(net *network.Network) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.net = net
+	if p.groups == nil {
+		p.groups = make(map[ID]*group)
+	}
+}
+
+func (p *Plugin) PeerConnect(client *network.PeerClient) {
+	p.clients.Store(client.Address, client)
+}
+
+func (p *Plugin) PeerDisconnect(client *network.PeerClient) {
+	p.clients.Delete(client.Address)
+}
+
+func (p *Plugin) Receive(ctx *network.PluginContext) error {
+	sender := ctx.Sender()
+
+	switch msg := ctx.Message().(type) {
+	case *protobuf.GroupKey:
+		p.receiveKey(sender, msg)
+	case *protobuf.GroupMessage:
+		p.receiveMessage(sender, msg)
+	}
+
+	// Deliver keys the peer missed whilst not connected to us.
+	p.deliver(ctx.Client())
+
+	return nil
+}
+
+// self returns the network of the node, or ErrNotStarted.
+func (p *Plugin) self() (*network.Network, error) {
+	if p.net == nil {
+		return nil, ErrNotStarted
+	}
+	return p.net, nil
+}
+
+// Create creates a group owned by this node under a name, and distributes its
+// key to its members.
+func (p *Plugin) Create(name string, members ...[]byte) (ID, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	net, err := p.self()
+	if err != nil {
+		return ID{}, err
+	}
+
+	id := ID{Owner: net.ID.PublicKeyHex(), Name: name}
+	if _, exists := p.groups[id]; exists {
+		return ID{}, ErrGroupExists
+	}
+
+	g := newGroup(id, members)
+	g.owned = true
+
+	// The owner is not a member of its own group.
+	delete(g.members, id.Owner)
+
+	if err := g.rotate(); err != nil {
+		return ID{}, err
+	}
+
+	p.groups[id] = g
+	p.distribute(g, nil)
+
+	return id, nil
+}
+
+// AddMembers adds members to a group owned by this node, and rotates its key.
+func (p *Plugin) AddMembers(id ID, members ...[]byte) error {
+	return p.update(id, func(g *group) [][]byte {
+		for _, member := range members {
+			if key := hex.EncodeToString(member); key != g.id.Owner {
+				g.members[key] = member
+			}
+		}
+		return nil
+	})
+}
+
+// RemoveMembers removes members from a group owned by this node, and rotates
+// its key. Removed members are notified that they left the group.
+func (p *Plugin) RemoveMembers(id ID, members ...[]byte) error {
+	return p.update(id, func(g *group) [][]byte {
+		var removed [][]byte
+		for _, member := range members {
+			key := hex.EncodeToString(member)
+			if _, exists := g.members[key]; exists {
+				delete(g.members, key)
+				delete(g.delivered, key)
+				removed = append(removed, member)
+			}
+		}
+		return removed
+	})
+}
+
+// Rotate rotates the key of a group owned by this node.
+func (p *Plugin) Rotate(id ID) error {
+	return p.update(id, func(g *group) [][]byte {
+		return nil
+	})
+}
+
+// update updates the members of a group owned by this node, rotates its key,
+// and distributes the new key to its members.
+func (p *Plugin) update(id ID, fn func(g *group) (removed [][]byte)) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if _, err := p.self(); err != nil {
+		return err
+	}
+
+	g, exists := p.groups[id]
+	if !exists {
+		return ErrUnknownGroup
+	}
+
+	if !g.owned {
+		return ErrNotOwner
+	}
+
+	removed := fn(g)
+
+	if err := g.rotate(); err != nil {
+		return err
+	}
+
+	p.distribute(g, removed)
+
+	return nil
+}
+
+// Members returns the public keys of all members of a group, excluding its
+// owner.
+func (p *Plugin) Members(id ID) ([][]byte, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	g, exists := p.groups[id]
+	if !exists {
+		return nil, ErrUnknownGroup
+	}
+
+	return g.memberList(), nil
+}
+
+// Groups returns the IDs of all groups this node owns or is a member of.
+func (p *Plugin) Groups() []ID {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	ids := make([]ID, 0, len(p.groups))
+	for id := range p.groups {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// SendToGroup encrypts data once with the key of a group, and sends it to the
+// owner and all members of the group this node is connected to.
+func (p *Plugin) SendToGroup(ctx context.Context, id ID, data []byte) error {
+	owner, err := hex.DecodeString(id.Owner)
+	if err != nil {
+		return errors.Wrap(err, "group: malformed owner")
+	}
+
+	p.mutex.Lock()
+
+	net, err := p.self()
+	if err != nil {
+		p.mutex.Unlock()
+		return err
+	}
+
+	g, exists := p.groups[id]
+	if !exists {
+		p.mutex.Unlock()
+		return ErrUnknownGroup
+	}
+
+	epoch, key := g.epoch, g.keys[g.epoch]
+
+	recipients := make(map[string]struct{}, len(g.members)+1)
+	for member := range g.members {
+		recipients[member] = struct{}{}
+	}
+	recipients[id.Owner] = struct{}{}
+	delete(recipients, net.ID.PublicKeyHex())
+
+	p.mutex.Unlock()
+
+	ciphertext, nonce, err := encrypt(data, key)
+	if err != nil {
+		return err
+	}
+
+	var ids []peer.ID
+	p.eachReady(func(client *network.PeerClient) {
+		peerID := client.ID()
+		if _, exists := recipients[peerID.PublicKeyHex()]; exists {
+			ids = append(ids, *peerID)
+		}
+	})
+
+	net.BroadcastByIDs(network.WithSignMessage(ctx, true), &protobuf.GroupMessage{
+		Owner:      owner,
+		Group:      id.Name,
+		Epoch:      epoch,
+		Nonce:      nonce,
+		Ciphertext: ciphertext,
+	}, ids...)
+
+	return nil
+}
+
+// eachReady calls fn with every connected peer whose ID is known.
+func (p *Plugin) eachReady(fn func(client *network.PeerClient)) {
+	p.clients.Range(func(_, value interface{}) bool {
+		if client := value.(*network.PeerClient); client.ID() != nil {
+			fn(client)
+		}
+		return true
+	})
+}
+
+// distribute seals the current key of a group owned by this node to every
+// member connected to, and notifies removed members connected to that they
+// left the group. It must be called with the mutex held.
+func (p *Plugin) distribute(g *group, removed [][]byte) {
+	notified := make(map[string]struct{}, len(removed))
+	for _, member := range removed {
+		notified[hex.EncodeToString(member)] = struct{}{}
+	}
+
+	p.eachReady(func(client *network.PeerClient) {
+		key := client.ID().PublicKeyHex()
+
+		if _, exists := notified[key]; exists {
+			p.tell(client, &protobuf.GroupKey{Group: g.id.Name, Epoch: g.epoch, Members: g.memberList()})
+			return
+		}
+
+		p.deliverTo(g, client)
+	})
+}
+
+// deliver delivers the current keys of all groups owned by this node a peer
+// is a member of, should it not have received them yet.
+func (p *Plugin) deliver(client *network.PeerClient) {
+	if client.ID() == nil {
+		return
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	for _, g := range p.groups {
+		if g.owned {
+			p.deliverTo(g, client)
+		}
+	}
+}
+
+// deliverTo seals the current key of a group owned by this node to a peer,
+// should it be a member which has not received it yet. It must be called with
+// the mutex held.
+func (p *Plugin) deliverTo(g *group, client *network.PeerClient) {
+	key := client.ID().PublicKeyHex()
+
+	member, exists := g.members[key]
+	if !exists || g.delivered[key] >= g.epoch {
+		return
+	}
+
+	sealed, nonce, err := sealKey(g.keys[g.epoch], member, p.net.GetKeys().PrivateKey)
+	if err != nil {
+		p.net.Log("group").Warn().Err(err).Str("group", g.id.String()).Str("peer_address", client.Address).Msg("Failed to seal group key.")
+		return
+	}
+
+	if p.tell(client, &protobuf.GroupKey{
+		Group:   g.id.Name,
+		Epoch:   g.epoch,
+		Nonce:   nonce,
+		Sealed:  sealed,
+		Members: g.memberList(),
+	}) {
+		g.delivered[key] = g.epoch
+	}
+}
+
+// tell sends a message to a peer, and returns whether or not it was sent.
+func (p *Plugin) tell(client *network.PeerClient, msg *protobuf.GroupKey) bool {
+	if err := client.Tell(network.WithSignMessage(context.Background(), true), msg); err != nil {
+		p.net.Log("group").Debug().Err(err).Str("peer_address", client.Address).Msg("Failed to send group key to peer.")
+		return false
+	}
+	return true
+}
+
+// receiveKey handles a group key sent by the owner of a group.
+func (p *Plugin) receiveKey(sender peer.ID, msg *protobuf.GroupKey) {
+	id := ID{Owner: sender.PublicKeyHex(), Name: msg.Group}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.net == nil || id.Owner == p.net.ID.PublicKeyHex() {
+		return
+	}
+
+	g, exists := p.groups[id]
+	if exists && msg.Epoch <= g.epoch {
+		return
+	}
+
+	// Keys are withheld from members removed from the group.
+	if len(msg.Sealed) == 0 {
+		delete(p.groups, id)
+		return
+	}
+
+	key, err := openKey(msg.Sealed, msg.Nonce, sender.PublicKey, p.net.GetKeys().PrivateKey)
+	if err != nil {
+		p.net.Log("group").Warn().Err(err).Str("group", id.String()).Msg("Failed to open group key.")
+		return
+	}
+
+	members := newGroup(id, msg.Members).members
+	if exists {
+		g.members = members
+	} else {
+		g = newGroup(id, nil)
+		g.members = members
+		p.groups[id] = g
+	}
+
+	g.setKey(msg.Epoch, key)
+}
+
+// receiveMessage handles a message sent to a group by one of its members.
+func (p *Plugin) receiveMessage(sender peer.ID, msg *protobuf.GroupMessage) {
+	id := ID{Owner: hex.EncodeToString(msg.Owner), Name: msg.Group}
+
+	p.mutex.Lock()
+
+	g, exists := p.groups[id]
+	if !exists {
+		p.mutex.Unlock()
+		return
+	}
+
+	_, member := g.members[sender.PublicKeyHex()]
+	key := g.keys[msg.Epoch]
+
+	p.mutex.Unlock()
+
+	if (!member && sender.PublicKeyHex() != id.Owner) || key == nil {
+		return
+	}
+
+	data, err := decrypt(msg.Ciphertext, msg.Nonce, key)
+	if err != nil {
+		p.net.Log("group").Warn().Err(err).Str("group", id.String()).Msg("Failed to decrypt group message.")
+		return
+	}
+
+	if p.OnMessage != nil {
+		p.OnMessage(id, sender.PublicKey, data)
+	}
+}
@@ -0,0 +1,137 @@
+package group
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/perlin-network/noise/internal/test"
+	"github.com/perlin-network/noise/network"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// inbox records the messages received by a node.
+type inbox chan string
+
+func buildNode(t *testing.T) (*network.Network, *Plugin, inbox) {
+	received := make(inbox, 16)
+
+	plugin := New(WithOnMessage(func(id ID, sender []byte, data []byte) {
+		received <- id.Name + ":" + string(data)
+	}))
+
+	return test.BuildNode(t, nil, plugin), plugin, received
+}
+
+func connect(t *testing.T, a, b *network.Network) {
+	a.Bootstrap(b.Address)
+	b.Bootstrap(a.Address)
+
+	waitFor(t, "nodes to connect", func() bool {
+		return a.ConnectionStateExists(b.Address) && b.ConnectionStateExists(a.Address)
+	})
+}
+
+func waitFor(t *testing.T, description string, cond func() bool) {
+	deadline := time.Now().Add(5 * time.Second)
+
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", description)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+func expect(t *testing.T, received inbox, message string) {
+	select {
+	case got := <-received:
+		assert.Equal(t, message, got)
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for %q", message)
+	}
+}
+
+func expectNothing(t *testing.T, received inbox) {
+	select {
+	case got := <-received:
+		t.Fatalf("expected no message, got %q", got)
+	case <-time.After(300 * time.Millisecond):
+	}
+}
+
+func member(p *Plugin, id ID) func() bool {
+	return func() bool {
+		_, err := p.Members(id)
+		return err == nil
+	}
+}
+
+func TestNotStarted(t *testing.T) {
+	_, err := New().Create("friends")
+	assert.Equal(t, ErrNotStarted, err)
+}
+
+func TestGroup(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping group test in short mode")
+	}
+
+	owner, ownerPlugin, ownerInbox := buildNode(t)
+	alice, alicePlugin, aliceInbox := buildNode(t)
+	bob, bobPlugin, bobInbox := buildNode(t)
+	eve, _, eveInbox := buildNode(t)
+
+	defer owner.Close()
+	defer alice.Close()
+	defer bob.Close()
+	defer eve.Close()
+
+	nodes := []*network.Network{owner, alice, bob, eve}
+	for i := range nodes {
+		for j := i + 1; j < len(nodes); j++ {
+			connect(t, nodes[i], nodes[j])
+		}
+	}
+
+	// Messages sent before the connections are ready on both ends are dropped.
+	time.Sleep(300 * time.Millisecond)
+
+	id, err := ownerPlugin.Create("friends", alice.ID.PublicKey, bob.ID.PublicKey)
+	assert.NoError(t, err)
+
+	_, err = ownerPlugin.Create("friends")
+	assert.Equal(t, ErrGroupExists, err)
+
+	waitFor(t, "members to receive the group key", func() bool {
+		return member(alicePlugin, id)() && member(bobPlugin, id)()
+	})
+
+	assert.NoError(t, alicePlugin.SendToGroup(context.Background(), id, []byte("hello")))
+	expect(t, ownerInbox, "friends:hello")
+	expect(t, bobInbox, "friends:hello")
+	expectNothing(t, eveInbox)
+
+	assert.Equal(t, ErrNotOwner, alicePlugin.Rotate(id), "expected members to not manage groups they do not own")
+
+	// Removed members are notified, and may not read messages sent thereafter.
+	assert.NoError(t, ownerPlugin.RemoveMembers(id, bob.ID.PublicKey))
+	waitFor(t, "removed member to leave the group", func() bool {
+		return !member(bobPlugin, id)()
+	})
+
+	waitFor(t, "remaining member to receive the rotated key", func() bool {
+		alicePlugin.mutex.Lock()
+		defer alicePlugin.mutex.Unlock()
+		return alicePlugin.groups[id].epoch == 2
+	})
+
+	assert.NoError(t, ownerPlugin.SendToGroup(context.Background(), id, []byte("secret")))
+	expect(t, aliceInbox, "friends:secret")
+	expectNothing(t, bobInbox)
+
+	members, err := ownerPlugin.Members(id)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{alice.ID.PublicKey}, members)
+}
@@ -0,0 +1,142 @@
+package network
+
+import (
+	"context"
+	"net"
+	"sync"
+
+	"github.com/perlin-network/noise/audit"
+	"github.com/perlin-network/noise/peer"
+
+	"github.com/pkg/errors"
+)
+
+// maxHandshakeFailureAddresses bounds how many remote addresses handshake
+// failures are counted for individually, such that peers cycling through
+// addresses may not grow the counters without bound. Failures past the bound
+// are only counted towards totals.
+const maxHandshakeFailureAddresses = 1024
+
+// HandshakeFailure classifies why a handshake with a peer failed.
+type HandshakeFailure string
+
+const (
+	// FailureTimeout is a handshake which timed out.
+	FailureTimeout HandshakeFailure = "timeout"
+	// FailureBadPuzzle is a handshake whose proof-of-work stamp is invalid.
+	FailureBadPuzzle HandshakeFailure = "bad_puzzle"
+	// FailureVersionMismatch is a handshake with a peer speaking an
+	// incompatible protocol version.
+	FailureVersionMismatch HandshakeFailure = "version_mismatch"
+	// FailureIdentityMismatch is a handshake with a peer whose ID differs from
+	// the one it connected with.
+	FailureIdentityMismatch HandshakeFailure = "identity_mismatch"
+	// FailureCrypto is a handshake with a peer whose signature does not verify.
+	FailureCrypto HandshakeFailure = "crypto_error"
+	// FailureRefused is a handshake with a peer refused by a plugin, such as
+	// for its key having been revoked.
+	FailureRefused HandshakeFailure = "refused"
+	// FailureAttestation is a handshake with a peer which presented no
+	// attestation evidence, or evidence which failed to be verified.
+	FailureAttestation HandshakeFailure = "attestation"
+	// FailureOther is a handshake which failed for any other reason, such as
+	// the peer refusing connections.
+	FailureOther HandshakeFailure = "other"
+)
+
+// HandshakeFailureStats counts handshake failures by cause.
+type HandshakeFailureStats struct {
+	// Total counts failures across all remote addresses.
+	Total map[HandshakeFailure]uint64 `json:"total"`
+	// ByAddress counts failures per remote address.
+	ByAddress map[string]map[HandshakeFailure]uint64 `json:"by_address"`
+}
+
+// handshakeFailures counts handshake failures by cause. The zero value is
+// ready for use.
+type handshakeFailures struct {
+	sync.Mutex
+	total     map[HandshakeFailure]uint64
+	byAddress map[string]map[HandshakeFailure]uint64
+}
+
+func (h *handshakeFailures) record(cause HandshakeFailure, address string) {
+	h.Lock()
+	defer h.Unlock()
+
+	if h.total == nil {
+		h.total = make(map[HandshakeFailure]uint64)
+		h.byAddress = make(map[string]map[HandshakeFailure]uint64)
+	}
+
+	h.total[cause]++
+
+	counts, exists := h.byAddress[address]
+	if !exists {
+		if len(h.byAddress) >= maxHandshakeFailureAddresses {
+			return
+		}
+
+		counts = make(map[HandshakeFailure]uint64)
+		h.byAddress[address] = counts
+	}
+
+	counts[cause]++
+}
+
+func (h *handshakeFailures) snapshot() HandshakeFailureStats {
+	h.Lock()
+	defer h.Unlock()
+
+	stats := HandshakeFailureStats{
+		Total:     make(map[HandshakeFailure]uint64, len(h.total)),
+		ByAddress: make(map[string]map[HandshakeFailure]uint64, len(h.byAddress)),
+	}
+
+	for cause, count := range h.total {
+		stats.Total[cause] = count
+	}
+
+	for address, counts := range h.byAddress {
+		stats.ByAddress[address] = make(map[HandshakeFailure]uint64, len(counts))
+		for cause, count := range counts {
+			stats.ByAddress[address][cause] = count
+		}
+	}
+
+	return stats
+}
+
+// classifyHandshakeFailure classifies an error a handshake failed with.
+func classifyHandshakeFailure(err error) HandshakeFailure {
+	cause := errors.Cause(err)
+
+	if cause == context.DeadlineExceeded {
+		return FailureTimeout
+	}
+
+	if err, ok := cause.(net.Error); ok && err.Timeout() {
+		return FailureTimeout
+	}
+
+	return FailureOther
+}
+
+// RecordHandshakeFailure counts a failed handshake with a peer under a cause.
+// Plugins which take part in handshakes, such as by verifying proof-of-work
+// stamps, report their failures through it.
+func (n *Network) RecordHandshakeFailure(cause HandshakeFailure, address string) {
+	n.handshakeFailures.record(cause, address)
+}
+
+// HandshakeFailures returns a snapshot of the number of failed handshakes by
+// cause, in total and per remote address.
+func (n *Network) HandshakeFailures() HandshakeFailureStats {
+	return n.handshakeFailures.snapshot()
+}
+
+// handshakeFailed counts and audits a failed handshake with a peer.
+func (n *Network) handshakeFailed(ctx context.Context, cause HandshakeFailure, reason string, id peer.ID, err error) {
+	n.RecordHandshakeFailure(cause, id.Address)
+	n.AuditPeer(ctx, audit.HandshakeFailed, reason, id, err)
+}
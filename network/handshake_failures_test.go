@@ -0,0 +1,52 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestClassifyHandshakeFailure(t *testing.T) {
+	t.Parallel()
+
+	timeout := &net.OpError{Op: "dial", Net: "tcp", Err: &net.DNSError{IsTimeout: true}}
+
+	for err, expected := range map[error]HandshakeFailure{
+		context.DeadlineExceeded:                 FailureTimeout,
+		errors.Wrap(timeout, "failed to dial"):   FailureTimeout,
+		errors.New("connection refused"):         FailureOther,
+		errors.Wrap(context.Canceled, "aborted"): FailureOther,
+	} {
+		if cause := classifyHandshakeFailure(err); cause != expected {
+			t.Errorf("classifyHandshakeFailure(%v) = expected %s, got %s", err, expected, cause)
+		}
+	}
+}
+
+func TestHandshakeFailures(t *testing.T) {
+	t.Parallel()
+
+	var failures handshakeFailures
+
+	for i := 0; i < maxHandshakeFailureAddresses+1; i++ {
+		failures.record(FailureBadPuzzle, fmt.Sprintf("tcp://127.0.0.1:%d", i))
+	}
+	failures.record(FailureCrypto, "tcp://127.0.0.1:0")
+
+	stats := failures.snapshot()
+
+	if stats.Total[FailureBadPuzzle] != maxHandshakeFailureAddresses+1 || stats.Total[FailureCrypto] != 1 {
+		t.Fatalf("unexpected totals %v", stats.Total)
+	}
+
+	if len(stats.ByAddress) != maxHandshakeFailureAddresses {
+		t.Fatalf("expected failures to be counted for at most %d addresses, got %d", maxHandshakeFailureAddresses, len(stats.ByAddress))
+	}
+
+	if counts := stats.ByAddress["tcp://127.0.0.1:0"]; counts[FailureBadPuzzle] != 1 || counts[FailureCrypto] != 1 {
+		t.Fatalf("unexpected counts %v", counts)
+	}
+}
@@ -0,0 +1,87 @@
+package network
+
+import (
+	"context"
+	"sort"
+
+	"github.com/perlin-network/noise/internal/protobuf"
+)
+
+// Headers are metadata of extensions carried alongside messages, keyed by the
+// name of the extension they belong to.
+type Headers map[string][]byte
+
+// Middleware maintains headers of messages on behalf of applications, such as
+// causal clocks, without changing the format of their messages. Middleware
+// must be safe for concurrent use.
+type Middleware interface {
+	// Send sets the headers of a message about to be sent.
+	Send(ctx context.Context, headers Headers)
+	// Receive reads the headers of a message received from a peer before it
+	// is handed to plugins.
+	Receive(client *PeerClient, headers Headers)
+}
+
+// Middlewares returns a BuilderOption that registers middleware maintaining
+// the headers of all messages sent and received, in order (default: messages
+// carry no headers).
+func Middlewares(middleware ...Middleware) BuilderOption {
+	return func(o *options) {
+		o.middleware = append(append([]Middleware(nil), o.middleware...), middleware...)
+	}
+}
+
+// encodeHeaders encodes headers sorted by name, such that messages carrying
+// the same headers encode the same.
+func encodeHeaders(headers Headers) []*protobuf.Header {
+	if len(headers) == 0 {
+		return nil
+	}
+
+	encoded := make([]*protobuf.Header, 0, len(headers))
+	for name, value := range headers {
+		encoded = append(encoded, &protobuf.Header{Name: name, Value: value})
+	}
+
+	sort.Slice(encoded, func(i, j int) bool {
+		return encoded[i].Name < encoded[j].Name
+	})
+
+	return encoded
+}
+
+// decodeHeaders decodes headers. Should a name repeat, its last value holds.
+func decodeHeaders(encoded []*protobuf.Header) Headers {
+	headers := make(Headers, len(encoded))
+	for _, header := range encoded {
+		if header != nil {
+			headers[header.Name] = header.Value
+		}
+	}
+	return headers
+}
+
+// sendHeaders has all middleware set the headers of a message about to be
+// sent.
+func (n *Network) sendHeaders(ctx context.Context, msg *protobuf.Message) {
+	if len(n.opts.middleware) == 0 {
+		return
+	}
+
+	headers := make(Headers)
+	for _, middleware := range n.opts.middleware {
+		middleware.Send(ctx, headers)
+	}
+
+	msg.Headers = encodeHeaders(headers)
+}
+
+// receiveHeaders has all middleware read the headers of a message received
+// from a peer, and returns them.
+func (n *Network) receiveHeaders(client *PeerClient, msg *protobuf.Message) Headers {
+	headers := decodeHeaders(msg.Headers)
+	for _, middleware := range n.opts.middleware {
+		middleware.Receive(client, headers)
+	}
+	return headers
+}
@@ -0,0 +1,78 @@
+package network
+
+import (
+	"context"
+	"testing"
+
+	"github.com/perlin-network/noise/crypto/ed25519"
+	"github.com/perlin-network/noise/internal/protobuf"
+	"github.com/perlin-network/noise/types/opcode"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/uber-go/atomic"
+)
+
+// countingMiddleware stamps messages with a header, and counts the messages
+// received carrying it.
+type countingMiddleware struct {
+	name     string
+	received atomic.Int32
+}
+
+func (m *countingMiddleware) Send(ctx context.Context, headers Headers) {
+	headers[m.name] = []byte(m.name)
+}
+
+func (m *countingMiddleware) Receive(client *PeerClient, headers Headers) {
+	if _, ok := headers[m.name]; ok {
+		m.received.Inc()
+	}
+}
+
+// headerPlugin records the value of a header of the last message received.
+type headerPlugin struct {
+	*Plugin
+	name  string
+	value []byte
+}
+
+func (p *headerPlugin) Receive(ctx *PluginContext) error {
+	p.value, _ = ctx.Header(p.name)
+	return nil
+}
+
+func TestMiddlewareHeaders(t *testing.T) {
+	t.Parallel()
+
+	first, second := &countingMiddleware{name: "first"}, &countingMiddleware{name: "second"}
+	plugin := &headerPlugin{name: "second"}
+
+	builder := NewBuilderWithOptions(Middlewares(first), Middlewares(second))
+	builder.SetKeys(ed25519.RandomKeyPair())
+	listener, address, err := ListenOnRandomPort()
+	assert.Equal(t, nil, err)
+	builder.SetListener(listener)
+	builder.SetAddress(address)
+	assert.Equal(t, nil, builder.AddPlugin(plugin))
+
+	node, err := builder.Build()
+	assert.Equal(t, nil, err)
+	defer node.Close()
+
+	msg, err := node.PrepareMessage(context.Background(), &protobuf.Ping{})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []*protobuf.Header{
+		{Name: "first", Value: []byte("first")},
+		{Name: "second", Value: []byte("second")},
+	}, msg.Headers, "expected headers of all middleware sorted by name")
+
+	client, err := createPeerClient(node, "tcp://localhost:3000")
+	assert.Equal(t, nil, err)
+
+	msg.Opcode = uint32(opcode.PingCode)
+	node.prepareDispatch(client, msg)()
+
+	assert.Equal(t, int32(1), first.received.Load())
+	assert.Equal(t, int32(1), second.received.Load())
+	assert.Equal(t, []byte("second"), plugin.value, "expected plugins to read headers")
+}
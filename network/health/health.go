@@ -0,0 +1,254 @@
+// Package health reports whether a node is alive and ready to serve, such
+// that orchestrators like Kubernetes may probe it.
+//
+// Liveness covers whether the node's I/O loop is still responsive. Readiness
+// covers whether the node has bootstrapped and holds enough peers, alongside
+// the status of any subsystems registered by the application.
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/perlin-network/noise/network"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// defaultHeartbeatTimeout is how far the I/O loop heartbeat may lag behind
+	// before the node is considered unresponsive.
+	defaultHeartbeatTimeout = 5 * time.Second
+	// defaultMinPeers is how many peers a node must be connected to before it
+	// is considered ready.
+	defaultMinPeers = 1
+	// defaultCheckTimeout is how long a subsystem check may take.
+	defaultCheckTimeout = 5 * time.Second
+)
+
+// Checker reports the status of a subsystem, returning an error should the
+// subsystem be unhealthy.
+type Checker interface {
+	Check(ctx context.Context) error
+}
+
+// CheckerFunc is an adapter to allow the use of ordinary functions as checkers.
+type CheckerFunc func(ctx context.Context) error
+
+// Check calls f(ctx).
+func (f CheckerFunc) Check(ctx context.Context) error {
+	return f(ctx)
+}
+
+// Report is the result of a liveness or readiness probe.
+type Report struct {
+	// Healthy is true should all checks have passed.
+	Healthy bool `json:"healthy"`
+	// Checks holds the status of every check, being "ok" or the error the
+	// check failed with.
+	Checks map[string]string `json:"checks"`
+}
+
+// Health probes the liveness and readiness of a node.
+type Health struct {
+	net *network.Network
+
+	heartbeatTimeout time.Duration
+	minPeers         int
+	requireBootstrap bool
+	checkTimeout     time.Duration
+
+	mutex      sync.RWMutex
+	subsystems map[string]Checker
+}
+
+// Option configures a health prober.
+type Option func(*Health)
+
+// WithHeartbeatTimeout sets how far the I/O loop heartbeat may lag behind
+// before the node is considered unresponsive (default: 5 seconds).
+func WithHeartbeatTimeout(d time.Duration) Option {
+	return func(h *Health) {
+		h.heartbeatTimeout = d
+	}
+}
+
+// WithMinPeers sets how many peers a node must be connected to before it is
+// considered ready (default: 1).
+func WithMinPeers(n int) Option {
+	return func(h *Health) {
+		h.minPeers = n
+	}
+}
+
+// WithoutBootstrap has nodes be considered ready without having bootstrapped,
+// such as for seed nodes (default: bootstrapping is required).
+func WithoutBootstrap() Option {
+	return func(h *Health) {
+		h.requireBootstrap = false
+	}
+}
+
+// WithCheckTimeout sets how long a subsystem check may take (default: 5
+// seconds).
+func WithCheckTimeout(d time.Duration) Option {
+	return func(h *Health) {
+		h.checkTimeout = d
+	}
+}
+
+// New returns a health prober for a node.
+func New(net *network.Network, opts ...Option) *Health {
+	h := &Health{
+		net:              net,
+		heartbeatTimeout: defaultHeartbeatTimeout,
+		minPeers:         defaultMinPeers,
+		requireBootstrap: true,
+		checkTimeout:     defaultCheckTimeout,
+		subsystems:       make(map[string]Checker),
+	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h
+}
+
+// Register registers a subsystem whose status is to be reported as part of
+// readiness. Registering a subsystem under an existing name replaces it.
+func (h *Health) Register(name string, checker Checker) {
+	h.mutex.Lock()
+	h.subsystems[name] = checker
+	h.mutex.Unlock()
+}
+
+// Unregister removes a subsystem from being reported as part of readiness.
+func (h *Health) Unregister(name string) {
+	h.mutex.Lock()
+	delete(h.subsystems, name)
+	h.mutex.Unlock()
+}
+
+// Liveness reports whether the node is still responsive.
+func (h *Health) Liveness(ctx context.Context) Report {
+	return h.run(ctx, map[string]Checker{"heartbeat": CheckerFunc(h.checkHeartbeat)})
+}
+
+// Readiness reports whether the node has bootstrapped and holds enough peers,
+// alongside the status of all registered subsystems.
+func (h *Health) Readiness(ctx context.Context) Report {
+	checks := map[string]Checker{
+		"heartbeat": CheckerFunc(h.checkHeartbeat),
+		"peers":     CheckerFunc(h.checkPeers),
+	}
+
+	if h.requireBootstrap {
+		checks["bootstrap"] = CheckerFunc(h.checkBootstrap)
+	}
+
+	h.mutex.RLock()
+	for name, checker := range h.subsystems {
+		checks[name] = checker
+	}
+	h.mutex.RUnlock()
+
+	return h.run(ctx, checks)
+}
+
+// run runs all checks in parallel, each bound to the check timeout.
+func (h *Health) run(ctx context.Context, checks map[string]Checker) Report {
+	report := Report{Healthy: true, Checks: make(map[string]string, len(checks))}
+
+	var (
+		wg    sync.WaitGroup
+		mutex sync.Mutex
+	)
+
+	for name, checker := range checks {
+		wg.Add(1)
+
+		go func(name string, checker Checker) {
+			defer wg.Done()
+
+			ctx, cancel := context.WithTimeout(ctx, h.checkTimeout)
+			defer cancel()
+
+			status := "ok"
+			if err := checker.Check(ctx); err != nil {
+				status = err.Error()
+			}
+
+			mutex.Lock()
+			report.Checks[name] = status
+			if status != "ok" {
+				report.Healthy = false
+			}
+			mutex.Unlock()
+		}(name, checker)
+	}
+
+	wg.Wait()
+
+	return report
+}
+
+func (h *Health) checkHeartbeat(ctx context.Context) error {
+	if h.net.Closed() {
+		return errors.New("node is closed")
+	}
+
+	if lag := time.Since(h.net.Heartbeat()); lag > h.heartbeatTimeout {
+		return errors.Errorf("I/O loop last ticked %s ago", lag.Round(time.Millisecond))
+	}
+
+	return nil
+}
+
+func (h *Health) checkPeers(ctx context.Context) error {
+	if peers := h.net.QueueStats().Connections; peers < h.minPeers {
+		return errors.Errorf("connected to %d peer(s), need at least %d", peers, h.minPeers)
+	}
+	return nil
+}
+
+func (h *Health) checkBootstrap(ctx context.Context) error {
+	if !h.net.Bootstrapped() {
+		return errors.New("node has not bootstrapped")
+	}
+	return nil
+}
+
+// Handler returns a http.Handler serving probes for Kubernetes:
+//
+//	/healthz  liveness
+//	/readyz   readiness
+//
+// Probes respond with a JSON report, and a 503 status code should any check
+// have failed.
+func (h *Health) Handler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		writeReport(w, h.Liveness(r.Context()))
+	})
+
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		writeReport(w, h.Readiness(r.Context()))
+	})
+
+	return mux
+}
+
+func writeReport(w http.ResponseWriter, report Report) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	if !report.Healthy {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+
+	json.NewEncoder(w).Encode(report)
+}
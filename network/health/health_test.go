@@ -0,0 +1,92 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/perlin-network/noise/crypto/ed25519"
+	"github.com/perlin-network/noise/network"
+)
+
+func buildNetwork(t *testing.T) *network.Network {
+	builder := network.NewBuilder()
+	builder.SetKeys(ed25519.RandomKeyPair())
+	listener, address, err := network.ListenOnRandomPort()
+	if err != nil {
+		t.Fatal(err)
+	}
+	builder.SetListener(listener)
+	builder.SetAddress(address)
+
+	net, err := builder.Build()
+	if err != nil {
+		t.Fatalf("Build() = expected no error, got %v", err)
+	}
+	return net
+}
+
+func TestProbes(t *testing.T) {
+	t.Parallel()
+
+	net := buildNetwork(t)
+	h := New(net)
+
+	if report := h.Liveness(context.Background()); !report.Healthy {
+		t.Fatalf("Liveness() = expected a fresh node to be alive, got %v", report.Checks)
+	}
+
+	report := h.Readiness(context.Background())
+	if report.Healthy || report.Checks["peers"] == "ok" || report.Checks["bootstrap"] == "ok" {
+		t.Fatalf("Readiness() = expected a node without peers to not be ready, got %v", report.Checks)
+	}
+
+	h = New(net, WithMinPeers(0), WithoutBootstrap())
+	if report := h.Readiness(context.Background()); !report.Healthy {
+		t.Fatalf("Readiness() = expected node to be ready, got %v", report.Checks)
+	}
+
+	h.Register("store", CheckerFunc(func(ctx context.Context) error {
+		return errors.New("store is unreachable")
+	}))
+
+	report = h.Readiness(context.Background())
+	if report.Healthy || report.Checks["store"] != "store is unreachable" {
+		t.Fatalf("Readiness() = expected failing subsystem to be reported, got %v", report.Checks)
+	}
+
+	h.Unregister("store")
+	net.Close()
+
+	if report := h.Liveness(context.Background()); report.Healthy {
+		t.Fatal("Liveness() = expected a closed node to not be alive")
+	}
+}
+
+func TestHandler(t *testing.T) {
+	t.Parallel()
+
+	net := buildNetwork(t)
+	defer net.Close()
+
+	server := httptest.NewServer(New(net).Handler())
+	defer server.Close()
+
+	for path, expected := range map[string]int{"/healthz": http.StatusOK, "/readyz": http.StatusServiceUnavailable} {
+		res, err := server.Client().Get(server.URL + path)
+		if err != nil {
+			t.Fatalf("GET %s = expected no error, got %v", path, err)
+		}
+
+		var report Report
+		err = json.NewDecoder(res.Body).Decode(&report)
+		res.Body.Close()
+
+		if err != nil || res.StatusCode != expected || report.Healthy != (expected == http.StatusOK) {
+			t.Fatalf("GET %s = expected status %d, got %d (err: %v)", path, expected, res.StatusCode, err)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package network
+
+import (
+	"context"
+	"testing"
+
+	"github.com/perlin-network/noise/crypto/ed25519"
+	"github.com/perlin-network/noise/internal/protobuf"
+	"github.com/perlin-network/noise/types/opcode"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// hopPlugin relays every message received, recording the hops and path of the
+// message received and the message it relays.
+type hopPlugin struct {
+	*Plugin
+	hops    uint32
+	path    [][]byte
+	relayed *protobuf.Message
+	err     error
+}
+
+func (p *hopPlugin) Receive(ctx *PluginContext) error {
+	p.hops, p.path = ctx.Hops(), ctx.Path()
+	p.relayed, p.err = ctx.Network().PrepareMessage(ctx.Context(), &protobuf.Ping{})
+	return nil
+}
+
+func buildHopNode(t *testing.T, opts ...BuilderOption) (*Network, *hopPlugin) {
+	plugin := new(hopPlugin)
+
+	builder := NewBuilderWithOptions(opts...)
+	builder.SetKeys(ed25519.RandomKeyPair())
+	listener, address, err := ListenOnRandomPort()
+	assert.Equal(t, nil, err)
+	builder.SetListener(listener)
+	builder.SetAddress(address)
+	assert.Equal(t, nil, builder.AddPlugin(plugin))
+
+	node, err := builder.Build()
+	assert.Equal(t, nil, err)
+
+	return node, plugin
+}
+
+// dispatchRelay dispatches a message to a node as though it arrived from a
+// peer, and returns the message the node relays.
+func dispatchRelay(t *testing.T, node *Network, plugin *hopPlugin, msg *protobuf.Message) *protobuf.Message {
+	client, err := createPeerClient(node, "tcp://localhost:3000")
+	assert.Equal(t, nil, err)
+
+	msg.Opcode = uint32(opcode.PingCode)
+	node.prepareDispatch(client, msg)()
+
+	return plugin.relayed
+}
+
+func TestHopLimit(t *testing.T) {
+	t.Parallel()
+
+	node, plugin := buildHopNode(t, MaxHops(2))
+	defer node.Close()
+
+	msg, err := node.PrepareMessage(context.Background(), &protobuf.Ping{})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, uint32(0), msg.Hops, "expected messages originated by a node to not count any hop")
+	assert.Empty(t, msg.Path, "expected paths to not be recorded by default")
+
+	msg = dispatchRelay(t, node, plugin, msg)
+	assert.Equal(t, nil, plugin.err)
+	assert.Equal(t, uint32(1), msg.Hops)
+
+	msg = dispatchRelay(t, node, plugin, msg)
+	assert.Equal(t, nil, plugin.err)
+	assert.Equal(t, uint32(2), msg.Hops)
+
+	dispatchRelay(t, node, plugin, msg)
+	assert.Equal(t, uint32(2), plugin.hops)
+	assert.Equal(t, ErrHopLimit, plugin.err, "expected messages to not be relayed past the hop limit")
+
+	msg, err = node.PrepareMessage(withRelay(context.Background(), nil), &protobuf.Ping{})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, uint32(0), msg.Hops, "expected replies to not count any hop")
+}
+
+func TestRecordPaths(t *testing.T) {
+	t.Parallel()
+
+	origin, _ := buildHopNode(t, RecordPaths())
+	defer origin.Close()
+
+	forwarder, plugin := buildHopNode(t)
+	defer forwarder.Close()
+
+	msg, err := origin.PrepareMessage(context.Background(), &protobuf.Ping{})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, [][]byte{origin.ID.PublicKey}, msg.Path, "expected originators to start the path")
+
+	msg = dispatchRelay(t, forwarder, plugin, msg)
+	assert.Equal(t, [][]byte{origin.ID.PublicKey}, plugin.path)
+	assert.Equal(t, nil, plugin.err)
+	assert.Equal(t, [][]byte{origin.ID.PublicKey, forwarder.ID.PublicKey}, msg.Path, "expected forwarders to extend recorded paths")
+}
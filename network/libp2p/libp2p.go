@@ -0,0 +1,162 @@
+// Package libp2p provides helpers converting the identities and addresses of
+// noise peers to and from libp2p peer IDs and multiaddresses, such that peers
+// may be looked up consistently across both networks while services migrate
+// between them.
+//
+// The helpers convert identifiers only. Noise nodes may not dial libp2p hosts
+// nor accept their streams, as bridging streams and protocols requires a
+// libp2p host implementation, which this module does not depend upon.
+package libp2p
+
+import (
+	"bytes"
+	"math/big"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/perlin-network/noise/crypto/ed25519"
+	"github.com/perlin-network/noise/network"
+	"github.com/perlin-network/noise/peer"
+
+	"github.com/pkg/errors"
+)
+
+// keyPrefix prefixes the public key of a peer within a libp2p peer ID: an
+// identity multihash (0x00) of 36 bytes (0x24), over a protobuf-encoded
+// public key of type Ed25519 (0x08 0x01) holding 32 bytes (0x12 0x20).
+var keyPrefix = []byte{0x00, 0x24, 0x08, 0x01, 0x12, 0x20}
+
+// alphabet is the base58 alphabet peer IDs are encoded with.
+const alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+var (
+	// ErrUnsupportedPeerID is returned when a libp2p peer ID does not embed
+	// an ed25519 public key, such as peer IDs hashing RSA keys.
+	ErrUnsupportedPeerID = errors.New("libp2p: peer id does not embed an ed25519 public key")
+)
+
+// PeerID returns the libp2p peer ID of a peer holding an ed25519 public key.
+func PeerID(publicKey []byte) (string, error) {
+	if len(publicKey) != ed25519.PublicKeySize {
+		return "", errors.Errorf("libp2p: public key has length %d", len(publicKey))
+	}
+
+	return encode(append(append([]byte(nil), keyPrefix...), publicKey...)), nil
+}
+
+// PublicKey returns the ed25519 public key embedded within a libp2p peer ID.
+func PublicKey(id string) ([]byte, error) {
+	raw, err := decode(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(raw) != len(keyPrefix)+ed25519.PublicKeySize || !bytes.HasPrefix(raw, keyPrefix) {
+		return nil, ErrUnsupportedPeerID
+	}
+
+	return raw[len(keyPrefix):], nil
+}
+
+// Multiaddr returns the libp2p multiaddress a peer may be dialed at. Only TCP
+// addresses may be mapped.
+func Multiaddr(id peer.ID) (string, error) {
+	info, err := network.ParseAddress(id.Address)
+	if err != nil {
+		return "", err
+	}
+
+	if info.Protocol != "tcp" {
+		return "", errors.Errorf("libp2p: protocol %q has no multiaddress", info.Protocol)
+	}
+
+	peerID, err := PeerID(id.PublicKey)
+	if err != nil {
+		return "", err
+	}
+
+	family := "dns4"
+	if ip := net.ParseIP(info.Host); ip != nil {
+		family = "ip6"
+		if ip.To4() != nil {
+			family = "ip4"
+		}
+	}
+
+	return "/" + family + "/" + info.Host + "/tcp/" + strconv.Itoa(int(info.Port)) + "/p2p/" + peerID, nil
+}
+
+// FromMultiaddr returns the ID of a peer holding an ed25519 public key, dialed
+// at a TCP multiaddress such as /ip4/127.0.0.1/tcp/3000/p2p/12D3KooW....
+func FromMultiaddr(addr string) (peer.ID, error) {
+	parts := strings.Split(addr, "/")
+
+	if len(parts) != 7 || parts[0] != "" || parts[3] != "tcp" || parts[5] != "p2p" {
+		return peer.ID{}, errors.Errorf("libp2p: multiaddress %q is not of a peer dialed over TCP", addr)
+	}
+
+	switch parts[1] {
+	case "ip4", "ip6", "dns4", "dns6", "dns":
+	default:
+		return peer.ID{}, errors.Errorf("libp2p: multiaddress %q has unsupported protocol %q", addr, parts[1])
+	}
+
+	port, err := strconv.ParseUint(parts[4], 10, 16)
+	if err != nil {
+		return peer.ID{}, errors.Errorf("libp2p: multiaddress %q has invalid port %q", addr, parts[4])
+	}
+
+	publicKey, err := PublicKey(parts[6])
+	if err != nil {
+		return peer.ID{}, err
+	}
+
+	return peer.CreateID(network.FormatAddress("tcp", parts[2], uint16(port)), publicKey), nil
+}
+
+// encode encodes bytes in base58.
+func encode(raw []byte) string {
+	n := new(big.Int).SetBytes(raw)
+	base, mod := big.NewInt(58), new(big.Int)
+
+	var encoded []byte
+	for n.Sign() > 0 {
+		n.DivMod(n, base, mod)
+		encoded = append(encoded, alphabet[mod.Int64()])
+	}
+
+	// Leading zero bytes are encoded as leading ones.
+	for _, b := range raw {
+		if b != 0 {
+			break
+		}
+		encoded = append(encoded, alphabet[0])
+	}
+
+	for i, j := 0, len(encoded)-1; i < j; i, j = i+1, j-1 {
+		encoded[i], encoded[j] = encoded[j], encoded[i]
+	}
+
+	return string(encoded)
+}
+
+// decode decodes a base58 string.
+func decode(encoded string) ([]byte, error) {
+	n, base := new(big.Int), big.NewInt(58)
+
+	zeros := 0
+	for zeros < len(encoded) && encoded[zeros] == alphabet[0] {
+		zeros++
+	}
+
+	for _, c := range []byte(encoded) {
+		digit := bytes.IndexByte([]byte(alphabet), c)
+		if digit < 0 {
+			return nil, errors.Errorf("libp2p: invalid base58 character %q", c)
+		}
+		n.Mul(n, base).Add(n, big.NewInt(int64(digit)))
+	}
+
+	return append(make([]byte, zeros), n.Bytes()...), nil
+}
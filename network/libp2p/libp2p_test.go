@@ -0,0 +1,113 @@
+package libp2p
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/perlin-network/noise/crypto/ed25519"
+	"github.com/perlin-network/noise/peer"
+)
+
+func TestPeerID(t *testing.T) {
+	t.Parallel()
+
+	keys := ed25519.RandomKeyPair()
+
+	id, err := PeerID(keys.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Peer IDs embedding ed25519 keys share a common prefix once encoded.
+	if !strings.HasPrefix(id, "12D3KooW") {
+		t.Fatalf("PeerID() = %s, expected an ed25519 libp2p peer ID", id)
+	}
+
+	publicKey, err := PublicKey(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(publicKey, keys.PublicKey) {
+		t.Fatalf("PublicKey() = expected the public key to round trip through the peer ID")
+	}
+
+	if _, err := PeerID(keys.PublicKey[:8]); err == nil {
+		t.Fatalf("PeerID() = expected a truncated public key to be rejected")
+	}
+
+	// Peer IDs of RSA keys are sha256 multihashes, which embed no key.
+	if _, err := PublicKey("QmYyQSo1c1Ym7orWxLYvCrM2EmxFTANf8wXmmE7DWjhx5N"); err != ErrUnsupportedPeerID {
+		t.Fatalf("PublicKey() = expected ErrUnsupportedPeerID, got %v", err)
+	}
+
+	if _, err := PublicKey("0OIl"); err == nil {
+		t.Fatalf("PublicKey() = expected invalid base58 to be rejected")
+	}
+}
+
+func TestMultiaddr(t *testing.T) {
+	t.Parallel()
+
+	keys := ed25519.RandomKeyPair()
+
+	id, err := PeerID(keys.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string]string{
+		"tcp://127.0.0.1:3000": "/ip4/127.0.0.1/tcp/3000/p2p/" + id,
+		"tcp://[::1]:3000":     "/ip6/::1/tcp/3000/p2p/" + id,
+		"tcp://example.com:80": "/dns4/example.com/tcp/80/p2p/" + id,
+	}
+
+	for address, expected := range cases {
+		addr, err := Multiaddr(peer.CreateID(address, keys.PublicKey))
+		if err != nil {
+			t.Fatalf("Multiaddr(%s) = %v", address, err)
+		}
+
+		if addr != expected {
+			t.Fatalf("Multiaddr(%s) = %s, expected %s", address, addr, expected)
+		}
+	}
+
+	if _, err := Multiaddr(peer.CreateID("kcp://127.0.0.1:3000", keys.PublicKey)); err == nil {
+		t.Fatalf("Multiaddr() = expected KCP addresses to have no multiaddress")
+	}
+}
+
+func TestFromMultiaddr(t *testing.T) {
+	t.Parallel()
+
+	keys := ed25519.RandomKeyPair()
+
+	for _, address := range []string{"tcp://127.0.0.1:3000", "tcp://[::1]:3000", "tcp://example.com:80"} {
+		addr, err := Multiaddr(peer.CreateID(address, keys.PublicKey))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		id, err := FromMultiaddr(addr)
+		if err != nil {
+			t.Fatalf("FromMultiaddr(%s) = %v", addr, err)
+		}
+
+		if !id.Equals(peer.CreateID(address, keys.PublicKey)) || id.Address != address {
+			t.Fatalf("FromMultiaddr(%s) = %v, expected the ID to round trip through the multiaddress", addr, id)
+		}
+	}
+
+	for _, addr := range []string{
+		"/ip4/127.0.0.1/udp/3000/quic/p2p/12D3KooW",
+		"/ip4/127.0.0.1/tcp/99999/p2p/12D3KooW",
+		"/unix/tmp/tcp/3000/p2p/12D3KooW",
+		"/ip4/127.0.0.1/tcp/3000/p2p/QmYyQSo1c1Ym7orWxLYvCrM2EmxFTANf8wXmmE7DWjhx5N",
+	} {
+		if _, err := FromMultiaddr(addr); err == nil {
+			t.Fatalf("FromMultiaddr(%s) = expected an error", addr)
+		}
+	}
+}
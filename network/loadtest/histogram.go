@@ -0,0 +1,148 @@
+package loadtest
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// bounds are the upper bounds of histogram buckets, which grow by a quarter
+// from a microsecond up to a minute.
+var bounds = func() (bounds []time.Duration) {
+	for bound := float64(time.Microsecond); bound < float64(time.Minute); bound *= 1.25 {
+		bounds = append(bounds, time.Duration(bound))
+	}
+	return append(bounds, time.Minute)
+}()
+
+// Bucket is a histogram bucket counting latencies up to its upper bound.
+type Bucket struct {
+	UpperBound time.Duration `json:"upper_bound"`
+	Count      uint64        `json:"count"`
+}
+
+// Histogram counts latencies into exponentially sized buckets, such that
+// percentiles may be estimated without keeping every sample. Latencies beyond
+// a minute are counted within the last bucket.
+type Histogram struct {
+	mutex sync.Mutex
+
+	counts   []uint64
+	count    uint64
+	sum      time.Duration
+	min, max time.Duration
+}
+
+// NewHistogram returns an empty histogram.
+func NewHistogram() *Histogram {
+	return &Histogram{counts: make([]uint64, len(bounds))}
+}
+
+// Record counts a latency.
+func (h *Histogram) Record(latency time.Duration) {
+	if latency < 0 {
+		latency = 0
+	}
+
+	i := sort.Search(len(bounds), func(i int) bool { return bounds[i] >= latency })
+	if i == len(bounds) {
+		i--
+	}
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.counts[i]++
+	h.sum += latency
+
+	if h.count == 0 || latency < h.min {
+		h.min = latency
+	}
+
+	if latency > h.max {
+		h.max = latency
+	}
+
+	h.count++
+}
+
+// Count returns the number of latencies recorded.
+func (h *Histogram) Count() uint64 {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	return h.count
+}
+
+// Mean returns the mean latency recorded.
+func (h *Histogram) Mean() time.Duration {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if h.count == 0 {
+		return 0
+	}
+	return h.sum / time.Duration(h.count)
+}
+
+// Min returns the lowest latency recorded.
+func (h *Histogram) Min() time.Duration {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	return h.min
+}
+
+// Max returns the highest latency recorded.
+func (h *Histogram) Max() time.Duration {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	return h.max
+}
+
+// Quantile estimates the latency below which a fraction q of all latencies
+// recorded fall, as the upper bound of the bucket the quantile falls within.
+func (h *Histogram) Quantile(q float64) time.Duration {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if h.count == 0 {
+		return 0
+	}
+
+	rank := uint64(q*float64(h.count) + 0.5)
+	if rank < 1 {
+		rank = 1
+	}
+
+	var seen uint64
+
+	for i, count := range h.counts {
+		seen += count
+
+		if seen >= rank {
+			// The last bucket has no upper bound of its own.
+			if bounds[i] > h.max || i == len(bounds)-1 {
+				return h.max
+			}
+			return bounds[i]
+		}
+	}
+
+	return h.max
+}
+
+// Buckets returns all non-empty buckets in order of their upper bound.
+func (h *Histogram) Buckets() (buckets []Bucket) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	for i, count := range h.counts {
+		if count > 0 {
+			buckets = append(buckets, Bucket{UpperBound: bounds[i], Count: count})
+		}
+	}
+
+	return
+}
@@ -1,23 +1,15 @@
 // Code generated by protoc-gen-gogo. DO NOT EDIT.
-// source: examples/request_benchmark/messages/load.proto
+// source: network/loadtest/load.proto
 
-/*
-Package messages is a generated protocol buffer package.
-
-It is generated from these files:
-	examples/request_benchmark/messages/load.proto
-
-It has these top-level messages:
-	LoadRequest
-	LoadReply
-*/
-package messages
+package loadtest
 
 import proto "github.com/gogo/protobuf/proto"
 import fmt "fmt"
 import math "math"
 import _ "github.com/gogo/protobuf/gogoproto"
 
+import bytes "bytes"
+
 import strings "strings"
 import reflect "reflect"
 
@@ -35,38 +27,59 @@ var _ = math.Inf
 const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
 
 type LoadRequest struct {
-	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// sent is when the request was sent in nanoseconds since the unix epoch.
+	Sent int64 `protobuf:"varint,1,opt,name=sent,proto3" json:"sent,omitempty"`
+	// payload pads the request out to the size under test.
+	Payload []byte `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
+	// reply is whether the sender awaits a reply.
+	Reply bool `protobuf:"varint,3,opt,name=reply,proto3" json:"reply,omitempty"`
 }
 
 func (m *LoadRequest) Reset()                    { *m = LoadRequest{} }
 func (*LoadRequest) ProtoMessage()               {}
 func (*LoadRequest) Descriptor() ([]byte, []int) { return fileDescriptorLoad, []int{0} }
 
-func (m *LoadRequest) GetId() string {
+func (m *LoadRequest) GetSent() int64 {
+	if m != nil {
+		return m.Sent
+	}
+	return 0
+}
+
+func (m *LoadRequest) GetPayload() []byte {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (m *LoadRequest) GetReply() bool {
 	if m != nil {
-		return m.Id
+		return m.Reply
 	}
-	return ""
+	return false
 }
 
 type LoadReply struct {
-	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// sent is when the request replied to was sent in nanoseconds since the
+	// unix epoch.
+	Sent int64 `protobuf:"varint,1,opt,name=sent,proto3" json:"sent,omitempty"`
 }
 
 func (m *LoadReply) Reset()                    { *m = LoadReply{} }
 func (*LoadReply) ProtoMessage()               {}
 func (*LoadReply) Descriptor() ([]byte, []int) { return fileDescriptorLoad, []int{1} }
 
-func (m *LoadReply) GetId() string {
+func (m *LoadReply) GetSent() int64 {
 	if m != nil {
-		return m.Id
+		return m.Sent
 	}
-	return ""
+	return 0
 }
 
 func init() {
-	proto.RegisterType((*LoadRequest)(nil), "messages.LoadRequest")
-	proto.RegisterType((*LoadReply)(nil), "messages.LoadReply")
+	proto.RegisterType((*LoadRequest)(nil), "loadtest.LoadRequest")
+	proto.RegisterType((*LoadReply)(nil), "loadtest.LoadReply")
 }
 func (this *LoadRequest) VerboseEqual(that interface{}) error {
 	if that == nil {
@@ -93,8 +106,14 @@ func (this *LoadRequest) VerboseEqual(that interface{}) error {
 	} else if this == nil {
 		return fmt.Errorf("that is type *LoadRequest but is not nil && this == nil")
 	}
-	if this.Id != that1.Id {
-		return fmt.Errorf("Id this(%v) Not Equal that(%v)", this.Id, that1.Id)
+	if this.Sent != that1.Sent {
+		return fmt.Errorf("Sent this(%v) Not Equal that(%v)", this.Sent, that1.Sent)
+	}
+	if !bytes.Equal(this.Payload, that1.Payload) {
+		return fmt.Errorf("Payload this(%v) Not Equal that(%v)", this.Payload, that1.Payload)
+	}
+	if this.Reply != that1.Reply {
+		return fmt.Errorf("Reply this(%v) Not Equal that(%v)", this.Reply, that1.Reply)
 	}
 	return nil
 }
@@ -117,7 +136,13 @@ func (this *LoadRequest) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.Id != that1.Id {
+	if this.Sent != that1.Sent {
+		return false
+	}
+	if !bytes.Equal(this.Payload, that1.Payload) {
+		return false
+	}
+	if this.Reply != that1.Reply {
 		return false
 	}
 	return true
@@ -147,8 +172,8 @@ func (this *LoadReply) VerboseEqual(that interface{}) error {
 	} else if this == nil {
 		return fmt.Errorf("that is type *LoadReply but is not nil && this == nil")
 	}
-	if this.Id != that1.Id {
-		return fmt.Errorf("Id this(%v) Not Equal that(%v)", this.Id, that1.Id)
+	if this.Sent != that1.Sent {
+		return fmt.Errorf("Sent this(%v) Not Equal that(%v)", this.Sent, that1.Sent)
 	}
 	return nil
 }
@@ -171,7 +196,7 @@ func (this *LoadReply) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.Id != that1.Id {
+	if this.Sent != that1.Sent {
 		return false
 	}
 	return true
@@ -180,9 +205,11 @@ func (this *LoadRequest) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&messages.LoadRequest{")
-	s = append(s, "Id: "+fmt.Sprintf("%#v", this.Id)+",\n")
+	s := make([]string, 0, 7)
+	s = append(s, "&loadtest.LoadRequest{")
+	s = append(s, "Sent: "+fmt.Sprintf("%#v", this.Sent)+",\n")
+	s = append(s, "Payload: "+fmt.Sprintf("%#v", this.Payload)+",\n")
+	s = append(s, "Reply: "+fmt.Sprintf("%#v", this.Reply)+",\n")
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
@@ -191,8 +218,8 @@ func (this *LoadReply) GoString() string {
 		return "nil"
 	}
 	s := make([]string, 0, 5)
-	s = append(s, "&messages.LoadReply{")
-	s = append(s, "Id: "+fmt.Sprintf("%#v", this.Id)+",\n")
+	s = append(s, "&loadtest.LoadReply{")
+	s = append(s, "Sent: "+fmt.Sprintf("%#v", this.Sent)+",\n")
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
@@ -219,11 +246,26 @@ func (m *LoadRequest) MarshalTo(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
-	if len(m.Id) > 0 {
-		dAtA[i] = 0xa
+	if m.Sent != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintLoad(dAtA, i, uint64(m.Sent))
+	}
+	if len(m.Payload) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintLoad(dAtA, i, uint64(len(m.Payload)))
+		i += copy(dAtA[i:], m.Payload)
+	}
+	if m.Reply {
+		dAtA[i] = 0x18
+		i++
+		if m.Reply {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
 		i++
-		i = encodeVarintLoad(dAtA, i, uint64(len(m.Id)))
-		i += copy(dAtA[i:], m.Id)
 	}
 	return i, nil
 }
@@ -243,11 +285,10 @@ func (m *LoadReply) MarshalTo(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
-	if len(m.Id) > 0 {
-		dAtA[i] = 0xa
+	if m.Sent != 0 {
+		dAtA[i] = 0x8
 		i++
-		i = encodeVarintLoad(dAtA, i, uint64(len(m.Id)))
-		i += copy(dAtA[i:], m.Id)
+		i = encodeVarintLoad(dAtA, i, uint64(m.Sent))
 	}
 	return i, nil
 }
@@ -261,110 +302,27 @@ func encodeVarintLoad(dAtA []byte, offset int, v uint64) int {
 	dAtA[offset] = uint8(v)
 	return offset + 1
 }
-func NewPopulatedLoadRequest(r randyLoad, easy bool) *LoadRequest {
-	this := &LoadRequest{}
-	this.Id = string(randStringLoad(r))
-	if !easy && r.Intn(10) != 0 {
-	}
-	return this
-}
-
-func NewPopulatedLoadReply(r randyLoad, easy bool) *LoadReply {
-	this := &LoadReply{}
-	this.Id = string(randStringLoad(r))
-	if !easy && r.Intn(10) != 0 {
-	}
-	return this
-}
-
-type randyLoad interface {
-	Float32() float32
-	Float64() float64
-	Int63() int64
-	Int31() int32
-	Uint32() uint32
-	Intn(n int) int
-}
-
-func randUTF8RuneLoad(r randyLoad) rune {
-	ru := r.Intn(62)
-	if ru < 10 {
-		return rune(ru + 48)
-	} else if ru < 36 {
-		return rune(ru + 55)
-	}
-	return rune(ru + 61)
-}
-func randStringLoad(r randyLoad) string {
-	v1 := r.Intn(100)
-	tmps := make([]rune, v1)
-	for i := 0; i < v1; i++ {
-		tmps[i] = randUTF8RuneLoad(r)
-	}
-	return string(tmps)
-}
-func randUnrecognizedLoad(r randyLoad, maxFieldNumber int) (dAtA []byte) {
-	l := r.Intn(5)
-	for i := 0; i < l; i++ {
-		wire := r.Intn(4)
-		if wire == 3 {
-			wire = 5
-		}
-		fieldNumber := maxFieldNumber + r.Intn(100)
-		dAtA = randFieldLoad(dAtA, r, fieldNumber, wire)
-	}
-	return dAtA
-}
-func randFieldLoad(dAtA []byte, r randyLoad, fieldNumber int, wire int) []byte {
-	key := uint32(fieldNumber)<<3 | uint32(wire)
-	switch wire {
-	case 0:
-		dAtA = encodeVarintPopulateLoad(dAtA, uint64(key))
-		v2 := r.Int63()
-		if r.Intn(2) == 0 {
-			v2 *= -1
-		}
-		dAtA = encodeVarintPopulateLoad(dAtA, uint64(v2))
-	case 1:
-		dAtA = encodeVarintPopulateLoad(dAtA, uint64(key))
-		dAtA = append(dAtA, byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)))
-	case 2:
-		dAtA = encodeVarintPopulateLoad(dAtA, uint64(key))
-		ll := r.Intn(100)
-		dAtA = encodeVarintPopulateLoad(dAtA, uint64(ll))
-		for j := 0; j < ll; j++ {
-			dAtA = append(dAtA, byte(r.Intn(256)))
-		}
-	default:
-		dAtA = encodeVarintPopulateLoad(dAtA, uint64(key))
-		dAtA = append(dAtA, byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)))
-	}
-	return dAtA
-}
-func encodeVarintPopulateLoad(dAtA []byte, v uint64) []byte {
-	for v >= 1<<7 {
-		dAtA = append(dAtA, uint8(uint64(v)&0x7f|0x80))
-		v >>= 7
-	}
-	dAtA = append(dAtA, uint8(v))
-	return dAtA
-}
 func (m *LoadRequest) Size() (n int) {
 	var l int
 	_ = l
-	l = len(m.Id)
+	if m.Sent != 0 {
+		n += 1 + sovLoad(uint64(m.Sent))
+	}
+	l = len(m.Payload)
 	if l > 0 {
 		n += 1 + l + sovLoad(uint64(l))
 	}
+	if m.Reply {
+		n += 2
+	}
 	return n
 }
 
 func (m *LoadReply) Size() (n int) {
 	var l int
 	_ = l
-	l = len(m.Id)
-	if l > 0 {
-		n += 1 + l + sovLoad(uint64(l))
+	if m.Sent != 0 {
+		n += 1 + sovLoad(uint64(m.Sent))
 	}
 	return n
 }
@@ -387,7 +345,9 @@ func (this *LoadRequest) String() string {
 		return "nil"
 	}
 	s := strings.Join([]string{`&LoadRequest{`,
-		`Id:` + fmt.Sprintf("%v", this.Id) + `,`,
+		`Sent:` + fmt.Sprintf("%v", this.Sent) + `,`,
+		`Payload:` + fmt.Sprintf("%v", this.Payload) + `,`,
+		`Reply:` + fmt.Sprintf("%v", this.Reply) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -397,7 +357,7 @@ func (this *LoadReply) String() string {
 		return "nil"
 	}
 	s := strings.Join([]string{`&LoadReply{`,
-		`Id:` + fmt.Sprintf("%v", this.Id) + `,`,
+		`Sent:` + fmt.Sprintf("%v", this.Sent) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -440,10 +400,29 @@ func (m *LoadRequest) Unmarshal(dAtA []byte) error {
 		}
 		switch fieldNum {
 		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sent", wireType)
+			}
+			m.Sent = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLoad
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Sent |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Payload", wireType)
 			}
-			var stringLen uint64
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowLoad
@@ -453,21 +432,43 @@ func (m *LoadRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				byteLen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthLoad
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + byteLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Id = string(dAtA[iNdEx:postIndex])
+			m.Payload = append(m.Payload[:0], dAtA[iNdEx:postIndex]...)
+			if m.Payload == nil {
+				m.Payload = []byte{}
+			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Reply", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLoad
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Reply = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipLoad(dAtA[iNdEx:])
@@ -519,10 +520,10 @@ func (m *LoadReply) Unmarshal(dAtA []byte) error {
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sent", wireType)
 			}
-			var stringLen uint64
+			m.Sent = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowLoad
@@ -532,21 +533,11 @@ func (m *LoadReply) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				m.Sent |= (int64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthLoad
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Id = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipLoad(dAtA[iNdEx:])
@@ -673,20 +664,21 @@ var (
 	ErrIntOverflowLoad   = fmt.Errorf("proto: integer overflow")
 )
 
-func init() { proto.RegisterFile("examples/request_benchmark/messages/load.proto", fileDescriptorLoad) }
+func init() { proto.RegisterFile("network/loadtest/load.proto", fileDescriptorLoad) }
 
 var fileDescriptorLoad = []byte{
-	// 188 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xd2, 0x4b, 0xad, 0x48, 0xcc,
-	0x2d, 0xc8, 0x49, 0x2d, 0xd6, 0x2f, 0x4a, 0x2d, 0x2c, 0x4d, 0x2d, 0x2e, 0x89, 0x4f, 0x4a, 0xcd,
-	0x4b, 0xce, 0xc8, 0x4d, 0x2c, 0xca, 0xd6, 0xcf, 0x4d, 0x2d, 0x2e, 0x4e, 0x4c, 0x4f, 0x2d, 0xd6,
-	0xcf, 0xc9, 0x4f, 0x4c, 0xd1, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0xe2, 0x80, 0x09, 0x4a, 0x29,
-	0xa5, 0xe7, 0xa7, 0xe7, 0xeb, 0x83, 0x45, 0x93, 0x4a, 0xd3, 0xf4, 0x41, 0x3c, 0x30, 0x07, 0xcc,
-	0x82, 0xa8, 0x56, 0x92, 0xe5, 0xe2, 0xf6, 0xc9, 0x4f, 0x4c, 0x09, 0x82, 0x18, 0x2d, 0xc4, 0xc7,
-	0xc5, 0x94, 0x99, 0x22, 0xc1, 0xa8, 0xc0, 0xa8, 0xc1, 0x19, 0xc4, 0x94, 0x99, 0xa2, 0x24, 0xcd,
-	0xc5, 0x09, 0x91, 0x2e, 0xc8, 0xa9, 0x44, 0x97, 0x74, 0xd2, 0xb9, 0xf1, 0x50, 0x8e, 0xe1, 0xc1,
-	0x43, 0x39, 0xc6, 0x0f, 0x0f, 0xe5, 0x18, 0x7f, 0x3c, 0x94, 0x63, 0x6c, 0x78, 0x24, 0xc7, 0xb8,
-	0xe2, 0x91, 0x1c, 0xe3, 0x8e, 0x47, 0x72, 0x8c, 0x27, 0x1e, 0xc9, 0x31, 0x5e, 0x78, 0x24, 0xc7,
-	0xf8, 0xe0, 0x91, 0x1c, 0xe3, 0x84, 0xc7, 0x72, 0x0c, 0x49, 0x6c, 0x60, 0x0b, 0x8d, 0x01, 0x01,
-	0x00, 0x00, 0xff, 0xff, 0x41, 0xf9, 0x7e, 0xcb, 0xd0, 0x00, 0x00, 0x00,
+	// 195 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x92, 0xce, 0x4b, 0x2d, 0x29,
+	0xcf, 0x2f, 0xca, 0xd6, 0xcf, 0xc9, 0x4f, 0x4c, 0x29, 0x49, 0x2d, 0x2e, 0x01, 0x33, 0xf4, 0x0a,
+	0x8a, 0xf2, 0x4b, 0xf2, 0x85, 0x38, 0x60, 0x82, 0x52, 0x4a, 0xe9, 0xf9, 0xe9, 0xf9, 0xfa, 0x60,
+	0xd1, 0xa4, 0xd2, 0x34, 0x7d, 0x10, 0x0f, 0xcc, 0x01, 0xb3, 0x20, 0xaa, 0x95, 0x02, 0xb9, 0xb8,
+	0x7d, 0xf2, 0x13, 0x53, 0x82, 0x52, 0x0b, 0x4b, 0x53, 0x8b, 0x4b, 0x84, 0x84, 0xb8, 0x58, 0x8a,
+	0x53, 0xf3, 0x4a, 0x24, 0x18, 0x15, 0x18, 0x35, 0x98, 0x83, 0xc0, 0x6c, 0x21, 0x09, 0x2e, 0xf6,
+	0x82, 0xc4, 0x4a, 0x90, 0xa9, 0x12, 0x4c, 0x0a, 0x8c, 0x1a, 0x3c, 0x41, 0x30, 0xae, 0x90, 0x08,
+	0x17, 0x6b, 0x51, 0x6a, 0x41, 0x4e, 0xa5, 0x04, 0xb3, 0x02, 0xa3, 0x06, 0x47, 0x10, 0x84, 0xa3,
+	0x24, 0xcf, 0xc5, 0x09, 0x31, 0xb2, 0x20, 0xa7, 0x12, 0x9b, 0x81, 0x4e, 0x2a, 0x37, 0x1e, 0xca,
+	0x31, 0x3c, 0x78, 0x28, 0xc7, 0xf8, 0xe1, 0xa1, 0x1c, 0x63, 0xc3, 0x23, 0x39, 0xc6, 0x15, 0x8f,
+	0xe4, 0x18, 0x4f, 0x3c, 0x92, 0x63, 0xbc, 0xf0, 0x48, 0x8e, 0xf1, 0xc1, 0x23, 0x39, 0xc6, 0x09,
+	0x8f, 0xe5, 0x18, 0x92, 0xd8, 0xc0, 0x0e, 0x34, 0x06, 0x0c, 0x00, 0x2f, 0x6e, 0x0a, 0x6c, 0xed,
+	0x00, 0x00, 0x00,
 }
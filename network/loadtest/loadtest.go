@@ -0,0 +1,407 @@
+// Package loadtest spins up clusters of in-process nodes, drives traffic
+// between them under configurable patterns, and reports the throughput and
+// latencies observed.
+//
+//	report, err := loadtest.Run(ctx, 8,
+//		loadtest.WithPattern(loadtest.RequestReply),
+//		loadtest.WithDuration(10*time.Second),
+//	)
+//	...
+//	report.WriteJSON(os.Stdout)
+package loadtest
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/perlin-network/noise/crypto"
+	"github.com/perlin-network/noise/crypto/ed25519"
+	"github.com/perlin-network/noise/network"
+	"github.com/perlin-network/noise/network/transport"
+	"github.com/perlin-network/noise/types/opcode"
+
+	"github.com/pkg/errors"
+)
+
+// Opcodes load requests and replies are registered under, which are not to be
+// taken by messages of applications importing the package.
+const (
+	LoadRequestCode opcode.Opcode = 2000
+	LoadReplyCode   opcode.Opcode = 2001
+)
+
+func init() {
+	opcode.RegisterMessageType(LoadRequestCode, &LoadRequest{})
+	opcode.RegisterMessageType(LoadReplyCode, &LoadReply{})
+}
+
+// Pattern is a pattern of traffic driven between nodes.
+type Pattern int
+
+const (
+	// Uniform has every node send messages to peers picked uniformly at random.
+	Uniform Pattern = iota
+	// Hotspot has every node send most messages to a single node.
+	Hotspot
+	// RequestReply has every node send requests to peers picked uniformly at
+	// random, and await their replies.
+	RequestReply
+)
+
+var patternNames = map[Pattern]string{
+	Uniform:      "uniform",
+	Hotspot:      "hotspot",
+	RequestReply: "request-reply",
+}
+
+func (p Pattern) String() string {
+	if name, ok := patternNames[p]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// ParsePattern returns the pattern of a given name.
+func ParsePattern(name string) (Pattern, error) {
+	for pattern, other := range patternNames {
+		if name == other {
+			return pattern, nil
+		}
+	}
+	return 0, errors.Errorf("loadtest: unknown traffic pattern %q", name)
+}
+
+// Option configures a load test.
+type Option func(*options)
+
+type options struct {
+	pattern     Pattern
+	hotspot     float64
+	payloadSize int
+	rate        int
+	duration    time.Duration
+	timeout     time.Duration
+	drain       time.Duration
+	seed        int64
+	protocol    string
+	transport   func(i int) transport.Layer
+	keys        func(i int) *crypto.KeyPair
+	builderOpts []network.BuilderOption
+}
+
+var defaultOptions = options{
+	pattern:     Uniform,
+	hotspot:     0.8,
+	payloadSize: 64,
+	rate:        100,
+	duration:    10 * time.Second,
+	timeout:     3 * time.Second,
+	drain:       time.Second,
+	protocol:    "tcp",
+	keys: func(int) *crypto.KeyPair {
+		return ed25519.RandomKeyPair()
+	},
+}
+
+// WithPattern sets the pattern of traffic driven (default: Uniform).
+func WithPattern(pattern Pattern) Option {
+	return func(o *options) {
+		o.pattern = pattern
+	}
+}
+
+// WithHotspotRatio sets the fraction of messages sent to the hot node under
+// the Hotspot pattern (default: 0.8).
+func WithHotspotRatio(ratio float64) Option {
+	return func(o *options) {
+		o.hotspot = ratio
+	}
+}
+
+// WithPayloadSize sets the size in bytes of the payload of each message
+// (default: 64).
+func WithPayloadSize(size int) Option {
+	return func(o *options) {
+		o.payloadSize = size
+	}
+}
+
+// WithRate sets the number of messages each node sends per second, or
+// unthrottles nodes should it be zero (default: 100).
+func WithRate(rate int) Option {
+	return func(o *options) {
+		o.rate = rate
+	}
+}
+
+// WithDuration sets how long traffic is driven for (default: 10 seconds).
+func WithDuration(duration time.Duration) Option {
+	return func(o *options) {
+		o.duration = duration
+	}
+}
+
+// WithTimeout sets how long a request awaits its reply under the
+// RequestReply pattern (default: 3 seconds).
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.timeout = timeout
+	}
+}
+
+// WithDrain sets how long messages still in flight are waited on once traffic
+// stops being driven (default: 1 second).
+func WithDrain(drain time.Duration) Option {
+	return func(o *options) {
+		o.drain = drain
+	}
+}
+
+// WithSeed seeds the choice of peers messages are sent to (default:
+// randomness is not seeded).
+func WithSeed(seed int64) Option {
+	return func(o *options) {
+		o.seed = seed
+	}
+}
+
+// WithTransport has node i listen and dial over the transport layer returned
+// by layer under a given protocol name (default: TCP).
+func WithTransport(protocol string, layer func(i int) transport.Layer) Option {
+	return func(o *options) {
+		o.protocol = protocol
+		o.transport = layer
+	}
+}
+
+// WithKeys sets the keys of node i to those returned by keys (default: random
+// ed25519 keys).
+func WithKeys(keys func(i int) *crypto.KeyPair) Option {
+	return func(o *options) {
+		o.keys = keys
+	}
+}
+
+// WithBuilderOptions builds all nodes with the given options.
+func WithBuilderOptions(opts ...network.BuilderOption) Option {
+	return func(o *options) {
+		o.builderOpts = append(o.builderOpts, opts...)
+	}
+}
+
+// stats are the counters of a load test shared by all of its nodes.
+type stats struct {
+	sent, received, failed uint64
+	latency                *Histogram
+}
+
+// Run spins up n nodes, drives traffic between them until the configured
+// duration passes or ctx is cancelled, and returns a report of the traffic.
+func Run(ctx context.Context, n int, opts ...Option) (*Report, error) {
+	if n < 2 {
+		return nil, errors.New("loadtest: at least two nodes are required")
+	}
+
+	o := defaultOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if o.seed == 0 {
+		o.seed = time.Now().UnixNano()
+	}
+
+	s := &stats{latency: NewHistogram()}
+
+	nodes := make([]*network.Network, 0, n)
+	defer func() {
+		for _, node := range nodes {
+			node.Close()
+		}
+	}()
+
+	for i := 0; i < n; i++ {
+		node, err := startNode(i, &o, s)
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, node)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, o.duration)
+	defer cancel()
+
+	start := time.Now()
+
+	var wg sync.WaitGroup
+
+	for i := range nodes {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+			drive(ctx, nodes, i, &o, s)
+		}(i)
+	}
+
+	wg.Wait()
+
+	elapsed := time.Since(start)
+
+	// Wait on messages which are still in flight.
+	if o.pattern != RequestReply {
+		time.Sleep(o.drain)
+	}
+
+	report := &Report{
+		Pattern:     o.pattern.String(),
+		Nodes:       n,
+		PayloadSize: o.payloadSize,
+		Duration:    elapsed,
+		Sent:        atomic.LoadUint64(&s.sent),
+		Received:    atomic.LoadUint64(&s.received),
+		Failed:      atomic.LoadUint64(&s.failed),
+		Latency:     summarize(s.latency),
+	}
+
+	report.Throughput = float64(report.Received) / elapsed.Seconds()
+
+	return report, nil
+}
+
+// startNode builds node i and starts it listening on a random local port.
+func startNode(i int, o *options, s *stats) (*network.Network, error) {
+	builder := network.NewBuilderWithOptions(o.builderOpts...)
+	builder.SetKeys(o.keys(i))
+
+	// Nodes served over plain TCP are handed a listener bound ahead of time,
+	// such that no other process may claim their port before they listen.
+	if o.transport == nil && o.protocol == "tcp" {
+		listener, address, err := network.ListenOnRandomPort()
+		if err != nil {
+			return nil, errors.Wrapf(err, "loadtest: failed to listen for node %d", i)
+		}
+
+		builder.SetListener(listener)
+		builder.SetAddress(address)
+	} else {
+		builder.SetAddress(network.FormatAddress(o.protocol, "127.0.0.1", uint16(network.GetRandomUnusedPort())))
+	}
+
+	if o.transport != nil {
+		builder.RegisterTransportLayer(o.protocol, o.transport(i))
+	}
+
+	if err := builder.AddPlugin(&plugin{stats: s}); err != nil {
+		return nil, errors.Wrapf(err, "loadtest: failed to add plugin to node %d", i)
+	}
+
+	node, err := builder.Build()
+	if err != nil {
+		return nil, errors.Wrapf(err, "loadtest: failed to build node %d", i)
+	}
+
+	go node.Listen()
+	node.BlockUntilListening()
+
+	return node, nil
+}
+
+// drive sends messages from node i under the configured pattern until ctx is
+// done.
+func drive(ctx context.Context, nodes []*network.Network, i int, o *options, s *stats) {
+	random := rand.New(rand.NewSource(o.seed + int64(i)))
+	payload := make([]byte, o.payloadSize)
+	random.Read(payload)
+
+	var tick <-chan time.Time
+	if o.rate > 0 {
+		ticker := time.NewTicker(time.Second / time.Duration(o.rate))
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	for {
+		if tick != nil {
+			select {
+			case <-ctx.Done():
+				return
+			case <-tick:
+			}
+		} else if ctx.Err() != nil {
+			return
+		}
+
+		target := nodes[pickTarget(random, len(nodes), i, o)]
+
+		client, err := nodes[i].Client(target.Address)
+		if err != nil {
+			atomic.AddUint64(&s.failed, 1)
+			continue
+		}
+
+		atomic.AddUint64(&s.sent, 1)
+
+		req := &LoadRequest{Sent: time.Now().UnixNano(), Payload: payload}
+
+		if o.pattern != RequestReply {
+			if err := client.Tell(context.Background(), req); err != nil {
+				atomic.AddUint64(&s.failed, 1)
+			}
+			continue
+		}
+
+		req.Reply = true
+
+		reqCtx, cancel := context.WithTimeout(context.Background(), o.timeout)
+		res, err := client.Request(reqCtx, req)
+		cancel()
+
+		if _, ok := res.(*LoadReply); err != nil || !ok {
+			atomic.AddUint64(&s.failed, 1)
+			continue
+		}
+
+		atomic.AddUint64(&s.received, 1)
+		s.latency.Record(time.Since(time.Unix(0, req.Sent)))
+	}
+}
+
+// pickTarget picks the node which node i sends its next message to.
+func pickTarget(random *rand.Rand, n int, i int, o *options) int {
+	// Node 0 is the hot node, which spreads its own messages uniformly.
+	if o.pattern == Hotspot && i != 0 && random.Float64() < o.hotspot {
+		return 0
+	}
+
+	target := random.Intn(n - 1)
+	if target >= i {
+		target++
+	}
+	return target
+}
+
+// plugin records the latency of messages received, and replies to requests.
+type plugin struct {
+	*network.Plugin
+	stats *stats
+}
+
+func (p *plugin) Receive(ctx *network.PluginContext) error {
+	req, ok := ctx.Message().(*LoadRequest)
+	if !ok {
+		return nil
+	}
+
+	if req.Reply {
+		return ctx.Reply(context.Background(), &LoadReply{Sent: req.Sent})
+	}
+
+	atomic.AddUint64(&p.stats.received, 1)
+	p.stats.latency.Record(time.Since(time.Unix(0, req.Sent)))
+
+	return nil
+}
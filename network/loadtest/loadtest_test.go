@@ -0,0 +1,156 @@
+package loadtest
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestHistogram(t *testing.T) {
+	t.Parallel()
+
+	h := NewHistogram()
+
+	if h.Quantile(0.5) != 0 || h.Mean() != 0 {
+		t.Fatalf("expected an empty histogram to report no latencies")
+	}
+
+	for i := 1; i <= 100; i++ {
+		h.Record(time.Duration(i) * time.Millisecond)
+	}
+
+	if h.Count() != 100 {
+		t.Fatalf("Count() = %d, expected 100", h.Count())
+	}
+
+	if h.Min() != time.Millisecond || h.Max() != 100*time.Millisecond {
+		t.Fatalf("expected min and max of 1ms and 100ms, got %s and %s", h.Min(), h.Max())
+	}
+
+	if mean := h.Mean(); mean != 50500*time.Microsecond {
+		t.Fatalf("Mean() = %s, expected 50.5ms", mean)
+	}
+
+	// Buckets are a quarter wider than the last, which bounds the error of
+	// quantile estimates.
+	for _, q := range []float64{0.5, 0.9, 0.99} {
+		expected := time.Duration(q * float64(100*time.Millisecond))
+
+		if estimate := h.Quantile(q); estimate < expected || float64(estimate) > 1.25*float64(expected) {
+			t.Fatalf("Quantile(%v) = %s, expected within a quarter above %s", q, estimate, expected)
+		}
+	}
+
+	var total uint64
+	for _, bucket := range h.Buckets() {
+		total += bucket.Count
+	}
+
+	if total != 100 {
+		t.Fatalf("expected buckets to hold all 100 latencies, got %d", total)
+	}
+
+	h.Record(time.Hour)
+
+	if h.Quantile(1) != time.Hour {
+		t.Fatalf("expected the highest quantile to be clamped to the max latency")
+	}
+}
+
+func TestParsePattern(t *testing.T) {
+	t.Parallel()
+
+	for _, pattern := range []Pattern{Uniform, Hotspot, RequestReply} {
+		parsed, err := ParsePattern(pattern.String())
+		if err != nil || parsed != pattern {
+			t.Fatalf("ParsePattern(%q) = %v, %v", pattern.String(), parsed, err)
+		}
+	}
+
+	if _, err := ParsePattern("bogus"); err == nil {
+		t.Fatalf("expected parsing an unknown pattern to fail")
+	}
+}
+
+func TestRun(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping load test in short mode")
+	}
+
+	for _, pattern := range []Pattern{Uniform, Hotspot, RequestReply} {
+		report, err := Run(context.Background(), 3,
+			WithPattern(pattern),
+			WithDuration(time.Second),
+			WithRate(50),
+			WithPayloadSize(128),
+			WithSeed(1),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if report.Pattern != pattern.String() || report.Nodes != 3 || report.PayloadSize != 128 {
+			t.Fatalf("expected report to describe the load test, got %+v", report)
+		}
+
+		if report.Sent == 0 || report.Received == 0 {
+			t.Fatalf("%s: expected traffic to be driven, got %d sent and %d received", pattern, report.Sent, report.Received)
+		}
+
+		if report.Received+report.Failed > report.Sent {
+			t.Fatalf("%s: received %d and failed %d out of only %d sent", pattern, report.Received, report.Failed, report.Sent)
+		}
+
+		if report.Throughput <= 0 || report.Latency.Max <= 0 || len(report.Latency.Histogram) == 0 {
+			t.Fatalf("%s: expected throughput and latencies to be reported, got %+v", pattern, report)
+		}
+	}
+}
+
+func TestRunRequiresPeers(t *testing.T) {
+	t.Parallel()
+
+	if _, err := Run(context.Background(), 1); err == nil {
+		t.Fatalf("expected a load test of a single node to fail")
+	}
+}
+
+func TestWriteReports(t *testing.T) {
+	t.Parallel()
+
+	h := NewHistogram()
+	h.Record(time.Millisecond)
+
+	report := &Report{Pattern: "uniform", Nodes: 4, Duration: time.Second, Sent: 10, Received: 9, Failed: 1, Throughput: 9, Latency: summarize(h)}
+
+	var buf bytes.Buffer
+	if err := report.WriteJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded Report
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	if decoded.Received != 9 || decoded.Latency.Max != time.Millisecond || len(decoded.Latency.Histogram) != 1 {
+		t.Fatalf("expected report to survive a round trip through JSON, got %+v", decoded)
+	}
+
+	buf.Reset()
+	if err := WriteCSV(&buf, report, report); err != nil {
+		t.Fatal(err)
+	}
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(rows) != 3 || len(rows[1]) != len(csvHeader) || rows[1][0] != "uniform" || rows[1][5] != "9" {
+		t.Fatalf("expected a header and a row per report, got %v", rows)
+	}
+}
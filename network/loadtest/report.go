@@ -0,0 +1,108 @@
+package loadtest
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"time"
+)
+
+// Report summarizes the traffic driven throughout a load test.
+type Report struct {
+	Pattern     string        `json:"pattern"`
+	Nodes       int           `json:"nodes"`
+	PayloadSize int           `json:"payload_size"`
+	Duration    time.Duration `json:"duration"`
+
+	// Sent is the number of messages sent.
+	Sent uint64 `json:"sent"`
+	// Received is the number of messages received, or for request/reply
+	// traffic the number of replies received.
+	Received uint64 `json:"received"`
+	// Failed is the number of messages which failed to send, or for
+	// request/reply traffic the number of requests which got no reply.
+	Failed uint64 `json:"failed"`
+
+	// Throughput is the number of messages received per second.
+	Throughput float64 `json:"throughput"`
+
+	// Latency summarizes one-way latencies, or for request/reply traffic
+	// round-trip latencies.
+	Latency Latency `json:"latency"`
+}
+
+// Latency summarizes the latencies recorded by a histogram.
+type Latency struct {
+	Min  time.Duration `json:"min"`
+	Mean time.Duration `json:"mean"`
+	P50  time.Duration `json:"p50"`
+	P90  time.Duration `json:"p90"`
+	P99  time.Duration `json:"p99"`
+	Max  time.Duration `json:"max"`
+
+	Histogram []Bucket `json:"histogram"`
+}
+
+func summarize(h *Histogram) Latency {
+	return Latency{
+		Min:       h.Min(),
+		Mean:      h.Mean(),
+		P50:       h.Quantile(0.5),
+		P90:       h.Quantile(0.9),
+		P99:       h.Quantile(0.99),
+		Max:       h.Max(),
+		Histogram: h.Buckets(),
+	}
+}
+
+// WriteJSON writes a report as indented JSON. Durations are written in
+// nanoseconds.
+func (r *Report) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+// csvHeader names the columns written by WriteCSV.
+var csvHeader = []string{
+	"pattern", "nodes", "payload_size", "duration_ns", "sent", "received", "failed", "throughput",
+	"min_ns", "mean_ns", "p50_ns", "p90_ns", "p99_ns", "max_ns",
+}
+
+// WriteCSV writes a header followed by a row summarizing each report, such
+// that the reports of several runs may be compared. Latency histograms are
+// left out.
+func WriteCSV(w io.Writer, reports ...*Report) error {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write(csvHeader); err != nil {
+		return err
+	}
+
+	for _, r := range reports {
+		row := []string{
+			r.Pattern,
+			strconv.Itoa(r.Nodes),
+			strconv.Itoa(r.PayloadSize),
+			strconv.FormatInt(int64(r.Duration), 10),
+			strconv.FormatUint(r.Sent, 10),
+			strconv.FormatUint(r.Received, 10),
+			strconv.FormatUint(r.Failed, 10),
+			strconv.FormatFloat(r.Throughput, 'f', 2, 64),
+			strconv.FormatInt(int64(r.Latency.Min), 10),
+			strconv.FormatInt(int64(r.Latency.Mean), 10),
+			strconv.FormatInt(int64(r.Latency.P50), 10),
+			strconv.FormatInt(int64(r.Latency.P90), 10),
+			strconv.FormatInt(int64(r.Latency.P99), 10),
+			strconv.FormatInt(int64(r.Latency.Max), 10),
+		}
+
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,148 @@
+package network
+
+import (
+	"sync"
+	"time"
+
+	"github.com/perlin-network/noise/internal/protobuf"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// defaultMaxInboundMemory is how many bytes inbound messages of all peers
+	// may hold before reads are delayed.
+	defaultMaxInboundMemory = 256 * 1024 * 1024
+	// defaultMaxPeerInboundMemory is how many bytes inbound messages of a
+	// single peer may hold before reads are delayed.
+	defaultMaxPeerInboundMemory = 32 * 1024 * 1024
+	// admissionTimeout is how long a read is delayed for memory to be freed
+	// before it is refused.
+	admissionTimeout = 10 * time.Second
+)
+
+// memoryBudget accounts for the memory held by inbound messages which have
+// been read off of connections, but have yet to be dispatched to plugins.
+// Memory is reserved before the body of a frame is allocated, such that a
+// burst of large frames from many peers delays reads instead of exhausting
+// the memory of the node.
+type memoryBudget struct {
+	mutex sync.Mutex
+	limit int64
+	used  int64
+
+	// released is closed and replaced every time memory is released, waking
+	// up reads awaiting memory.
+	released chan struct{}
+}
+
+func newMemoryBudget(limit int64) *memoryBudget {
+	return &memoryBudget{limit: limit, released: make(chan struct{})}
+}
+
+// inboundMessage is a message read off of a connection alongside the number
+// of bytes reserved for it.
+type inboundMessage struct {
+	msg      *protobuf.Message
+	reserved int64
+}
+
+// peerMemory accounts for the memory held by inbound messages of a single
+// connection against both its own limit and the budget of the node.
+type peerMemory struct {
+	budget *memoryBudget
+	limit  int64
+	used   int64
+}
+
+func (b *memoryBudget) peer(limit int64) *peerMemory {
+	return &peerMemory{budget: b, limit: limit}
+}
+
+// fits returns whether or not size bytes may be reserved. Expects the budget
+// to be locked.
+func (p *peerMemory) fits(size int64) bool {
+	b := p.budget
+	return (p.limit <= 0 || p.used+size <= p.limit) && (b.limit <= 0 || b.used+size <= b.limit)
+}
+
+// reserve reserves size bytes, waiting up to admissionTimeout for memory held
+// by other messages to be released. Reservations which may never fit are
+// refused right away.
+func (p *peerMemory) reserve(size int64) error {
+	b := p.budget
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if (p.limit > 0 && size > p.limit) || (b.limit > 0 && size > b.limit) {
+		return errors.Errorf("message of length %d exceeds the inbound memory limit", size)
+	}
+
+	var timeout <-chan time.Time
+
+	for !p.fits(size) {
+		if timeout == nil {
+			timer := time.NewTimer(admissionTimeout)
+			defer timer.Stop()
+			timeout = timer.C
+		}
+
+		released := b.released
+		b.mutex.Unlock()
+
+		select {
+		case <-released:
+			b.mutex.Lock()
+		case <-timeout:
+			b.mutex.Lock()
+			return errors.Errorf("timed out awaiting %d bytes of inbound memory", size)
+		}
+	}
+
+	p.used += size
+	b.used += size
+
+	return nil
+}
+
+// release releases up to size bytes reserved.
+func (p *peerMemory) release(size int64) {
+	b := p.budget
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	// Memory may have already been released by releaseAll.
+	if size > p.used {
+		size = p.used
+	}
+	if size <= 0 {
+		return
+	}
+
+	p.used -= size
+	b.used -= size
+
+	close(b.released)
+	b.released = make(chan struct{})
+}
+
+// releaseAll releases all memory reserved, such as once the connection
+// closes with messages yet to be dispatched.
+func (p *peerMemory) releaseAll() {
+	p.budget.mutex.Lock()
+	used := p.used
+	p.budget.mutex.Unlock()
+
+	p.release(used)
+}
+
+// InboundMemory returns the number of bytes held by inbound messages of all
+// peers which have yet to be dispatched to plugins.
+func (n *Network) InboundMemory() int64 {
+	n.memory.mutex.Lock()
+	defer n.memory.mutex.Unlock()
+
+	return n.memory.used
+}
@@ -0,0 +1,83 @@
+package network
+
+import (
+	"bytes"
+	"compress/flate"
+	"testing"
+	"time"
+
+	"github.com/perlin-network/noise/internal/protobuf"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryBudget(t *testing.T) {
+	t.Parallel()
+
+	budget := newMemoryBudget(100)
+	a, b := budget.peer(60), budget.peer(60)
+
+	assert.NoError(t, a.reserve(60))
+	assert.Error(t, a.reserve(61), "expected reservations larger than the peer limit to be refused")
+	assert.Error(t, newMemoryBudget(10).peer(0).reserve(11), "expected reservations larger than the node limit to be refused")
+
+	// b fits within its own limit, but not within what a leaves of the budget.
+	reserved := make(chan error, 1)
+	go func() {
+		reserved <- b.reserve(50)
+	}()
+
+	select {
+	case <-reserved:
+		t.Fatal("expected reservations exceeding the budget to be delayed")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	a.release(20)
+
+	select {
+	case err := <-reserved:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("expected delayed reservations to go through once memory is released")
+	}
+
+	assert.Equal(t, int64(90), budget.used)
+
+	a.releaseAll()
+	a.release(40)
+	assert.Equal(t, int64(50), budget.used, "expected memory to be released at most once")
+
+	b.release(50)
+	assert.Equal(t, int64(0), budget.used)
+}
+
+func TestDecompressionMemory(t *testing.T) {
+	t.Parallel()
+
+	// A megabyte of zeroes compresses down to a frame of a few kilobytes.
+	var compressed bytes.Buffer
+	w, _ := flate.NewWriter(&compressed, flate.BestCompression)
+	w.Write(make([]byte, 1024*1024))
+	w.Close()
+
+	budget := newMemoryBudget(0)
+
+	msg := &protobuf.Message{Message: compressed.Bytes(), Compressed: true}
+	memory := budget.peer(decompressChunkSize / 2)
+
+	reserved, err := decompressMessage(msg, memory)
+	assert.Error(t, err, "expected bodies inflating past the peer limit to be refused")
+	assert.Equal(t, budget.used, reserved)
+
+	memory.releaseAll()
+
+	msg = &protobuf.Message{Message: compressed.Bytes(), Compressed: true}
+	memory = budget.peer(2 * 1024 * 1024)
+
+	reserved, err = decompressMessage(msg, memory)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1024*1024), reserved, "expected the decompressed body to be charged")
+	assert.Equal(t, reserved, budget.used)
+	assert.Equal(t, 1024*1024, len(msg.Message))
+}
@@ -2,9 +2,12 @@ package network
 
 import (
 	"context"
+	"time"
 
 	"github.com/gogo/protobuf/proto"
+	"github.com/perlin-network/noise/internal/protobuf"
 	"github.com/perlin-network/noise/peer"
+	"github.com/perlin-network/noise/tracing"
 )
 
 // PluginContext provides parameters and helper functions to a Plugin
@@ -13,13 +16,103 @@ type PluginContext struct {
 	client  *PeerClient
 	message proto.Message
 	nonce   uint64
+	span    *tracing.Span
+	traceID tracing.TraceID
+
+	// Deadline of the incoming request as set by its sender, and the context
+	// bound to it while the message is handled.
+	deadline time.Time
+	ctx      context.Context
+
+	// Headers of the incoming message.
+	headers Headers
+
+	// Number of times the incoming message was relayed, and the path it was
+	// relayed along should it be recorded.
+	hops uint32
+	path [][]byte
 }
 
 // Reply sends back a message to an incoming message's incoming stream.
 func (pctx *PluginContext) Reply(ctx context.Context, message proto.Message) error {
+	// Continue the senders trace should the reply not be part of another one.
+	if tracing.TraceIDFromContext(ctx).IsZero() {
+		ctx = pctx.withTrace(ctx)
+	}
 	return pctx.client.Reply(ctx, pctx.nonce, message)
 }
 
+// ReplyError replies to an incoming request with an error code, which the
+// requester receives as an error from Request matching the code's sentinel
+// error under errors.Is.
+func (pctx *PluginContext) ReplyError(ctx context.Context, code ErrorCode, message string) error {
+	return pctx.Reply(ctx, &protobuf.ErrorReply{Code: uint32(code), Message: message})
+}
+
+// Context returns a context carrying the trace of the incoming message, such
+// that messages sent on its behalf, such as relays, continue its trace, count
+// a hop towards MaxHops, and are not broadcast back to its sender. It is cancelled once the sender
+// disconnects or the node shuts down, such that long-running handlers may
+// abort work whose result may no longer be delivered. Should the message be a request with a deadline, it additionally
+// expires at the deadline, and is cancelled once Receive returns.
+func (pctx *PluginContext) Context() context.Context {
+	if pctx.ctx != nil {
+		return pctx.withTrace(pctx.ctx)
+	}
+	return pctx.withTrace(pctx.client.Context())
+}
+
+// Deadline returns the time the sender of the incoming request stops awaiting
+// its reply, and false should the message not be a request with a deadline.
+func (pctx *PluginContext) Deadline() (time.Time, bool) {
+	return pctx.deadline, !pctx.deadline.IsZero()
+}
+
+// Hops returns the number of times the incoming message was relayed on its way
+// from its originator.
+func (pctx *PluginContext) Hops() uint32 {
+	return pctx.hops
+}
+
+// Path returns the public keys of the originator of the incoming message and
+// of every peer which relayed it, or nil should its path not be recorded.
+func (pctx *PluginContext) Path() [][]byte {
+	return pctx.path
+}
+
+// withTrace returns a copy of ctx which carries the trace, origin and hops of
+// the incoming message.
+func (pctx *PluginContext) withTrace(ctx context.Context) context.Context {
+	if id := pctx.client.ID(); id != nil {
+		ctx = WithOrigin(ctx, *id)
+	}
+	ctx = withRelay(ctx, &relay{hops: pctx.hops, path: pctx.path})
+
+	if pctx.span != nil {
+		return tracing.ContextWithSpan(ctx, pctx.span)
+	}
+	return tracing.ContextWithTraceID(ctx, pctx.traceID)
+}
+
+// Header returns the value of a header of the incoming message, such as one
+// set by middleware of the sender, and false should it not be set.
+func (pctx *PluginContext) Header(name string) ([]byte, bool) {
+	value, ok := pctx.headers[name]
+	return value, ok
+}
+
+// TraceID returns the ID of the trace the incoming message is correlated
+// under.
+func (pctx *PluginContext) TraceID() tracing.TraceID {
+	return pctx.traceID
+}
+
+// Span returns the span tracing the dispatch of the incoming message, or nil
+// should tracing be disabled.
+func (pctx *PluginContext) Span() *tracing.Span {
+	return pctx.span
+}
+
 // Message returns the decoded protobuf message.
 func (pctx *PluginContext) Message() proto.Message {
 	return pctx.message
@@ -42,5 +135,5 @@ func (pctx *PluginContext) Self() peer.ID {
 
 // Sender returns the peer's ID.
 func (pctx *PluginContext) Sender() peer.ID {
-	return *pctx.client.ID
+	return *pctx.client.ID()
 }
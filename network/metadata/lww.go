@@ -0,0 +1,121 @@
+package metadata
+
+import (
+	"bytes"
+	"sync"
+)
+
+// Entry is a value held under a key alongside when and by which node it was
+// written.
+type Entry struct {
+	Value []byte
+	// Timestamp is when the entry was written in nanoseconds since the unix
+	// epoch.
+	Timestamp int64
+	// Writer is the public key of the node which wrote the entry.
+	Writer []byte
+	// Deleted marks a tombstone, which is kept such that deletions win over
+	// older writes.
+	Deleted bool
+}
+
+// newer returns whether the entry wins over another under last-writer-wins:
+// later timestamps win, and ties are broken by the greater writer.
+func (e Entry) newer(other Entry) bool {
+	if e.Timestamp != other.Timestamp {
+		return e.Timestamp > other.Timestamp
+	}
+	return bytes.Compare(e.Writer, other.Writer) > 0
+}
+
+// Map is a map of last-writer-wins registers. Replicas which have merged the
+// same entries hold the same values regardless of the order entries were
+// merged in. It is safe for concurrent use.
+type Map struct {
+	mutex   sync.RWMutex
+	entries map[string]Entry
+}
+
+// NewMap returns an empty map.
+func NewMap() *Map {
+	return &Map{entries: make(map[string]Entry)}
+}
+
+// Merge merges an entry under a key, and returns whether or not it won over
+// the entry held.
+func (m *Map) Merge(key string, entry Entry) bool {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if held, exists := m.entries[key]; exists && !entry.newer(held) {
+		return false
+	}
+
+	m.entries[key] = entry
+	return true
+}
+
+// Get returns the value held under a key, and false should there be none.
+func (m *Map) Get(key string) ([]byte, bool) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	entry, exists := m.entries[key]
+	if !exists || entry.Deleted {
+		return nil, false
+	}
+	return entry.Value, true
+}
+
+// Entry returns the entry held under a key, including tombstones.
+func (m *Map) Entry(key string) (Entry, bool) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	entry, exists := m.entries[key]
+	return entry, exists
+}
+
+// Len returns the number of entries held, including tombstones.
+func (m *Map) Len() int {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	return len(m.entries)
+}
+
+// Values returns a copy of all values held keyed by their key.
+func (m *Map) Values() map[string][]byte {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	values := make(map[string][]byte, len(m.entries))
+	for key, entry := range m.entries {
+		if !entry.Deleted {
+			values[key] = entry.Value
+		}
+	}
+	return values
+}
+
+// Each calls fn with every entry held, including tombstones.
+func (m *Map) Each(fn func(key string, entry Entry)) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	for key, entry := range m.entries {
+		fn(key, entry)
+	}
+}
+
+// Compact removes tombstones written before a timestamp.
+func (m *Map) Compact(before int64) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for key, entry := range m.entries {
+		if entry.Deleted && entry.Timestamp < before {
+			delete(m.entries, key)
+		}
+	}
+}
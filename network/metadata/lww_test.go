@@ -0,0 +1,45 @@
+package metadata
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMapConverges(t *testing.T) {
+	entries := []struct {
+		key   string
+		entry Entry
+	}{
+		{"flag", Entry{Value: []byte("a"), Timestamp: 1, Writer: []byte{1}}},
+		{"flag", Entry{Value: []byte("b"), Timestamp: 2, Writer: []byte{1}}},
+		{"flag", Entry{Value: []byte("c"), Timestamp: 2, Writer: []byte{2}}},
+		{"endpoint", Entry{Value: []byte("tcp://localhost:3000"), Timestamp: 1, Writer: []byte{1}}},
+		{"endpoint", Entry{Timestamp: 3, Writer: []byte{2}, Deleted: true}},
+		{"endpoint", Entry{Value: []byte("tcp://localhost:3001"), Timestamp: 2, Writer: []byte{3}}},
+	}
+
+	forward, backward := NewMap(), NewMap()
+	for i := range entries {
+		forward.Merge(entries[i].key, entries[i].entry)
+		backward.Merge(entries[len(entries)-1-i].key, entries[len(entries)-1-i].entry)
+	}
+
+	assert.Equal(t, forward.Values(), backward.Values(), "expected replicas to converge regardless of merge order")
+
+	value, ok := forward.Get("flag")
+	assert.True(t, ok)
+	assert.Equal(t, []byte("c"), value, "expected ties to be broken by the greater writer")
+
+	_, ok = forward.Get("endpoint")
+	assert.False(t, ok, "expected the deletion to win over older writes")
+	assert.Equal(t, 2, forward.Len())
+
+	assert.False(t, forward.Merge("flag", Entry{Value: []byte("d"), Timestamp: 1, Writer: []byte{9}}), "expected older writes to lose")
+
+	forward.Compact(3)
+	assert.Equal(t, 2, forward.Len(), "expected tombstones to be kept until they expire")
+
+	forward.Compact(4)
+	assert.Equal(t, 1, forward.Len(), "expected expired tombstones to be removed")
+}
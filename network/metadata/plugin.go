@@ -0,0 +1,376 @@
+// Package metadata replicates a small map of soft state among peers, such as
+// feature flags, advertised endpoints or capacity hints.
+//
+// Every key holds a last-writer-wins register: writes are timestamped by the
+// clock of the writing node, with ties broken by its public key, such that
+// replicas converge regardless of the order they learn of writes in. Writes
+// are broadcast to connected peers as they happen, and every node periodically
+// gossips its entire map to a few random peers to repair missed writes.
+//
+// Entries are relayed by peers, and are thus only as trustworthy as the peers
+// of a node; sensitive state should be signed by its writer.
+package metadata
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/perlin-network/noise/clock"
+	"github.com/perlin-network/noise/internal/protobuf"
+	"github.com/perlin-network/noise/network"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// defaultGossipInterval is how often the map is gossiped to random peers.
+	defaultGossipInterval = 10 * time.Second
+	// defaultFanout is how many peers the map is gossiped to per round.
+	defaultFanout = 3
+	// defaultMaxEntries is how many entries the map holds at most.
+	defaultMaxEntries = 1024
+	// defaultMaxKeySize is the size in bytes keys may be at most.
+	defaultMaxKeySize = 256
+	// defaultMaxValueSize is the size in bytes values may be at most.
+	defaultMaxValueSize = 1024
+	// defaultTombstoneTTL is how long deleted entries are remembered for.
+	defaultTombstoneTTL = 1 * time.Hour
+)
+
+var (
+	// ErrNotStarted is returned upon writing to the map of a plugin which
+	// has yet to start up.
+	ErrNotStarted = errors.New("metadata: plugin has not started")
+	// ErrFull is returned upon writing a new key to a full map.
+	ErrFull = errors.New("metadata: map is full")
+)
+
+// Plugin replicates a map of last-writer-wins registers among peers.
+type Plugin struct {
+	*network.Plugin
+
+	// GossipInterval is how often the map is gossiped to random peers
+	// (default: 10 seconds).
+	GossipInterval time.Duration
+	// Fanout is how many random peers the map is gossiped to per round
+	// (default: 3).
+	Fanout int
+	// MaxEntries is how many entries, including deleted ones, the map holds
+	// at most. Entries under new keys are discarded past it (default: 1024).
+	MaxEntries int
+	// MaxKeySize and MaxValueSize are the sizes in bytes keys and values may
+	// be at most. Larger entries are discarded (default: 256 and 1024).
+	MaxKeySize   int
+	MaxValueSize int
+	// TombstoneTTL is how long deleted entries are remembered for, such that
+	// they win over older writes still being gossiped (default: 1 hour).
+	TombstoneTTL time.Duration
+
+	// OnChange is called with every change to the map, be it written locally
+	// or by a peer. Deleted entries are passed with a nil value.
+	OnChange func(key string, value []byte)
+
+	state *Map
+
+	// Connected peers: address -> *network.PeerClient.
+	clients sync.Map
+
+	mutex sync.Mutex
+	net   *network.Network
+
+	// Clock and source of randomness of the node.
+	clock  clock.Clock
+	random *network.Random
+
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+var (
+	// PluginID is used to check existence of the metadata plugin.
+	PluginID                         = (*Plugin)(nil)
+	_        network.PluginInterface = (*Plugin)(nil)
+)
+
+// PluginOption are configurable options for the metadata plugin.
+type PluginOption func(*Plugin)
+
+// WithGossipInterval sets how often the map is gossiped to random peers.
+func WithGossipInterval(d time.Duration) PluginOption {
+	return func(p *Plugin) {
+		p.GossipInterval = d
+	}
+}
+
+// WithFanout sets how many random peers the map is gossiped to per round.
+func WithFanout(n int) PluginOption {
+	return func(p *Plugin) {
+		p.Fanout = n
+	}
+}
+
+// WithMaxEntries sets how many entries the map holds at most.
+func WithMaxEntries(n int) PluginOption {
+	return func(p *Plugin) {
+		p.MaxEntries = n
+	}
+}
+
+// WithMaxSizes sets the sizes in bytes keys and values may be at most.
+func WithMaxSizes(key int, value int) PluginOption {
+	return func(p *Plugin) {
+		p.MaxKeySize, p.MaxValueSize = key, value
+	}
+}
+
+// WithTombstoneTTL sets how long deleted entries are remembered for.
+func WithTombstoneTTL(d time.Duration) PluginOption {
+	return func(p *Plugin) {
+		p.TombstoneTTL = d
+	}
+}
+
+// WithOnChange sets the callback called with every change to the map.
+func WithOnChange(fn func(key string, value []byte)) PluginOption {
+	return func(p *Plugin) {
+		p.OnChange = fn
+	}
+}
+
+// New returns a new metadata plugin with specified options. Options left
+// unspecified take on their defaults once the plugin starts up, such that
+// new(Plugin) remains valid.
+func New(opts ...PluginOption) *Plugin {
+	p := new(Plugin)
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+// setDefaults fills in all options which have been left unspecified.
+func (p *Plugin) setDefaults() {
+	if p.GossipInterval <= 0 {
+		p.GossipInterval = defaultGossipInterval
+	}
+
+	if p.Fanout <= 0 {
+		p.Fanout = defaultFanout
+	}
+
+	if p.MaxEntries <= 0 {
+		p.MaxEntries = defaultMaxEntries
+	}
+
+	if p.MaxKeySize <= 0 {
+		p.MaxKeySize = defaultMaxKeySize
+	}
+
+	if p.MaxValueSize <= 0 {
+		p.MaxValueSize = defaultMaxValueSize
+	}
+
+	if p.TombstoneTTL <= 0 {
+		p.TombstoneTTL = defaultTombstoneTTL
+	}
+
+	p.state = NewMap()
+}
+
+func (p *Plugin) Startup(net *network.Network) {
+	p.setDefaults()
+
+	p.clock, p.random = net.Clock(), net.Random()
+
+	p.mutex.Lock()
+	p.net = net
+	p.mutex.Unlock()
+
+	p.ctx, p.cancel = context.WithCancel(context.Background())
+	go p.gossipLoop(p.ctx)
+}
+
+func (p *Plugin) Cleanup(net *network.Network) {
+	if p.cancel != nil {
+		p.cancel()
+	}
+}
+
+func (p *Plugin) Receive(ctx *network.PluginContext) error {
+	switch msg := ctx.Message().(type) {
+	case *protobuf.MetadataGossip:
+		for _, entry := range msg.Entries {
+			if entry != nil {
+				p.merge(entry)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (p *Plugin) PeerConnect(client *network.PeerClient) {
+	p.clients.Store(client.Address, client)
+}
+
+func (p *Plugin) PeerDisconnect(client *network.PeerClient) {
+	p.clients.Delete(client.Address)
+}
+
+// Get returns the value held under a key, and false should there be none.
+func (p *Plugin) Get(key string) ([]byte, bool) {
+	if p.state == nil {
+		return nil, false
+	}
+	return p.state.Get(key)
+}
+
+// Values returns a copy of all values held keyed by their key.
+func (p *Plugin) Values() map[string][]byte {
+	if p.state == nil {
+		return map[string][]byte{}
+	}
+	return p.state.Values()
+}
+
+// Set writes a value under a key, and broadcasts the write to all peers.
+func (p *Plugin) Set(key string, value []byte) error {
+	return p.write(key, value, false)
+}
+
+// Delete deletes the value under a key, and broadcasts the deletion to all
+// peers.
+func (p *Plugin) Delete(key string) error {
+	return p.write(key, nil, true)
+}
+
+// write writes an entry under a key, and broadcasts it to all peers.
+func (p *Plugin) write(key string, value []byte, deleted bool) error {
+	p.mutex.Lock()
+	net := p.net
+	p.mutex.Unlock()
+
+	if net == nil {
+		return ErrNotStarted
+	}
+
+	if len(key) == 0 || len(key) > p.MaxKeySize {
+		return errors.Errorf("metadata: key must be between 1 and %d bytes", p.MaxKeySize)
+	}
+
+	if len(value) > p.MaxValueSize {
+		return errors.Errorf("metadata: value must be at most %d bytes", p.MaxValueSize)
+	}
+
+	// Our write must win over the entry held should our clock lag behind
+	// that of its writer.
+	timestamp := p.clock.Now().UnixNano()
+	if held, exists := p.state.Entry(key); exists {
+		if held.Timestamp >= timestamp {
+			timestamp = held.Timestamp + 1
+		}
+	} else if p.state.Len() >= p.MaxEntries {
+		return ErrFull
+	}
+
+	entry := &protobuf.MetadataEntry{
+		Key:       key,
+		Value:     value,
+		Timestamp: timestamp,
+		Writer:    net.ID.PublicKey,
+		Deleted:   deleted,
+	}
+
+	p.merge(entry)
+
+	net.Broadcast(network.WithSignMessage(context.Background(), true), &protobuf.MetadataGossip{
+		Entries: []*protobuf.MetadataEntry{entry},
+	})
+
+	return nil
+}
+
+// merge merges an entry written locally or by a peer into the map, and calls
+// OnChange should it win over the entry held.
+func (p *Plugin) merge(entry *protobuf.MetadataEntry) {
+	if len(entry.Key) == 0 || len(entry.Key) > p.MaxKeySize || len(entry.Value) > p.MaxValueSize {
+		return
+	}
+
+	if _, exists := p.state.Entry(entry.Key); !exists && p.state.Len() >= p.MaxEntries {
+		return
+	}
+
+	value := entry.Value
+	if entry.Deleted {
+		value = nil
+	}
+
+	merged := p.state.Merge(entry.Key, Entry{
+		Value:     value,
+		Timestamp: entry.Timestamp,
+		Writer:    entry.Writer,
+		Deleted:   entry.Deleted,
+	})
+
+	if merged && p.OnChange != nil {
+		p.OnChange(entry.Key, value)
+	}
+}
+
+// gossipLoop gossips the map to random peers every interval.
+func (p *Plugin) gossipLoop(ctx context.Context) {
+	t := p.clock.NewTicker(p.GossipInterval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C():
+			p.state.Compact(p.clock.Now().Add(-p.TombstoneTTL).UnixNano())
+			p.Gossip(ctx)
+		}
+	}
+}
+
+// Gossip sends the entire map to a few random peers.
+func (p *Plugin) Gossip(ctx context.Context) {
+	if p.state == nil || p.state.Len() == 0 {
+		return
+	}
+
+	var clients []*network.PeerClient
+	p.clients.Range(func(_, value interface{}) bool {
+		clients = append(clients, value.(*network.PeerClient))
+		return true
+	})
+
+	p.random.Shuffle(len(clients), func(i, j int) {
+		clients[i], clients[j] = clients[j], clients[i]
+	})
+
+	if len(clients) > p.Fanout {
+		clients = clients[:p.Fanout]
+	}
+
+	gossip := &protobuf.MetadataGossip{}
+	p.state.Each(func(key string, entry Entry) {
+		gossip.Entries = append(gossip.Entries, &protobuf.MetadataEntry{
+			Key:       key,
+			Value:     entry.Value,
+			Timestamp: entry.Timestamp,
+			Writer:    entry.Writer,
+			Deleted:   entry.Deleted,
+		})
+	})
+
+	for _, client := range clients {
+		if err := client.Tell(network.WithSignMessage(ctx, true), gossip); err != nil {
+			client.Network.Log("metadata").Debug().Err(err).Str("peer_address", client.Address).Msg("Failed to gossip metadata to peer.")
+		}
+	}
+}
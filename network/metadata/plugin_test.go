@@ -0,0 +1,107 @@
+package metadata
+
+import (
+	"testing"
+	"time"
+
+	"github.com/perlin-network/noise/internal/test"
+	"github.com/perlin-network/noise/network"
+)
+
+func connect(t *testing.T, a, b *network.Network) {
+	a.Bootstrap(b.Address)
+	b.Bootstrap(a.Address)
+
+	waitFor(t, "nodes to connect", func() bool {
+		return a.ConnectionStateExists(b.Address) && b.ConnectionStateExists(a.Address)
+	})
+
+	// Messages sent before the connection is ready on both ends are dropped.
+	time.Sleep(300 * time.Millisecond)
+}
+
+func waitFor(t *testing.T, description string, cond func() bool) {
+	deadline := time.Now().Add(5 * time.Second)
+
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", description)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+func TestNotStarted(t *testing.T) {
+	if err := New().Set("flag", []byte("on")); err != ErrNotStarted {
+		t.Fatalf("expected writes before startup to fail, got %v", err)
+	}
+}
+
+func TestReplication(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping metadata test in short mode")
+	}
+
+	changes := make(chan string, 16)
+
+	a := New(WithGossipInterval(100*time.Millisecond), WithMaxSizes(16, 16))
+	b := New(WithGossipInterval(100*time.Millisecond), WithOnChange(func(key string, value []byte) {
+		changes <- key + "=" + string(value)
+	}))
+	c := New(WithGossipInterval(100 * time.Millisecond))
+
+	nodeA, nodeB, nodeC := test.BuildNode(t, nil, a), test.BuildNode(t, nil, b), test.BuildNode(t, nil, c)
+	defer nodeA.Close()
+	defer nodeB.Close()
+	defer nodeC.Close()
+
+	connect(t, nodeA, nodeB)
+
+	if err := a.Set("flag", []byte("on")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := a.Set("oversized", []byte("a value past the limit")); err == nil {
+		t.Fatal("expected oversized values to be refused")
+	}
+
+	waitFor(t, "write to replicate", func() bool {
+		value, ok := b.Get("flag")
+		return ok && string(value) == "on"
+	})
+
+	if change := <-changes; change != "flag=on" {
+		t.Fatalf("expected change to be reported, got %s", change)
+	}
+
+	// Writes by peers win over older writes.
+	if err := b.Set("flag", []byte("off")); err != nil {
+		t.Fatal(err)
+	}
+
+	waitFor(t, "overwrite to replicate", func() bool {
+		value, ok := a.Get("flag")
+		return ok && string(value) == "off"
+	})
+
+	if err := a.Delete("flag"); err != nil {
+		t.Fatal(err)
+	}
+
+	waitFor(t, "deletion to replicate", func() bool {
+		_, ok := b.Get("flag")
+		return !ok
+	})
+
+	// Nodes joining later catch up through gossip.
+	if err := a.Set("endpoint", []byte("tcp://a")); err != nil {
+		t.Fatal(err)
+	}
+
+	connect(t, nodeC, nodeB)
+
+	waitFor(t, "late joiner to catch up", func() bool {
+		value, ok := c.Get("endpoint")
+		return ok && string(value) == "tcp://a"
+	})
+}
@@ -0,0 +1,198 @@
+package network
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/perlin-network/noise/peer"
+)
+
+// Response is the response of a single peer to a request issued through
+// MultiRequest, or the error the peer failed to respond with.
+type Response struct {
+	Peer    peer.ID
+	Message proto.Message
+	Err     error
+}
+
+// MultiRequestOption are configurable options for a single MultiRequest.
+type MultiRequestOption func(*multiRequestOptions)
+
+type multiRequestOptions struct {
+	timeout time.Duration
+	quorum  int
+}
+
+// PeerTimeout bounds how long every peer is given to respond, on top of the
+// deadline of the context of the request.
+func PeerTimeout(d time.Duration) MultiRequestOption {
+	return func(o *multiRequestOptions) {
+		o.timeout = d
+	}
+}
+
+// Quorum stops awaiting peers once n of them responded successfully, such as
+// to read from the first n replicas to respond.
+func Quorum(n int) MultiRequestOption {
+	return func(o *multiRequestOptions) {
+		o.quorum = n
+	}
+}
+
+// MultiRequest concurrently issues the same request to many peers, dialing
+// those not connected to, and returns a channel of their responses in the
+// order they arrive in. Peers which fail to respond are delivered with their
+// error. The channel is closed once every peer has responded or failed to, or
+// once a Quorum is reached, in which case the requests outstanding are
+// cancelled and not delivered.
+//
+// Requests are issued by workers of the "requests" pool. Should the pool be
+// capped through MaxWorkers, MultiRequest blocks until earlier requests
+// complete.
+func (n *Network) MultiRequest(ctx context.Context, recipients []peer.ID, req proto.Message, opts ...MultiRequestOption) <-chan Response {
+	o := new(multiRequestOptions)
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	responses := make(chan Response, len(recipients))
+	ctx, cancel := context.WithCancel(ctx)
+
+	var (
+		wg        sync.WaitGroup
+		mutex     sync.Mutex
+		succeeded int
+	)
+
+	for _, id := range recipients {
+		wg.Add(1)
+
+		n.goWorker(workerRequests, func() {
+			defer wg.Done()
+
+			response := Response{Peer: id}
+			response.Message, response.Err = n.requestPeer(ctx, id, req, o.timeout)
+
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			if o.quorum > 0 && succeeded >= o.quorum {
+				return
+			}
+
+			if response.Err == nil {
+				succeeded++
+			}
+			responses <- response
+
+			if o.quorum > 0 && succeeded >= o.quorum {
+				cancel()
+			}
+		})
+	}
+
+	n.goWorker(workerRequests, func() {
+		wg.Wait()
+		cancel()
+		close(responses)
+	})
+
+	return responses
+}
+
+// requestPeer issues a request to a peer, dialing it through ClientByID should
+// it not be connected to.
+func (n *Network) requestPeer(ctx context.Context, id peer.ID, req proto.Message, timeout time.Duration) (proto.Message, error) {
+	client, err := n.ClientByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	return client.Request(ctx, req)
+}
+
+// QuorumResult is the outcome of a request issued through QuorumRequest.
+type QuorumResult struct {
+	// Message is the response agreed upon, or nil should there be no quorum.
+	Message proto.Message
+	// Agreed are the peers which responded with Message.
+	Agreed []peer.ID
+	// Divergent are the responses which differed from Message, or should
+	// there be no quorum, all responses received.
+	Divergent []Response
+	// Failed are the responses of peers which failed to respond.
+	Failed []Response
+}
+
+// QuorumRequest issues the same request to many peers as MultiRequest, and
+// returns once quorum peers responded with matching responses, cancelling the
+// requests outstanding. Responses match should equal report so, or should
+// equal be nil, should they be equal under proto.Equal. Responses which
+// diverge from the one agreed upon are reported, such that peers serving
+// stale or bogus answers may be told apart. ErrNoQuorum is returned alongside
+// all responses received should every peer respond without a quorum agreeing.
+func (n *Network) QuorumRequest(ctx context.Context, recipients []peer.ID, req proto.Message, quorum int, equal func(a, b proto.Message) bool, opts ...MultiRequestOption) (QuorumResult, error) {
+	if equal == nil {
+		equal = proto.Equal
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		result QuorumResult
+		groups [][]Response
+	)
+
+	for response := range n.MultiRequest(ctx, recipients, req, opts...) {
+		if response.Err != nil {
+			result.Failed = append(result.Failed, response)
+			continue
+		}
+
+		i := 0
+		for ; i < len(groups); i++ {
+			if equal(groups[i][0].Message, response.Message) {
+				break
+			}
+		}
+		if i == len(groups) {
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], response)
+
+		if len(groups[i]) < quorum {
+			continue
+		}
+
+		result.Message = response.Message
+		for j, group := range groups {
+			for _, member := range group {
+				if j == i {
+					result.Agreed = append(result.Agreed, member.Peer)
+				} else {
+					result.Divergent = append(result.Divergent, member)
+				}
+			}
+		}
+
+		return result, nil
+	}
+
+	for _, group := range groups {
+		result.Divergent = append(result.Divergent, group...)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return result, err
+	}
+	return result, ErrNoQuorum
+}
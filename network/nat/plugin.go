@@ -4,7 +4,6 @@ import (
 	"net"
 	"time"
 
-	"github.com/perlin-network/noise/log"
 	"github.com/perlin-network/noise/network"
 	"github.com/perlin-network/noise/peer"
 
@@ -30,7 +29,7 @@ var (
 )
 
 func (p *plugin) Startup(n *network.Network) {
-	log.Info().
+	n.Log("nat").Info().
 		Str("address", n.Address).
 		Msg("setting up NAT traversal")
 
@@ -43,7 +42,7 @@ func (p *plugin) Startup(n *network.Network) {
 
 	gateway, err := nat.DiscoverGateway()
 	if err != nil {
-		log.Warn().
+		n.Log("nat").Warn().
 			Err(err).
 			Msg("unable to discover gateway")
 		return
@@ -51,7 +50,7 @@ func (p *plugin) Startup(n *network.Network) {
 
 	p.internalIP, err = gateway.GetInternalAddress()
 	if err != nil {
-		log.Warn().
+		n.Log("nat").Warn().
 			Err(err).
 			Msg("unable to fetch internal IP")
 		return
@@ -59,17 +58,17 @@ func (p *plugin) Startup(n *network.Network) {
 
 	p.externalIP, err = gateway.GetExternalAddress()
 	if err != nil {
-		log.Warn().
+		n.Log("nat").Warn().
 			Err(err).
 			Msg("unable to fetch external IP")
 		return
 	}
 
-	log.Info().
+	n.Log("nat").Info().
 		Str("protocol", gateway.Type()).
 		Msg("discovered gateway")
 
-	log.Info().
+	n.Log("nat").Info().
 		Str("internal_ip", p.internalIP.String()).
 		Str("external_ip", p.externalIP.String()).
 		Msg("")
@@ -77,13 +76,13 @@ func (p *plugin) Startup(n *network.Network) {
 	p.externalPort, err = gateway.AddPortMapping("tcp", p.internalPort, "noise", 1*time.Second)
 
 	if err != nil {
-		log.Warn().
+		n.Log("nat").Warn().
 			Err(err).
 			Msg("cannot setup port mapping")
 		return
 	}
 
-	log.Info().
+	n.Log("nat").Info().
 		Int("internal_port", p.internalPort).
 		Int("external_port", p.externalPort).
 		Msgf("external port now forwards to your local port")
@@ -97,16 +96,16 @@ func (p *plugin) Startup(n *network.Network) {
 	n.Address = info.String()
 	n.ID = peer.CreateID(n.Address, n.GetKeys().PublicKey)
 
-	log.Info().Msgf("other peers may connect to you through the address %s.", n.Address)
+	n.Log("nat").Info().Msgf("other peers may connect to you through the address %s.", n.Address)
 }
 
 func (p *plugin) Cleanup(n *network.Network) {
 	if p.gateway != nil {
-		log.Info().Msg("removing port binding...")
+		n.Log("nat").Info().Msg("removing port binding...")
 
 		err := p.gateway.DeletePortMapping("tcp", p.internalPort)
 		if err != nil {
-			log.Error().Err(err).Msg("")
+			n.Log("nat").Error().Err(err).Msg("")
 		}
 	}
 }
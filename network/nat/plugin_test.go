@@ -17,8 +17,10 @@ func TestNatConnect(t *testing.T) {
 	nodes := make([]*network.Network, 0)
 	for i := 0; i < numNodes; i++ {
 		b := network.NewBuilder()
-		port := network.GetRandomUnusedPort()
-		b.SetAddress(network.FormatAddress("tcp", "localhost", uint16(port)))
+		listener, address, err := network.ListenOnRandomPort()
+		assert.Equal(t, nil, err)
+		b.SetListener(listener)
+		b.SetAddress(address)
 		RegisterPlugin(b)
 		b.AddPlugin(new(discovery.Plugin))
 		n, err := b.Build()
@@ -3,17 +3,24 @@ package network
 import (
 	"bufio"
 	"context"
-	"math/rand"
 	"net"
+	"reflect"
+	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
 
+	"github.com/perlin-network/noise/audit"
+	"github.com/perlin-network/noise/audit/trail"
+	"github.com/perlin-network/noise/clock"
 	"github.com/perlin-network/noise/crypto"
 	"github.com/perlin-network/noise/internal/protobuf"
 	"github.com/perlin-network/noise/log"
+	"github.com/perlin-network/noise/network/capture"
+	"github.com/perlin-network/noise/network/fault"
 	"github.com/perlin-network/noise/network/transport"
 	"github.com/perlin-network/noise/peer"
+	"github.com/perlin-network/noise/tracing"
 	"github.com/perlin-network/noise/types/opcode"
 
 	"github.com/gogo/protobuf/proto"
@@ -27,6 +34,8 @@ const (
 	defaultWriteBufferSize   = 4096
 	defaultWriteFlushLatency = 50 * time.Millisecond
 	defaultWriteTimeout      = 3 * time.Second
+	defaultShutdownTimeout   = 10 * time.Second
+	defaultMaxHops           = 16
 )
 
 var contextPool = sync.Pool{
@@ -68,11 +77,57 @@ type Network struct {
 	// Map of protocol addresses (string) <-> *transport.Layer
 	transports *sync.Map
 
+	// Listener bound ahead of time to serve peers from upon Listen, if any.
+	listener net.Listener
+
 	// listeningCh will block a goroutine until this node is listening for peers.
 	listeningCh chan struct{}
 
 	// <-kill will begin the server shutdown process
 	kill chan struct{}
+
+	// stopped is closed once plugins are cleaned up after the node stops
+	// listening.
+	stopped chan struct{}
+
+	// Lifecycle of the node, which is cancelled once it shuts down. Contexts
+	// of peers derive from it.
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	// Live goroutines per worker pool.
+	workers [numWorkerPools]*workerPool
+
+	// Live goroutines of subsystems spawned through Go.
+	// map[string]*workerPool
+	subsystems sync.Map
+
+	// Memory held by inbound messages yet to be dispatched.
+	memory *memoryBudget
+
+	// Scheduler of writes should sending be fair, or nil otherwise.
+	sender *sendScheduler
+
+	// Greatest pressure of all resources as of when they were last sampled.
+	pressure int32
+
+	// Unix time in nanoseconds the write flusher last ticked at.
+	heartbeat int64
+
+	// Time the node started at.
+	started time.Time
+
+	// Whether or not bootstrapping has succeeded with at least one seed.
+	bootstrapped int32
+
+	// Set once the node starts shutting down.
+	shutdown int32
+
+	// Number of failed handshakes by cause.
+	handshakeFailures handshakeFailures
+
+	// Number of requests awaiting a response across all peers.
+	pendingRequests int64
 }
 
 // options for network struct
@@ -85,6 +140,42 @@ type options struct {
 	writeBufferSize   int
 	writeFlushLatency time.Duration
 	writeTimeout      time.Duration
+	shutdownTimeout   time.Duration
+	tracer            *tracing.Tracer
+	stampDifficulty   int
+	logger            log.Logger
+	auditSink         audit.Sink
+	capture           capture.Sink
+	faults            fault.Policy
+	clock             clock.Clock
+	random            *Random
+	qos               qosOptions
+	middleware        []Middleware
+	maxHops           uint32
+	recordPaths       bool
+
+	maxInboundMemory     int64
+	maxPeerInboundMemory int64
+
+	resourceLimits [numResources]Watermarks
+
+	maxWorkers map[string]int
+
+	resolver Resolver
+
+	maxPeerPendingRequests int
+	maxPendingRequests     int
+
+	sendWorkers int
+
+	attestor            Attestor
+	attestationVerifier AttestationVerifier
+
+	trail *trail.Log
+
+	seedsFile     string
+	maxSeeds      int
+	seedsInterval time.Duration
 }
 
 // ConnState represents a connection.
@@ -93,12 +184,46 @@ type ConnState struct {
 	writer       *bufio.Writer
 	messageNonce uint64
 	writerMutex  *sync.Mutex
+
+	address string
+
+	// When the connection was established.
+	connectedAt time.Time
+
+	// Frames awaiting their turn to be written should sending be fair.
+	queue *sendQueue
+}
+
+// ConnectedAt returns when the connection was established.
+func (s *ConnState) ConnectedAt() time.Time {
+	return s.connectedAt
 }
 
 // Init starts all network I/O workers.
 func (n *Network) Init() {
+	atomic.StoreInt64(&n.heartbeat, time.Now().UnixNano())
+	n.started = n.Clock().Now()
+
 	// Spawn write flusher.
-	go n.flushLoop()
+	n.goWorker(workerFlush, n.flushLoop)
+
+	// Spawn workers writing queued frames should sending be fair.
+	for i := 0; i < n.opts.sendWorkers; i++ {
+		n.goWorker(workerSend, n.sendLoop)
+	}
+
+	// Spawn sampler of resource usage should any resource be limited.
+	if n.limitsResources() {
+		n.goWorker(workerResources, n.resourceLoop)
+	}
+
+	// Spawn persister of the best known peers should a seeds file be set.
+	if n.opts.seedsFile != "" {
+		n.goWorker(workerSeeds, n.seedsLoop)
+	}
+
+	// Spawn workers of plugins with limits.
+	n.startPluginWorkers()
 }
 
 func (n *Network) flushLoop() {
@@ -108,12 +233,14 @@ func (n *Network) flushLoop() {
 		select {
 		case <-n.kill:
 			return
-		case <-t.C:
+		case now := <-t.C:
+			atomic.StoreInt64(&n.heartbeat, now.UnixNano())
+
 			n.connections.Range(func(key, value interface{}) bool {
 				if state, ok := value.(*ConnState); ok {
 					state.writerMutex.Lock()
 					if err := state.writer.Flush(); err != nil {
-						log.Warn().Err(err).Msg("")
+						n.log().Warn().Err(err).Msg("")
 					}
 					state.writerMutex.Unlock()
 				}
@@ -128,13 +255,32 @@ func (n *Network) GetKeys() *crypto.KeyPair {
 	return n.keys
 }
 
+// Sign signs a message with this nodes private key under the networks
+// signature and hash policies.
+func (n *Network) Sign(message []byte) ([]byte, error) {
+	return n.keys.Sign(n.opts.signaturePolicy, n.opts.hashPolicy, message)
+}
+
+// Verify checks a signature of a message made by a public key under the
+// networks signature and hash policies.
+func (n *Network) Verify(publicKey []byte, message []byte, signature []byte) bool {
+	return crypto.Verify(n.opts.signaturePolicy, n.opts.hashPolicy, publicKey, message, signature)
+}
+
 func (n *Network) dispatchMessage(client *PeerClient, msg *protobuf.Message) {
 	if !client.IsIncomingReady() {
 		return
 	}
+
+	if job := n.prepareDispatch(client, msg); job != nil {
+		n.goWorker(workerDispatch, job)
+	}
+}
+
+// decodeMessageBody unmarshals the body of a message based on its opcode.
+func decodeMessageBody(code opcode.Opcode, body []byte) (proto.Message, error) {
 	var ptr proto.Message
-	// unmarshal message based on specified opcode
-	code := opcode.Opcode(msg.Opcode)
+
 	switch code {
 	case opcode.BytesCode:
 		ptr = new(protobuf.Bytes)
@@ -146,25 +292,127 @@ func (n *Network) dispatchMessage(client *PeerClient, msg *protobuf.Message) {
 		ptr = new(protobuf.LookupNodeRequest)
 	case opcode.LookupNodeResponseCode:
 		ptr = new(protobuf.LookupNodeResponse)
+	case opcode.StoreRequestCode:
+		ptr = new(protobuf.StoreRequest)
+	case opcode.StoreResponseCode:
+		ptr = new(protobuf.StoreResponse)
+	case opcode.FindValueRequestCode:
+		ptr = new(protobuf.FindValueRequest)
+	case opcode.FindValueResponseCode:
+		ptr = new(protobuf.FindValueResponse)
+	case opcode.AddProviderRequestCode:
+		ptr = new(protobuf.AddProviderRequest)
+	case opcode.AddProviderResponseCode:
+		ptr = new(protobuf.AddProviderResponse)
+	case opcode.GetProvidersRequestCode:
+		ptr = new(protobuf.GetProvidersRequest)
+	case opcode.GetProvidersResponseCode:
+		ptr = new(protobuf.GetProvidersResponse)
+	case opcode.IdentityLinkCode:
+		ptr = new(protobuf.IdentityLink)
+	case opcode.RoutingSummaryRequestCode:
+		ptr = new(protobuf.RoutingSummaryRequest)
+	case opcode.RoutingSummaryResponseCode:
+		ptr = new(protobuf.RoutingSummaryResponse)
+	case opcode.BlockRequestCode:
+		ptr = new(protobuf.BlockRequest)
+	case opcode.BlockResponseCode:
+		ptr = new(protobuf.BlockResponse)
+	case opcode.ManifestRequestCode:
+		ptr = new(protobuf.ManifestRequest)
+	case opcode.ManifestResponseCode:
+		ptr = new(protobuf.ManifestResponse)
+	case opcode.ChunkRequestCode:
+		ptr = new(protobuf.ChunkRequest)
+	case opcode.ChunkResponseCode:
+		ptr = new(protobuf.ChunkResponse)
+	case opcode.TimeRequestCode:
+		ptr = new(protobuf.TimeRequest)
+	case opcode.TimeResponseCode:
+		ptr = new(protobuf.TimeResponse)
+	case opcode.BridgeMessageCode:
+		ptr = new(protobuf.BridgeMessage)
+	case opcode.ErrorReplyCode:
+		ptr = new(protobuf.ErrorReply)
+	case opcode.MetadataGossipCode:
+		ptr = new(protobuf.MetadataGossip)
+	case opcode.ReconcileRequestCode:
+		ptr = new(protobuf.ReconcileRequest)
+	case opcode.ReconcileResponseCode:
+		ptr = new(protobuf.ReconcileResponse)
+	case opcode.SnapshotRequestCode:
+		ptr = new(protobuf.SnapshotRequest)
+	case opcode.SnapshotOfferCode:
+		ptr = new(protobuf.SnapshotOffer)
+	case opcode.GroupKeyCode:
+		ptr = new(protobuf.GroupKey)
+	case opcode.GroupMessageCode:
+		ptr = new(protobuf.GroupMessage)
+	case opcode.TopologyRequestCode:
+		ptr = new(protobuf.TopologyRequest)
+	case opcode.TopologyResponseCode:
+		ptr = new(protobuf.TopologyResponse)
+	case opcode.RevocationGossipCode:
+		ptr = new(protobuf.RevocationGossip)
+	case opcode.StoreBatchRequestCode:
+		ptr = new(protobuf.StoreBatchRequest)
 	case opcode.UnregisteredCode:
-		log.Error().Msg("network: message received had no opcode")
-		return
+		return nil, errors.New("network: message received had no opcode")
 	default:
 		var err error
 		ptr, err = opcode.GetMessageType(code)
 		if err != nil {
-			log.Error().Err(err).Msg("network: received message opcode is not registered")
-			return
+			return nil, errors.Wrap(err, "network: received message opcode is not registered")
 		}
 	}
 
-	if len(msg.Message) > 0 {
-		if err := proto.Unmarshal(msg.Message, ptr); err != nil {
-			log.Error().Msgf("%v", err)
-			return
+	if len(body) > 0 {
+		if err := proto.Unmarshal(body, ptr); err != nil {
+			return nil, errors.Wrap(err, "network: failed to unmarshal message body")
+		}
+	}
+
+	return ptr, nil
+}
+
+// replyError replies to a request with an error code.
+func (n *Network) replyError(client *PeerClient, msg *protobuf.Message, code ErrorCode) {
+	ctx := tracing.ContextWithTraceID(context.Background(), fromTraceContext(msg.Trace).TraceID)
+
+	if err := client.Reply(ctx, msg.RequestNonce, &protobuf.ErrorReply{Code: uint32(code)}); err != nil {
+		n.log().Warn().Err(err).Uint64("code", uint64(code)).Msg("Failed to reply to request with an error.")
+	}
+}
+
+// prepareDispatch decodes a message received from a peer, and returns a job
+// dispatching it to all plugins. Nil is returned should the message not
+// decode, or be handled without involving plugins.
+func (n *Network) prepareDispatch(client *PeerClient, msg *protobuf.Message) func() {
+	// unmarshal message based on specified opcode
+	code := opcode.Opcode(msg.Opcode)
+	traceID := fromTraceContext(msg.Trace).TraceID
+
+	// Requests are replied to with an error code should they not be served,
+	// such that the requester need not wait for them to time out.
+	isRequest := msg.RequestNonce > 0 && !msg.ReplyFlag
+
+	ptr, err := decodeMessageBody(code, msg.Message)
+	if err != nil {
+		n.log().Error().Err(err).Str("trace_id", traceID.String()).Msg("")
+
+		if _, unknown := opcode.GetMessageType(code); unknown != nil && isRequest {
+			n.replyError(client, msg, CodeUnknownService)
 		}
+		return nil
 	}
 
+	if isRequest && n.Closed() {
+		n.replyError(client, msg, CodeShuttingDown)
+		return nil
+	}
+
+	headers := n.receiveHeaders(client, msg)
+
 	if msg.RequestNonce > 0 && msg.ReplyFlag {
 		if _state, exists := client.Requests.Load(msg.RequestNonce); exists {
 			state := _state.(*RequestState)
@@ -172,49 +420,78 @@ func (n *Network) dispatchMessage(client *PeerClient, msg *protobuf.Message) {
 			case state.data <- ptr:
 			case <-state.closeSignal:
 			}
-			return
+			return nil
 		}
 	}
 
+	if class := n.QoSClass(code); !client.allowQoS(class, n.opts.qos.policies[class]) {
+		n.rateLimited(client, msg, class)
+		return nil
+	} else if n.sheds(class) {
+		n.shed(client, msg, class)
+		return nil
+	}
+
 	switch msgRaw := ptr.(type) {
 	case *protobuf.Bytes:
 		client.handleBytes(msgRaw.Data)
+		return nil
 	default:
+		span := n.opts.tracer.StartSpanWithParent("noise.Dispatch", fromTraceContext(msg.Trace))
+		span.SetTag("opcode", uint32(code))
+		span.SetTag("peer_address", client.Address)
+
 		ctx := contextPool.Get().(*PluginContext)
 		ctx.client = client
 		ctx.message = msgRaw
 		ctx.nonce = msg.RequestNonce
+		ctx.span = span
+		ctx.traceID = traceID
+		ctx.headers = headers
+		ctx.hops = msg.Hops
+		ctx.path = msg.Path
+
+		if isRequest && msg.Timeout > 0 {
+			ctx.deadline = time.Now().Add(time.Duration(msg.Timeout))
+		}
 
-		go func() {
-			// Execute 'on receive message' callback for all plugins.
-			n.plugins.Each(func(plugin PluginInterface) {
-				if err := plugin.Receive(ctx); err != nil {
-					log.Error().Err(err).Msg("")
+		return func() {
+			spanCtx := tracing.ContextWithSpan(context.Background(), span)
+
+			// Execute 'on receive message' callback for all plugins, queueing
+			// the message for plugins with limits.
+			for _, info := range n.plugins.values {
+				if info.limiter != nil {
+					n.receiveLimited(spanCtx, info, ctx)
+				} else {
+					n.receive(spanCtx, info.Plugin, ctx)
 				}
-			})
+			}
+
+			span.Finish()
 
+			ctx.span = nil
+			ctx.traceID = tracing.TraceID{}
+			ctx.deadline = time.Time{}
+			ctx.ctx = nil
+			ctx.headers = nil
+			ctx.hops = 0
+			ctx.path = nil
 			contextPool.Put(ctx)
-		}()
+		}
 	}
 }
 
 // Listen starts listening for peers on a port.
 func (n *Network) Listen() {
-	// Handle 'network starts listening' callback for plugins.
-	n.plugins.Each(func(plugin PluginInterface) {
-		plugin.Startup(n)
-	})
-
-	// Handle 'network stops listening' callback for plugins.
-	defer func() {
-		n.plugins.Each(func(plugin PluginInterface) {
-			plugin.Cleanup(n)
-		})
-	}()
-
 	addrInfo, err := ParseAddress(n.Address)
 	if err != nil {
-		log.Fatal().Err(err).Msg("")
+		n.log().Fatal().Err(err).Msg("")
+	}
+
+	if n.listener != nil {
+		n.Serve(n.listener)
+		return
 	}
 
 	var listener net.Listener
@@ -222,16 +499,36 @@ func (n *Network) Listen() {
 	if t, exists := n.transports.Load(addrInfo.Protocol); exists {
 		listener, err = t.(transport.Layer).Listen(int(addrInfo.Port))
 		if err != nil {
-			log.Fatal().Err(err).Msg("")
+			n.log().Fatal().Err(err).Msg("")
 		}
 	} else {
 		err := errors.New("network: invalid protocol " + addrInfo.Protocol)
-		log.Fatal().Err(err).Msg("")
+		n.log().Fatal().Err(err).Msg("")
 	}
 
+	n.Serve(listener)
+}
+
+// Serve accepts peers from a listener until the node is closed, such as a
+// listener of VirtualHosts handing over connections to the node.
+func (n *Network) Serve(listener net.Listener) {
+	// Handle 'network starts listening' callback for plugins.
+	n.plugins.Each(func(plugin PluginInterface) {
+		n.safely(plugin, "Startup", nil, func() { plugin.Startup(n) })
+	})
+
+	// Handle 'network stops listening' callback for plugins, in reverse order
+	// of their startup.
+	defer func() {
+		n.plugins.EachReverse(func(plugin PluginInterface) {
+			n.safely(plugin, "Cleanup", nil, func() { plugin.Cleanup(n) })
+		})
+		close(n.stopped)
+	}()
+
 	n.startListening()
 
-	log.Info().
+	n.log().Info().
 		Str("address", n.Address).
 		Msg("Listening for peers.")
 
@@ -246,16 +543,18 @@ func (n *Network) Listen() {
 
 	// Handle new clients.
 	for {
+		n.awaitAccepting()
+
 		if conn, err := listener.Accept(); err == nil {
-			go n.Accept(conn)
+			n.goWorker(workerAccept, func() { n.Accept(conn) })
 		} else {
 			// if the Shutdown flag is set, no need to continue with the for loop
 			select {
 			case <-n.kill:
-				log.Info().Msgf("Shutting down server %s.", n.Address)
+				n.log().Info().Msgf("Shutting down server %s.", n.Address)
 				return
 			default:
-				log.Error().Msgf("%v", err)
+				n.log().Error().Msgf("%v", err)
 			}
 		}
 	}
@@ -269,20 +568,29 @@ func (n *Network) Client(address string) (*PeerClient, error) {
 	}
 
 	if address == n.Address {
-		return nil, errors.New("network: peer should not dial itself")
+		return nil, ErrSelfSend
 	}
 
-	clientNew, err := createPeerClient(n, address)
-	if err != nil {
-		return nil, err
+	// Clients of known peers are returned without creating a new client.
+	c, exists := n.peers.Load(address)
+	if !exists {
+		clientNew, err := createPeerClient(n, address)
+		if err != nil {
+			return nil, err
+		}
+
+		// Should the peer have been stored in the meantime, the new client is
+		// discarded, and its context released.
+		if c, exists = n.peers.LoadOrStore(address, clientNew); exists {
+			clientNew.cancel()
+		}
 	}
 
-	c, exists := n.peers.LoadOrStore(address, clientNew)
 	if exists {
 		client := c.(*PeerClient)
 
 		if !client.IsOutgoingReady() {
-			return nil, errors.New("network: peer failed to connect")
+			return nil, peerError(ErrPeerUnreachable, address, nil)
 		}
 
 		return client, nil
@@ -293,17 +601,37 @@ func (n *Network) Client(address string) (*PeerClient, error) {
 		client.setOutgoingReady()
 	}()
 
+	span := n.opts.tracer.StartSpanWithParent("noise.Dial", tracing.SpanContext{})
+	span.SetTag("peer_address", address)
+	defer span.Finish()
+
 	conn, err := n.Dial(address)
+	if err == nil {
+		if err = n.greet(conn, address); err == nil {
+			err = n.attest(conn, address)
+		}
+		if err != nil {
+			conn.Close()
+		}
+	}
 	if err != nil {
+		span.SetError(err)
 		n.peers.Delete(address)
-		return nil, err
+		return nil, peerError(ErrPeerUnreachable, address, err)
 	}
 
-	n.connections.Store(address, &ConnState{
+	state := &ConnState{
 		conn:        conn,
 		writer:      bufio.NewWriterSize(conn, n.opts.writeBufferSize),
 		writerMutex: new(sync.Mutex),
-	})
+		address:     address,
+		connectedAt: n.Clock().Now(),
+	}
+	if n.sender != nil {
+		state.queue = new(sendQueue)
+	}
+
+	n.connections.Store(address, state)
 
 	client.Init()
 
@@ -335,24 +663,132 @@ func (n *Network) BlockUntilListening() {
 	<-n.listeningCh
 }
 
+// SeedResult is the outcome of bootstrapping with a single seed peer.
+type SeedResult struct {
+	Address string
+	Err     error
+}
+
+// BootstrapResult reports the outcome of bootstrapping with a number of seed
+// peers, such that applications may decide whether or not they are
+// sufficiently connected.
+type BootstrapResult struct {
+	// Seeds holds the outcome of bootstrapping with every seed peer.
+	Seeds []SeedResult
+	// Peers is the number of peers connected to once bootstrapped.
+	Peers int
+	// Elapsed is how long bootstrapping took.
+	Elapsed time.Duration
+}
+
+// Succeeded returns the number of seed peers which were successfully handshaked with.
+func (r BootstrapResult) Succeeded() int {
+	succeeded := 0
+	for _, seed := range r.Seeds {
+		if seed.Err == nil {
+			succeeded++
+		}
+	}
+	return succeeded
+}
+
 // Bootstrap with a number of peers and commence a handshake.
-func (n *Network) Bootstrap(addresses ...string) {
+func (n *Network) Bootstrap(addresses ...string) (result BootstrapResult) {
 	n.BlockUntilListening()
 
-	addresses = FilterPeers(n.Address, addresses)
+	start := time.Now()
+
+	// Peers persisted to the seeds file are contacted before the bootstrap
+	// peers given, should the latter be unreachable.
+	addresses = FilterPeers(n.Address, append(n.loadSeeds(), addresses...))
 
 	for _, address := range addresses {
 		client, err := n.Client(address)
-
-		if err != nil {
-			log.Error().Err(err).Msg("")
-			continue
+		if err == nil {
+			nonce := client.NewChallenge()
+			err = client.Tell(context.Background(), &protobuf.Ping{Nonce: nonce, Stamp: n.NewStamp(nonce)})
 		}
 
-		err = client.Tell(context.Background(), &protobuf.Ping{})
 		if err != nil {
-			continue
+			cause := classifyHandshakeFailure(err)
+
+			n.log().Error().
+				Err(err).
+				Str("peer_address", address).
+				Str("cause", string(cause)).
+				Msg("network: failed to bootstrap with peer")
+			n.handshakeFailed(context.Background(), cause, audit.ReasonDialFailed, peer.ID{Address: address}, err)
+
+			err = peerError(ErrHandshakeFailed, address, err)
 		}
+
+		result.Seeds = append(result.Seeds, SeedResult{Address: address, Err: err})
+	}
+
+	n.eachPeer(func(client *PeerClient) bool {
+		result.Peers++
+		return true
+	})
+
+	result.Elapsed = time.Since(start)
+
+	if result.Succeeded() > 0 {
+		atomic.StoreInt32(&n.bootstrapped, 1)
+	}
+
+	return
+}
+
+// Bootstrapped returns whether or not the node has successfully bootstrapped
+// with at least one seed.
+func (n *Network) Bootstrapped() bool {
+	return atomic.LoadInt32(&n.bootstrapped) == 1
+}
+
+// Heartbeat returns the time the node's I/O loop last ticked at. A heartbeat
+// lagging far behind the current time indicates the node is unresponsive.
+func (n *Network) Heartbeat() time.Time {
+	return time.Unix(0, atomic.LoadInt64(&n.heartbeat))
+}
+
+// Logger returns the logger the node was built with, or nil should the node
+// write events to the global logger.
+func (n *Network) Logger() log.Logger {
+	return n.opts.logger
+}
+
+// Log returns the logger of a module of noise, such as a plugin, which writes
+// events to the logger of the node.
+func (n *Network) Log(module string) log.Module {
+	return log.For(module, n.opts.logger)
+}
+
+// Clock returns the clock the node and its plugins tell time and schedule
+// timers against.
+func (n *Network) Clock() clock.Clock {
+	if n.opts.clock == nil {
+		return clock.Real()
+	}
+	return n.opts.clock
+}
+
+// Random returns the source of randomness of the node and its plugins.
+func (n *Network) Random() *Random {
+	return n.opts.random
+}
+
+// log returns the logger of the network module.
+func (n *Network) log() log.Module {
+	return n.Log("network")
+}
+
+// Closed returns whether or not the node has been closed.
+func (n *Network) Closed() bool {
+	select {
+	case <-n.kill:
+		return true
+	default:
+		return false
 	}
 }
 
@@ -378,7 +814,7 @@ func (n *Network) Dial(address string) (net.Conn, error) {
 	t, exists := n.transports.Load(addrInfo.Protocol)
 	if !exists {
 		err := errors.New("network: invalid protocol " + addrInfo.Protocol)
-		log.Fatal().Err(err).Msg("")
+		n.log().Fatal().Err(err).Msg("")
 	}
 
 	var conn net.Conn
@@ -396,8 +832,13 @@ func (n *Network) Accept(incoming net.Conn) {
 
 	recvWindow := NewRecvWindow(n.opts.recvWindowSize)
 
+	// Memory held by messages of the peer yet to be dispatched.
+	memory := n.memory.peer(n.opts.maxPeerInboundMemory)
+
 	// Cleanup connections when we are done with them.
 	defer func() {
+		memory.releaseAll()
+
 		time.Sleep(1 * time.Second)
 
 		if client != nil {
@@ -409,28 +850,116 @@ func (n *Network) Accept(incoming net.Conn) {
 		}
 	}()
 
+	greeted := false
+
+	// Challenge issued in reply to the attestation request of the peer, and
+	// the peer which presented attestation evidence accepted by the verifier.
+	var challenge []byte
+	var attested *peer.ID
+
 	for {
-		msg, err := n.receiveMessage(incoming)
+		msg, reserved, err := n.receiveMessage(incoming, memory)
 		if err != nil {
 			if err != errEmptyMsg {
-				log.Error().Msgf("%v", err)
+				n.log().Error().Msgf("%v", err)
 			}
 			break
 		}
 
-		// Initialize client if not exists.
+		// Connections dialed to virtual hosts open with a greeting, which
+		// carries no message.
+		if isGreeting(msg) {
+			memory.release(reserved)
+
+			if client != nil || greeted || challenge != nil || attested != nil {
+				n.log().Error().Msg("Received a greeting past the start of a connection.")
+				break
+			}
+
+			greeted = true
+			continue
+		}
+
+		// Connections dialed by nodes with an attestor ask for a challenge
+		// after their greeting, and present attestation evidence signed
+		// together with it, which is verified should the node have been built
+		// with a verifier.
+		if isAttestationRequest(msg) {
+			memory.release(reserved)
+
+			if client != nil || challenge != nil {
+				n.log().Error().Msg("Received an attestation request past the start of a connection.")
+				break
+			}
+
+			if challenge, err = n.challengeAttestation(incoming); err != nil {
+				n.log().Error().Err(err).Msg("Failed to reply to an attestation request.")
+				break
+			}
+			continue
+		}
+
+		if isAttestation(msg) {
+			memory.release(reserved)
+
+			if client != nil || attested != nil {
+				n.log().Error().Msg("Received attestation evidence past the start of a connection.")
+				break
+			}
+
+			if n.opts.attestationVerifier == nil {
+				continue
+			}
+
+			if err := n.verifyAttestation(msg, challenge); err != nil {
+				id := peer.ID{}
+				if msg.Sender != nil {
+					id = peer.ID(*msg.Sender)
+				}
+
+				n.handshakeFailed(context.Background(), FailureAttestation, audit.ReasonAttestation, id, err)
+				return
+			}
+
+			id := peer.ID(*msg.Sender)
+			attested = &id
+			continue
+		}
+
+		// Messages over connections gated on attestation must be signed, such
+		// that none but the attested peer may send them.
+		if attested != nil && msg.Signature == nil {
+			memory.release(reserved)
+
+			n.log().Error().Str("peer_address", msg.Sender.Address).Msg("Received an unsigned message over a connection gated on attestation.")
+			break
+		}
+
+		// Initialize client if not exists, refusing peers filtered out by
+		// plugins.
 		if client == nil {
+			if n.opts.attestationVerifier != nil && (attested == nil || !attested.Equals(peer.ID(*msg.Sender))) {
+				n.handshakeFailed(context.Background(), FailureAttestation, audit.ReasonAttestation, peer.ID(*msg.Sender), ErrAttestationFailed)
+				return
+			}
+
+			if !n.allowPeer(peer.ID(*msg.Sender)) {
+				n.handshakeFailed(context.Background(), FailureRefused, audit.ReasonRefused, peer.ID(*msg.Sender), ErrPeerRefused)
+				return
+			}
+
 			client, err = n.Client(msg.Sender.Address)
 
 			if err != nil {
+				n.handshakeFailed(context.Background(), classifyHandshakeFailure(err), audit.ReasonDialFailed, peer.ID(*msg.Sender), err)
 				return
 			}
 		}
 
 		client.Do(func() {
-			client.ID = (*peer.ID)(msg.Sender)
+			client.setID((*peer.ID)(msg.Sender))
 
-			if !n.ConnectionStateExists(client.ID.Address) {
+			if !n.ConnectionStateExists(msg.Sender.Address) {
 				err = errors.New("network: failed to load session")
 			}
 
@@ -438,33 +967,63 @@ func (n *Network) Accept(incoming net.Conn) {
 		})
 
 		if err != nil {
-			log.Error().Err(err).Msg("network: error initializing client")
+			n.log().Error().Err(err).Msg("network: error initializing client")
+			n.handshakeFailed(context.Background(), FailureOther, audit.ReasonSessionFailed, peer.ID(*msg.Sender), err)
 			return
 		}
 
-		go func() {
+		n.goWorker(workerRecv, func() {
 			// Peer sent message with a completely different ID. Disconnect.
-			if !client.ID.Equals(peer.ID(*msg.Sender)) {
-				log.Error().
+			if !client.ID().Equals(peer.ID(*msg.Sender)) {
+				traceID := fromTraceContext(msg.Trace).TraceID
+
+				n.log().Error().
 					Interface("peer_id", peer.ID(*msg.Sender)).
-					Interface("client_id", client.ID).
+					Interface("client_id", client.ID()).
+					Str("trace_id", traceID.String()).
 					Msg("Message signed by peer does not match client ID.")
+				n.handshakeFailed(
+					tracing.ContextWithTraceID(context.Background(), traceID),
+					FailureIdentityMismatch, audit.ReasonIDMismatch, peer.ID(*msg.Sender), nil,
+				)
+				memory.release(reserved)
 				return
 			}
 
-			recvWindow.Push(msg.MessageNonce, msg)
+			recvWindow.Push(msg.MessageNonce, &inboundMessage{msg: msg, reserved: reserved})
 
 			ready := recvWindow.Pop()
-			for _, msg := range ready {
-				msg := msg
-				client.Submit(func() {
-					n.dispatchMessage(client, msg.(*protobuf.Message))
-				})
+			for _, inbound := range ready {
+				inbound := inbound.(*inboundMessage)
+				job := func() {
+					n.dispatchMessage(client, inbound.msg)
+					memory.release(inbound.reserved)
+				}
+
+				if n.qosPolicy(opcode.Opcode(inbound.msg.Opcode)).Priority {
+					client.submitPriority(job)
+				} else {
+					client.Submit(job)
+				}
 			}
-		}()
+		})
 	}
 }
 
+// allowPeer returns whether or not all plugins implementing PeerFilter allow a
+// peer to connect.
+func (n *Network) allowPeer(id peer.ID) bool {
+	allowed := true
+
+	n.plugins.Each(func(plugin PluginInterface) {
+		if filter, ok := plugin.(PeerFilter); ok && allowed {
+			allowed = filter.AllowPeer(id)
+		}
+	})
+
+	return allowed
+}
+
 // Plugin returns a plugins proxy interface should it be registered with the
 // network. The second returning parameter is false otherwise.
 //
@@ -498,7 +1057,21 @@ func (n *Network) PrepareMessage(ctx context.Context, message proto.Message) (*p
 		Sender:  &id,
 	}
 
-	if GetSignMessage(ctx) {
+	// Correlate the message under the trace of ctx, or under a new trace.
+	if span := tracing.SpanFromContext(ctx); span != nil {
+		msg.Trace = toTraceContext(span.Context)
+	} else {
+		traceID := tracing.TraceIDFromContext(ctx)
+		if traceID.IsZero() {
+			traceID = tracing.NewTraceID()
+		}
+		msg.Trace = toTraceContext(tracing.SpanContext{TraceID: traceID})
+	}
+
+	// Messages are always signed in audit mode, such that their records prove
+	// what was sent, and by nodes which attest, such that peers gating on
+	// attestation may authenticate them.
+	if GetSignMessage(ctx) || n.opts.trail != nil || n.opts.attestor != nil {
 		signature, err := n.keys.Sign(
 			n.opts.signaturePolicy,
 			n.opts.hashPolicy,
@@ -510,41 +1083,128 @@ func (n *Network) PrepareMessage(ctx context.Context, message proto.Message) (*p
 		msg.Signature = signature
 	}
 
+	if err := n.stampHops(ctx, msg); err != nil {
+		return nil, err
+	}
+
+	n.sendHeaders(ctx, msg)
+
+	// Compress the body once signed, such that signatures cover its contents.
+	n.compressMessage(msg)
+
 	return msg, nil
 }
 
+// stampHops counts a hop on a message should ctx be relaying an incoming one,
+// and extends the path it was relayed along should it be recorded. Messages
+// originated by this node start their path should RecordPaths be set.
+func (n *Network) stampHops(ctx context.Context, msg *protobuf.Message) error {
+	r := getRelay(ctx)
+	if r == nil {
+		if n.opts.recordPaths {
+			msg.Path = [][]byte{n.ID.PublicKey}
+		}
+		return nil
+	}
+
+	if r.hops >= n.opts.maxHops {
+		return ErrHopLimit
+	}
+	msg.Hops = r.hops + 1
+
+	if len(r.path) > 0 || n.opts.recordPaths {
+		msg.Path = append(append(make([][]byte, 0, len(r.path)+1), r.path...), n.ID.PublicKey)
+	}
+	return nil
+}
+
 // Write asynchronously sends a message to a denoted target address.
 func (n *Network) Write(address string, message *protobuf.Message) error {
 	state, ok := n.ConnectionState(address)
 	if !ok {
-		return errors.New("network: connection does not exist")
+		return peerError(ErrPeerUnreachable, address, errors.New("connection does not exist"))
 	}
 
 	message.MessageNonce = atomic.AddUint64(&state.messageNonce, 1)
 
+	// In audit mode, messages are only sent once recorded.
+	if err := n.recordOutbound(address, message); err != nil {
+		return peerError(ErrPeerUnreachable, address, err)
+	}
+
+	priority := n.qosPolicy(opcode.Opcode(message.Opcode)).Priority
+
+	// Should sending be fair, frames are queued to be written by send
+	// workers upon the turn of the peer.
+	if state.queue != nil {
+		buffer, f, err := n.encodeFrame(address, message)
+		if err != nil {
+			return peerError(ErrPeerUnreachable, address, err)
+		}
+		if f.Drop {
+			return nil
+		}
+
+		if err := n.enqueue(state, queuedFrame{buffer: buffer, opcode: message.Opcode, duplicates: f.Duplicates, flush: priority}); err != nil {
+			return peerError(ErrPeerUnreachable, address, err)
+		}
+
+		return nil
+	}
+
 	state.conn.SetWriteDeadline(time.Now().Add(n.opts.writeTimeout))
 
-	err := n.sendMessage(state.writer, message, state.writerMutex)
+	err := n.sendMessage(address, state.writer, message, state.writerMutex)
 	if err != nil {
-		return err
+		return peerError(ErrPeerUnreachable, address, err)
+	}
+
+	// Flush messages with priority rather than waiting on the flusher.
+	if priority {
+		state.writerMutex.Lock()
+		err = state.writer.Flush()
+		state.writerMutex.Unlock()
+
+		if err != nil {
+			return peerError(ErrPeerUnreachable, address, err)
+		}
 	}
+
 	return nil
 }
 
-// Broadcast asynchronously broadcasts a message to all peer clients.
-func (n *Network) Broadcast(ctx context.Context, message proto.Message) {
+// Broadcast asynchronously broadcasts a message to all peer clients, or to
+// another set of peers specified through Targets. Should ctx be derived from
+// an incoming message, such as by PluginContext.Context, the peer it arrived
+// from is skipped.
+func (n *Network) Broadcast(ctx context.Context, message proto.Message, opts ...BroadcastOption) {
 	signed, err := n.PrepareMessage(ctx, message)
+	if err == ErrHopLimit {
+		n.log().Debug().Err(err).Msg("network: dropped message instead of relaying it")
+		return
+	}
 	if err != nil {
-		log.Error().Err(err).Msg("network: failed to broadcast message")
+		n.log().Error().Err(err).Msg("network: failed to broadcast message")
+		return
+	}
+
+	o := newBroadcastOptions(ctx, opts)
+
+	if o.targets.kind != targetConnected {
+		n.broadcastDirectory(signed, o)
 		return
 	}
 
 	n.eachPeer(func(client *PeerClient) bool {
+		if o.skips(client) {
+			return true
+		}
+
 		err := n.Write(client.Address, signed)
 		if err != nil {
-			log.Warn().
+			n.log().Warn().
 				Err(err).
-				Interface("peer_id", client.ID).
+				Interface("peer_id", client.ID()).
 				Msg("failed to send message to peer")
 		}
 		return true
@@ -575,38 +1235,125 @@ func (n *Network) BroadcastByIDs(ctx context.Context, message proto.Message, ids
 	}
 }
 
-// BroadcastRandomly asynchronously broadcasts a message to random selected K peers.
+// BroadcastRandomly asynchronously broadcasts a message to K peers sampled
+// uniformly at random among all peers, or should WeightPeers be specified, with
+// probability proportional to their weight. Should PreferScored be specified,
+// the best scoring peers are picked first. Peers are skipped as by Broadcast.
 // Does not guarantee broadcasting to exactly K peers.
-func (n *Network) BroadcastRandomly(ctx context.Context, message proto.Message, K int) {
-	var addresses []string
+func (n *Network) BroadcastRandomly(ctx context.Context, message proto.Message, K int, opts ...BroadcastOption) {
+	var clients []*PeerClient
 
-	n.eachPeer(func(client *PeerClient) bool {
-		addresses = append(addresses, client.Address)
+	o := newBroadcastOptions(ctx, opts)
 
-		// Limit total amount of addresses in case we have a lot of peers.
-		return len(addresses) <= K*3
+	n.eachPeer(func(client *PeerClient) bool {
+		if !o.skips(client) {
+			clients = append(clients, client)
+		}
+		return true
 	})
 
-	// Flip a coin and shuffle :).
-	rand.Shuffle(len(addresses), func(i, j int) {
-		addresses[i], addresses[j] = addresses[j], addresses[i]
+	// Peers are ranged over in no particular order, so sort them for samples
+	// to be reproducible from a seed.
+	sort.Slice(clients, func(i, j int) bool {
+		return clients[i].Address < clients[j].Address
 	})
 
-	if len(addresses) < K {
-		K = len(addresses)
+	var addresses []string
+	for _, client := range n.pick(o, clients, K) {
+		addresses = append(addresses, client.Address)
 	}
 
-	n.BroadcastByAddresses(ctx, message, addresses[:K]...)
+	n.BroadcastByAddresses(ctx, message, addresses...)
 }
 
 // Close shuts down the entire network.
 func (n *Network) Close() {
+	ctx, cancel := context.WithTimeout(context.Background(), n.opts.shutdownTimeout)
+	defer cancel()
+
+	n.Shutdown(ctx)
+}
+
+// Shutdown stops the node from accepting peers, and has plugins flush their
+// state before disconnecting from all peers. Plugins implementing
+// PluginShutdown are shut down in reverse order of their startup, and their
+// Cleanup callbacks invoked thereafter. Shutdown waits for them until ctx is
+// done, and returns the first error a plugin failed with, or the error of ctx
+// should it be done first. Calls past the first do nothing.
+func (n *Network) Shutdown(ctx context.Context) error {
+	if !atomic.CompareAndSwapInt32(&n.shutdown, 0, 1) {
+		return nil
+	}
+
 	close(n.kill)
+	n.cancel()
+
+	var err error
+
+	n.plugins.EachReverse(func(plugin PluginInterface) {
+		shutdown, ok := plugin.(PluginShutdown)
+		if !ok || ctx.Err() != nil {
+			return
+		}
+
+		var shutdownErr error
+		n.safely(plugin, "Shutdown", nil, func() { shutdownErr = shutdown.Shutdown(ctx) })
+
+		if shutdownErr != nil {
+			n.log().Warn().Err(shutdownErr).Str("plugin", reflect.TypeOf(plugin).String()).Msg("Plugin failed to shut down.")
+
+			if err == nil {
+				err = shutdownErr
+			}
+		}
+	})
+
+	// Persist the best known peers one last time before disconnecting.
+	if n.opts.seedsFile != "" {
+		if err := n.persistSeeds(); err != nil {
+			n.log().Warn().Err(err).Str("path", n.opts.seedsFile).Msg("Failed to persist seeds.")
+		}
+	}
+
+	// Frames queued but not yet written are dropped.
+	if n.sender != nil {
+		n.sender.close()
+	}
 
 	n.eachPeer(func(client *PeerClient) bool {
 		client.Close()
 		return true
 	})
+
+	// Plugins are only cleaned up should the node have been listening.
+	select {
+	case <-n.listeningCh:
+		select {
+		case <-n.stopped:
+		case <-ctx.Done():
+		}
+	default:
+		// Release listeners bound ahead of time which were never served from.
+		if n.listener != nil {
+			n.listener.Close()
+		}
+	}
+
+	if err == nil {
+		err = ctx.Err()
+	}
+
+	return err
+}
+
+// PeerCount returns the number of peer clients held by the node, connected or
+// still being dialed.
+func (n *Network) PeerCount() (count int) {
+	n.eachPeer(func(*PeerClient) bool {
+		count++
+		return true
+	})
+	return
 }
 
 func (n *Network) eachPeer(fn func(client *PeerClient) bool) {
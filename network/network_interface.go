@@ -20,9 +20,18 @@ type NetworkInterface interface {
 	// GetKeys() returns the keypair for this network
 	GetKeys() *crypto.KeyPair
 
+	// Sign signs a message with this nodes private key.
+	Sign(message []byte) ([]byte, error)
+
+	// Verify checks a signature of a message made by a public key.
+	Verify(publicKey []byte, message []byte, signature []byte) bool
+
 	// Listen starts listening for peers on a port.
 	Listen()
 
+	// Serve accepts peers from a listener until the node is closed.
+	Serve(listener net.Listener)
+
 	// Client either creates or returns a cached peer client given its host address.
 	Client(address string) (*PeerClient, error)
 
@@ -30,7 +39,7 @@ type NetworkInterface interface {
 	BlockUntilListening()
 
 	// Bootstrap with a number of peers and commence a handshake.
-	Bootstrap(addresses ...string)
+	Bootstrap(addresses ...string) BootstrapResult
 
 	// Dial establishes a bidirectional connection to an address, and additionally handshakes with said address.
 	Dial(address string) (net.Conn, error)
@@ -51,8 +60,9 @@ type NetworkInterface interface {
 	// Write asynchronously sends a message to a denoted target address.
 	Write(address string, message *protobuf.Message) error
 
-	// Broadcast asynchronously broadcasts a message to all peer clients.
-	Broadcast(ctx context.Context, message proto.Message)
+	// Broadcast asynchronously broadcasts a message to all peer clients, skipping
+	// the peer a message being relayed under ctx arrived from.
+	Broadcast(ctx context.Context, message proto.Message, opts ...BroadcastOption)
 
 	// BroadcastByAddresses broadcasts a message to a set of peer clients denoted by their addresses.
 	BroadcastByAddresses(ctx context.Context, message proto.Message, addresses ...string)
@@ -62,7 +72,15 @@ type NetworkInterface interface {
 
 	// BroadcastRandomly asynchronously broadcasts a message to random selected K peers.
 	// Does not guarantee broadcasting to exactly K peers.
-	BroadcastRandomly(ctx context.Context, message proto.Message, K int)
+	BroadcastRandomly(ctx context.Context, message proto.Message, K int, opts ...BroadcastOption)
+
+	// MultiRequest concurrently issues the same request to many peers, and returns a channel of
+	// their responses, or the errors they failed to respond with, in the order they arrive in.
+	MultiRequest(ctx context.Context, recipients []peer.ID, req proto.Message, opts ...MultiRequestOption) <-chan Response
+
+	// QuorumRequest issues the same request to many peers, and returns once a quorum of them
+	// responded with matching responses, reporting responses which diverged.
+	QuorumRequest(ctx context.Context, recipients []peer.ID, req proto.Message, quorum int, equal func(a, b proto.Message) bool, opts ...MultiRequestOption) (QuorumResult, error)
 
 	// Close shuts down the entire network.
 	Close()
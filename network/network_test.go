@@ -2,11 +2,21 @@ package network_test
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/gogo/protobuf/proto"
+	"github.com/perlin-network/noise/crypto/ed25519"
+	"github.com/perlin-network/noise/dht"
 	"github.com/perlin-network/noise/internal/test/protobuf"
 	"github.com/perlin-network/noise/network"
+	"github.com/perlin-network/noise/network/discovery"
+	"github.com/perlin-network/noise/peer"
+	"github.com/perlin-network/noise/tracing"
 	"github.com/perlin-network/noise/types/opcode"
 
 	"github.com/stretchr/testify/assert"
@@ -67,6 +77,54 @@ func testNodeBroadcast(t *testing.T, e env, numNodes int) {
 	}
 }
 
+func TestNodeBroadcastFairSend(t *testing.T) {
+	t.Parallel()
+
+	te := newTest(t, tcpEnv, network.FairSend(2))
+	te.startBoostrap(4)
+	defer te.tearDown()
+
+	expected := "test message"
+	te.bootstrapNode.Broadcast(context.Background(), &protobuf.TestMessage{Message: expected})
+
+	for i, node := range te.nodes {
+		select {
+		case received := <-te.getMailbox(node).RecvMailbox:
+			assert.Equalf(t, received.Message, expected, "Expected message %s to be received by node %d but got %v\n", expected, i+1, received.Message)
+		case <-time.After(1 * time.Second):
+			t.Errorf("Timed out attempting to receive message from Node 0.\n")
+		}
+	}
+}
+
+func TestInboundMemoryReleased(t *testing.T) {
+	if testing.Short() {
+		t.Skipf("skipping %s in short mode", t.Name())
+	}
+
+	te := newTest(t, tcpEnv, network.WriteTimeout(1*time.Second), network.MaxPeerInboundMemory(4e+6))
+	te.startBoostrap(2)
+	defer te.tearDown()
+
+	node := te.nodes[0]
+
+	for i := 0; i < 8; i++ {
+		te.bootstrapNode.Broadcast(context.Background(), &protobuf.TestMessage{Message: "test message"})
+
+		select {
+		case <-te.getMailbox(node).RecvMailbox:
+		case <-time.After(1 * time.Second):
+			t.Fatal("timed out waiting for message")
+		}
+	}
+
+	deadline := time.Now().Add(1 * time.Second)
+	for node.InboundMemory() != 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.Equal(t, int64(0), node.InboundMemory(), "expected memory of dispatched messages to be released")
+}
+
 func TestNodeBroadcastByIDs(t *testing.T) {
 	if testing.Short() {
 		t.Skipf("skipping %s in short mode", t.Name())
@@ -102,6 +160,139 @@ func testNodeBroadcastByIDs(t *testing.T, e env, numNodes, numPeers int) {
 	}
 }
 
+func TestNodeBroadcastExcludePeers(t *testing.T) {
+	if testing.Short() {
+		t.Skipf("skipping %s in short mode", t.Name())
+	}
+
+	te := newTest(t, tcpEnv, network.WriteTimeout(1*time.Second))
+	te.startBoostrap(5)
+	defer te.tearDown()
+
+	excluded := te.getPeers(te.bootstrapNode)[:2]
+	te.bootstrapNode.Broadcast(context.Background(), &protobuf.TestMessage{Message: "test message"}, network.ExcludePeers(excluded...))
+
+	time.Sleep(100 * time.Millisecond)
+
+	for _, node := range te.nodes {
+		numMsgs := len(te.getMailbox(node).RecvMailbox)
+
+		if isIn(node.Address, excluded...) {
+			assert.Equalf(t, 0, numMsgs, "expected excluded node [%v] to receive no messages", node.Address)
+		} else {
+			assert.Equalf(t, 1, numMsgs, "expected node [%v] to receive the message", node.Address)
+		}
+	}
+}
+
+// relayPlugin relays messages of the origin to all peers.
+type relayPlugin struct {
+	*network.Plugin
+}
+
+func (p *relayPlugin) Receive(ctx *network.PluginContext) error {
+	if msg, ok := ctx.Message().(*protobuf.TestMessage); ok && msg.Message == "origin" {
+		ctx.Network().Broadcast(ctx.Context(), &protobuf.TestMessage{Message: "relayed"})
+	}
+	return nil
+}
+
+func TestNodeBroadcastOriginSuppression(t *testing.T) {
+	if testing.Short() {
+		t.Skipf("skipping %s in short mode", t.Name())
+	}
+
+	te := newTest(t, tcpEnv, network.WriteTimeout(1*time.Second))
+	te.startBoostrap(4, new(relayPlugin))
+	defer te.tearDown()
+
+	te.bootstrapNode.Broadcast(context.Background(), &protobuf.TestMessage{Message: "origin"})
+
+	time.Sleep(200 * time.Millisecond)
+
+	assert.Equal(t, 0, len(te.getMailbox(te.bootstrapNode).RecvMailbox), "expected relays to not be echoed back to their origin")
+
+	for _, node := range te.nodes {
+		// Every node receives the message of the origin, and its relays by
+		// the two other nodes.
+		assert.Equalf(t, 3, len(te.getMailbox(node).RecvMailbox), "expected node [%v] to receive relays of its peers", node.Address)
+	}
+}
+
+// directoryPlugin knows of a fixed list of peers, sorted by distance.
+type directoryPlugin struct {
+	*network.Plugin
+	peers []peer.ID
+}
+
+func (p *directoryPlugin) KnownPeers() []peer.ID {
+	return p.peers
+}
+
+func (p *directoryPlugin) ClosestPeers(target peer.ID, count int) []peer.ID {
+	if count > len(p.peers) {
+		count = len(p.peers)
+	}
+	return p.peers[:count]
+}
+
+func TestNodeBroadcastTargets(t *testing.T) {
+	if testing.Short() {
+		t.Skipf("skipping %s in short mode", t.Name())
+	}
+
+	directory := new(directoryPlugin)
+
+	var nodes []*network.Network
+	for i := 0; i < 3; i++ {
+		builder := network.NewBuilderWithOptions(network.WriteTimeout(1 * time.Second))
+		builder.SetKeys(ed25519.RandomKeyPair())
+		listener, address, err := network.ListenOnRandomPort()
+		assert.Equal(t, nil, err)
+		builder.SetListener(listener)
+		builder.SetAddress(address)
+		builder.AddPlugin(new(MailBoxPlugin))
+
+		if i == 0 {
+			builder.AddPlugin(directory)
+		}
+
+		node, err := builder.Build()
+		assert.Equal(t, nil, err)
+		defer node.Close()
+
+		go node.Listen()
+		node.BlockUntilListening()
+
+		nodes = append(nodes, node)
+	}
+
+	te := &testSuite{t: t}
+	sender, known := nodes[0], nodes[1:]
+
+	for _, node := range known {
+		directory.peers = append(directory.peers, node.ID)
+	}
+
+	received := func() (counts []int) {
+		time.Sleep(200 * time.Millisecond)
+		for _, node := range known {
+			counts = append(counts, len(te.getMailbox(node).RecvMailbox))
+		}
+		return counts
+	}
+
+	// Known peers are not dialed by default.
+	sender.Broadcast(context.Background(), &protobuf.TestMessage{Message: "connected"})
+	assert.Equal(t, []int{0, 0}, received())
+
+	sender.Broadcast(context.Background(), &protobuf.TestMessage{Message: "closest"}, network.Targets(network.Closest(1)))
+	assert.Equal(t, []int{1, 0}, received())
+
+	sender.Broadcast(context.Background(), &protobuf.TestMessage{Message: "known"}, network.Targets(network.Known))
+	assert.Equal(t, []int{2, 1}, received())
+}
+
 func TestNodeBroadcastByAddresses(t *testing.T) {
 	if testing.Short() {
 		t.Skipf("skipping %s in short mode", t.Name())
@@ -184,3 +375,601 @@ func testClientRequest(t *testing.T, e env, numNodes int) {
 	}(ctx)
 	cancel()
 }
+
+func TestMultiRequest(t *testing.T) {
+	if testing.Short() {
+		t.Skipf("skipping %s in short mode", t.Name())
+	}
+
+	te := newTest(t, tcpEnv, network.WriteTimeout(1*time.Second), network.ConnectionTimeout(1*time.Second))
+	te.startBoostrap(4, new(clientTestPlugin))
+	defer te.tearDown()
+
+	var recipients []peer.ID
+	for _, node := range te.nodes {
+		recipients = append(recipients, node.ID)
+	}
+
+	unreachable := peer.CreateID(network.FormatAddress("tcp", "localhost", uint16(network.GetRandomUnusedPort())), ed25519.RandomKeyPair().PublicKey)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	responded := make(map[string]bool)
+	for response := range te.bootstrapNode.MultiRequest(ctx, append(recipients, unreachable), &protobuf.TestMessage{Message: "test message"}) {
+		if response.Peer.Equals(unreachable) {
+			assert.NotNil(t, response.Err, "expected unreachable peers to fail to respond")
+			continue
+		}
+
+		assert.Equal(t, nil, response.Err)
+		assert.Equal(t, "test message", response.Message.(*protobuf.TestMessage).Message)
+		responded[response.Peer.Address] = true
+	}
+	assert.Len(t, responded, len(recipients), "expected every peer to respond")
+
+	var quorum []network.Response
+	for response := range te.bootstrapNode.MultiRequest(ctx, recipients, &protobuf.TestMessage{Message: "test message"}, network.Quorum(2)) {
+		quorum = append(quorum, response)
+	}
+	assert.Len(t, quorum, 2, "expected requests to stop once a quorum responded")
+}
+
+// staleReplyPlugin echoes test message requests, save for the node of the
+// stale address which replies with a stale message.
+type staleReplyPlugin struct {
+	*network.Plugin
+	stale atomic.Value
+}
+
+func (p *staleReplyPlugin) Receive(ctx *network.PluginContext) error {
+	if msg, ok := ctx.Message().(*protobuf.TestMessage); ok {
+		if ctx.Network().Address == p.stale.Load() {
+			return ctx.Reply(context.Background(), &protobuf.TestMessage{Message: "stale"})
+		}
+		return ctx.Reply(context.Background(), &protobuf.TestMessage{Message: msg.Message})
+	}
+
+	return nil
+}
+
+func TestQuorumRequest(t *testing.T) {
+	if testing.Short() {
+		t.Skipf("skipping %s in short mode", t.Name())
+	}
+
+	plugin := new(staleReplyPlugin)
+
+	te := newTest(t, tcpEnv, network.WriteTimeout(1*time.Second))
+	te.startBoostrap(5, plugin)
+	defer te.tearDown()
+
+	plugin.stale.Store(te.nodes[0].Address)
+
+	var recipients []peer.ID
+	for _, node := range te.nodes {
+		recipients = append(recipients, node.ID)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := te.bootstrapNode.QuorumRequest(ctx, recipients, &protobuf.TestMessage{Message: "latest"}, 3, nil)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "latest", result.Message.(*protobuf.TestMessage).Message)
+	assert.Len(t, result.Agreed, 3)
+	for _, response := range result.Divergent {
+		assert.Equal(t, te.nodes[0].ID, response.Peer, "expected only the stale peer to diverge")
+	}
+
+	result, err = te.bootstrapNode.QuorumRequest(ctx, recipients, &protobuf.TestMessage{Message: "latest"}, 4, nil)
+	assert.Equal(t, network.ErrNoQuorum, err)
+	assert.Nil(t, result.Message)
+	assert.Len(t, result.Divergent, 4, "expected all responses to be reported without a quorum")
+
+	// Comparators may consider responses to match despite them differing.
+	anything := func(a, b proto.Message) bool { return true }
+	result, err = te.bootstrapNode.QuorumRequest(ctx, recipients, &protobuf.TestMessage{Message: "latest"}, 4, anything)
+	assert.Equal(t, nil, err)
+	assert.Len(t, result.Agreed, 4)
+}
+
+// rateLimitPlugin replies to all test message requests with CodeRateLimited.
+type rateLimitPlugin struct {
+	*network.Plugin
+}
+
+func (p *rateLimitPlugin) Receive(ctx *network.PluginContext) error {
+	if _, ok := ctx.Message().(*protobuf.TestMessage); ok {
+		return ctx.ReplyError(context.Background(), network.CodeRateLimited, "slow down")
+	}
+
+	return nil
+}
+
+func TestClientRequestError(t *testing.T) {
+	if testing.Short() {
+		t.Skipf("skipping %s in short mode", t.Name())
+	}
+
+	te := newTest(t, tcpEnv, network.WriteTimeout(1*time.Second))
+	te.startBoostrap(2, new(rateLimitPlugin))
+	defer te.tearDown()
+
+	client, err := te.bootstrapNode.Client(te.nodes[0].Address)
+	assert.Equal(t, nil, err, "expected client error to be nil")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	response, err := client.Request(ctx, &protobuf.TestMessage{Message: "test message"})
+	assert.Equal(t, nil, response, "expected response to be nil")
+	assert.True(t, errors.Is(err, network.ErrRateLimited), "expected the request to be rate limited, got %v", err)
+
+	var peerErr *network.PeerError
+	if assert.True(t, errors.As(err, &peerErr), "expected a peer error") {
+		assert.Equal(t, te.nodes[0].Address, peerErr.Address, "expected the error to name the peer")
+		assert.Equal(t, "slow down", peerErr.Err.Error(), "expected the error to carry the peers message")
+	}
+}
+
+// deadlinePlugin records the deadlines of test message requests, and waits
+// for their contexts to be done.
+type deadlinePlugin struct {
+	*network.Plugin
+
+	deadlines chan time.Time
+	errs      chan error
+}
+
+func (p *deadlinePlugin) Receive(ctx *network.PluginContext) error {
+	if _, ok := ctx.Message().(*protobuf.TestMessage); ok {
+		deadline, _ := ctx.Context().Deadline()
+		p.deadlines <- deadline
+
+		<-ctx.Context().Done()
+		p.errs <- ctx.Context().Err()
+	}
+
+	return nil
+}
+
+func TestClientRequestDeadline(t *testing.T) {
+	if testing.Short() {
+		t.Skipf("skipping %s in short mode", t.Name())
+	}
+
+	plugin := &deadlinePlugin{deadlines: make(chan time.Time, 1), errs: make(chan error, 1)}
+
+	te := newTest(t, tcpEnv, network.WriteTimeout(1*time.Second))
+	te.startBoostrap(2, plugin)
+	defer te.tearDown()
+
+	client, err := te.nodes[0].Client(te.bootstrapNode.Address)
+	assert.Equal(t, nil, err, "expected client error to be nil")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+
+	expected, _ := ctx.Deadline()
+
+	_, err = client.Request(ctx, &protobuf.TestMessage{Message: "test message"})
+	assert.Equal(t, context.DeadlineExceeded, err, "expected the request to time out")
+
+	select {
+	case deadline := <-plugin.deadlines:
+		assert.False(t, deadline.IsZero(), "expected the handler to be given a deadline")
+		skew := deadline.Sub(expected)
+		assert.True(t, skew > -100*time.Millisecond && skew < 100*time.Millisecond, "expected the deadline of the requester, off by %s", skew)
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for the request to be handled")
+	}
+
+	select {
+	case err := <-plugin.errs:
+		assert.Equal(t, context.DeadlineExceeded, err, "expected the handlers context to expire")
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for the handlers context to expire")
+	}
+}
+
+type spanRecorder struct {
+	sync.Mutex
+	spans []*tracing.Span
+}
+
+func (r *spanRecorder) Export(span *tracing.Span) {
+	r.Lock()
+	r.spans = append(r.spans, span)
+	r.Unlock()
+}
+
+func (r *spanRecorder) find(name string) []*tracing.Span {
+	r.Lock()
+	defer r.Unlock()
+
+	var spans []*tracing.Span
+	for _, span := range r.spans {
+		if span.Name == name {
+			spans = append(spans, span)
+		}
+	}
+	return spans
+}
+
+func TestTracePropagation(t *testing.T) {
+	if testing.Short() {
+		t.Skipf("skipping %s in short mode", t.Name())
+	}
+
+	recorder := new(spanRecorder)
+
+	te := newTest(t, tcpEnv, network.WriteTimeout(1*time.Second), network.Tracer(tracing.NewTracer(recorder)))
+	te.startBoostrap(2, new(clientTestPlugin))
+	defer te.tearDown()
+
+	client, err := te.bootstrapNode.Client(te.nodes[0].Address)
+	assert.Equal(t, nil, err, "expected client error to be nil")
+
+	_, err = client.Request(context.Background(), &protobuf.TestMessage{Message: "test message"})
+	assert.Equal(t, nil, err, "expected request error to be nil")
+
+	requests := recorder.find("noise.Request")
+	assert.NotEqual(t, 0, len(requests), "expected request span to be exported")
+	traceID := requests[len(requests)-1].Context.TraceID
+
+	found := false
+	for _, span := range recorder.find("noise.Dispatch") {
+		if span.Context.TraceID == traceID && span.ParentID != (tracing.SpanID{}) {
+			found = true
+		}
+	}
+	assert.Equal(t, true, found, "expected remote dispatch span to continue the request trace")
+}
+
+func TestTraceSampling(t *testing.T) {
+	if testing.Short() {
+		t.Skipf("skipping %s in short mode", t.Name())
+	}
+
+	recorder := new(spanRecorder)
+	tracer := tracing.NewTracer(recorder, tracing.WithSampler(tracing.NeverSample()))
+
+	te := newTest(t, tcpEnv, network.WriteTimeout(1*time.Second), network.Tracer(tracer))
+	te.startBoostrap(2, new(clientTestPlugin))
+	defer te.tearDown()
+
+	client, err := te.bootstrapNode.Client(te.nodes[0].Address)
+	assert.Equal(t, nil, err, "expected client error to be nil")
+
+	_, err = client.Request(context.Background(), &protobuf.TestMessage{Message: "test message"})
+	assert.Equal(t, nil, err, "expected request error to be nil")
+	assert.Equal(t, 0, len(recorder.find("noise.Request")), "expected unsampled request span to not be exported")
+
+	// Requests made within a sampled trace are traced across nodes regardless
+	// of the sampler, as the decision is carried along with the trace.
+	parent := tracing.SpanContext{TraceID: tracing.NewTraceID(), Sampled: true}
+	parent.SpanID[0] = 1
+
+	ctx := tracing.ContextWithSpan(context.Background(), tracer.StartSpanWithParent("root", parent))
+
+	_, err = client.Request(ctx, &protobuf.TestMessage{Message: "test message"})
+	assert.Equal(t, nil, err, "expected request error to be nil")
+
+	found := false
+	for _, span := range recorder.find("noise.Dispatch") {
+		if span.Context.TraceID == parent.TraceID {
+			found = true
+		}
+	}
+	assert.Equal(t, true, found, "expected remote dispatch span of the sampled trace to be exported")
+}
+
+// traceRelayPlugin records the trace IDs of test messages received per node,
+// and relays test messages to the address they hold.
+type traceRelayPlugin struct {
+	*network.Plugin
+	traceIDs sync.Map // address -> tracing.TraceID
+}
+
+func (p *traceRelayPlugin) Receive(ctx *network.PluginContext) error {
+	msg, ok := ctx.Message().(*protobuf.TestMessage)
+	if !ok {
+		return nil
+	}
+
+	p.traceIDs.Store(ctx.Network().Address, ctx.TraceID())
+
+	if msg.Message == "" {
+		return nil
+	}
+
+	client, err := ctx.Network().Client(msg.Message)
+	if err != nil {
+		return err
+	}
+	return client.Tell(ctx.Context(), &protobuf.TestMessage{})
+}
+
+func TestTraceIDPropagation(t *testing.T) {
+	if testing.Short() {
+		t.Skipf("skipping %s in short mode", t.Name())
+	}
+
+	plugin := new(traceRelayPlugin)
+
+	te := newTest(t, tcpEnv, network.WriteTimeout(1*time.Second))
+	te.startBoostrap(3, plugin)
+	defer te.tearDown()
+
+	client, err := te.bootstrapNode.Client(te.nodes[0].Address)
+	assert.Equal(t, nil, err, "expected client error to be nil")
+
+	traceID := tracing.NewTraceID()
+	ctx := tracing.ContextWithTraceID(context.Background(), traceID)
+
+	err = client.Tell(ctx, &protobuf.TestMessage{Message: te.nodes[1].Address})
+	assert.Equal(t, nil, err, "expected tell error to be nil")
+
+	for i := 0; i < 50; i++ {
+		if _, relayed := plugin.traceIDs.Load(te.nodes[1].Address); relayed {
+			break
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	for _, node := range te.nodes {
+		received, _ := plugin.traceIDs.Load(node.Address)
+		assert.Equal(t, traceID, received, "expected %s to receive the message under the given trace ID", node.Address)
+	}
+}
+
+func TestDHTStoreFindValue(t *testing.T) {
+	if testing.Short() {
+		t.Skipf("skipping %s in short mode", t.Name())
+	}
+
+	te := newTest(t, tcpEnv, network.WriteTimeout(1*time.Second))
+	te.startBoostrap(4)
+	defer te.tearDown()
+
+	key, expected := []byte("key"), []byte("value")
+
+	_, found := discovery.FindValue(te.nodes[1], key, 3)
+	assert.Equal(t, false, found, "expected value to not be found before being stored")
+
+	err := discovery.StoreValue(te.nodes[0], key, expected)
+	assert.Equal(t, nil, err, "expected store error to be nil")
+
+	value, found := discovery.FindValue(te.nodes[1], key, 3)
+	assert.Equal(t, true, found, "expected value to be found")
+	assert.Equal(t, expected, value, "expected found value to be %s, got %s", expected, value)
+
+	// Held by node 0 alone, such that it may only be found by querying peers.
+	plugin, _ := te.nodes[0].Plugin(discovery.PluginID)
+	err = plugin.(*discovery.Plugin).Records.Put([]byte("held"), expected, time.Now().Add(time.Hour))
+	assert.Equal(t, nil, err, "expected put error to be nil")
+
+	value, found = discovery.FindValue(te.nodes[1], []byte("held"), 0)
+	assert.Equal(t, true, found, "expected value to be found with alpha clamped to at least 1")
+	assert.Equal(t, expected, value, "expected found value to be %s, got %s", expected, value)
+}
+
+func TestDHTProviders(t *testing.T) {
+	if testing.Short() {
+		t.Skipf("skipping %s in short mode", t.Name())
+	}
+
+	te := newTest(t, tcpEnv, network.WriteTimeout(1*time.Second))
+	te.startBoostrap(4)
+	defer te.tearDown()
+
+	key := []byte("content hash")
+
+	err := discovery.Provide(te.nodes[0], key)
+	assert.Equal(t, nil, err, "expected provide error to be nil")
+
+	providers := discovery.FindProviders(te.nodes[1], key, 3, 1)
+	assert.Equal(t, 1, len(providers), "expected a single provider to be found")
+	if len(providers) == 1 {
+		assert.Equal(t, te.nodes[0].Address, providers[0].Address, "expected provider to be node 0")
+	}
+}
+
+func TestDHTRecursiveLookup(t *testing.T) {
+	if testing.Short() {
+		t.Skipf("skipping %s in short mode", t.Name())
+	}
+
+	te := newTest(t, tcpEnv, network.WriteTimeout(1*time.Second))
+	te.startBoostrap(4)
+	defer te.tearDown()
+
+	for _, node := range append(te.nodes, te.bootstrapNode) {
+		plugin, _ := node.Plugin(discovery.PluginID)
+		plugin.(*discovery.Plugin).RecursiveLookups = true
+	}
+
+	target := te.nodes[2].ID
+	peers := discovery.FindNode(te.nodes[0], target, 3, 2)
+
+	assert.NotEqual(t, 0, len(peers), "expected recursive lookup to find peers")
+	if len(peers) > 0 {
+		assert.Equal(t, target.Address, peers[0].Address, "expected target to be the closest peer found")
+	}
+}
+
+func TestDHTSendTo(t *testing.T) {
+	if testing.Short() {
+		t.Skipf("skipping %s in short mode", t.Name())
+	}
+
+	te := newTest(t, tcpEnv, network.WriteTimeout(1*time.Second))
+	te.startBoostrap(4)
+	defer te.tearDown()
+
+	expected := "test message"
+	target := te.nodes[2]
+
+	err := discovery.SendTo(context.Background(), te.nodes[0], target.ID.PublicKey, &protobuf.TestMessage{Message: expected})
+	assert.Equal(t, nil, err, "expected send error to be nil")
+
+	select {
+	case received := <-te.getMailbox(target).RecvMailbox:
+		assert.Equal(t, expected, received.Message, "expected message %s to be received, got %s", expected, received.Message)
+	case <-time.After(1 * time.Second):
+		t.Errorf("Timed out attempting to receive message sent by public key.\n")
+	}
+}
+
+func TestBootstrapResult(t *testing.T) {
+	if testing.Short() {
+		t.Skipf("skipping %s in short mode", t.Name())
+	}
+
+	te := newTest(t, tcpEnv, network.WriteTimeout(1*time.Second))
+	te.startBoostrap(2)
+	defer te.tearDown()
+
+	unreachable := network.FormatAddress("tcp", "localhost", uint16(network.GetRandomUnusedPort()))
+	result := te.nodes[0].Bootstrap(te.bootstrapNode.Address, unreachable)
+
+	assert.Equal(t, 2, len(result.Seeds), "expected a result for every seed")
+	assert.Equal(t, 1, result.Succeeded(), "expected only the reachable seed to succeed")
+	assert.Equal(t, nil, result.Seeds[0].Err, "expected bootstrapping with a live seed to succeed")
+	assert.NotEqual(t, nil, result.Seeds[1].Err, "expected bootstrapping with an unreachable seed to fail")
+	assert.True(t, errors.Is(result.Seeds[1].Err, network.ErrHandshakeFailed), "expected the handshake to have failed")
+	assert.True(t, errors.Is(result.Seeds[1].Err, network.ErrPeerUnreachable), "expected the seed to be unreachable")
+	assert.NotEqual(t, 0, result.Peers, "expected to be connected to peers")
+
+	_, err := te.nodes[0].Client(te.nodes[0].Address)
+	assert.True(t, errors.Is(err, network.ErrSelfSend), "expected dialing itself to fail")
+}
+
+func TestSnapshot(t *testing.T) {
+	if testing.Short() {
+		t.Skipf("skipping %s in short mode", t.Name())
+	}
+
+	te := newTest(t, tcpEnv)
+	te.startBoostrap(2)
+	defer te.tearDown()
+
+	snapshot := te.nodes[0].Snapshot()
+
+	assert.Equal(t, te.nodes[0].Address, snapshot.Address)
+	assert.Equal(t, te.nodes[0].ID.PublicKeyHex(), snapshot.PublicKey)
+	assert.True(t, snapshot.Listening, "expected the node to be listening")
+	assert.True(t, snapshot.Bootstrapped, "expected the node to be bootstrapped")
+	assert.True(t, snapshot.UptimeSeconds > 0, "expected the node to have been up")
+	assert.NotEqual(t, "", snapshot.Version)
+
+	if assert.Equal(t, 1, len(snapshot.Peers)) {
+		assert.Equal(t, te.bootstrapNode.Address, snapshot.Peers[0].Address)
+		assert.Equal(t, te.bootstrapNode.ID.PublicKeyHex(), snapshot.Peers[0].PublicKey)
+	}
+
+	routes, ok := snapshot.Plugins["*discovery.Plugin"].(dht.Stats)
+	assert.True(t, ok, "expected the routing table to be summarized")
+	assert.Equal(t, 1, routes.Peers)
+
+	_, err := json.Marshal(snapshot)
+	assert.Equal(t, nil, err)
+}
+
+func TestIdentityRotation(t *testing.T) {
+	if testing.Short() {
+		t.Skipf("skipping %s in short mode", t.Name())
+	}
+
+	te := newTest(t, tcpEnv, network.WriteTimeout(1*time.Second))
+	te.startBoostrap(3)
+	defer te.tearDown()
+
+	builder := network.NewBuilderWithOptions(te.builderOptions...)
+	builder.SetKeys(te.e.signature.RandomKeyPair())
+	builder.SetAddress(network.FormatAddress(te.e.networkType, "localhost", uint16(network.GetRandomUnusedPort())))
+	builder.AddPlugin(new(discovery.Plugin))
+
+	replacement, err := builder.Build()
+	assert.Equal(t, nil, err, "expected build error to be nil")
+	go replacement.Listen()
+
+	retiring := te.nodes[0]
+	te.nodes[0] = replacement
+
+	err = discovery.RotateIdentity(context.Background(), retiring, replacement, 200*time.Millisecond)
+	assert.Equal(t, nil, err, "expected identity rotation error to be nil")
+
+	plugin, _ := te.bootstrapNode.Plugin(discovery.PluginID)
+	routes := plugin.(*discovery.Plugin).Routes
+
+	assert.Equal(t, true, routes.PeerExists(replacement.ID), "expected the new identity to be within the routing table")
+	assert.Equal(t, false, routes.PeerExists(retiring.ID), "expected the retired identity to be migrated away")
+}
+
+func TestDHTQueryEvents(t *testing.T) {
+	if testing.Short() {
+		t.Skipf("skipping %s in short mode", t.Name())
+	}
+
+	te := newTest(t, tcpEnv, network.WriteTimeout(1*time.Second))
+	te.startBoostrap(4)
+	defer te.tearDown()
+
+	var mutex sync.Mutex
+	counts := make(map[discovery.QueryEventType]int)
+
+	plugin, _ := te.nodes[0].Plugin(discovery.PluginID)
+	plugin.(*discovery.Plugin).Observer = discovery.QueryObserverFunc(func(event discovery.QueryEvent) {
+		mutex.Lock()
+		counts[event.Type]++
+		mutex.Unlock()
+	})
+
+	discovery.FindNode(te.nodes[0], te.nodes[2].ID, 3, 2)
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	assert.Equal(t, 1, counts[discovery.QueryStarted], "expected a single query to be started")
+	assert.Equal(t, 1, counts[discovery.QueryFinished], "expected a single query to be finished")
+	assert.NotEqual(t, 0, counts[discovery.PeerQueried], "expected peers to be queried")
+}
+
+func TestDHTStamps(t *testing.T) {
+	if testing.Short() {
+		t.Skipf("skipping %s in short mode", t.Name())
+	}
+
+	te := newTest(t, tcpEnv, network.WriteTimeout(1*time.Second), network.StampDifficulty(8))
+	te.startBoostrap(4)
+	defer te.tearDown()
+
+	target := te.nodes[2].ID
+	peers := discovery.FindNode(te.nodes[0], target, 3, 2)
+
+	assert.NotEqual(t, 0, len(peers), "expected stamped lookup to find peers")
+	if len(peers) > 0 {
+		assert.Equal(t, target.Address, peers[0].Address, "expected target to be the closest peer found")
+	}
+
+	// A node which does not stamp its pings should not be let into routing tables.
+	builder := network.NewBuilderWithOptions(network.WriteTimeout(1 * time.Second))
+	builder.SetKeys(te.e.signature.RandomKeyPair())
+	builder.SetAddress(network.FormatAddress(te.e.networkType, "localhost", uint16(network.GetRandomUnusedPort())))
+	builder.AddPlugin(new(discovery.Plugin))
+
+	unstamped, err := builder.Build()
+	assert.Equal(t, nil, err, "expected build error to be nil")
+	go unstamped.Listen()
+	defer unstamped.Close()
+
+	unstamped.Bootstrap(te.bootstrapNode.Address)
+	time.Sleep(200 * time.Millisecond)
+
+	plugin, _ := te.bootstrapNode.Plugin(discovery.PluginID)
+	routes := plugin.(*discovery.Plugin).Routes
+
+	assert.Equal(t, false, routes.PeerExists(unstamped.ID), "expected unstamped peer to not be within the routing table")
+}
@@ -0,0 +1,358 @@
+package peerstore
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// Backend stores opaque values under opaque keys. Implementations must be safe
+// for concurrent use.
+//
+// Databases such as BoltDB or Badger may be plugged into a store by wrapping
+// them as a Backend.
+type Backend interface {
+	// Get returns the value held under a key, and whether or not one is.
+	Get(key []byte) ([]byte, bool, error)
+	// Put stores a value under a key, replacing any value held under it.
+	Put(key []byte, value []byte) error
+	// Delete removes the value held under a key.
+	Delete(key []byte) error
+	// Range calls fn for every key and value until fn returns false.
+	Range(fn func(key, value []byte) bool) error
+	// Close releases the backend.
+	Close() error
+}
+
+// MemoryBackend is a Backend held in memory, which is lost once the process
+// exits.
+type MemoryBackend struct {
+	sync.RWMutex
+	entries map[string][]byte
+}
+
+var _ Backend = (*MemoryBackend)(nil)
+
+// NewMemoryBackend creates a backend held in memory.
+func NewMemoryBackend() *MemoryBackend {
+	return &MemoryBackend{entries: make(map[string][]byte)}
+}
+
+// Get returns the value held under a key, and whether or not one is.
+func (b *MemoryBackend) Get(key []byte) ([]byte, bool, error) {
+	b.RLock()
+	defer b.RUnlock()
+
+	value, exists := b.entries[string(key)]
+	return value, exists, nil
+}
+
+// Put stores a value under a key, replacing any value held under it.
+func (b *MemoryBackend) Put(key []byte, value []byte) error {
+	b.Lock()
+	defer b.Unlock()
+
+	b.entries[string(key)] = append([]byte(nil), value...)
+	return nil
+}
+
+// Delete removes the value held under a key.
+func (b *MemoryBackend) Delete(key []byte) error {
+	b.Lock()
+	defer b.Unlock()
+
+	delete(b.entries, string(key))
+	return nil
+}
+
+// Range calls fn for every key and value until fn returns false. fn may not
+// modify the backend.
+func (b *MemoryBackend) Range(fn func(key, value []byte) bool) error {
+	b.RLock()
+	defer b.RUnlock()
+
+	for key, value := range b.entries {
+		if !fn([]byte(key), value) {
+			break
+		}
+	}
+	return nil
+}
+
+// Close does nothing, as there is nothing to release.
+func (b *MemoryBackend) Close() error {
+	return nil
+}
+
+const (
+	opPut byte = iota + 1
+	opDelete
+)
+
+// compactRatio is how many times larger than its live entries a log may grow
+// before being compacted.
+const compactRatio = 4
+
+// FileBackend is a Backend persisted as an append-only log within a file, with
+// all entries held in memory. The log is replayed upon being opened, and
+// compacted once it grows much larger than the entries it holds.
+type FileBackend struct {
+	*MemoryBackend
+
+	path   string
+	file   *os.File
+	writer *bufio.Writer
+
+	// Sizes in bytes of the log, and of the entries live within it.
+	size, live int64
+}
+
+var _ Backend = (*FileBackend)(nil)
+
+// OpenFile opens a backend persisted within a file, creating it should it not
+// exist.
+func OpenFile(path string) (*FileBackend, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, errors.Wrapf(err, "peerstore: failed to open %s", path)
+	}
+
+	b := &FileBackend{MemoryBackend: NewMemoryBackend(), path: path, file: file}
+
+	if err := b.replay(); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	b.writer = bufio.NewWriter(file)
+
+	return b, nil
+}
+
+// replay loads the entries of the log, truncating any record torn by a crash
+// midway through being appended.
+func (b *FileBackend) replay() error {
+	reader := bufio.NewReader(b.file)
+
+	for {
+		op, key, value, n, err := readRecord(reader)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			if err := b.file.Truncate(b.size); err != nil {
+				return errors.Wrapf(err, "peerstore: failed to truncate torn record of %s", b.path)
+			}
+			break
+		}
+
+		b.apply(op, key, value)
+		b.size += n
+	}
+
+	if _, err := b.file.Seek(b.size, io.SeekStart); err != nil {
+		return errors.Wrapf(err, "peerstore: failed to seek %s", b.path)
+	}
+
+	return nil
+}
+
+// apply applies a record to the entries held in memory. The caller must hold
+// the lock should the backend be in use.
+func (b *FileBackend) apply(op byte, key, value []byte) {
+	if existing, exists := b.entries[string(key)]; exists {
+		b.live -= recordSize(key, existing)
+		delete(b.entries, string(key))
+	}
+
+	if op == opPut {
+		b.entries[string(key)] = value
+		b.live += recordSize(key, value)
+	}
+}
+
+// Put stores a value under a key, replacing any value held under it.
+func (b *FileBackend) Put(key []byte, value []byte) error {
+	return b.append(opPut, key, append([]byte(nil), value...))
+}
+
+// Delete removes the value held under a key.
+func (b *FileBackend) Delete(key []byte) error {
+	b.RLock()
+	_, exists := b.entries[string(key)]
+	b.RUnlock()
+
+	if !exists {
+		return nil
+	}
+
+	return b.append(opDelete, key, nil)
+}
+
+func (b *FileBackend) append(op byte, key, value []byte) error {
+	b.Lock()
+	defer b.Unlock()
+
+	n, err := writeRecord(b.writer, op, key, value)
+	if err == nil {
+		err = b.writer.Flush()
+	}
+	if err != nil {
+		return errors.Wrapf(err, "peerstore: failed to append to %s", b.path)
+	}
+
+	b.apply(op, key, value)
+	b.size += n
+
+	if b.size > compactRatio*b.live && b.size > 64*1024 {
+		return b.compact()
+	}
+
+	return nil
+}
+
+// Compact rewrites the log such that it only holds live entries.
+func (b *FileBackend) Compact() error {
+	b.Lock()
+	defer b.Unlock()
+
+	return b.compact()
+}
+
+func (b *FileBackend) compact() error {
+	path := b.path + ".compact"
+
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return errors.Wrapf(err, "peerstore: failed to create %s", path)
+	}
+
+	writer := bufio.NewWriter(file)
+
+	var size int64
+
+	for key, value := range b.entries {
+		n, err := writeRecord(writer, opPut, []byte(key), value)
+		if err != nil {
+			file.Close()
+			os.Remove(path)
+			return errors.Wrapf(err, "peerstore: failed to compact %s", b.path)
+		}
+		size += n
+	}
+
+	if err = writer.Flush(); err == nil {
+		err = file.Sync()
+	}
+	if err == nil {
+		err = os.Rename(path, b.path)
+	}
+	if err != nil {
+		file.Close()
+		os.Remove(path)
+		return errors.Wrapf(err, "peerstore: failed to compact %s", b.path)
+	}
+
+	b.file.Close()
+
+	b.file, b.writer = file, bufio.NewWriter(file)
+	b.size, b.live = size, size
+
+	return nil
+}
+
+// Close syncs the log to disk and closes it.
+func (b *FileBackend) Close() error {
+	b.Lock()
+	defer b.Unlock()
+
+	if err := b.writer.Flush(); err != nil {
+		b.file.Close()
+		return errors.Wrapf(err, "peerstore: failed to flush %s", b.path)
+	}
+
+	if err := b.file.Sync(); err != nil {
+		b.file.Close()
+		return errors.Wrapf(err, "peerstore: failed to sync %s", b.path)
+	}
+
+	return b.file.Close()
+}
+
+// A record is encoded as an op byte, followed by the key and value each
+// prefixed by their uvarint-encoded lengths.
+
+func recordSize(key, value []byte) int64 {
+	var buf [binary.MaxVarintLen64]byte
+	return int64(1 + binary.PutUvarint(buf[:], uint64(len(key))) + len(key) + binary.PutUvarint(buf[:], uint64(len(value))) + len(value))
+}
+
+func writeRecord(w io.Writer, op byte, key, value []byte) (int64, error) {
+	buf := make([]byte, 0, recordSize(key, value))
+
+	buf = append(buf, op)
+	buf = binary.AppendUvarint(buf, uint64(len(key)))
+	buf = append(buf, key...)
+	buf = binary.AppendUvarint(buf, uint64(len(value)))
+	buf = append(buf, value...)
+
+	n, err := w.Write(buf)
+	return int64(n), err
+}
+
+// maxRecordField bounds the length of keys and values read from a log, such
+// that corrupt lengths are not allocated.
+const maxRecordField = 16 * 1024 * 1024
+
+func readRecord(r *bufio.Reader) (op byte, key, value []byte, n int64, err error) {
+	if op, err = r.ReadByte(); err != nil {
+		return
+	}
+
+	if op != opPut && op != opDelete {
+		err = errors.Errorf("peerstore: unknown record op %d", op)
+		return
+	}
+
+	if key, err = readField(r); err != nil {
+		return
+	}
+
+	if value, err = readField(r); err != nil {
+		return
+	}
+
+	n = recordSize(key, value)
+	return
+}
+
+func readField(r *bufio.Reader) ([]byte, error) {
+	length, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, noEOF(err)
+	}
+
+	if length > maxRecordField {
+		return nil, errors.Errorf("peerstore: record field of %d bytes is too large", length)
+	}
+
+	field := make([]byte, length)
+	if _, err := io.ReadFull(r, field); err != nil {
+		return nil, noEOF(err)
+	}
+
+	return field, nil
+}
+
+// noEOF reports a log ending midway through a record as torn, rather than as
+// having ended.
+func noEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
@@ -0,0 +1,72 @@
+package peerstore_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/perlin-network/noise/network/peerstore"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileBackend(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "peers")
+
+	backend, err := peerstore.OpenFile(path)
+	assert.Equal(t, nil, err)
+
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, nil, backend.Put([]byte(fmt.Sprint(i)), []byte(fmt.Sprint("value ", i))))
+	}
+	assert.Equal(t, nil, backend.Put([]byte("0"), []byte("replaced")))
+	assert.Equal(t, nil, backend.Delete([]byte("1")))
+	assert.Equal(t, nil, backend.Close())
+
+	// Records torn midway through being appended are dropped upon reopening.
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0600)
+	assert.Equal(t, nil, err)
+	file.Write([]byte{1, 5, 'a'})
+	file.Close()
+
+	backend, err = peerstore.OpenFile(path)
+	assert.Equal(t, nil, err)
+
+	value, exists, err := backend.Get([]byte("0"))
+	assert.Equal(t, nil, err)
+	assert.True(t, exists)
+	assert.Equal(t, []byte("replaced"), value)
+
+	_, exists, _ = backend.Get([]byte("1"))
+	assert.False(t, exists)
+
+	value, _, _ = backend.Get([]byte("9"))
+	assert.Equal(t, []byte("value 9"), value)
+
+	assert.Equal(t, nil, backend.Put([]byte("10"), []byte("appended")))
+
+	before, _ := os.Stat(path)
+	assert.Equal(t, nil, backend.Compact())
+	after, _ := os.Stat(path)
+	assert.True(t, after.Size() < before.Size(), "expected compaction to shrink the log")
+
+	assert.Equal(t, nil, backend.Put([]byte("11"), []byte("compacted")))
+	assert.Equal(t, nil, backend.Close())
+
+	backend, err = peerstore.OpenFile(path)
+	assert.Equal(t, nil, err)
+	defer backend.Close()
+
+	count := 0
+	backend.Range(func(key, value []byte) bool {
+		count++
+		return true
+	})
+	assert.Equal(t, 11, count)
+
+	value, _, _ = backend.Get([]byte("11"))
+	assert.Equal(t, []byte("compacted"), value)
+}
@@ -0,0 +1,88 @@
+package peerstore
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrInvalidKey is returned upon encrypting a store under a key which is
+	// not 16, 24 or 32 bytes long.
+	ErrInvalidKey = errors.New("peerstore: encryption key must be 16, 24 or 32 bytes long")
+	// ErrDecrypt is returned upon an entry failing to be decrypted, such as
+	// should it have been encrypted under another key.
+	ErrDecrypt = errors.New("peerstore: failed to decrypt entry")
+)
+
+// sealer encrypts entries with AES-GCM, and hides the public keys they are
+// stored under behind an HMAC. Both are keyed by subkeys derived from the key
+// of the store.
+type sealer struct {
+	aead   cipher.AEAD
+	macKey []byte
+}
+
+func newSealer(key []byte) (*sealer, error) {
+	switch len(key) {
+	case 16, 24, 32:
+	default:
+		return nil, ErrInvalidKey
+	}
+
+	block, err := aes.NewCipher(subkey(key, "encryption")[:len(key)])
+	if err != nil {
+		return nil, errors.Wrap(err, "peerstore: failed to create cipher")
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, errors.Wrap(err, "peerstore: failed to create cipher")
+	}
+
+	return &sealer{aead: aead, macKey: subkey(key, "keys")}, nil
+}
+
+// subkey derives a subkey for a given purpose from a key.
+func subkey(key []byte, purpose string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(purpose))
+	return mac.Sum(nil)
+}
+
+// hashKey returns the key a public key is stored under.
+func (s *sealer) hashKey(publicKey []byte) []byte {
+	mac := hmac.New(sha256.New, s.macKey)
+	mac.Write(publicKey)
+	return mac.Sum(nil)
+}
+
+// seal encrypts an entry, binding it to the key it is stored under such that
+// entries may not be swapped around.
+func (s *sealer) seal(key []byte, value []byte) ([]byte, error) {
+	nonce := make([]byte, s.aead.NonceSize(), s.aead.NonceSize()+len(value)+s.aead.Overhead())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, errors.Wrap(err, "peerstore: failed to generate nonce")
+	}
+
+	return s.aead.Seal(nonce, nonce, value, key), nil
+}
+
+// open decrypts an entry stored under a key.
+func (s *sealer) open(key []byte, sealed []byte) ([]byte, error) {
+	if len(sealed) < s.aead.NonceSize() {
+		return nil, ErrDecrypt
+	}
+
+	value, err := s.aead.Open(nil, sealed[:s.aead.NonceSize()], sealed[s.aead.NonceSize():], key)
+	if err != nil {
+		return nil, ErrDecrypt
+	}
+
+	return value, nil
+}
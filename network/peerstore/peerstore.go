@@ -0,0 +1,243 @@
+// Package peerstore persists what a node knows of its peers, such as their
+// addresses, signed peer records, scores, bans and metadata, on top of a
+// pluggable storage backend.
+//
+// Entries may be encrypted at rest, in which case both the public keys they
+// are stored under and their contents are hidden from whoever reads the
+// backend without the key.
+package peerstore
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Peer is everything known of a peer.
+type Peer struct {
+	PublicKey []byte `json:"public_key"`
+
+	// Addresses are the addresses the peer may be dialed on, most preferred
+	// first.
+	Addresses []string `json:"addresses,omitempty"`
+	// Record is the marshaled signed peer record of the peer, should one be
+	// held.
+	Record []byte `json:"record,omitempty"`
+	// Score rates the peer from 0 for the worst peers to 1 for the best.
+	Score float64 `json:"score,omitempty"`
+	// BannedUntil is the time the peer is banned until.
+	BannedUntil time.Time `json:"banned_until"`
+	// Metadata holds arbitrary values of subsystems keyed by their names.
+	Metadata map[string][]byte `json:"metadata,omitempty"`
+
+	// UpdatedAt is the time the peer was last updated at.
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Banned returns whether or not the peer is banned as of a given time.
+func (p Peer) Banned(now time.Time) bool {
+	return now.Before(p.BannedUntil)
+}
+
+// AddAddress inserts an address at the front of the addresses of the peer,
+// moving it there should it already be held.
+func (p *Peer) AddAddress(address string) {
+	addresses := []string{address}
+	for _, existing := range p.Addresses {
+		if existing != address {
+			addresses = append(addresses, existing)
+		}
+	}
+	p.Addresses = addresses
+}
+
+// PeerStore persists what is known of peers keyed by their public keys.
+type PeerStore interface {
+	// Get returns what is known of the peer owning a public key, and whether
+	// or not anything is.
+	Get(publicKey []byte) (Peer, bool, error)
+	// Update has fn modify what is known of the peer owning a public key, be
+	// it known of yet or not, and stores the result.
+	Update(publicKey []byte, fn func(peer *Peer)) error
+	// Delete forgets the peer owning a public key.
+	Delete(publicKey []byte) error
+	// Range calls fn for every peer until fn returns false.
+	Range(fn func(peer Peer) bool) error
+	// Close releases the backend of the store.
+	Close() error
+}
+
+// Store is a PeerStore which encodes peers as JSON into a Backend, encrypting
+// them should a key be set through Encrypt.
+type Store struct {
+	backend Backend
+	sealer  *sealer
+
+	// Serializes read-modify-write cycles of Update.
+	mutex sync.Mutex
+}
+
+var _ PeerStore = (*Store)(nil)
+
+// Option are configurable options for a peer store.
+type Option func(*Store) error
+
+// Encrypt encrypts entries at rest under a 16, 24 or 32 byte long key.
+func Encrypt(key []byte) Option {
+	return func(s *Store) (err error) {
+		s.sealer, err = newSealer(key)
+		return
+	}
+}
+
+// New creates a peer store on top of a backend.
+//
+// Example: peerstore.New(peerstore.NewMemoryBackend())
+func New(backend Backend, opts ...Option) (*Store, error) {
+	s := &Store{backend: backend}
+
+	for _, opt := range opts {
+		if err := opt(s); err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+// NewMemory creates a peer store held in memory.
+func NewMemory() *Store {
+	s, _ := New(NewMemoryBackend())
+	return s
+}
+
+// Get returns what is known of the peer owning a public key, and whether or
+// not anything is.
+func (s *Store) Get(publicKey []byte) (Peer, bool, error) {
+	value, exists, err := s.backend.Get(s.key(publicKey))
+	if err != nil || !exists {
+		return Peer{}, false, err
+	}
+
+	peer, err := s.decode(s.key(publicKey), value)
+	if err != nil {
+		return Peer{}, false, err
+	}
+
+	return peer, true, nil
+}
+
+// Update has fn modify what is known of the peer owning a public key, be it
+// known of yet or not, and stores the result.
+func (s *Store) Update(publicKey []byte, fn func(peer *Peer)) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	peer, exists, err := s.Get(publicKey)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		peer = Peer{PublicKey: append([]byte(nil), publicKey...)}
+	}
+
+	fn(&peer)
+
+	peer.PublicKey = append([]byte(nil), publicKey...)
+	peer.UpdatedAt = time.Now()
+
+	key := s.key(publicKey)
+
+	value, err := s.encode(key, peer)
+	if err != nil {
+		return err
+	}
+
+	return s.backend.Put(key, value)
+}
+
+// Delete forgets the peer owning a public key.
+func (s *Store) Delete(publicKey []byte) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.backend.Delete(s.key(publicKey))
+}
+
+// Range calls fn for every peer until fn returns false. Errors should any
+// entry fail to be decoded, such as should it be encrypted under another key.
+func (s *Store) Range(fn func(peer Peer) bool) error {
+	var err error
+
+	rangeErr := s.backend.Range(func(key, value []byte) bool {
+		var peer Peer
+
+		if peer, err = s.decode(key, value); err != nil {
+			return false
+		}
+
+		return fn(peer)
+	})
+
+	if rangeErr != nil {
+		return rangeErr
+	}
+
+	return err
+}
+
+// Close releases the backend of the store.
+func (s *Store) Close() error {
+	return s.backend.Close()
+}
+
+// Ban bans the peer owning a public key until a given time.
+func Ban(store PeerStore, publicKey []byte, until time.Time) error {
+	return store.Update(publicKey, func(peer *Peer) {
+		peer.BannedUntil = until
+	})
+}
+
+// Banned returns whether or not the peer owning a public key is banned as of
+// now. Peers are not considered banned should the store fail to be read.
+func Banned(store PeerStore, publicKey []byte) bool {
+	peer, exists, err := store.Get(publicKey)
+	return err == nil && exists && peer.Banned(time.Now())
+}
+
+// key returns the key a peer is stored under within the backend.
+func (s *Store) key(publicKey []byte) []byte {
+	if s.sealer != nil {
+		return s.sealer.hashKey(publicKey)
+	}
+	return publicKey
+}
+
+func (s *Store) encode(key []byte, peer Peer) ([]byte, error) {
+	value, err := json.Marshal(peer)
+	if err != nil {
+		return nil, errors.Wrap(err, "peerstore: failed to encode peer")
+	}
+
+	if s.sealer != nil {
+		return s.sealer.seal(key, value)
+	}
+
+	return value, nil
+}
+
+func (s *Store) decode(key []byte, value []byte) (peer Peer, err error) {
+	if s.sealer != nil {
+		if value, err = s.sealer.open(key, value); err != nil {
+			return
+		}
+	}
+
+	if err = json.Unmarshal(value, &peer); err != nil {
+		err = errors.Wrap(err, "peerstore: failed to decode peer")
+	}
+
+	return
+}
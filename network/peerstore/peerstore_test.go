@@ -0,0 +1,111 @@
+package peerstore_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/perlin-network/noise/network/peerstore"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStore(t *testing.T) {
+	t.Parallel()
+
+	store := peerstore.NewMemory()
+	defer store.Close()
+
+	alice, bob := []byte("alice"), []byte("bob")
+
+	_, exists, err := store.Get(alice)
+	assert.Equal(t, nil, err)
+	assert.False(t, exists)
+
+	assert.Equal(t, nil, store.Update(alice, func(peer *peerstore.Peer) {
+		peer.AddAddress("tcp://localhost:3000")
+		peer.Score = 0.5
+	}))
+	assert.Equal(t, nil, store.Update(alice, func(peer *peerstore.Peer) {
+		peer.AddAddress("tcp://localhost:3001")
+		peer.AddAddress("tcp://localhost:3000")
+	}))
+	assert.Equal(t, nil, store.Update(bob, func(peer *peerstore.Peer) {}))
+
+	peer, exists, err := store.Get(alice)
+	assert.Equal(t, nil, err)
+	assert.True(t, exists)
+	assert.Equal(t, alice, peer.PublicKey)
+	assert.Equal(t, []string{"tcp://localhost:3000", "tcp://localhost:3001"}, peer.Addresses)
+	assert.Equal(t, 0.5, peer.Score)
+	assert.False(t, peer.UpdatedAt.IsZero())
+
+	count := 0
+	assert.Equal(t, nil, store.Range(func(peer peerstore.Peer) bool {
+		count++
+		return true
+	}))
+	assert.Equal(t, 2, count)
+
+	assert.Equal(t, nil, store.Delete(bob))
+
+	_, exists, _ = store.Get(bob)
+	assert.False(t, exists)
+}
+
+func TestBan(t *testing.T) {
+	t.Parallel()
+
+	store := peerstore.NewMemory()
+	defer store.Close()
+
+	key := []byte("mallory")
+
+	assert.False(t, peerstore.Banned(store, key))
+
+	assert.Equal(t, nil, peerstore.Ban(store, key, time.Now().Add(time.Hour)))
+	assert.True(t, peerstore.Banned(store, key))
+
+	assert.Equal(t, nil, peerstore.Ban(store, key, time.Now().Add(-time.Second)))
+	assert.False(t, peerstore.Banned(store, key), "expected bans to lapse")
+}
+
+func TestEncrypt(t *testing.T) {
+	t.Parallel()
+
+	_, err := peerstore.New(peerstore.NewMemoryBackend(), peerstore.Encrypt([]byte("short")))
+	assert.Equal(t, peerstore.ErrInvalidKey, err)
+
+	backend := peerstore.NewMemoryBackend()
+	key := bytes.Repeat([]byte{1}, 32)
+
+	store, err := peerstore.New(backend, peerstore.Encrypt(key))
+	assert.Equal(t, nil, err)
+
+	publicKey := []byte("alice")
+	address := "tcp://localhost:3000"
+
+	assert.Equal(t, nil, store.Update(publicKey, func(peer *peerstore.Peer) {
+		peer.AddAddress(address)
+	}))
+
+	// Neither public keys nor entries are readable from the backend.
+	backend.Range(func(k, v []byte) bool {
+		assert.False(t, bytes.Contains(k, publicKey))
+		assert.False(t, bytes.Contains(v, publicKey))
+		assert.False(t, bytes.Contains(v, []byte(address)))
+		return true
+	})
+
+	peer, exists, err := store.Get(publicKey)
+	assert.Equal(t, nil, err)
+	assert.True(t, exists)
+	assert.Equal(t, []string{address}, peer.Addresses)
+
+	other, err := peerstore.New(backend, peerstore.Encrypt(bytes.Repeat([]byte{2}, 32)))
+	assert.Equal(t, nil, err)
+
+	_, exists, _ = other.Get(publicKey)
+	assert.False(t, exists, "expected entries to be hidden under other keys")
+	assert.Equal(t, peerstore.ErrDecrypt, other.Range(func(peer peerstore.Peer) bool { return true }))
+}
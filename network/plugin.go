@@ -1,5 +1,11 @@
 package network
 
+import (
+	"context"
+
+	"github.com/perlin-network/noise/peer"
+)
+
 // PluginInterface is used to proxy callbacks to a particular Plugin instance.
 type PluginInterface interface {
 	// Callback for when the network starts listening for peers.
@@ -19,6 +25,48 @@ type PluginInterface interface {
 	PeerDisconnect(client *PeerClient)
 }
 
+// PluginShutdown is implemented by plugins which flush their state, such as
+// queued messages, as the network shuts down. Shutdown is invoked before the
+// network disconnects from its peers, and should return once ctx is done.
+type PluginShutdown interface {
+	Shutdown(ctx context.Context) error
+}
+
+// PluginStatus is implemented by plugins which report their state, such as a
+// summary of their routing table, within snapshots of the network. Status
+// must return a value which may be marshaled into JSON.
+type PluginStatus interface {
+	Status() interface{}
+}
+
+// PeerDirectory is implemented by plugins which know of peers beyond those
+// connected to, such as through a routing table, such that broadcasts may
+// target them.
+type PeerDirectory interface {
+	// KnownPeers returns the IDs of all peers known of.
+	KnownPeers() []peer.ID
+	// ClosestPeers returns the IDs of up to count known peers closest to a
+	// target ID.
+	ClosestPeers(target peer.ID, count int) []peer.ID
+}
+
+// PeerScorer is implemented by plugins which rate peers, such as by their
+// round-trip time or how reliably they deliver messages, such that broadcasts
+// may prefer the best rated peers through PreferScored.
+type PeerScorer interface {
+	// PeerScore rates a peer from 0 for the worst peers to 1 for the best,
+	// and returns false should the peer not be rated yet.
+	PeerScore(id peer.ID) (float64, bool)
+}
+
+// PeerFilter is implemented by plugins which refuse peers, such as those
+// whose keys have been revoked. Connections opened by refused peers are closed
+// upon their first message.
+type PeerFilter interface {
+	// AllowPeer returns whether or not a peer may connect.
+	AllowPeer(id peer.ID) bool
+}
+
 // Plugin is an abstract class which all plugins extend.
 type Plugin struct{}
 
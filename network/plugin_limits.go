@@ -0,0 +1,138 @@
+package network
+
+import (
+	"context"
+	"reflect"
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+)
+
+// PluginLimits bounds how many messages a plugin handles at once, such that a
+// slow plugin may not hold up dispatch workers and starve other plugins.
+// Plugins without limits receive messages within the dispatch worker handling
+// them.
+type PluginLimits struct {
+	// MaxConcurrent is the most Receive callbacks of the plugin running at
+	// once.
+	MaxConcurrent int
+	// QueueSize is the most messages queued for the plugin, beyond which
+	// messages are dropped for it.
+	QueueSize int
+}
+
+// PluginQueueStats holds the depth of the queue of a plugin with limits.
+type PluginQueueStats struct {
+	// Queued is the number of messages awaiting the plugin.
+	Queued int `json:"queued"`
+	// Dropped is the number of messages dropped for the queue being full.
+	Dropped uint64 `json:"dropped"`
+}
+
+// pluginLimiter queues messages for a plugin with limits, and has a bounded
+// number of workers hand them to it.
+type pluginLimiter struct {
+	limits  PluginLimits
+	jobs    chan func()
+	dropped uint64
+}
+
+func newPluginLimiter(limits PluginLimits) (*pluginLimiter, error) {
+	if limits.MaxConcurrent < 1 {
+		return nil, errors.Errorf("builder: plugins must be allowed at least 1 concurrent receive, got %d", limits.MaxConcurrent)
+	}
+
+	if limits.QueueSize < 0 {
+		return nil, errors.Errorf("builder: plugin queue size must not be negative, got %d", limits.QueueSize)
+	}
+
+	return &pluginLimiter{limits: limits, jobs: make(chan func(), limits.QueueSize)}, nil
+}
+
+// startPluginWorkers spawns the workers of all plugins with limits, which
+// stop once the network is closed.
+func (n *Network) startPluginWorkers() {
+	for _, info := range n.plugins.values {
+		if info.limiter == nil {
+			continue
+		}
+
+		limiter := info.limiter
+
+		for i := 0; i < limiter.limits.MaxConcurrent; i++ {
+			n.goWorker(workerPlugin, func() {
+				for {
+					select {
+					case <-n.kill:
+						return
+					case job := <-limiter.jobs:
+						job()
+					}
+				}
+			})
+		}
+	}
+}
+
+// receive hands a message to a plugin.
+func (n *Network) receive(spanCtx context.Context, plugin PluginInterface, ctx *PluginContext) {
+	_, pluginSpan := n.opts.tracer.StartSpan(spanCtx, "noise.Plugin.Receive")
+	pluginSpan.SetTag("plugin", reflect.TypeOf(plugin).String())
+	defer pluginSpan.Finish()
+
+	// Bind the handler to the deadline of the request, such that it may skip
+	// work which may not complete in time.
+	if !ctx.deadline.IsZero() {
+		var cancel context.CancelFunc
+		ctx.ctx, cancel = context.WithDeadline(ctx.client.Context(), ctx.deadline)
+		defer cancel()
+	}
+
+	defer n.recoverPlugin(plugin, "Receive", ctx.client, ctx)
+
+	if err := plugin.Receive(ctx); err != nil {
+		pluginSpan.SetError(err)
+		n.log().Error().Err(err).Str("trace_id", ctx.traceID.String()).Msg("")
+	}
+}
+
+// receiveLimited queues a message for a plugin with limits, or drops it should
+// the plugins queue be full. The plugin context is copied, as the one given
+// is reused once all plugins have been handed the message.
+func (n *Network) receiveLimited(spanCtx context.Context, info *PluginInfo, ctx *PluginContext) {
+	copied := *ctx
+
+	select {
+	case info.limiter.jobs <- func() { n.receive(spanCtx, info.Plugin, &copied) }:
+	default:
+		atomic.AddUint64(&info.limiter.dropped, 1)
+
+		n.log().Warn().
+			Str("plugin", reflect.TypeOf(info.Plugin).String()).
+			Str("trace_id", ctx.traceID.String()).
+			Msg("Dropped message for plugin as its queue is full.")
+	}
+}
+
+// pluginQueueStats returns the depths of the queues of all plugins with
+// limits keyed by their type.
+func (n *Network) pluginQueueStats() map[string]PluginQueueStats {
+	var stats map[string]PluginQueueStats
+
+	for _, info := range n.plugins.values {
+		if info.limiter == nil {
+			continue
+		}
+
+		if stats == nil {
+			stats = make(map[string]PluginQueueStats)
+		}
+
+		stats[reflect.TypeOf(info.Plugin).String()] = PluginQueueStats{
+			Queued:  len(info.limiter.jobs),
+			Dropped: atomic.LoadUint64(&info.limiter.dropped),
+		}
+	}
+
+	return stats
+}
@@ -9,6 +9,9 @@ import (
 type PluginInfo struct {
 	Priority int
 	Plugin   PluginInterface
+
+	// Queues messages for the plugin should it have limits.
+	limiter *pluginLimiter
 }
 
 // PluginList holds a statically-typed sorted map of plugins
@@ -78,3 +81,11 @@ func (m *PluginList) Each(f func(value PluginInterface)) {
 		f(item.Plugin)
 	}
 }
+
+// EachReverse goes through every plugin in descending order of priority of the
+// plugin list.
+func (m *PluginList) EachReverse(f func(value PluginInterface)) {
+	for i := len(m.values) - 1; i >= 0; i-- {
+		f(m.values[i].Plugin)
+	}
+}
@@ -1,10 +1,17 @@
 package network
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/perlin-network/noise/audit"
 	"github.com/perlin-network/noise/crypto/ed25519"
+	"github.com/perlin-network/noise/internal/protobuf"
+	"github.com/perlin-network/noise/types/opcode"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/uber-go/atomic"
@@ -42,14 +49,16 @@ func (p *MockPlugin) PeerDisconnect(client *PeerClient) {
 }
 
 func TestPluginHooks(t *testing.T) {
-	host := "localhost"
 	var nodes []*Network
 	nodeCount := 4
 
 	for i := 0; i < nodeCount; i++ {
 		builder := NewBuilder()
 		builder.SetKeys(ed25519.RandomKeyPair())
-		builder.SetAddress(FormatAddress("tcp", host, uint16(GetRandomUnusedPort())))
+		listener, address, err := ListenOnRandomPort()
+		assert.Equal(t, nil, err)
+		builder.SetListener(listener)
+		builder.SetAddress(address)
 		builder.AddPlugin(new(MockPlugin))
 
 		node, err := builder.Build()
@@ -118,3 +127,343 @@ func TestRegisterPlugin(t *testing.T) {
 	plugin := p.(*Plugin)
 	assert.NotEqual(t, nil, plugin)
 }
+
+// lifecyclePlugin records its shutdown and cleanup into a shared log.
+type lifecyclePlugin struct {
+	*Plugin
+
+	name  string
+	block bool
+
+	mutex  *sync.Mutex
+	events *[]string
+}
+
+func (p *lifecyclePlugin) record(event string) {
+	p.mutex.Lock()
+	*p.events = append(*p.events, p.name+"."+event)
+	p.mutex.Unlock()
+}
+
+func (p *lifecyclePlugin) Shutdown(ctx context.Context) error {
+	p.record("shutdown")
+
+	if p.block {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	return nil
+}
+
+func (p *lifecyclePlugin) Cleanup(net *Network) {
+	p.record("cleanup")
+}
+
+type firstLifecyclePlugin struct{ lifecyclePlugin }
+type secondLifecyclePlugin struct{ lifecyclePlugin }
+
+func TestShutdown(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mutex  sync.Mutex
+		events []string
+	)
+
+	first := &firstLifecyclePlugin{lifecyclePlugin{name: "first", mutex: &mutex, events: &events}}
+	second := &secondLifecyclePlugin{lifecyclePlugin{name: "second", mutex: &mutex, events: &events}}
+
+	builder := NewBuilder()
+	builder.SetKeys(ed25519.RandomKeyPair())
+	listener, address, err := ListenOnRandomPort()
+	assert.Equal(t, nil, err)
+	builder.SetListener(listener)
+	builder.SetAddress(address)
+	builder.AddPlugin(first)
+	builder.AddPlugin(second)
+
+	node, err := builder.Build()
+	assert.Equal(t, nil, err)
+
+	go node.Listen()
+	node.BlockUntilListening()
+
+	assert.Equal(t, nil, node.Shutdown(context.Background()))
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	assert.Equal(t, []string{"second.shutdown", "first.shutdown", "second.cleanup", "first.cleanup"}, events,
+		"expected plugins to be shut down and cleaned up in reverse order")
+}
+
+func TestShutdownDeadline(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mutex  sync.Mutex
+		events []string
+	)
+
+	builder := NewBuilderWithOptions(ShutdownTimeout(100 * time.Millisecond))
+	builder.SetKeys(ed25519.RandomKeyPair())
+	listener, address, err := ListenOnRandomPort()
+	assert.Equal(t, nil, err)
+	builder.SetListener(listener)
+	builder.SetAddress(address)
+	builder.AddPlugin(&firstLifecyclePlugin{lifecyclePlugin{name: "first", mutex: &mutex, events: &events}})
+	builder.AddPlugin(&secondLifecyclePlugin{lifecyclePlugin{name: "second", block: true, mutex: &mutex, events: &events}})
+
+	node, err := builder.Build()
+	assert.Equal(t, nil, err)
+
+	start := time.Now()
+	node.Close()
+
+	assert.True(t, time.Since(start) < time.Second, "expected Close to give up on plugins past its deadline")
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	assert.Equal(t, []string{"second.shutdown"}, events, "expected no plugin to be shut down past the deadline")
+}
+
+// blockingPlugin blocks upon receiving messages until released.
+type blockingPlugin struct {
+	*Plugin
+
+	release  chan struct{}
+	received atomic.Int32
+}
+
+func (p *blockingPlugin) Receive(ctx *PluginContext) error {
+	p.received.Inc()
+	<-p.release
+	return nil
+}
+
+func TestPluginLimits(t *testing.T) {
+	t.Parallel()
+
+	blocking := &blockingPlugin{release: make(chan struct{})}
+	counting := new(MockPlugin)
+
+	builder := NewBuilder()
+	builder.SetKeys(ed25519.RandomKeyPair())
+	listener, address, err := ListenOnRandomPort()
+	assert.Equal(t, nil, err)
+	builder.SetListener(listener)
+	builder.SetAddress(address)
+
+	assert.NotEqual(t, nil, builder.AddPluginWithLimits(blocking, PluginLimits{}), "expected plugins to require at least 1 concurrent receive")
+	assert.Equal(t, nil, builder.AddPluginWithLimits(blocking, PluginLimits{MaxConcurrent: 1, QueueSize: 1}))
+	assert.Equal(t, nil, builder.AddPlugin(counting))
+
+	node, err := builder.Build()
+	assert.Equal(t, nil, err)
+	defer node.Close()
+
+	client, err := createPeerClient(node, "tcp://localhost:3000")
+	assert.Equal(t, nil, err)
+
+	dispatch := func() {
+		node.prepareDispatch(client, &protobuf.Message{Opcode: uint32(opcode.PingCode)})()
+	}
+
+	dispatch()
+
+	for i := 0; i < 100 && blocking.received.Load() == 0; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// Whilst the slow plugin handles the first message, one more is queued
+	// for it and the rest are dropped without holding up other plugins.
+	for i := 0; i < 3; i++ {
+		dispatch()
+	}
+
+	assert.Equal(t, int32(4), counting.receive.Load(), "expected plugins without limits to receive all messages")
+	assert.Equal(t, PluginQueueStats{Queued: 1, Dropped: 2}, node.QueueStats().Plugins["*network.blockingPlugin"])
+
+	close(blocking.release)
+
+	for i := 0; i < 100 && blocking.received.Load() < 2; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	assert.Equal(t, int32(2), blocking.received.Load(), "expected queued messages to be received")
+}
+
+// panickingPlugin panics in all of its callbacks.
+type panickingPlugin struct {
+	*Plugin
+}
+
+func (p *panickingPlugin) Startup(net *Network)             { panic("startup") }
+func (p *panickingPlugin) Receive(ctx *PluginContext) error { panic("receive") }
+func (p *panickingPlugin) PeerConnect(client *PeerClient)   { panic("peer connect") }
+
+func TestPluginPanics(t *testing.T) {
+	t.Parallel()
+
+	events := make(chan audit.Event, 1)
+	counting := new(MockPlugin)
+
+	builder := NewBuilderWithOptions(AuditSink(audit.Channel(events)))
+	builder.SetKeys(ed25519.RandomKeyPair())
+	listener, address, err := ListenOnRandomPort()
+	assert.Equal(t, nil, err)
+	builder.SetListener(listener)
+	builder.SetAddress(address)
+	builder.AddPlugin(new(panickingPlugin))
+	builder.AddPlugin(counting)
+
+	node, err := builder.Build()
+	assert.Equal(t, nil, err)
+	defer node.Close()
+
+	go node.Listen()
+	node.BlockUntilListening()
+
+	client, err := createPeerClient(node, "tcp://localhost:3000")
+	assert.Equal(t, nil, err)
+
+	client.Init()
+	node.prepareDispatch(client, &protobuf.Message{Opcode: uint32(opcode.PingCode)})()
+
+	assert.Equal(t, int32(1), counting.startup.Load(), "expected plugins after a panicking one to start up")
+	assert.Equal(t, int32(1), counting.peerConnect.Load(), "expected plugins after a panicking one to be notified of peers")
+	assert.Equal(t, int32(1), counting.receive.Load(), "expected plugins after a panicking one to receive messages")
+
+	select {
+	case event := <-events:
+		assert.Equal(t, audit.PluginPanicked, event.Kind)
+		assert.Equal(t, "tcp://localhost:3000", event.PeerAddress)
+	case <-time.After(time.Second):
+		t.Fatal("expected the panic to be audited")
+	}
+
+	assert.Equal(t, int32(1), counting.peerDisconnect.Load(), "expected the peer whose message caused a panic to be disconnected")
+}
+
+// contextPlugin hands out the contexts of messages it receives.
+type contextPlugin struct {
+	*Plugin
+
+	contexts chan context.Context
+}
+
+func (p *contextPlugin) Receive(ctx *PluginContext) error {
+	p.contexts <- ctx.Context()
+	return nil
+}
+
+func TestReceiveContextCancelledOnDisconnect(t *testing.T) {
+	t.Parallel()
+
+	plugin := &contextPlugin{contexts: make(chan context.Context, 1)}
+
+	builder := NewBuilder()
+	builder.SetKeys(ed25519.RandomKeyPair())
+	listener, address, err := ListenOnRandomPort()
+	assert.Equal(t, nil, err)
+	builder.SetListener(listener)
+	builder.SetAddress(address)
+	builder.AddPlugin(plugin)
+
+	node, err := builder.Build()
+	assert.Equal(t, nil, err)
+	defer node.Close()
+
+	client, err := createPeerClient(node, "tcp://localhost:3000")
+	assert.Equal(t, nil, err)
+
+	client.Init()
+	node.prepareDispatch(client, &protobuf.Message{Opcode: uint32(opcode.PingCode)})()
+
+	ctx := <-plugin.contexts
+	assert.Equal(t, nil, ctx.Err(), "expected the context to be live whilst the peer is connected")
+
+	client.Close()
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("expected the context to be cancelled once the peer disconnects")
+	}
+}
+
+func TestPeerContextLifecycle(t *testing.T) {
+	t.Parallel()
+
+	build := func() *Network {
+		builder := NewBuilder()
+		builder.SetKeys(ed25519.RandomKeyPair())
+		listener, address, err := ListenOnRandomPort()
+		assert.Equal(t, nil, err)
+		builder.SetListener(listener)
+		builder.SetAddress(address)
+
+		node, err := builder.Build()
+		assert.Equal(t, nil, err)
+
+		go node.Listen()
+		node.BlockUntilListening()
+
+		return node
+	}
+
+	silent, node := build(), build()
+	defer silent.Close()
+
+	client, err := node.Client(silent.Address)
+	assert.Equal(t, nil, err)
+
+	// Requests awaiting a response fail once the peer disconnects.
+	errs := make(chan error, 1)
+	go func() {
+		_, err := client.Request(context.Background(), &protobuf.Ping{})
+		errs <- err
+	}()
+
+	time.Sleep(100 * time.Millisecond)
+	client.Close()
+
+	select {
+	case err := <-errs:
+		assert.True(t, errors.Is(err, ErrPeerUnreachable), "expected the request to fail as the peer disconnected, got %v", err)
+	case <-time.After(time.Second):
+		t.Fatal("expected the request to be aborted once the peer disconnects")
+	}
+
+	// Contexts of peers derive from the lifecycle of the node.
+	detached, err := createPeerClient(node, "tcp://localhost:3000")
+	assert.Equal(t, nil, err)
+	detached.Init()
+
+	node.Close()
+
+	select {
+	case <-detached.Context().Done():
+	case <-time.After(time.Second):
+		t.Fatal("expected the context of the peer to be cancelled once the node shuts down")
+	}
+}
+
+func TestShutdownTwice(t *testing.T) {
+	t.Parallel()
+
+	builder := NewBuilder()
+	builder.SetKeys(ed25519.RandomKeyPair())
+	listener, address, err := ListenOnRandomPort()
+	assert.Equal(t, nil, err)
+	builder.SetListener(listener)
+	builder.SetAddress(address)
+
+	node, err := builder.Build()
+	assert.Equal(t, nil, err)
+
+	node.Close()
+	node.Close()
+}
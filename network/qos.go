@@ -0,0 +1,273 @@
+package network
+
+import (
+	"bytes"
+	"compress/flate"
+	"context"
+	"io"
+	"time"
+
+	"github.com/perlin-network/noise/audit"
+	"github.com/perlin-network/noise/internal/protobuf"
+	"github.com/perlin-network/noise/peer"
+	"github.com/perlin-network/noise/tracing"
+	"github.com/perlin-network/noise/types/opcode"
+
+	"github.com/pkg/errors"
+)
+
+// compressThreshold is the size in bytes a message body must reach for it to
+// be compressed, as smaller bodies hardly shrink.
+const compressThreshold = 512
+
+// decompressChunkSize is how many bytes of a compressed body are decompressed
+// at a time, having memory reserved for them beforehand.
+const decompressChunkSize = 64 * 1024
+
+// QoSClass is a class of service messages are sent and dispatched under.
+type QoSClass int
+
+const (
+	// QoSNormal is the class of all messages not assigned to another class.
+	QoSNormal QoSClass = iota
+	// QoSLatencySensitive is the class of messages which are to be delivered
+	// as soon as possible, such as pings and lookups.
+	QoSLatencySensitive
+	// QoSBulk is the class of large messages whose throughput matters more
+	// than their latency, such as file transfers.
+	QoSBulk
+
+	numQoSClasses
+)
+
+var qosClassNames = [numQoSClasses]string{
+	QoSNormal:           "normal",
+	QoSLatencySensitive: "latency_sensitive",
+	QoSBulk:             "bulk",
+}
+
+func (c QoSClass) String() string {
+	if c < 0 || c >= numQoSClasses {
+		return "unknown"
+	}
+	return qosClassNames[c]
+}
+
+// QoSPolicy describes how messages of a QoS class are sent and dispatched.
+type QoSPolicy struct {
+	// Priority has messages flushed to peers as soon as they are written
+	// rather than upon the next flush, and dispatched ahead of messages of
+	// classes without priority.
+	Priority bool
+
+	// RateLimit is the number of messages per second each peer may send under
+	// the class. Messages past the limit are dropped, and requests past the
+	// limit are replied to with CodeRateLimited. Zero disables rate limiting.
+	RateLimit float64
+	// Burst is the number of messages each peer may send at once under the
+	// class in excess of RateLimit (default: 1).
+	Burst int
+
+	// Compress has message bodies larger than 512 bytes compressed.
+	Compress bool
+}
+
+// defaultQoSPolicies are the policies of QoS classes not configured otherwise.
+var defaultQoSPolicies = [numQoSClasses]QoSPolicy{
+	QoSNormal:           {},
+	QoSLatencySensitive: {Priority: true},
+	QoSBulk:             {Compress: true},
+}
+
+// qosOptions assigns opcodes to QoS classes, and QoS classes to policies.
+type qosOptions struct {
+	classes  map[opcode.Opcode]QoSClass
+	policies [numQoSClasses]QoSPolicy
+}
+
+// QoS returns a BuilderOption that assigns messages of a set of opcodes to a
+// QoS class (default: all messages are of class QoSNormal).
+//
+// Example: network.QoS(network.QoSBulk, opcode.Opcode(1000))
+func QoS(class QoSClass, opcodes ...opcode.Opcode) BuilderOption {
+	return func(o *options) {
+		// Copy the assignments, as options are copied from defaults.
+		classes := make(map[opcode.Opcode]QoSClass, len(o.qos.classes)+len(opcodes))
+		for code, class := range o.qos.classes {
+			classes[code] = class
+		}
+		for _, code := range opcodes {
+			classes[code] = class
+		}
+		o.qos.classes = classes
+	}
+}
+
+// QoSClassPolicy returns a BuilderOption that sets the policy of a QoS class
+// (default: latency sensitive messages have priority, bulk messages are
+// compressed, and no class is rate limited).
+func QoSClassPolicy(class QoSClass, policy QoSPolicy) BuilderOption {
+	return func(o *options) {
+		if class >= 0 && class < numQoSClasses {
+			o.qos.policies[class] = policy
+		}
+	}
+}
+
+// QoSClass returns the QoS class messages of an opcode are sent and
+// dispatched under.
+func (n *Network) QoSClass(code opcode.Opcode) QoSClass {
+	if class, ok := n.opts.qos.classes[code]; ok {
+		return class
+	}
+	return QoSNormal
+}
+
+// qosPolicy returns the policy of the QoS class of an opcode.
+func (n *Network) qosPolicy(code opcode.Opcode) QoSPolicy {
+	return n.opts.qos.policies[n.QoSClass(code)]
+}
+
+// compressMessage compresses the body of a message should its QoS class
+// call for it, and should it shrink.
+func (n *Network) compressMessage(msg *protobuf.Message) {
+	if len(msg.Message) < compressThreshold || !n.qosPolicy(opcode.Opcode(msg.Opcode)).Compress {
+		return
+	}
+
+	var buf bytes.Buffer
+
+	w, _ := flate.NewWriter(&buf, flate.DefaultCompression)
+	if _, err := w.Write(msg.Message); err != nil {
+		return
+	}
+	if err := w.Close(); err != nil {
+		return
+	}
+
+	if buf.Len() < len(msg.Message) {
+		msg.Message = buf.Bytes()
+		msg.Compressed = true
+	}
+}
+
+// decompressMessage decompresses the body of a message should it be
+// compressed. Bodies may not decompress to more than maxMessageSize bytes.
+//
+// Should memory be non-nil, memory is reserved for the decompressed body chunk
+// by chunk before it is inflated, such that small frames may not inflate past
+// the inbound memory limits. The number of bytes reserved is returned, even
+// should decompression fail.
+func decompressMessage(msg *protobuf.Message, memory *peerMemory) (int64, error) {
+	if !msg.Compressed {
+		return 0, nil
+	}
+
+	r := flate.NewReader(bytes.NewReader(msg.Message))
+	defer r.Close()
+
+	var body bytes.Buffer
+	var reserved int64
+
+	for {
+		if body.Len() > maxMessageSize {
+			return reserved, errors.Errorf("message decompresses to more than %d bytes", int(maxMessageSize))
+		}
+
+		if memory != nil {
+			if err := memory.reserve(decompressChunkSize); err != nil {
+				return reserved, errors.Wrap(err, "failed to reserve memory to decompress message")
+			}
+			reserved += decompressChunkSize
+		}
+
+		if _, err := io.CopyN(&body, r, decompressChunkSize); err == io.EOF {
+			break
+		} else if err != nil {
+			return reserved, errors.Wrap(err, "failed to decompress message")
+		}
+	}
+
+	// Memory reserved past the end of the body is released right away.
+	if memory != nil {
+		memory.release(reserved - int64(body.Len()))
+		reserved = int64(body.Len())
+	}
+
+	msg.Message, msg.Compressed = body.Bytes(), false
+	return reserved, nil
+}
+
+// allowQoS returns whether or not the peer may send another message of a QoS
+// class under its rate limit.
+func (c *PeerClient) allowQoS(class QoSClass, policy QoSPolicy) bool {
+	if policy.RateLimit <= 0 {
+		return true
+	}
+
+	c.qosMutex.Lock()
+	defer c.qosMutex.Unlock()
+
+	bucket := c.qosBuckets[class]
+	if bucket == nil {
+		burst := policy.Burst
+		if burst <= 0 {
+			burst = 1
+		}
+
+		bucket = &tokenBucket{rate: policy.RateLimit, burst: float64(burst), tokens: float64(burst), last: c.Network.Clock().Now()}
+		c.qosBuckets[class] = bucket
+	}
+
+	return bucket.take(c.Network.Clock().Now())
+}
+
+// rateLimited drops a message of a peer which exceeded the rate limit of its
+// QoS class, replying with CodeRateLimited should it be a request.
+func (n *Network) rateLimited(client *PeerClient, msg *protobuf.Message, class QoSClass) {
+	traceID := fromTraceContext(msg.Trace).TraceID
+
+	n.log().Warn().
+		Str("peer_address", client.Address).
+		Str("qos_class", class.String()).
+		Uint64("opcode", uint64(msg.Opcode)).
+		Str("trace_id", traceID.String()).
+		Msg("Dropped message as peer exceeded the rate limit of its QoS class.")
+
+	var id peer.ID
+	if clientID := client.ID(); clientID != nil {
+		id = *clientID
+	}
+	n.AuditPeer(tracing.ContextWithTraceID(context.Background(), traceID), audit.RateLimited, audit.ReasonQoSClass, id, nil)
+
+	if msg.RequestNonce > 0 && !msg.ReplyFlag {
+		n.replyError(client, msg, CodeRateLimited)
+	}
+}
+
+// tokenBucket is a token bucket refilled at a given rate per second up to a
+// burst.
+type tokenBucket struct {
+	rate, burst float64
+
+	tokens float64
+	last   time.Time
+}
+
+// take takes a token from the bucket should one be available.
+func (b *tokenBucket) take(now time.Time) bool {
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens += elapsed.Seconds() * b.rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+		b.last = now
+	}
+
+	if b.tokens < 1 {
+		return false
+	}
+
+	b.tokens--
+	return true
+}
@@ -0,0 +1,121 @@
+package network
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/perlin-network/noise/audit"
+	"github.com/perlin-network/noise/clock"
+	"github.com/perlin-network/noise/crypto/ed25519"
+	"github.com/perlin-network/noise/internal/protobuf"
+	"github.com/perlin-network/noise/types/opcode"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQoSCompression(t *testing.T) {
+	t.Parallel()
+
+	builder := NewBuilderWithOptions(QoS(QoSBulk, opcode.PingCode))
+	builder.SetKeys(ed25519.RandomKeyPair())
+	listener, address, err := ListenOnRandomPort()
+	assert.Equal(t, nil, err)
+	builder.SetListener(listener)
+	builder.SetAddress(address)
+
+	node, err := builder.Build()
+	assert.Equal(t, nil, err)
+
+	assert.Equal(t, QoSBulk, node.QoSClass(opcode.PingCode))
+	assert.Equal(t, QoSNormal, node.QoSClass(opcode.PongCode))
+
+	nonce := bytes.Repeat([]byte("noise"), 1024)
+	ctx := WithSignMessage(context.Background(), true)
+
+	msg, err := node.PrepareMessage(ctx, &protobuf.Ping{Nonce: nonce})
+	assert.Equal(t, nil, err)
+	assert.True(t, msg.Compressed, "expected bulk messages to be compressed")
+	assert.True(t, len(msg.Message) < len(nonce), "expected the body to shrink")
+
+	_, err = decompressMessage(msg, nil)
+	assert.Equal(t, nil, err)
+	assert.False(t, msg.Compressed)
+	assert.Equal(t, nil, node.verifyMessage(msg), "expected the signature to cover the decompressed body")
+
+	body, err := decodeMessageBody(opcode.PingCode, msg.Message)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, nonce, body.(*protobuf.Ping).Nonce)
+
+	msg, err = node.PrepareMessage(ctx, &protobuf.Pong{Nonce: nonce})
+	assert.Equal(t, nil, err)
+	assert.False(t, msg.Compressed, "expected messages of other classes to not be compressed")
+
+	msg.Message, msg.Compressed = []byte("garbage"), true
+	_, err = decompressMessage(msg, nil)
+	assert.NotEqual(t, nil, err)
+}
+
+func TestQoSRateLimit(t *testing.T) {
+	t.Parallel()
+
+	var mutex sync.Mutex
+	var events []audit.Event
+
+	virtual := clock.NewVirtual(time.Unix(0, 0))
+	counting := new(MockPlugin)
+
+	builder := NewBuilderWithOptions(
+		Clock(virtual),
+		AuditSink(audit.SinkFunc(func(event audit.Event) {
+			mutex.Lock()
+			events = append(events, event)
+			mutex.Unlock()
+		})),
+		QoS(QoSLatencySensitive, opcode.PingCode),
+		QoSClassPolicy(QoSLatencySensitive, QoSPolicy{Priority: true, RateLimit: 1, Burst: 2}),
+	)
+	builder.SetKeys(ed25519.RandomKeyPair())
+	listener, address, err := ListenOnRandomPort()
+	assert.Equal(t, nil, err)
+	builder.SetListener(listener)
+	builder.SetAddress(address)
+	assert.Equal(t, nil, builder.AddPlugin(counting))
+
+	node, err := builder.Build()
+	assert.Equal(t, nil, err)
+	defer node.Close()
+
+	client, err := createPeerClient(node, "tcp://localhost:3000")
+	assert.Equal(t, nil, err)
+
+	dispatch := func(code opcode.Opcode) {
+		if fn := node.prepareDispatch(client, &protobuf.Message{Opcode: uint32(code)}); fn != nil {
+			fn()
+		}
+	}
+
+	// The burst is let through, after which pings are dropped until the
+	// bucket refills. Messages of other classes are not limited.
+	for i := 0; i < 3; i++ {
+		dispatch(opcode.PingCode)
+	}
+	dispatch(opcode.PongCode)
+
+	assert.Equal(t, int32(3), counting.receive.Load())
+
+	virtual.Advance(1 * time.Second)
+	dispatch(opcode.PingCode)
+
+	assert.Equal(t, int32(4), counting.receive.Load(), "expected the bucket to refill")
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	if assert.Equal(t, 1, len(events)) {
+		assert.Equal(t, audit.RateLimited, events[0].Kind)
+		assert.Equal(t, audit.ReasonQoSClass, events[0].Reason)
+	}
+}
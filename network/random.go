@@ -0,0 +1,67 @@
+package network
+
+import (
+	crand "crypto/rand"
+	"math/rand"
+	"sync"
+)
+
+// Random is the source of randomness of a node and its plugins, safe for
+// concurrent use. Unless seeded, it draws from math/rand, and from
+// crypto/rand for nonces.
+type Random struct {
+	mutex sync.Mutex
+	rand  *rand.Rand
+}
+
+// NewRandom returns a source of randomness seeded by seed, such that all
+// random decisions a node makes may be reproduced. Nonces drawn from a seeded
+// source are predictable, so it is only to be used for simulations and tests.
+func NewRandom(seed int64) *Random {
+	return &Random{rand: rand.New(rand.NewSource(seed))}
+}
+
+// Intn returns a random number within [0, n).
+func (r *Random) Intn(n int) int {
+	if r == nil || r.rand == nil {
+		return rand.Intn(n)
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.rand.Intn(n)
+}
+
+// Float64 returns a random number within [0.0, 1.0).
+func (r *Random) Float64() float64 {
+	if r == nil || r.rand == nil {
+		return rand.Float64()
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.rand.Float64()
+}
+
+// Shuffle randomly permutes n elements through swap.
+func (r *Random) Shuffle(n int, swap func(i, j int)) {
+	if r == nil || r.rand == nil {
+		rand.Shuffle(n, swap)
+		return
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.rand.Shuffle(n, swap)
+}
+
+// Read fills p with random bytes, such as for nonces.
+func (r *Random) Read(p []byte) (int, error) {
+	if r == nil || r.rand == nil {
+		return crand.Read(p)
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.rand.Read(p)
+}
@@ -0,0 +1,261 @@
+// Package reconcile lets two peers discover which items of a keyed set either
+// of them lacks, exchanging data proportional to the number of items their
+// sets differ by rather than to the sets themselves.
+//
+// The requesting peer sends an invertible Bloom lookup table of the IDs of its
+// items, where the ID of an item is the hash of its key. The responding peer
+// subtracts the table from its own, decodes the difference, and replies with
+// the keys of items the requester lacks alongside the IDs of items only the
+// requester holds. Should the sets differ by more than the table can tell
+// apart, the requester retries with a table twice as large.
+//
+// Sets are registered under a name, such that a node may reconcile several
+// sets such as gossiped messages, pending transactions or DHT records.
+package reconcile
+
+import (
+	"context"
+	"sync"
+
+	"github.com/perlin-network/noise/internal/protobuf"
+	"github.com/perlin-network/noise/network"
+	"github.com/perlin-network/noise/types/iblt"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// defaultInitialCells is the number of cells of the first table sent.
+	defaultInitialCells = 64
+	// defaultMaxCells is the number of cells tables may have at most.
+	defaultMaxCells = 16384
+)
+
+// ErrTooManyDifferences is returned upon reconciling sets which differ by more
+// items than the largest table allowed can tell apart.
+var ErrTooManyDifferences = errors.New("reconcile: sets differ by too many items")
+
+// Set is a keyed set of items which may be reconciled with peers.
+type Set interface {
+	// Keys returns the keys of all items held.
+	Keys() [][]byte
+}
+
+// SetFunc adapts a function listing keys into a Set.
+type SetFunc func() [][]byte
+
+// Keys calls fn().
+func (fn SetFunc) Keys() [][]byte {
+	return fn()
+}
+
+// Diff is the difference between a set held locally and that of a peer.
+type Diff struct {
+	// Missing are the keys of items only the peer holds.
+	Missing [][]byte
+	// Extra are the keys of items only this node holds.
+	Extra [][]byte
+}
+
+// Plugin reconciles registered sets with peers upon request.
+type Plugin struct {
+	*network.Plugin
+
+	// InitialCells is the number of cells of the first table sent upon
+	// reconciling a set (default: 64).
+	InitialCells int
+	// MaxCells is the number of cells tables sent or served may have at most
+	// (default: 16384).
+	MaxCells int
+
+	mutex sync.RWMutex
+	sets  map[string]Set
+}
+
+var (
+	// PluginID is used to check existence of the reconcile plugin.
+	PluginID                         = (*Plugin)(nil)
+	_        network.PluginInterface = (*Plugin)(nil)
+)
+
+// PluginOption are configurable options for the reconcile plugin.
+type PluginOption func(*Plugin)
+
+// WithInitialCells sets the number of cells of the first table sent.
+func WithInitialCells(n int) PluginOption {
+	return func(p *Plugin) {
+		p.InitialCells = n
+	}
+}
+
+// WithMaxCells sets the number of cells tables may have at most.
+func WithMaxCells(n int) PluginOption {
+	return func(p *Plugin) {
+		p.MaxCells = n
+	}
+}
+
+// New returns a new reconcile plugin with specified options.
+func New(opts ...PluginOption) *Plugin {
+	p := new(Plugin)
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	p.setDefaults()
+
+	return p
+}
+
+// setDefaults fills in all options which have been left unspecified.
+func (p *Plugin) setDefaults() {
+	if p.InitialCells <= 0 {
+		p.InitialCells = defaultInitialCells
+	}
+
+	if p.MaxCells <= 0 {
+		p.MaxCells = defaultMaxCells
+	}
+
+	if p.InitialCells > p.MaxCells {
+		p.InitialCells = p.MaxCells
+	}
+}
+
+func (p *Plugin) Startup(net *network.Network) {
+	p.setDefaults()
+}
+
+// Register registers a set to be reconciled under a name, replacing any set
+// registered under it before.
+func (p *Plugin) Register(name string, set Set) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.sets == nil {
+		p.sets = make(map[string]Set)
+	}
+	p.sets[name] = set
+}
+
+// Unregister unregisters the set registered under a name.
+func (p *Plugin) Unregister(name string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	delete(p.sets, name)
+}
+
+func (p *Plugin) set(name string) (Set, bool) {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	set, exists := p.sets[name]
+	return set, exists
+}
+
+// index returns the keys of a set keyed by their ID.
+func index(set Set) map[string][]byte {
+	keys := set.Keys()
+
+	ids := make(map[string][]byte, len(keys))
+	for _, key := range keys {
+		ids[string(iblt.ID(key))] = key
+	}
+	return ids
+}
+
+// table returns a table of a number of cells holding a set of IDs.
+func table(ids map[string][]byte, cells int) *iblt.Table {
+	t := iblt.New(cells)
+	for id := range ids {
+		t.Insert([]byte(id))
+	}
+	return t
+}
+
+func (p *Plugin) Receive(ctx *network.PluginContext) error {
+	switch msg := ctx.Message().(type) {
+	case *protobuf.ReconcileRequest:
+		set, exists := p.set(msg.Set)
+		if !exists {
+			return ctx.ReplyError(context.Background(), network.CodeUnknownService, "unknown set "+msg.Set)
+		}
+
+		theirs, err := iblt.FromBytes(msg.Table, msg.Hashes)
+		if err != nil {
+			return errors.Wrap(err, "reconcile: received malformed table")
+		}
+
+		// Tables are rounded up to a multiple of their number of hashes.
+		if theirs.Cells() > iblt.New(p.MaxCells).Cells() {
+			return ctx.ReplyError(context.Background(), network.CodePayloadTooLarge, "table has too many cells")
+		}
+
+		ids := index(set)
+
+		ours := table(ids, theirs.Cells())
+		if err := ours.Subtract(theirs); err != nil {
+			return errors.Wrap(err, "reconcile: received incompatible table")
+		}
+
+		onlyOurs, onlyTheirs, ok := ours.Decode()
+		if !ok {
+			return ctx.Reply(context.Background(), &protobuf.ReconcileResponse{Undecodable: true})
+		}
+
+		res := &protobuf.ReconcileResponse{Ids: onlyTheirs}
+		for _, id := range onlyOurs {
+			if key, exists := ids[string(id)]; exists {
+				res.Keys = append(res.Keys, key)
+			}
+		}
+
+		return ctx.Reply(context.Background(), res)
+	}
+
+	return nil
+}
+
+// Reconcile reconciles the set registered under a name with that of a peer,
+// growing the table sent until it is large enough to tell both sets apart or
+// exceeds MaxCells, in which case ErrTooManyDifferences is returned. Items are
+// neither fetched nor sent: it is up to the caller to act upon the difference.
+func (p *Plugin) Reconcile(ctx context.Context, client *network.PeerClient, name string) (Diff, error) {
+	set, exists := p.set(name)
+	if !exists {
+		return Diff{}, errors.Errorf("reconcile: no set registered under %q", name)
+	}
+
+	ids := index(set)
+
+	for cells := p.InitialCells; cells <= p.MaxCells; cells *= 2 {
+		t := table(ids, cells)
+
+		res, err := client.Request(ctx, &protobuf.ReconcileRequest{Set: name, Table: t.Bytes(), Hashes: t.Hashes()})
+		if err != nil {
+			return Diff{}, err
+		}
+
+		response, ok := res.(*protobuf.ReconcileResponse)
+		if !ok {
+			return Diff{}, errors.Errorf("reconcile: unexpected response %T from %s", res, client.Address)
+		}
+
+		if response.Undecodable {
+			continue
+		}
+
+		diff := Diff{Missing: response.Keys}
+		for _, id := range response.Ids {
+			if key, exists := ids[string(id)]; exists {
+				diff.Extra = append(diff.Extra, key)
+			}
+		}
+
+		return diff, nil
+	}
+
+	return Diff{}, ErrTooManyDifferences
+}
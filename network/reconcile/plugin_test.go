@@ -0,0 +1,92 @@
+package reconcile
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/perlin-network/noise/internal/test"
+	"github.com/perlin-network/noise/network"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// keys returns a set of keys formatted from a prefix and indices [from, to).
+func keys(prefix string, from, to int) [][]byte {
+	var keys [][]byte
+	for i := from; i < to; i++ {
+		keys = append(keys, []byte(fmt.Sprintf("%s %d", prefix, i)))
+	}
+	return keys
+}
+
+func sorted(keys [][]byte) [][]byte {
+	sort.Slice(keys, func(i, j int) bool {
+		return bytes.Compare(keys[i], keys[j]) < 0
+	})
+	return keys
+}
+
+func TestReconcile(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping reconcile test in short mode")
+	}
+
+	shared := keys("shared", 0, 1000)
+
+	// Sets differing by 300 items overflow the initial table of 16 cells, and
+	// must be reconciled with larger tables.
+	a, b := New(WithInitialCells(16)), New(WithInitialCells(16))
+	a.Register("mempool", SetFunc(func() [][]byte {
+		return append(append([][]byte{}, shared...), keys("a", 0, 100)...)
+	}))
+	b.Register("mempool", SetFunc(func() [][]byte {
+		return append(append([][]byte{}, shared...), keys("b", 0, 200)...)
+	}))
+
+	nodeA, nodeB := test.BuildNode(t, nil, a), test.BuildNode(t, nil, b)
+	defer nodeA.Close()
+	defer nodeB.Close()
+
+	client, err := nodeA.Client(nodeB.Address)
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	diff, err := a.Reconcile(ctx, client, "mempool")
+	assert.NoError(t, err)
+	assert.Equal(t, sorted(keys("b", 0, 200)), sorted(diff.Missing))
+	assert.Equal(t, sorted(keys("a", 0, 100)), sorted(diff.Extra))
+
+	_, err = a.Reconcile(ctx, client, "unknown")
+	assert.Error(t, err, "expected sets not registered locally to not be reconciled")
+
+	a.Register("unknown", SetFunc(func() [][]byte { return nil }))
+	_, err = a.Reconcile(ctx, client, "unknown")
+	assert.True(t, errors.Is(err, network.ErrUnknownService), "expected sets not registered by the peer to be refused, got %v", err)
+}
+
+func TestTooManyDifferences(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping reconcile test in short mode")
+	}
+
+	a, b := New(WithInitialCells(16), WithMaxCells(32)), New()
+	a.Register("records", SetFunc(func() [][]byte { return keys("a", 0, 500) }))
+	b.Register("records", SetFunc(func() [][]byte { return keys("b", 0, 500) }))
+
+	nodeA, nodeB := test.BuildNode(t, nil, a), test.BuildNode(t, nil, b)
+	defer nodeA.Close()
+	defer nodeB.Close()
+
+	client, err := nodeA.Client(nodeB.Address)
+	assert.NoError(t, err)
+
+	_, err = a.Reconcile(context.Background(), client, "records")
+	assert.Equal(t, ErrTooManyDifferences, err)
+}
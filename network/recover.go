@@ -0,0 +1,62 @@
+package network
+
+import (
+	"fmt"
+	"reflect"
+	"runtime/debug"
+
+	"github.com/perlin-network/noise/audit"
+	"github.com/perlin-network/noise/peer"
+
+	"github.com/pkg/errors"
+)
+
+// recoverPlugin recovers from a panic of a plugin callback, such that a buggy
+// plugin may not take the node down. It must be deferred directly.
+//
+// The panic is logged alongside the callback, and the peer and message which
+// triggered it. Should a message have triggered it, its sender is audited and
+// disconnected from, as the message may have been crafted to crash nodes.
+func (n *Network) recoverPlugin(plugin PluginInterface, callback string, client *PeerClient, ctx *PluginContext) {
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	event := n.log().Error().
+		Str("plugin", reflect.TypeOf(plugin).String()).
+		Str("callback", callback).
+		Str("panic", fmt.Sprint(r)).
+		Str("stack", string(debug.Stack()))
+
+	if client != nil {
+		event = event.Str("peer_address", client.Address)
+	}
+
+	if ctx != nil {
+		event = event.
+			Str("message", fmt.Sprintf("%T", ctx.message)).
+			Str("trace_id", ctx.traceID.String())
+	}
+
+	event.Msg("Recovered from plugin panic.")
+
+	if ctx == nil || client == nil {
+		return
+	}
+
+	id := peer.ID{Address: client.Address}
+	if clientID := client.ID(); clientID != nil {
+		id = *clientID
+	}
+
+	n.AuditPeer(ctx.Context(), audit.PluginPanicked, callback, id, errors.Errorf("%v", r))
+
+	client.Close()
+}
+
+// safely invokes a plugin callback, recovering from any panic it raises.
+func (n *Network) safely(plugin PluginInterface, callback string, client *PeerClient, fn func()) {
+	defer n.recoverPlugin(plugin, callback, client, nil)
+	fn()
+}
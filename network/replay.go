@@ -0,0 +1,51 @@
+package network
+
+import (
+	"bytes"
+
+	"github.com/perlin-network/noise/internal/protobuf"
+	"github.com/perlin-network/noise/peer"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/pkg/errors"
+)
+
+// ReplayFrame decodes a frame as captured off the wire, and synchronously
+// dispatches the message it holds to all plugins as though it was received
+// from its sender, such as to reproduce traffic recorded in production.
+//
+// The message is dispatched from a client detached from the node, so
+// plugins see its sender but replies they send are only delivered should the
+// node happen to be connected to the sender.
+func (n *Network) ReplayFrame(raw []byte) error {
+	reader := bytes.NewReader(raw)
+
+	_, body, err := readFrame(reader)
+	if err != nil || reader.Len() > 0 {
+		return errors.New("network: frame is not length-prefixed")
+	}
+
+	msg := new(protobuf.Message)
+	if err := proto.Unmarshal(body, msg); err != nil {
+		return errors.Wrap(err, "failed to unmarshal message")
+	}
+
+	if err := n.verifyMessage(msg); err != nil {
+		return err
+	}
+
+	client, err := createPeerClient(n, msg.Sender.Address)
+	if err != nil {
+		return err
+	}
+	defer client.cancel()
+
+	client.setID((*peer.ID)(msg.Sender))
+	client.setIncomingReady()
+
+	if job := n.prepareDispatch(client, msg); job != nil {
+		job()
+	}
+
+	return nil
+}
@@ -0,0 +1,164 @@
+// Package replay records the messages a node receives alongside their timing,
+// and replays them into the plugins of another node deterministically, such
+// that bugs observed in production traffic may be reproduced in tests.
+//
+// A Recorder is a capture sink:
+//
+//	recorder := replay.NewRecorder()
+//	builder := network.NewBuilderWithOptions(network.Capture(recorder))
+//	...
+//	recorder.Recording().Save(file)
+//
+// Recordings are replayed frame by frame in the order they were received,
+// with each frame dispatched to all plugins before the next one is.
+package replay
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/perlin-network/noise/network"
+	"github.com/perlin-network/noise/network/capture"
+
+	"github.com/pkg/errors"
+)
+
+// Frame is a frame received by a node.
+type Frame struct {
+	// Offset is when the frame was received, relative to the first frame.
+	Offset time.Duration `json:"offset"`
+	// Remote is the address of the peer the frame claims to have been sent by.
+	Remote string `json:"remote"`
+	// Opcode is the opcode of the message within the frame.
+	Opcode uint32 `json:"opcode"`
+	// Raw is the frame as it appeared on the wire.
+	Raw []byte `json:"raw"`
+}
+
+// Recording is a sequence of frames received by a node.
+type Recording struct {
+	Frames []Frame `json:"frames"`
+}
+
+// Save writes the recording to w as JSON.
+func (r *Recording) Save(w io.Writer) error {
+	return errors.Wrap(json.NewEncoder(w).Encode(r), "replay: failed to save recording")
+}
+
+// Load reads a recording saved through Save from r.
+func Load(r io.Reader) (*Recording, error) {
+	recording := new(Recording)
+	if err := json.NewDecoder(r).Decode(recording); err != nil {
+		return nil, errors.Wrap(err, "replay: failed to load recording")
+	}
+	return recording, nil
+}
+
+// Recorder records all frames received by a node. It is to be set as the
+// capture sink of the node through network.Capture.
+type Recorder struct {
+	mutex  sync.Mutex
+	start  time.Time
+	frames []Frame
+}
+
+// NewRecorder returns a new recorder.
+func NewRecorder() *Recorder {
+	return new(Recorder)
+}
+
+// Capture records a frame should it have been received by the node.
+func (r *Recorder) Capture(frame *capture.Frame) {
+	if frame.Direction != capture.Inbound {
+		return
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if len(r.frames) == 0 {
+		r.start = frame.Time
+	}
+
+	r.frames = append(r.frames, Frame{
+		Offset: frame.Time.Sub(r.start),
+		Remote: frame.Remote,
+		Opcode: frame.Opcode,
+		Raw:    frame.Raw,
+	})
+}
+
+// Recording returns all frames recorded so far.
+func (r *Recorder) Recording() *Recording {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return &Recording{Frames: append([]Frame(nil), r.frames...)}
+}
+
+// Option configures how a recording is replayed.
+type Option func(*options)
+
+type options struct {
+	speed   float64
+	filters []capture.Filter
+}
+
+// WithTiming replays frames spaced apart as they were received, sped up by a
+// factor of speed (default: frames are replayed back to back).
+func WithTiming(speed float64) Option {
+	return func(o *options) {
+		o.speed = speed
+	}
+}
+
+// WithFilter only replays frames passing all filters.
+func WithFilter(filters ...capture.Filter) Option {
+	return func(o *options) {
+		o.filters = append(o.filters, filters...)
+	}
+}
+
+// Replay dispatches all frames of a recording to the plugins of a node in the
+// order they were received. Replaying stops at the first frame failing to
+// decode or verify, or should ctx be cancelled.
+func Replay(ctx context.Context, net *network.Network, recording *Recording, opts ...Option) error {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	start := time.Now()
+
+frames:
+	for i, frame := range recording.Frames {
+		for _, filter := range o.filters {
+			if !filter(&capture.Frame{Direction: capture.Inbound, Remote: frame.Remote, Opcode: frame.Opcode, Raw: frame.Raw}) {
+				continue frames
+			}
+		}
+
+		if o.speed > 0 {
+			wait := time.Until(start.Add(time.Duration(float64(frame.Offset) / o.speed)))
+
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(wait):
+			}
+		}
+
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if err := net.ReplayFrame(frame.Raw); err != nil {
+			return errors.Wrapf(err, "replay: failed to replay frame %d", i)
+		}
+	}
+
+	return nil
+}
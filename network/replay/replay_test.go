@@ -0,0 +1,120 @@
+package replay
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/perlin-network/noise/internal/test"
+	"github.com/perlin-network/noise/internal/test/protobuf"
+	"github.com/perlin-network/noise/network"
+	"github.com/perlin-network/noise/network/capture"
+	"github.com/perlin-network/noise/types/opcode"
+)
+
+func init() {
+	opcode.RegisterMessageType(opcode.Opcode(1000), &protobuf.TestMessage{})
+}
+
+// inbox records the test messages it receives in order.
+type inbox struct {
+	*network.Plugin
+
+	mutex    sync.Mutex
+	messages []string
+	senders  []string
+}
+
+func (p *inbox) Receive(ctx *network.PluginContext) error {
+	if msg, ok := ctx.Message().(*protobuf.TestMessage); ok {
+		p.mutex.Lock()
+		p.messages = append(p.messages, msg.Message)
+		p.senders = append(p.senders, ctx.Sender().Address)
+		p.mutex.Unlock()
+	}
+	return nil
+}
+
+func (p *inbox) received() []string {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return append([]string(nil), p.messages...)
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	if testing.Short() {
+		t.Skipf("skipping %s in short mode", t.Name())
+	}
+
+	recorder := NewRecorder()
+
+	live := new(inbox)
+	net := test.BuildNode(t, []network.BuilderOption{network.Capture(recorder)}, live)
+	defer net.Close()
+
+	sender := test.BuildNode(t, nil, new(inbox))
+	defer sender.Close()
+
+	client, err := sender.Client(net.Address)
+	if err != nil {
+		t.Fatalf("Client() = expected no error, got %v", err)
+	}
+
+	var expected []string
+	for i := 0; i < 5; i++ {
+		expected = append(expected, fmt.Sprintf("message %d", i))
+		if err := client.Tell(context.Background(), &protobuf.TestMessage{Message: expected[i]}); err != nil {
+			t.Fatalf("Tell() = expected no error, got %v", err)
+		}
+	}
+
+	for i := 0; i < 50 && len(live.received()) < len(expected); i++ {
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	var buf bytes.Buffer
+	if err := recorder.Recording().Save(&buf); err != nil {
+		t.Fatalf("Save() = expected no error, got %v", err)
+	}
+
+	recording, err := Load(&buf)
+	if err != nil {
+		t.Fatalf("Load() = expected no error, got %v", err)
+	}
+
+	replayed := new(inbox)
+	target := test.BuildNode(t, nil, replayed)
+	defer target.Close()
+
+	err = Replay(context.Background(), target, recording, WithTiming(100), WithFilter(capture.Opcodes(1000)))
+	if err != nil {
+		t.Fatalf("Replay() = expected no error, got %v", err)
+	}
+
+	// Frames are replayed synchronously and in order.
+	if fmt.Sprint(replayed.messages) != fmt.Sprint(expected) {
+		t.Fatalf("expected replayed messages %v, got %v", expected, replayed.messages)
+	}
+
+	for _, address := range replayed.senders {
+		if address != sender.Address {
+			t.Fatalf("expected messages to be replayed from %s, got %s", sender.Address, address)
+		}
+	}
+}
+
+func TestReplayCorruptFrame(t *testing.T) {
+	t.Parallel()
+
+	net := test.BuildNode(t, nil, new(inbox))
+	defer net.Close()
+
+	recording := &Recording{Frames: []Frame{{Raw: []byte{0, 0, 0, 2, 1}}}}
+
+	if err := Replay(context.Background(), net, recording); err == nil {
+		t.Fatal("Replay() = expected an error replaying a truncated frame")
+	}
+}
@@ -0,0 +1,45 @@
+package network
+
+import (
+	"sync/atomic"
+)
+
+const (
+	// defaultMaxPeerPendingRequests is how many requests may await a response
+	// from a single peer at once.
+	defaultMaxPeerPendingRequests = 1024
+	// defaultMaxPendingRequests is how many requests may await a response
+	// across all peers at once.
+	defaultMaxPendingRequests = 16384
+)
+
+// reserveRequest reserves room for a request to await a response from the
+// peer, and returns a func releasing it. Errors with ErrTooManyRequests should
+// too many requests await a response from the peer, or across all peers.
+func (c *PeerClient) reserveRequest() (release func(), err error) {
+	opts := c.Network.opts
+
+	if !reserve(&c.pendingRequests, opts.maxPeerPendingRequests) {
+		return nil, peerError(ErrTooManyRequests, c.Address, nil)
+	}
+
+	if !reserve(&c.Network.pendingRequests, opts.maxPendingRequests) {
+		atomic.AddInt64(&c.pendingRequests, -1)
+		return nil, peerError(ErrTooManyRequests, c.Address, nil)
+	}
+
+	return func() {
+		atomic.AddInt64(&c.pendingRequests, -1)
+		atomic.AddInt64(&c.Network.pendingRequests, -1)
+	}, nil
+}
+
+// reserve increments a counter should it be below a limit, or zero for no
+// limit, and returns whether or not it was incremented.
+func reserve(counter *int64, limit int) bool {
+	if atomic.AddInt64(counter, 1) > int64(limit) && limit > 0 {
+		atomic.AddInt64(counter, -1)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,61 @@
+package network
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/perlin-network/noise/crypto/ed25519"
+	"github.com/perlin-network/noise/internal/protobuf"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaxPendingRequests(t *testing.T) {
+	t.Parallel()
+
+	builder := NewBuilderWithOptions(MaxPendingRequests(2, 3))
+	builder.SetKeys(ed25519.RandomKeyPair())
+	listener, address, err := ListenOnRandomPort()
+	assert.Equal(t, nil, err)
+	builder.SetListener(listener)
+	builder.SetAddress(address)
+
+	node, err := builder.Build()
+	assert.Equal(t, nil, err)
+	defer node.Close()
+
+	a, err := createPeerClient(node, "tcp://localhost:3000")
+	assert.Equal(t, nil, err)
+	b, err := createPeerClient(node, "tcp://localhost:3001")
+	assert.Equal(t, nil, err)
+
+	var releases []func()
+
+	for i := 0; i < 2; i++ {
+		release, err := a.reserveRequest()
+		assert.Equal(t, nil, err)
+		releases = append(releases, release)
+	}
+
+	// Requests past the limit of a peer are refused.
+	_, err = a.Request(context.Background(), &protobuf.Ping{})
+	assert.True(t, errors.Is(err, ErrTooManyRequests), "expected requests past the limit of a peer to be refused, got %v", err)
+
+	release, err := b.reserveRequest()
+	assert.Equal(t, nil, err)
+	releases = append(releases, release)
+
+	// Requests past the limit across all peers are refused.
+	_, err = b.reserveRequest()
+	assert.True(t, errors.Is(err, ErrTooManyRequests), "expected requests past the limit of the node to be refused, got %v", err)
+
+	for _, release := range releases {
+		release()
+	}
+
+	_, err = b.reserveRequest()
+	assert.Equal(t, nil, err, "expected room to be released")
+	assert.Equal(t, int64(1), node.pendingRequests)
+	assert.Equal(t, int64(0), a.pendingRequests)
+}
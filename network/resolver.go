@@ -0,0 +1,161 @@
+package network
+
+import (
+	"context"
+	"encoding/hex"
+	"net"
+	"strings"
+
+	"github.com/perlin-network/noise/peer"
+
+	"github.com/pkg/errors"
+)
+
+// Resolver resolves the addresses a peer may be dialed on given its public
+// key, such as through a routing table, a static map, DNS or an external
+// directory service.
+type Resolver interface {
+	// Resolve returns the addresses of the peer owning a public key, most
+	// preferred first. Resolve returns ErrUnresolved should it not know of
+	// the peer.
+	Resolve(ctx context.Context, publicKey []byte) ([]string, error)
+}
+
+// ResolverFunc adapts a function into a Resolver.
+type ResolverFunc func(ctx context.Context, publicKey []byte) ([]string, error)
+
+// Resolve calls fn(ctx, publicKey).
+func (fn ResolverFunc) Resolve(ctx context.Context, publicKey []byte) ([]string, error) {
+	return fn(ctx, publicKey)
+}
+
+// StaticResolver resolves peers from a fixed map of hex-encoded public keys to
+// their addresses.
+type StaticResolver map[string][]string
+
+// Resolve returns the addresses mapped to a public key.
+func (r StaticResolver) Resolve(ctx context.Context, publicKey []byte) ([]string, error) {
+	addresses := r[hex.EncodeToString(publicKey)]
+	if len(addresses) == 0 {
+		return nil, ErrUnresolved
+	}
+	return addresses, nil
+}
+
+// DNSResolver resolves peers through the TXT records of a subdomain named
+// after their hex-encoded public key, each record holding an address.
+//
+// Example: the addresses of a peer within the domain peers.example.com are
+// held by the TXT records of <public key>.peers.example.com.
+type DNSResolver struct {
+	// Domain is the domain peers are named under.
+	Domain string
+	// Resolver performs DNS lookups (default: net.DefaultResolver).
+	Resolver *net.Resolver
+}
+
+// Resolve looks up the TXT records of the subdomain of a public key.
+func (r *DNSResolver) Resolve(ctx context.Context, publicKey []byte) ([]string, error) {
+	resolver := r.Resolver
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+
+	name := hex.EncodeToString(publicKey) + "." + strings.TrimSuffix(r.Domain, ".")
+
+	records, err := resolver.LookupTXT(ctx, name)
+	if err != nil {
+		if dnsErr, ok := err.(*net.DNSError); ok && dnsErr.IsNotFound {
+			return nil, ErrUnresolved
+		}
+		return nil, errors.Wrapf(err, "failed to look up %s", name)
+	}
+
+	var addresses []string
+	for _, record := range records {
+		if record = strings.TrimSpace(record); record != "" {
+			addresses = append(addresses, record)
+		}
+	}
+
+	if len(addresses) == 0 {
+		return nil, ErrUnresolved
+	}
+
+	return addresses, nil
+}
+
+// Resolvers chains resolvers, consulting each in order until one resolves a
+// peer. Errors other than ErrUnresolved are returned should no resolver
+// resolve the peer.
+func Resolvers(resolvers ...Resolver) Resolver {
+	return ResolverFunc(func(ctx context.Context, publicKey []byte) ([]string, error) {
+		err := ErrUnresolved
+
+		for _, resolver := range resolvers {
+			addresses, resolveErr := resolver.Resolve(ctx, publicKey)
+			if resolveErr == nil && len(addresses) > 0 {
+				return addresses, nil
+			}
+
+			if resolveErr != nil && resolveErr != ErrUnresolved {
+				err = resolveErr
+			}
+		}
+
+		return nil, err
+	})
+}
+
+// resolver returns the resolver set through AddressResolver, or a chain of
+// all plugins implementing Resolver in order of priority otherwise.
+func (n *Network) resolver() Resolver {
+	if n.opts.resolver != nil {
+		return n.opts.resolver
+	}
+
+	var resolvers []Resolver
+
+	n.plugins.Each(func(plugin PluginInterface) {
+		if r, ok := plugin.(Resolver); ok {
+			resolvers = append(resolvers, r)
+		}
+	})
+
+	return Resolvers(resolvers...)
+}
+
+// Resolve resolves the addresses of the peer owning a public key through the
+// resolver set through AddressResolver, or through plugins implementing
+// Resolver otherwise.
+func (n *Network) Resolve(ctx context.Context, publicKey []byte) ([]string, error) {
+	return n.resolver().Resolve(ctx, publicKey)
+}
+
+// ClientByID either creates or returns a cached peer client given its ID.
+// Should the ID not hold an address, its addresses are resolved through
+// Resolve and dialed in order until one is reachable.
+func (n *Network) ClientByID(ctx context.Context, id peer.ID) (*PeerClient, error) {
+	if id.Address != "" {
+		return n.Client(id.Address)
+	}
+
+	addresses, err := n.Resolve(ctx, id.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, address := range addresses {
+		var client *PeerClient
+
+		if client, err = n.Client(address); err == nil {
+			return client, nil
+		}
+
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+	}
+
+	return nil, err
+}
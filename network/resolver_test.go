@@ -0,0 +1,62 @@
+package network_test
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/perlin-network/noise/network"
+	"github.com/perlin-network/noise/peer"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolvers(t *testing.T) {
+	t.Parallel()
+
+	known, unknown := []byte("known"), []byte("unknown")
+
+	static := network.StaticResolver{hex.EncodeToString(known): {"tcp://localhost:3000"}}
+	failing := network.ResolverFunc(func(ctx context.Context, publicKey []byte) ([]string, error) {
+		return nil, errors.New("directory unavailable")
+	})
+
+	addresses, err := network.Resolvers(failing, static).Resolve(context.Background(), known)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{"tcp://localhost:3000"}, addresses)
+
+	_, err = static.Resolve(context.Background(), unknown)
+	assert.Equal(t, network.ErrUnresolved, err)
+
+	_, err = network.Resolvers(failing, static).Resolve(context.Background(), unknown)
+	assert.Equal(t, "directory unavailable", err.Error(), "expected errors other than ErrUnresolved to be returned")
+
+	_, err = network.Resolvers().Resolve(context.Background(), known)
+	assert.Equal(t, network.ErrUnresolved, err)
+}
+
+func TestClientByID(t *testing.T) {
+	t.Parallel()
+
+	te := newTest(t, tcpEnv)
+	te.startBoostrap(3)
+	defer te.tearDown()
+
+	node, target := te.nodes[0], te.nodes[1]
+
+	// Without a resolver set, the discovery plugin resolves the peer.
+	client, err := node.ClientByID(context.Background(), peer.ID{PublicKey: target.ID.PublicKey})
+	assert.Equal(t, nil, err)
+	if client != nil {
+		assert.Equal(t, target.Address, client.Address)
+	}
+
+	// A resolver set bypasses the discovery plugin.
+	static := newTest(t, tcpEnv, network.AddressResolver(network.StaticResolver{}))
+	static.startBoostrap(2)
+	defer static.tearDown()
+
+	_, err = static.nodes[0].ClientByID(context.Background(), peer.ID{PublicKey: static.bootstrapNode.ID.PublicKey})
+	assert.Equal(t, network.ErrUnresolved, err)
+}
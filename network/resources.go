@@ -0,0 +1,249 @@
+package network
+
+import (
+	"io/ioutil"
+	"runtime"
+	"sync/atomic"
+	"time"
+
+	"github.com/perlin-network/noise/internal/protobuf"
+)
+
+// resourceSampleInterval is how often the usage of resources is sampled.
+const resourceSampleInterval = 1 * time.Second
+
+// Resource is a resource of the node whose usage is bounded by watermarks.
+type Resource int
+
+const (
+	// ResourceConnections is the number of established connections.
+	ResourceConnections Resource = iota
+	// ResourceGoroutines is the number of goroutines of the process.
+	ResourceGoroutines
+	// ResourceMemory is the number of bytes of heap in use by the process.
+	ResourceMemory
+	// ResourceFileDescriptors is the number of file descriptors open by the
+	// process. It is only sampled on platforms exposing /proc/self/fd.
+	ResourceFileDescriptors
+
+	numResources
+)
+
+var resourceNames = [numResources]string{
+	ResourceConnections:     "connections",
+	ResourceGoroutines:      "goroutines",
+	ResourceMemory:          "memory",
+	ResourceFileDescriptors: "file_descriptors",
+}
+
+func (r Resource) String() string {
+	if r < 0 || r >= numResources {
+		return "unknown"
+	}
+	return resourceNames[r]
+}
+
+// Watermarks are the soft and hard limits of the usage of a resource. Zero
+// disables a limit.
+type Watermarks struct {
+	Soft int64 `json:"soft"`
+	Hard int64 `json:"hard"`
+}
+
+// Pressure is how close the usage of resources is to their limits.
+type Pressure int32
+
+const (
+	// PressureNone is the pressure of resources below their soft watermarks.
+	PressureNone Pressure = iota
+	// PressureSoft is the pressure of a resource at or past its soft
+	// watermark. The node stops accepting connections, and drops messages
+	// of the bulk QoS class.
+	PressureSoft
+	// PressureHard is the pressure of a resource at or past its hard
+	// watermark. The node additionally drops all messages but those of the
+	// latency sensitive QoS class.
+	PressureHard
+)
+
+var pressureNames = [...]string{
+	PressureNone: "none",
+	PressureSoft: "soft",
+	PressureHard: "hard",
+}
+
+func (p Pressure) String() string {
+	if p < 0 || int(p) >= len(pressureNames) {
+		return "unknown"
+	}
+	return pressureNames[p]
+}
+
+// ResourceUsage is the usage of a resource against its watermarks.
+type ResourceUsage struct {
+	Resource   string     `json:"resource"`
+	Used       int64      `json:"used"`
+	Watermarks Watermarks `json:"watermarks"`
+	Pressure   string     `json:"pressure"`
+}
+
+// pressure returns the pressure of a given usage against the watermarks.
+func (w Watermarks) pressure(used int64) Pressure {
+	switch {
+	case w.Hard > 0 && used >= w.Hard:
+		return PressureHard
+	case w.Soft > 0 && used >= w.Soft:
+		return PressureSoft
+	default:
+		return PressureNone
+	}
+}
+
+// limitsResources returns whether or not watermarks are set for any resource.
+func (n *Network) limitsResources() bool {
+	for _, w := range n.opts.resourceLimits {
+		if w.Soft > 0 || w.Hard > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// sampleResources samples the usage of all resources. Resources which may not
+// be sampled on this platform are left out.
+func (n *Network) sampleResources() (used [numResources]int64, sampled [numResources]bool) {
+	n.connections.Range(func(_, value interface{}) bool {
+		if _, ok := value.(*ConnState); ok {
+			used[ResourceConnections]++
+		}
+		return true
+	})
+	sampled[ResourceConnections] = true
+
+	used[ResourceGoroutines], sampled[ResourceGoroutines] = int64(runtime.NumGoroutine()), true
+
+	// Reading memory statistics stops the world, so only do so should memory
+	// be limited.
+	if w := n.opts.resourceLimits[ResourceMemory]; w.Soft > 0 || w.Hard > 0 {
+		var stats runtime.MemStats
+		runtime.ReadMemStats(&stats)
+		used[ResourceMemory], sampled[ResourceMemory] = int64(stats.HeapInuse), true
+	}
+
+	if fds, err := ioutil.ReadDir("/proc/self/fd"); err == nil {
+		used[ResourceFileDescriptors], sampled[ResourceFileDescriptors] = int64(len(fds)), true
+	}
+
+	return
+}
+
+// ResourceUsage samples the usage of all resources against their watermarks.
+func (n *Network) ResourceUsage() []ResourceUsage {
+	used, sampled := n.sampleResources()
+
+	var usage []ResourceUsage
+	for r := Resource(0); r < numResources; r++ {
+		if !sampled[r] {
+			continue
+		}
+
+		w := n.opts.resourceLimits[r]
+		usage = append(usage, ResourceUsage{
+			Resource:   r.String(),
+			Used:       used[r],
+			Watermarks: w,
+			Pressure:   w.pressure(used[r]).String(),
+		})
+	}
+
+	return usage
+}
+
+// ResourcePressure returns the greatest pressure of all resources as of when
+// they were last sampled.
+func (n *Network) ResourcePressure() Pressure {
+	return Pressure(atomic.LoadInt32(&n.pressure))
+}
+
+// updatePressure samples the usage of all resources, and records their
+// greatest pressure.
+func (n *Network) updatePressure() {
+	used, sampled := n.sampleResources()
+
+	pressure, worst := PressureNone, Resource(0)
+	for r := Resource(0); r < numResources; r++ {
+		if p := n.opts.resourceLimits[r].pressure(used[r]); sampled[r] && p > pressure {
+			pressure, worst = p, r
+		}
+	}
+
+	previous := Pressure(atomic.SwapInt32(&n.pressure, int32(pressure)))
+
+	switch {
+	case pressure > previous:
+		n.log().Warn().
+			Str("resource", worst.String()).
+			Int64("used", used[worst]).
+			Str("pressure", pressure.String()).
+			Msg("Resource usage crossed its watermark; shedding load.")
+	case pressure < previous:
+		n.log().Info().
+			Str("pressure", pressure.String()).
+			Msg("Resource pressure eased.")
+	}
+}
+
+func (n *Network) resourceLoop() {
+	t := time.NewTicker(resourceSampleInterval)
+	defer t.Stop()
+
+	for {
+		n.updatePressure()
+
+		select {
+		case <-n.kill:
+			return
+		case <-t.C:
+		}
+	}
+}
+
+// awaitAccepting blocks whilst resources are under pressure, such that
+// incoming connections queue up within the backlog of the listener rather
+// than being accepted.
+func (n *Network) awaitAccepting() {
+	for n.ResourcePressure() >= PressureSoft {
+		select {
+		case <-n.kill:
+			return
+		case <-time.After(resourceSampleInterval):
+		}
+	}
+}
+
+// sheds returns whether or not messages of a QoS class are dropped under the
+// current pressure of resources.
+func (n *Network) sheds(class QoSClass) bool {
+	switch n.ResourcePressure() {
+	case PressureHard:
+		return class != QoSLatencySensitive
+	case PressureSoft:
+		return class == QoSBulk
+	default:
+		return false
+	}
+}
+
+// shed drops a message received whilst shedding load, replying with
+// CodeRateLimited should it be a request such that the requester backs off.
+func (n *Network) shed(client *PeerClient, msg *protobuf.Message, class QoSClass) {
+	n.log().Debug().
+		Str("peer_address", client.Address).
+		Str("qos_class", class.String()).
+		Uint64("opcode", uint64(msg.Opcode)).
+		Msg("Dropped message as resources are under pressure.")
+
+	if msg.RequestNonce > 0 && !msg.ReplyFlag {
+		n.replyError(client, msg, CodeRateLimited)
+	}
+}
@@ -0,0 +1,103 @@
+package network
+
+import (
+	"sync/atomic"
+	"testing"
+
+	"github.com/perlin-network/noise/crypto/ed25519"
+	"github.com/perlin-network/noise/internal/protobuf"
+	"github.com/perlin-network/noise/types/opcode"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatermarksPressure(t *testing.T) {
+	t.Parallel()
+
+	w := Watermarks{Soft: 10, Hard: 20}
+
+	assert.Equal(t, PressureNone, w.pressure(9))
+	assert.Equal(t, PressureSoft, w.pressure(10))
+	assert.Equal(t, PressureHard, w.pressure(20))
+	assert.Equal(t, PressureNone, Watermarks{}.pressure(1<<40), "expected zero watermarks to disable limits")
+	assert.Equal(t, PressureHard, Watermarks{Hard: 5}.pressure(5))
+}
+
+func TestResourceShedding(t *testing.T) {
+	t.Parallel()
+
+	counting := new(MockPlugin)
+
+	builder := NewBuilderWithOptions(
+		QoS(QoSBulk, opcode.LookupNodeRequestCode),
+		QoS(QoSLatencySensitive, opcode.PingCode),
+	)
+	builder.SetKeys(ed25519.RandomKeyPair())
+	listener, address, err := ListenOnRandomPort()
+	assert.Equal(t, nil, err)
+	builder.SetListener(listener)
+	builder.SetAddress(address)
+	assert.Equal(t, nil, builder.AddPlugin(counting))
+
+	node, err := builder.Build()
+	assert.Equal(t, nil, err)
+	defer node.Close()
+
+	// Limits are set once built rather than through ResourceLimits, such that
+	// pressure is only sampled by the test.
+	node.opts.resourceLimits[ResourceGoroutines] = Watermarks{Soft: 1}
+
+	client, err := createPeerClient(node, "tcp://localhost:3000")
+	assert.Equal(t, nil, err)
+
+	dispatch := func(code opcode.Opcode) {
+		if fn := node.prepareDispatch(client, &protobuf.Message{Opcode: uint32(code)}); fn != nil {
+			fn()
+		}
+	}
+
+	dispatchAll := func() {
+		dispatch(opcode.PingCode)
+		dispatch(opcode.PongCode)
+		dispatch(opcode.LookupNodeRequestCode)
+	}
+
+	dispatchAll()
+	assert.Equal(t, int32(3), counting.receive.Load(), "expected no messages to be shed without pressure")
+
+	// There is always at least one goroutine running.
+	node.updatePressure()
+	assert.Equal(t, PressureSoft, node.ResourcePressure())
+
+	dispatchAll()
+	assert.Equal(t, int32(5), counting.receive.Load(), "expected only bulk messages to be shed under soft pressure")
+
+	atomic.StoreInt32(&node.pressure, int32(PressureHard))
+
+	dispatchAll()
+	assert.Equal(t, int32(6), counting.receive.Load(), "expected only latency sensitive messages to be kept under hard pressure")
+
+	var goroutines *ResourceUsage
+	for _, usage := range node.ResourceUsage() {
+		if usage.Resource == ResourceGoroutines.String() {
+			usage := usage
+			goroutines = &usage
+		}
+	}
+
+	if assert.NotNil(t, goroutines) {
+		assert.Equal(t, Watermarks{Soft: 1}, goroutines.Watermarks)
+		assert.Equal(t, PressureSoft.String(), goroutines.Pressure)
+	}
+}
+
+func TestResourceLimitsOption(t *testing.T) {
+	t.Parallel()
+
+	o := defaultBuilderOptions
+	ResourceLimits(ResourceMemory, Watermarks{Soft: 1 << 20, Hard: 1 << 30})(&o)
+	ResourceLimits(numResources, Watermarks{Soft: 1})(&o)
+
+	assert.Equal(t, Watermarks{Soft: 1 << 20, Hard: 1 << 30}, o.resourceLimits[ResourceMemory])
+	assert.Equal(t, Watermarks{}, o.resourceLimits[ResourceConnections])
+}
@@ -0,0 +1,497 @@
+package revocation
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/perlin-network/noise/audit"
+	"github.com/perlin-network/noise/clock"
+	"github.com/perlin-network/noise/internal/protobuf"
+	"github.com/perlin-network/noise/network"
+	"github.com/perlin-network/noise/network/peerstore"
+	"github.com/perlin-network/noise/peer"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/pkg/errors"
+)
+
+const (
+	// defaultGossipInterval is how often revocations are gossiped to random
+	// peers.
+	defaultGossipInterval = 1 * time.Minute
+	// defaultFanout is how many peers revocations are gossiped to per round.
+	defaultFanout = 3
+	// defaultMaxRevocations is how many revocations are held at most.
+	defaultMaxRevocations = 16384
+	// gossipChunkSize is roughly how many bytes of revocations are gossiped
+	// per message, well within the message size limit.
+	gossipChunkSize = 256 * 1024
+
+	// metadataKey is the key revocations are persisted under within the
+	// metadata of peers within a peer store.
+	metadataKey = "revocation"
+)
+
+var (
+	// ErrNotStarted is returned upon revoking a key through a plugin which has
+	// yet to start up.
+	ErrNotStarted = errors.New("revocation: plugin has not started")
+	// ErrInvalid is returned upon revoking a key with a revocation whose
+	// signature does not verify.
+	ErrInvalid = errors.New("revocation: revocation is not signed by the key it revokes")
+)
+
+// bannedForever is the time revoked keys are banned until within peer stores.
+var bannedForever = time.Date(9999, time.December, 31, 0, 0, 0, 0, time.UTC)
+
+// Plugin distributes revocations of compromised keys, and refuses peers whose
+// keys have been revoked.
+type Plugin struct {
+	*network.Plugin
+
+	// GossipInterval is how often all revocations held are gossiped to random
+	// peers (default: 1 minute).
+	GossipInterval time.Duration
+	// Fanout is how many random peers revocations are gossiped to per round
+	// (default: 3).
+	Fanout int
+	// MaxRevocations is how many revocations are held at most. Revocations
+	// of keys unknown to this node are evicted to make room for those of keys
+	// it knows, and are otherwise discarded past it, such that peers may
+	// neither exhaust memory nor crowd out revocations that matter by revoking
+	// throwaway keys (default: 16384).
+	MaxRevocations int
+
+	// Store persists revocations, and bans revoked keys such that other
+	// plugins sharing the store refuse them as well (default: none).
+	Store peerstore.PeerStore
+
+	// OnRevoke is called with every revocation learned of, be it locally or
+	// from a peer.
+	OnRevoke func(revocation *protobuf.Revocation)
+
+	// Revocations held: hex-encoded public key -> *protobuf.Revocation.
+	revocations map[string]*protobuf.Revocation
+	// Revocations held of keys unknown to this node, which are evicted first.
+	unknown map[string]struct{}
+	mutex   sync.RWMutex
+
+	// Connected peers: address -> *network.PeerClient.
+	clients sync.Map
+
+	net *network.Network
+
+	// Clock and source of randomness of the node.
+	clock  clock.Clock
+	random *network.Random
+
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+var (
+	// PluginID is used to check existence of the revocation plugin.
+	PluginID                         = (*Plugin)(nil)
+	_        network.PluginInterface = (*Plugin)(nil)
+	_        network.PeerFilter      = (*Plugin)(nil)
+)
+
+// PluginOption are configurable options for the revocation plugin.
+type PluginOption func(*Plugin)
+
+// WithGossipInterval sets how often revocations are gossiped to random peers.
+func WithGossipInterval(d time.Duration) PluginOption {
+	return func(p *Plugin) {
+		p.GossipInterval = d
+	}
+}
+
+// WithFanout sets how many random peers revocations are gossiped to per round.
+func WithFanout(n int) PluginOption {
+	return func(p *Plugin) {
+		p.Fanout = n
+	}
+}
+
+// WithMaxRevocations sets how many revocations are held at most.
+func WithMaxRevocations(n int) PluginOption {
+	return func(p *Plugin) {
+		p.MaxRevocations = n
+	}
+}
+
+// WithStore sets the peer store revocations are persisted to.
+func WithStore(store peerstore.PeerStore) PluginOption {
+	return func(p *Plugin) {
+		p.Store = store
+	}
+}
+
+// WithOnRevoke sets the callback called with every revocation learned of.
+func WithOnRevoke(fn func(revocation *protobuf.Revocation)) PluginOption {
+	return func(p *Plugin) {
+		p.OnRevoke = fn
+	}
+}
+
+// New returns a new revocation plugin with specified options. Options left
+// unspecified take on their defaults once the plugin starts up, such that
+// new(Plugin) remains valid.
+func New(opts ...PluginOption) *Plugin {
+	p := new(Plugin)
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+// setDefaults fills in all options which have been left unspecified.
+func (p *Plugin) setDefaults() {
+	if p.GossipInterval <= 0 {
+		p.GossipInterval = defaultGossipInterval
+	}
+
+	if p.Fanout <= 0 {
+		p.Fanout = defaultFanout
+	}
+
+	if p.MaxRevocations <= 0 {
+		p.MaxRevocations = defaultMaxRevocations
+	}
+}
+
+func (p *Plugin) Startup(net *network.Network) {
+	p.setDefaults()
+
+	p.clock, p.random = net.Clock(), net.Random()
+
+	p.mutex.Lock()
+	p.net = net
+	p.revocations = make(map[string]*protobuf.Revocation)
+	p.unknown = make(map[string]struct{})
+	p.mutex.Unlock()
+
+	p.restore(net)
+
+	p.ctx, p.cancel = context.WithCancel(context.Background())
+	go p.gossipLoop(p.ctx)
+}
+
+func (p *Plugin) Cleanup(net *network.Network) {
+	if p.cancel != nil {
+		p.cancel()
+	}
+}
+
+func (p *Plugin) Receive(ctx *network.PluginContext) error {
+	// Peers whose keys were revoked whilst connected are disconnected upon
+	// their next message.
+	if p.Revoked(ctx.Sender().PublicKey) {
+		ctx.Client().Close()
+		return nil
+	}
+
+	switch msg := ctx.Message().(type) {
+	case *protobuf.RevocationGossip:
+		for _, revocation := range msg.Revocations {
+			if p.apply(revocation, false) {
+				p.relay(revocation)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (p *Plugin) PeerConnect(client *network.PeerClient) {
+	p.clients.Store(client.Address, client)
+}
+
+func (p *Plugin) PeerDisconnect(client *network.PeerClient) {
+	p.clients.Delete(client.Address)
+}
+
+// AllowPeer refuses peers whose keys have been revoked.
+func (p *Plugin) AllowPeer(id peer.ID) bool {
+	return !p.Revoked(id.PublicKey)
+}
+
+// Revoked returns whether or not a public key has been revoked.
+func (p *Plugin) Revoked(publicKey []byte) bool {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	_, revoked := p.revocations[hex.EncodeToString(publicKey)]
+	return revoked
+}
+
+// Revocations returns all revocations held.
+func (p *Plugin) Revocations() []*protobuf.Revocation {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	revocations := make([]*protobuf.Revocation, 0, len(p.revocations))
+	for _, revocation := range p.revocations {
+		revocations = append(revocations, revocation)
+	}
+
+	return revocations
+}
+
+// Revoke applies a revocation, and broadcasts it to all peers.
+func (p *Plugin) Revoke(revocation *protobuf.Revocation) error {
+	p.mutex.RLock()
+	net := p.net
+	p.mutex.RUnlock()
+
+	if net == nil {
+		return ErrNotStarted
+	}
+
+	if !Verify(net, revocation) {
+		return ErrInvalid
+	}
+
+	if p.apply(revocation, true) {
+		p.relay(revocation)
+	}
+
+	return nil
+}
+
+// knows returns whether or not this node knows of a peer under a public key,
+// be it connected or held within the peer store.
+func (p *Plugin) knows(publicKey []byte) (known bool) {
+	p.clients.Range(func(_, value interface{}) bool {
+		if id := value.(*network.PeerClient).ID(); id != nil && bytes.Equal(id.PublicKey, publicKey) {
+			known = true
+		}
+		return !known
+	})
+
+	if !known && p.Store != nil {
+		_, known, _ = p.Store.Get(publicKey)
+	}
+
+	return
+}
+
+// apply verifies and holds a revocation, disconnects from and bans its key,
+// and returns whether or not it was newly learned of. Revocations applied
+// locally, or of keys this node knows of, are persisted. Revocations of other
+// keys are only held in memory, and are evicted should the revocation of a
+// known key need room.
+func (p *Plugin) apply(revocation *protobuf.Revocation, local bool) bool {
+	key := hex.EncodeToString(revocation.GetPublicKey())
+
+	p.mutex.RLock()
+	net := p.net
+	_, exists := p.revocations[key]
+	p.mutex.RUnlock()
+
+	if exists || !Verify(net, revocation) {
+		return false
+	}
+
+	known := local || p.knows(revocation.PublicKey)
+
+	p.mutex.Lock()
+
+	if _, exists := p.revocations[key]; exists || !p.makeRoom(known) {
+		p.mutex.Unlock()
+		return false
+	}
+
+	p.revocations[key] = revocation
+	if !known {
+		p.unknown[key] = struct{}{}
+	}
+	p.mutex.Unlock()
+
+	net.Log("revocation").Warn().
+		Str("public_key", key).
+		Str("reason", revocation.Reason).
+		Msg("Key has been revoked.")
+
+	net.AuditPeer(context.Background(), audit.PeerBanned, audit.ReasonRevoked, peer.ID{PublicKey: revocation.PublicKey}, nil)
+
+	p.disconnect(revocation.PublicKey)
+
+	if known {
+		p.persist(net, revocation)
+	}
+
+	if p.OnRevoke != nil {
+		p.OnRevoke(revocation)
+	}
+
+	return true
+}
+
+// makeRoom returns whether or not another revocation may be held, evicting a
+// revocation of an unknown key to make room for that of a known key should
+// MaxRevocations be reached. Expects the plugin to be locked.
+func (p *Plugin) makeRoom(known bool) bool {
+	if len(p.revocations) < p.MaxRevocations {
+		return true
+	}
+
+	if !known {
+		return false
+	}
+
+	for key := range p.unknown {
+		delete(p.unknown, key)
+		delete(p.revocations, key)
+		return true
+	}
+
+	return false
+}
+
+// disconnect closes all connections to peers under a public key.
+func (p *Plugin) disconnect(publicKey []byte) {
+	p.clients.Range(func(_, value interface{}) bool {
+		client := value.(*network.PeerClient)
+
+		if id := client.ID(); id != nil && bytes.Equal(id.PublicKey, publicKey) {
+			client.Close()
+		}
+
+		return true
+	})
+}
+
+// relay broadcasts a newly learned of revocation to all peers.
+func (p *Plugin) relay(revocation *protobuf.Revocation) {
+	p.net.Broadcast(network.WithSignMessage(context.Background(), true), &protobuf.RevocationGossip{
+		Revocations: []*protobuf.Revocation{revocation},
+	})
+}
+
+// persist bans a revoked key within the peer store, alongside the revocation
+// such that it may be restored and gossiped upon restarting.
+func (p *Plugin) persist(net *network.Network, revocation *protobuf.Revocation) {
+	if p.Store == nil {
+		return
+	}
+
+	encoded, err := proto.Marshal(revocation)
+	if err == nil {
+		err = p.Store.Update(revocation.PublicKey, func(peer *peerstore.Peer) {
+			peer.BannedUntil = bannedForever
+
+			if peer.Metadata == nil {
+				peer.Metadata = make(map[string][]byte)
+			}
+			peer.Metadata[metadataKey] = encoded
+		})
+	}
+
+	if err != nil {
+		net.Log("revocation").Warn().Err(err).Msg("Failed to persist revocation.")
+	}
+}
+
+// restore applies all revocations persisted within the peer store.
+func (p *Plugin) restore(net *network.Network) {
+	if p.Store == nil {
+		return
+	}
+
+	var revocations []*protobuf.Revocation
+
+	err := p.Store.Range(func(peer peerstore.Peer) bool {
+		if encoded, exists := peer.Metadata[metadataKey]; exists {
+			revocation := &protobuf.Revocation{}
+			if proto.Unmarshal(encoded, revocation) == nil {
+				revocations = append(revocations, revocation)
+			}
+		}
+		return true
+	})
+
+	if err != nil {
+		net.Log("revocation").Warn().Err(err).Msg("Failed to restore revocations from the peer store.")
+	}
+
+	// Revocations within the peer store are of keys known to this node.
+	for _, revocation := range revocations {
+		p.apply(revocation, true)
+	}
+}
+
+// gossipLoop gossips revocations to random peers every interval.
+func (p *Plugin) gossipLoop(ctx context.Context) {
+	t := p.clock.NewTicker(p.GossipInterval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C():
+			p.Gossip(ctx)
+		}
+	}
+}
+
+// Gossip sends all revocations held to a few random peers, split across as
+// many messages as it takes to keep each within the message size limit.
+func (p *Plugin) Gossip(ctx context.Context) {
+	revocations := p.Revocations()
+	if len(revocations) == 0 {
+		return
+	}
+
+	var clients []*network.PeerClient
+	p.clients.Range(func(_, value interface{}) bool {
+		clients = append(clients, value.(*network.PeerClient))
+		return true
+	})
+
+	p.random.Shuffle(len(clients), func(i, j int) {
+		clients[i], clients[j] = clients[j], clients[i]
+	})
+
+	if len(clients) > p.Fanout {
+		clients = clients[:p.Fanout]
+	}
+
+	for _, gossip := range chunkRevocations(revocations, gossipChunkSize) {
+		for _, client := range clients {
+			if err := client.Tell(network.WithSignMessage(ctx, true), gossip); err != nil {
+				client.Network.Log("revocation").Debug().Err(err).Str("peer_address", client.Address).Msg("Failed to gossip revocations to peer.")
+			}
+		}
+	}
+}
+
+// chunkRevocations splits revocations into gossip messages of roughly at most
+// size bytes each.
+func chunkRevocations(revocations []*protobuf.Revocation, size int) (chunks []*protobuf.RevocationGossip) {
+	chunk, chunkSize := new(protobuf.RevocationGossip), 0
+
+	for _, revocation := range revocations {
+		revocationSize := proto.Size(revocation)
+
+		if len(chunk.Revocations) > 0 && chunkSize+revocationSize > size {
+			chunks = append(chunks, chunk)
+			chunk, chunkSize = new(protobuf.RevocationGossip), 0
+		}
+
+		chunk.Revocations = append(chunk.Revocations, revocation)
+		chunkSize += revocationSize
+	}
+
+	if len(chunk.Revocations) > 0 {
+		chunks = append(chunks, chunk)
+	}
+
+	return
+}
@@ -0,0 +1,196 @@
+package revocation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/perlin-network/noise/crypto"
+	"github.com/perlin-network/noise/crypto/blake2b"
+	"github.com/perlin-network/noise/crypto/ed25519"
+	"github.com/perlin-network/noise/internal/protobuf"
+	"github.com/perlin-network/noise/internal/test"
+	"github.com/perlin-network/noise/network"
+	"github.com/perlin-network/noise/network/peerstore"
+
+	"github.com/gogo/protobuf/proto"
+)
+
+func connect(t *testing.T, a, b *network.Network) {
+	a.Bootstrap(b.Address)
+	b.Bootstrap(a.Address)
+
+	waitFor(t, "nodes to connect", func() bool {
+		return a.ConnectionStateExists(b.Address) && b.ConnectionStateExists(a.Address)
+	})
+
+	// Messages sent before the connection is ready on both ends are dropped.
+	time.Sleep(300 * time.Millisecond)
+}
+
+func waitFor(t *testing.T, description string, cond func() bool) {
+	deadline := time.Now().Add(5 * time.Second)
+
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", description)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+func TestSignVerify(t *testing.T) {
+	keys := ed25519.RandomKeyPair()
+
+	a := New()
+	node := test.BuildNode(t, nil, a)
+	defer node.Close()
+
+	revocation, err := Sign(keys, ed25519.New(), blake2b.New(), "compromised", time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !Verify(node, revocation) {
+		t.Fatal("expected revocation to verify")
+	}
+
+	revocation.Reason = "tampered"
+	if Verify(node, revocation) {
+		t.Fatal("expected tampered revocation not to verify")
+	}
+
+	if err := a.Revoke(revocation); err != ErrInvalid {
+		t.Fatalf("expected tampered revocation to be refused, got %v", err)
+	}
+
+	if err := New().Revoke(revocation); err != ErrNotStarted {
+		t.Fatalf("expected revocations before startup to fail, got %v", err)
+	}
+}
+
+func TestRevocation(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping revocation test in short mode")
+	}
+
+	store := peerstore.NewMemory()
+	compromised := ed25519.RandomKeyPair()
+
+	revoked := make(chan []byte, 1)
+
+	a := New(WithGossipInterval(100*time.Millisecond), WithStore(store))
+	b := New(WithGossipInterval(100*time.Millisecond), WithOnRevoke(func(revocation *protobuf.Revocation) {
+		revoked <- revocation.PublicKey
+	}))
+	c := New(WithGossipInterval(100 * time.Millisecond))
+
+	nodeA, nodeB, nodeC := test.BuildNode(t, nil, a), test.BuildNode(t, nil, b), test.BuildNode(t, nil, c)
+	defer nodeA.Close()
+	defer nodeB.Close()
+	defer nodeC.Close()
+
+	nodeM := test.BuildNodeWithKeys(t, compromised, nil, New())
+	defer nodeM.Close()
+
+	connect(t, nodeA, nodeB)
+	connect(t, nodeM, nodeA)
+	connect(t, nodeM, nodeB)
+
+	revocation, err := Sign(compromised, ed25519.New(), blake2b.New(), "compromised", time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := a.Revoke(revocation); err != nil {
+		t.Fatal(err)
+	}
+
+	// Revocations are relayed to peers, which disconnect from the revoked key.
+	if key := <-revoked; string(key) != string(compromised.PublicKey) {
+		t.Fatalf("expected revocation to be relayed, got %x", key)
+	}
+
+	waitFor(t, "revoked peer to be disconnected", func() bool {
+		return !nodeA.ConnectionStateExists(nodeM.Address) && !nodeB.ConnectionStateExists(nodeM.Address)
+	})
+
+	// Revoked keys are refused from then on.
+	waitFor(t, "revoked peer to notice it was disconnected", func() bool {
+		return !nodeM.ConnectionStateExists(nodeA.Address)
+	})
+
+	nodeM.Bootstrap(nodeA.Address)
+
+	waitFor(t, "revoked peer to be refused", func() bool {
+		return nodeA.HandshakeFailures().Total[network.FailureRefused] > 0
+	})
+
+	// Revocations are persisted, and restored upon restarting.
+	if !peerstore.Banned(store, compromised.PublicKey) {
+		t.Fatal("expected revoked key to be banned within the peer store")
+	}
+
+	restarted := New(WithStore(store))
+	nodeR := test.BuildNode(t, nil, restarted)
+	defer nodeR.Close()
+
+	if !restarted.Revoked(compromised.PublicKey) {
+		t.Fatal("expected revocation to be restored from the peer store")
+	}
+
+	// Nodes joining later catch up through gossip.
+	connect(t, nodeC, nodeB)
+
+	waitFor(t, "late joiner to catch up", func() bool {
+		return c.Revoked(compromised.PublicKey)
+	})
+}
+
+func TestRevocationFlood(t *testing.T) {
+	store := peerstore.NewMemory()
+
+	p := New(WithMaxRevocations(4), WithStore(store))
+	node := test.BuildNode(t, nil, p)
+	defer node.Close()
+
+	revoke := func(keys *crypto.KeyPair) *protobuf.Revocation {
+		revocation, err := Sign(keys, ed25519.New(), blake2b.New(), "compromised", time.Now())
+		if err != nil {
+			t.Fatal(err)
+		}
+		return revocation
+	}
+
+	// Peers may fill the node with revocations of throwaway keys.
+	for i := 0; i < 8; i++ {
+		p.apply(revoke(ed25519.RandomKeyPair()), false)
+	}
+
+	if held := len(p.Revocations()); held != 4 {
+		t.Fatalf("expected 4 revocations to be held, got %d", held)
+	}
+
+	// Yet revocations of keys the node knows of still make it through.
+	compromised := ed25519.RandomKeyPair()
+	if err := store.Update(compromised.PublicKey, func(peer *peerstore.Peer) {}); err != nil {
+		t.Fatal(err)
+	}
+
+	if !p.apply(revoke(compromised), false) || !p.Revoked(compromised.PublicKey) {
+		t.Fatal("expected revocation of a known key to evict that of a throwaway key")
+	}
+
+	if held := len(p.Revocations()); held != 4 {
+		t.Fatalf("expected 4 revocations to be held, got %d", held)
+	}
+
+	if !peerstore.Banned(store, compromised.PublicKey) {
+		t.Fatal("expected revocation of a known key to be persisted")
+	}
+
+	// Gossip is split into messages within the message size limit.
+	chunks := chunkRevocations(p.Revocations(), 2*proto.Size(revoke(compromised)))
+	if len(chunks) != 2 || len(chunks[0].Revocations) != 2 || len(chunks[1].Revocations) != 2 {
+		t.Fatalf("expected revocations to be gossiped in 2 chunks of 2, got %d chunks", len(chunks))
+	}
+}
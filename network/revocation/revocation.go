@@ -0,0 +1,72 @@
+// Package revocation distributes signed statements revoking compromised node
+// keys.
+//
+// A revocation is signed by the very key it revokes, such that only whoever
+// holds the key may revoke it. Operators are advised to sign a revocation for
+// every node key upon generating it, and to keep it offline until needed.
+//
+// Nodes which learn of a valid revocation disconnect from peers under the
+// revoked key, ban it, refuse connections from it from then on, and relay the
+// revocation to their own peers. Revocations are additionally gossiped to a
+// few random peers periodically, and may be persisted into a peer store such
+// that they survive restarts.
+package revocation
+
+import (
+	"bytes"
+	"encoding/binary"
+	"time"
+
+	"github.com/perlin-network/noise/crypto"
+	"github.com/perlin-network/noise/internal/protobuf"
+	"github.com/perlin-network/noise/network"
+)
+
+// payloadPrefix separates revocation signatures from signatures over any other
+// data made by the same key.
+const payloadPrefix = "noise/revocation"
+
+// payload deterministically serializes all fields of a revocation except for
+// its signature, for signing purposes.
+func payload(revocation *protobuf.Revocation) []byte {
+	var buf bytes.Buffer
+
+	writeBytes := func(b []byte) {
+		binary.Write(&buf, binary.LittleEndian, uint32(len(b)))
+		buf.Write(b)
+	}
+
+	buf.WriteString(payloadPrefix)
+	writeBytes(revocation.PublicKey)
+	binary.Write(&buf, binary.LittleEndian, revocation.Timestamp)
+	writeBytes([]byte(revocation.Reason))
+
+	return buf.Bytes()
+}
+
+// Sign returns a revocation of a key pair signed by the key pair itself under
+// the signature and hash policies of the network it is to be distributed on.
+func Sign(keys *crypto.KeyPair, sp crypto.SignaturePolicy, hp crypto.HashPolicy, reason string, at time.Time) (*protobuf.Revocation, error) {
+	revocation := &protobuf.Revocation{
+		PublicKey: keys.PublicKey,
+		Timestamp: at.UnixNano(),
+		Reason:    reason,
+	}
+
+	signature, err := keys.Sign(sp, hp, payload(revocation))
+	if err != nil {
+		return nil, err
+	}
+	revocation.Signature = signature
+
+	return revocation, nil
+}
+
+// Verify checks that a revocation was signed by the key it revokes under the
+// signature and hash policies of a network.
+func Verify(net *network.Network, revocation *protobuf.Revocation) bool {
+	if revocation == nil || len(revocation.PublicKey) == 0 || len(revocation.Signature) == 0 {
+		return false
+	}
+	return net.Verify(revocation.PublicKey, payload(revocation), revocation.Signature)
+}
@@ -0,0 +1,233 @@
+package network
+
+import (
+	"sync"
+	"time"
+
+	"github.com/perlin-network/noise/network/capture"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// sendQuantum is how many bytes of frames a peer is credited with per turn
+	// whilst sending is fair.
+	sendQuantum = 64 * 1024
+	// maxSendQueueBytes is how many bytes of frames may be queued for a peer
+	// whilst sending is fair before writes to it are refused.
+	maxSendQueueBytes = 16 * 1024 * 1024
+)
+
+// errSendQueueFull is returned upon writing to a peer whose send queue is full.
+var errSendQueueFull = errors.New("send queue is full")
+
+// queuedFrame is a frame queued for a peer whilst sending is fair.
+type queuedFrame struct {
+	buffer     []byte
+	opcode     uint32
+	duplicates int
+	flush      bool
+}
+
+// sendQueue holds the frames queued for a connection whilst sending is fair.
+type sendQueue struct {
+	mutex  sync.Mutex
+	frames []queuedFrame
+	bytes  int
+
+	// deficit is how many bytes of frames the connection may send upon its
+	// turn.
+	deficit int
+	// scheduled is whether or not the connection awaits its turn, or is being
+	// served.
+	scheduled bool
+}
+
+// push queues a frame, and returns whether or not the connection is to be
+// scheduled.
+func (q *sendQueue) push(frame queuedFrame) (schedule bool, err error) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	if q.bytes+len(frame.buffer) > maxSendQueueBytes {
+		return false, errSendQueueFull
+	}
+
+	q.frames = append(q.frames, frame)
+	q.bytes += len(frame.buffer)
+
+	schedule = !q.scheduled
+	q.scheduled = true
+
+	return schedule, nil
+}
+
+// take credits the connection with a quantum, and dequeues as many frames as
+// its deficit covers.
+func (q *sendQueue) take() (frames []queuedFrame) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	q.deficit += sendQuantum
+
+	for len(q.frames) > 0 && len(q.frames[0].buffer) <= q.deficit {
+		frame := q.frames[0]
+		q.frames[0] = queuedFrame{}
+		q.frames = q.frames[1:]
+
+		q.deficit -= len(frame.buffer)
+		q.bytes -= len(frame.buffer)
+
+		frames = append(frames, frame)
+	}
+
+	return
+}
+
+// done ends the turn of the connection, and returns whether or not it is to be
+// scheduled again.
+func (q *sendQueue) done() (reschedule bool) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	if len(q.frames) == 0 {
+		q.deficit = 0
+		q.scheduled = false
+		return false
+	}
+
+	return true
+}
+
+// clear drops all frames queued, such as once the connection breaks.
+func (q *sendQueue) clear() {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	q.frames, q.bytes = nil, 0
+}
+
+// sendScheduler hands connections with queued frames to send workers in
+// deficit round-robin order, such that every peer gets to send a quantum of
+// bytes in turn however busy other peers are.
+type sendScheduler struct {
+	mutex  sync.Mutex
+	cond   *sync.Cond
+	ready  []*ConnState
+	closed bool
+}
+
+// newSendScheduler creates a scheduler should sending be fair, and returns nil
+// otherwise.
+func newSendScheduler(workers int) *sendScheduler {
+	if workers <= 0 {
+		return nil
+	}
+
+	s := new(sendScheduler)
+	s.cond = sync.NewCond(&s.mutex)
+	return s
+}
+
+// schedule appends a connection to the back of the line.
+func (s *sendScheduler) schedule(state *ConnState) {
+	s.mutex.Lock()
+	s.ready = append(s.ready, state)
+	s.mutex.Unlock()
+
+	s.cond.Signal()
+}
+
+// next pops the connection at the front of the line, waiting for one should
+// the line be empty. Returns nil once the scheduler is closed.
+func (s *sendScheduler) next() *ConnState {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for len(s.ready) == 0 && !s.closed {
+		s.cond.Wait()
+	}
+
+	if s.closed {
+		return nil
+	}
+
+	state := s.ready[0]
+	s.ready[0] = nil
+	s.ready = s.ready[1:]
+
+	return state
+}
+
+func (s *sendScheduler) close() {
+	s.mutex.Lock()
+	s.closed = true
+	s.mutex.Unlock()
+
+	s.cond.Broadcast()
+}
+
+// enqueue queues a message for a connection, to be written by send workers.
+func (n *Network) enqueue(state *ConnState, frame queuedFrame) error {
+	schedule, err := state.queue.push(frame)
+	if err != nil {
+		return err
+	}
+
+	if schedule {
+		n.sender.schedule(state)
+	}
+
+	return nil
+}
+
+// sendLoop serves connections as they get their turn until the node is
+// closed.
+func (n *Network) sendLoop() {
+	for {
+		state := n.sender.next()
+		if state == nil {
+			return
+		}
+
+		n.serveTurn(state)
+
+		if state.queue.done() {
+			n.sender.schedule(state)
+		}
+	}
+}
+
+// serveTurn writes the frames a connection may send upon its turn.
+func (n *Network) serveTurn(state *ConnState) {
+	frames := state.queue.take()
+	if len(frames) == 0 {
+		return
+	}
+
+	state.writerMutex.Lock()
+	defer state.writerMutex.Unlock()
+
+	state.conn.SetWriteDeadline(time.Now().Add(n.opts.writeTimeout))
+
+	flush := false
+
+	for _, frame := range frames {
+		if err := n.writeFrame(state.writer, frame.buffer, frame.duplicates); err != nil {
+			n.log().Warn().Err(err).Str("peer_address", state.address).Msg("Dropped frames queued for peer.")
+			state.queue.clear()
+			return
+		}
+
+		n.captureFrame(capture.Outbound, state.address, frame.opcode, frame.buffer)
+
+		flush = flush || frame.flush
+	}
+
+	// Flush messages with priority rather than waiting on the flusher.
+	if flush {
+		if err := state.writer.Flush(); err != nil {
+			n.log().Warn().Err(err).Str("peer_address", state.address).Msg("Failed to flush frames queued for peer.")
+		}
+	}
+}
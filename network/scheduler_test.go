@@ -0,0 +1,132 @@
+package network
+
+import (
+	"bufio"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/perlin-network/noise/crypto/ed25519"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSendQueueDeficit(t *testing.T) {
+	t.Parallel()
+
+	q := new(sendQueue)
+
+	for i := 0; i < 3; i++ {
+		schedule, err := q.push(queuedFrame{buffer: make([]byte, sendQuantum/2)})
+		assert.Equal(t, nil, err)
+		assert.Equal(t, i == 0, schedule, "expected connections to be scheduled only once")
+	}
+
+	assert.Equal(t, 2, len(q.take()), "expected a quantum worth of frames per turn")
+	assert.True(t, q.done())
+	assert.Equal(t, 1, len(q.take()))
+	assert.False(t, q.done())
+
+	// Frames larger than a quantum are sent once enough turns went by.
+	q.push(queuedFrame{buffer: make([]byte, sendQuantum*2)})
+	assert.Equal(t, 0, len(q.take()))
+	assert.Equal(t, 1, len(q.take()))
+
+	_, err := q.push(queuedFrame{buffer: make([]byte, maxSendQueueBytes+1)})
+	assert.Equal(t, errSendQueueFull, err)
+}
+
+// deadlineConn is a connection whose deadlines may be set, and nothing else.
+type deadlineConn struct {
+	net.Conn
+}
+
+func (deadlineConn) SetWriteDeadline(time.Time) error {
+	return nil
+}
+
+// recorder records which peer every write is made to, holding writes until
+// released.
+type recorder struct {
+	mutex   sync.Mutex
+	writes  []string
+	release chan struct{}
+}
+
+func (r *recorder) writer(address string) *bufio.Writer {
+	return bufio.NewWriterSize(writerFunc(func(b []byte) (int, error) {
+		<-r.release
+
+		r.mutex.Lock()
+		r.writes = append(r.writes, address)
+		r.mutex.Unlock()
+
+		return len(b), nil
+	}), 16)
+}
+
+type writerFunc func(b []byte) (int, error)
+
+func (fn writerFunc) Write(b []byte) (int, error) {
+	return fn(b)
+}
+
+func TestFairSend(t *testing.T) {
+	t.Parallel()
+
+	builder := NewBuilderWithOptions(FairSend(1))
+	builder.SetKeys(ed25519.RandomKeyPair())
+	listener, address, err := ListenOnRandomPort()
+	assert.Equal(t, nil, err)
+	builder.SetListener(listener)
+	builder.SetAddress(address)
+
+	node, err := builder.Build()
+	assert.Equal(t, nil, err)
+	defer node.Close()
+
+	r := &recorder{release: make(chan struct{})}
+
+	conn := func(address string) *ConnState {
+		return &ConnState{
+			conn:        deadlineConn{},
+			writer:      r.writer(address),
+			writerMutex: new(sync.Mutex),
+			address:     address,
+			queue:       new(sendQueue),
+		}
+	}
+
+	busy, quiet := conn("busy"), conn("quiet")
+
+	frames := 100
+	for i := 0; i < frames; i++ {
+		assert.Equal(t, nil, node.enqueue(busy, queuedFrame{buffer: make([]byte, sendQuantum/2)}))
+	}
+	assert.Equal(t, nil, node.enqueue(quiet, queuedFrame{buffer: make([]byte, sendQuantum/2)}))
+
+	close(r.release)
+
+	for i := 0; i < 100; i++ {
+		r.mutex.Lock()
+		written := len(r.writes)
+		r.mutex.Unlock()
+
+		if written == frames+1 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	assert.Equal(t, frames+1, len(r.writes))
+
+	for i, address := range r.writes {
+		if address == "quiet" {
+			assert.True(t, i <= 4, "expected the quiet peer to be written to within a couple of turns, got write %d", i)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package network
+
+import (
+	"bufio"
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// defaultMaxSeeds is how many peers are persisted to the seeds file.
+	defaultMaxSeeds = 32
+	// defaultSeedsInterval is how often peers are persisted to the seeds file.
+	defaultSeedsInterval = 5 * time.Minute
+)
+
+// seeds returns the addresses of up to maxSeeds of the best peers known of:
+// connected peers ranked by their scores first, followed by peers known of
+// through a PeerDirectory such as a routing table.
+func (n *Network) seeds() []string {
+	var clients []*PeerClient
+
+	n.eachPeer(func(client *PeerClient) bool {
+		clients = append(clients, client)
+		return true
+	})
+
+	candidates := make([]string, 0, len(clients))
+
+	for _, client := range n.rank(clients) {
+		candidates = append(candidates, client.Address)
+	}
+
+	if directory, ok := n.directory(); ok {
+		for _, id := range directory.KnownPeers() {
+			candidates = append(candidates, id.Address)
+		}
+	}
+
+	seeds := FilterPeers(n.Address, candidates)
+
+	if n.opts.maxSeeds > 0 && len(seeds) > n.opts.maxSeeds {
+		seeds = seeds[:n.opts.maxSeeds]
+	}
+
+	return seeds
+}
+
+// persistSeeds writes the best peers known of to the seeds file, one address
+// per line. The file is left untouched should no peers be known of, such that
+// a node cut off from the network does not forget how to rejoin it.
+func (n *Network) persistSeeds() error {
+	seeds := n.seeds()
+	if len(seeds) == 0 {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	for _, address := range seeds {
+		buf.WriteString(address)
+		buf.WriteByte('\n')
+	}
+
+	// Write to a temporary file first, such that a crash midway through may not
+	// leave behind a truncated seeds file.
+	tmp, err := ioutil.TempFile(filepath.Dir(n.opts.seedsFile), filepath.Base(n.opts.seedsFile)+".*")
+	if err != nil {
+		return errors.Wrap(err, "network: failed to persist seeds")
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(buf.Bytes()); err != nil {
+		tmp.Close()
+		return errors.Wrap(err, "network: failed to persist seeds")
+	}
+
+	if err := tmp.Close(); err != nil {
+		return errors.Wrap(err, "network: failed to persist seeds")
+	}
+
+	return errors.Wrap(os.Rename(tmp.Name(), n.opts.seedsFile), "network: failed to persist seeds")
+}
+
+// loadSeeds reads the addresses persisted to the seeds file. Blank lines and
+// lines starting with '#' are ignored, such that the file may be edited by
+// hand.
+func (n *Network) loadSeeds() (seeds []string) {
+	if n.opts.seedsFile == "" {
+		return nil
+	}
+
+	data, err := ioutil.ReadFile(n.opts.seedsFile)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			n.log().Warn().Err(err).Str("path", n.opts.seedsFile).Msg("Failed to load seeds.")
+		}
+		return nil
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		seeds = append(seeds, line)
+	}
+
+	return seeds
+}
+
+// seedsLoop periodically persists the best peers known of to the seeds file
+// until the node is closed.
+func (n *Network) seedsLoop() {
+	interval := n.opts.seedsInterval
+	if interval <= 0 {
+		interval = defaultSeedsInterval
+	}
+
+	t := n.Clock().NewTicker(interval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-n.kill:
+			return
+		case <-t.C():
+			if err := n.persistSeeds(); err != nil {
+				n.log().Warn().Err(err).Str("path", n.opts.seedsFile).Msg("Failed to persist seeds.")
+			}
+		}
+	}
+}
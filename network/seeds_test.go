@@ -0,0 +1,65 @@
+package network
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/perlin-network/noise/crypto/ed25519"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSeedsFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "seeds")
+
+	build := func(opts ...BuilderOption) *Network {
+		builder := NewBuilderWithOptions(opts...)
+		builder.SetKeys(ed25519.RandomKeyPair())
+		listener, address, err := ListenOnRandomPort()
+		assert.Equal(t, nil, err)
+		builder.SetListener(listener)
+		builder.SetAddress(address)
+
+		node, err := builder.Build()
+		assert.Equal(t, nil, err)
+
+		go node.Listen()
+		node.BlockUntilListening()
+
+		return node
+	}
+
+	seed := build()
+	defer seed.Close()
+
+	first := build(SeedsFile(path))
+	first.Bootstrap(seed.Address)
+
+	known := false
+	for i := 0; i < 100 && !known; i++ {
+		known = len(first.seeds()) == 1
+		time.Sleep(20 * time.Millisecond)
+	}
+	assert.True(t, known, "expected the seed to be known of")
+
+	// Seeds are persisted upon closing.
+	first.Close()
+
+	data, err := ioutil.ReadFile(path)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, seed.Address, strings.TrimSpace(string(data)))
+
+	// Restarted nodes bootstrap off the seeds file, should their bootstrap
+	// peers be unreachable.
+	restarted := build(SeedsFile(path))
+	defer restarted.Close()
+
+	result := restarted.Bootstrap(FormatAddress("tcp", "localhost", uint16(GetRandomUnusedPort())))
+	assert.Equal(t, 1, result.Succeeded())
+	assert.Equal(t, seed.Address, result.Seeds[0].Address)
+}
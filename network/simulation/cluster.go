@@ -0,0 +1,279 @@
+// Package simulation runs clusters of in-process nodes over simulated
+// transports, and scripts scenarios against them such as partitioning the
+// cluster, healing it, and killing and restarting nodes, such that churn
+// behavior may be covered by automated regression tests.
+//
+//	cluster, err := simulation.NewCluster(4)
+//	...
+//	err = simulation.Run(ctx, cluster,
+//		simulation.Bootstrap(0, 1, 2, 3),
+//		simulation.Eventually("converged", 5*time.Second, simulation.Converged()),
+//		simulation.Partition([]int{0, 1}, []int{2, 3}),
+//		simulation.Eventually("split", 30*time.Second, simulation.Not(simulation.Knows(0, 2))),
+//		simulation.Heal(),
+//		simulation.Bootstrap(0, 2),
+//		simulation.Eventually("healed", 5*time.Second, simulation.Converged()),
+//	)
+package simulation
+
+import (
+	"net"
+	"sync"
+	"time"
+
+	"github.com/perlin-network/noise/crypto"
+	"github.com/perlin-network/noise/crypto/ed25519"
+	"github.com/perlin-network/noise/network"
+	"github.com/perlin-network/noise/network/discovery"
+	"github.com/perlin-network/noise/network/transport"
+
+	"github.com/pkg/errors"
+)
+
+// Option configures a cluster.
+type Option func(*options)
+
+type options struct {
+	conditions  transport.Conditions
+	seed        int64
+	seeded      bool
+	plugins     func(i int) []network.PluginInterface
+	builderOpts []network.BuilderOption
+}
+
+// WithConditions subjects all frames written between nodes to the given
+// network conditions (default: frames are delivered immediately).
+func WithConditions(conditions transport.Conditions) Option {
+	return func(o *options) {
+		o.conditions = conditions
+	}
+}
+
+// WithSeed seeds the randomness of all nodes and their transports, such that
+// scenarios may be reproduced (default: randomness is not seeded).
+func WithSeed(seed int64) Option {
+	return func(o *options) {
+		o.seed = seed
+		o.seeded = true
+	}
+}
+
+// WithPlugins sets the plugins node i is built with, which is called anew
+// whenever a node is restarted (default: the discovery plugin).
+func WithPlugins(plugins func(i int) []network.PluginInterface) Option {
+	return func(o *options) {
+		o.plugins = plugins
+	}
+}
+
+// WithBuilderOptions builds all nodes with the given options.
+func WithBuilderOptions(opts ...network.BuilderOption) Option {
+	return func(o *options) {
+		o.builderOpts = append(o.builderOpts, opts...)
+	}
+}
+
+// Cluster is a set of in-process nodes connected through simulated transports
+// attached to a single fabric.
+type Cluster struct {
+	opts   options
+	fabric *transport.Fabric
+
+	mutex sync.RWMutex
+	nodes []*node
+}
+
+// node is a member of a cluster, which keeps its keys, address and transport
+// across restarts.
+type node struct {
+	keys      *crypto.KeyPair
+	address   string
+	transport *transport.Simulated
+	net       *network.Network
+}
+
+// NewCluster builds n nodes listening on random local ports.
+func NewCluster(n int, opts ...Option) (*Cluster, error) {
+	c := &Cluster{fabric: transport.NewFabric()}
+
+	for _, opt := range opts {
+		opt(&c.opts)
+	}
+
+	seed := c.opts.seed
+	if !c.opts.seeded {
+		seed = time.Now().UnixNano()
+	}
+
+	for i := 0; i < n; i++ {
+		sim := transport.NewSimulated(transport.NewTCP(), c.opts.conditions, seed+int64(i))
+		c.fabric.Attach(sim)
+
+		c.nodes = append(c.nodes, &node{
+			keys:      ed25519.RandomKeyPair(),
+			address:   network.FormatAddress("tcp", "127.0.0.1", uint16(network.GetRandomUnusedPort())),
+			transport: sim,
+		})
+
+		if err := c.start(i); err != nil {
+			c.Close()
+			return nil, err
+		}
+	}
+
+	return c, nil
+}
+
+// start builds and starts node i.
+func (c *Cluster) start(i int) error {
+	n := c.nodes[i]
+
+	builderOpts := append([]network.BuilderOption(nil), c.opts.builderOpts...)
+	if c.opts.seeded {
+		builderOpts = append(builderOpts, network.Seed(c.opts.seed+int64(i)))
+	}
+
+	builder := network.NewBuilderWithOptions(builderOpts...)
+	builder.SetKeys(n.keys)
+	builder.SetAddress(n.address)
+	builder.RegisterTransportLayer("tcp", n.transport)
+
+	plugins := []network.PluginInterface{discovery.New()}
+	if c.opts.plugins != nil {
+		plugins = c.opts.plugins(i)
+	}
+
+	for _, plugin := range plugins {
+		if err := builder.AddPlugin(plugin); err != nil {
+			return errors.Wrapf(err, "simulation: failed to add plugin to node %d", i)
+		}
+	}
+
+	net, err := builder.Build()
+	if err != nil {
+		return errors.Wrapf(err, "simulation: failed to build node %d", i)
+	}
+
+	go net.Listen()
+	net.BlockUntilListening()
+
+	c.mutex.Lock()
+	n.net = net
+	c.mutex.Unlock()
+
+	return nil
+}
+
+// Len returns the number of nodes within the cluster, dead or alive.
+func (c *Cluster) Len() int {
+	return len(c.nodes)
+}
+
+// Node returns node i, or nil should it have been killed.
+func (c *Cluster) Node(i int) *network.Network {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.nodes[i].net
+}
+
+// Alive returns whether node i is running.
+func (c *Cluster) Alive(i int) bool {
+	return c.Node(i) != nil
+}
+
+// Transport returns the simulated transport of node i, such that the
+// conditions its frames are subjected to may be changed.
+func (c *Cluster) Transport(i int) *transport.Simulated {
+	return c.nodes[i].transport
+}
+
+// Partition splits the cluster into groups of nodes which may only reach
+// nodes within the same group. Nodes not listed form a group of their own.
+func (c *Cluster) Partition(groups ...[]int) {
+	partitions := make([][]*transport.Simulated, len(groups))
+
+	for i, group := range groups {
+		for _, j := range group {
+			partitions[i] = append(partitions[i], c.nodes[j].transport)
+		}
+	}
+
+	c.fabric.Partition(partitions...)
+}
+
+// Heal removes all partitions.
+func (c *Cluster) Heal() {
+	c.fabric.Heal()
+}
+
+// Kill shuts node i down. Does nothing should it already be dead.
+func (c *Cluster) Kill(i int) {
+	c.mutex.Lock()
+	net := c.nodes[i].net
+	c.nodes[i].net = nil
+	c.mutex.Unlock()
+
+	if net != nil {
+		net.Close()
+	}
+}
+
+// Restart kills node i should it be running, and starts it anew under the
+// same keys and address with freshly built plugins.
+func (c *Cluster) Restart(i int) error {
+	c.Kill(i)
+
+	if err := waitForPort(c.nodes[i].address); err != nil {
+		return errors.Wrapf(err, "simulation: failed to restart node %d", i)
+	}
+
+	return c.start(i)
+}
+
+// waitForPort waits for the port of an address to be freed up, as nodes close
+// their listener asynchronously once killed.
+func waitForPort(address string) error {
+	info, err := network.ParseAddress(address)
+	if err != nil {
+		return err
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+
+	for {
+		listener, err := net.Listen("tcp", info.HostPort())
+		if err == nil {
+			return listener.Close()
+		}
+
+		if time.Now().After(deadline) {
+			return err
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// Bootstrap connects node i to the nodes listed.
+func (c *Cluster) Bootstrap(i int, peers ...int) error {
+	net := c.Node(i)
+	if net == nil {
+		return errors.Errorf("simulation: node %d is dead", i)
+	}
+
+	addresses := make([]string, 0, len(peers))
+	for _, j := range peers {
+		addresses = append(addresses, c.nodes[j].address)
+	}
+
+	net.Bootstrap(addresses...)
+
+	return nil
+}
+
+// Close kills all nodes.
+func (c *Cluster) Close() {
+	for i := range c.nodes {
+		c.Kill(i)
+	}
+}
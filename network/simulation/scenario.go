@@ -0,0 +1,195 @@
+package simulation
+
+import (
+	"context"
+	"time"
+
+	"github.com/perlin-network/noise/network/discovery"
+
+	"github.com/pkg/errors"
+)
+
+// pollInterval is how often conditions are checked while waiting on them.
+const pollInterval = 50 * time.Millisecond
+
+// Step is a single step of a scenario run against a cluster.
+type Step func(ctx context.Context, c *Cluster) error
+
+// Condition is a predicate over the state of a cluster.
+type Condition func(c *Cluster) bool
+
+// Run runs the steps of a scenario against a cluster in order, and stops at
+// the first step which fails or should ctx be cancelled.
+func Run(ctx context.Context, c *Cluster, steps ...Step) error {
+	for i, step := range steps {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if err := step(ctx, c); err != nil {
+			return errors.Wrapf(err, "simulation: step %d failed", i)
+		}
+	}
+
+	return nil
+}
+
+// Partition splits the cluster into groups of nodes which may only reach
+// nodes within the same group.
+func Partition(groups ...[]int) Step {
+	return func(ctx context.Context, c *Cluster) error {
+		c.Partition(groups...)
+		return nil
+	}
+}
+
+// Heal removes all partitions.
+func Heal() Step {
+	return func(ctx context.Context, c *Cluster) error {
+		c.Heal()
+		return nil
+	}
+}
+
+// Kill shuts node i down.
+func Kill(i int) Step {
+	return func(ctx context.Context, c *Cluster) error {
+		c.Kill(i)
+		return nil
+	}
+}
+
+// Restart starts node i anew under the same keys and address.
+func Restart(i int) Step {
+	return func(ctx context.Context, c *Cluster) error {
+		return c.Restart(i)
+	}
+}
+
+// Bootstrap connects node i to the nodes listed.
+func Bootstrap(i int, peers ...int) Step {
+	return func(ctx context.Context, c *Cluster) error {
+		return c.Bootstrap(i, peers...)
+	}
+}
+
+// Wait waits for d to pass.
+func Wait(d time.Duration) Step {
+	return func(ctx context.Context, c *Cluster) error {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(d):
+			return nil
+		}
+	}
+}
+
+// Do runs fn against the cluster, such as to send messages between nodes.
+func Do(fn func(c *Cluster) error) Step {
+	return func(ctx context.Context, c *Cluster) error {
+		return fn(c)
+	}
+}
+
+// Eventually waits for a condition to hold, and fails should it not hold
+// within timeout.
+func Eventually(description string, timeout time.Duration, cond Condition) Step {
+	return func(ctx context.Context, c *Cluster) error {
+		deadline := time.NewTimer(timeout)
+		defer deadline.Stop()
+
+		ticker := time.NewTicker(pollInterval)
+		defer ticker.Stop()
+
+		for !cond(c) {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-deadline.C:
+				return errors.Errorf("simulation: %s did not hold within %s", description, timeout)
+			case <-ticker.C:
+			}
+		}
+
+		return nil
+	}
+}
+
+// Not negates a condition.
+func Not(cond Condition) Condition {
+	return func(c *Cluster) bool {
+		return !cond(c)
+	}
+}
+
+// All holds should all conditions hold.
+func All(conds ...Condition) Condition {
+	return func(c *Cluster) bool {
+		for _, cond := range conds {
+			if !cond(c) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Knows holds should node i be alive, and hold node j within the routing
+// table of its discovery plugin.
+func Knows(i, j int) Condition {
+	return func(c *Cluster) bool {
+		net := c.Node(i)
+		if net == nil {
+			return false
+		}
+
+		plugin, ok := net.Plugin(discovery.PluginID)
+		if !ok {
+			return false
+		}
+
+		other := c.nodes[j]
+
+		for _, address := range plugin.(*discovery.Plugin).Routes.GetPeerAddresses() {
+			if address == other.address {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// Connected holds should node i be alive, and hold an open connection to
+// node j.
+func Connected(i, j int) Condition {
+	return func(c *Cluster) bool {
+		net := c.Node(i)
+		if net == nil {
+			return false
+		}
+
+		return net.ConnectionStateExists(c.nodes[j].address)
+	}
+}
+
+// Converged holds should every node alive hold every other node alive, and
+// none of the dead nodes, within its routing table.
+func Converged() Condition {
+	return func(c *Cluster) bool {
+		for i := 0; i < c.Len(); i++ {
+			if !c.Alive(i) {
+				continue
+			}
+
+			for j := 0; j < c.Len(); j++ {
+				if i != j && Knows(i, j)(c) != c.Alive(j) {
+					return false
+				}
+			}
+		}
+
+		return true
+	}
+}
@@ -0,0 +1,74 @@
+package simulation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/perlin-network/noise/clock"
+	"github.com/perlin-network/noise/network"
+	"github.com/perlin-network/noise/network/discovery"
+)
+
+func TestPartitionAndHeal(t *testing.T) {
+	if testing.Short() {
+		t.Skipf("skipping %s in short mode", t.Name())
+	}
+
+	// Peers are re-verified whenever the virtual clock is advanced past the
+	// sweep interval of the discovery plugin.
+	virtual := clock.NewVirtual(time.Now())
+
+	cluster, err := NewCluster(3,
+		WithSeed(1),
+		WithBuilderOptions(network.Clock(virtual)),
+		WithPlugins(func(i int) []network.PluginInterface {
+			return []network.PluginInterface{discovery.New(discovery.WithPeerTTL(time.Nanosecond), discovery.WithQueryTimeout(200*time.Millisecond))}
+		}),
+	)
+	if err != nil {
+		t.Fatalf("NewCluster() = expected no error, got %v", err)
+	}
+	defer cluster.Close()
+
+	sweep := Do(func(c *Cluster) error {
+		virtual.Advance(1 * time.Minute)
+		return nil
+	})
+
+	err = Run(context.Background(), cluster,
+		Bootstrap(1, 0),
+		Bootstrap(2, 0),
+		Eventually("convergence", 5*time.Second, Converged()),
+
+		Partition([]int{0, 1}, []int{2}),
+		sweep,
+		Eventually("partition", 5*time.Second, All(Knows(0, 1), Knows(1, 0), Not(Knows(0, 2)), Not(Knows(2, 0)))),
+		Eventually("severed connections", 5*time.Second, All(Not(Connected(0, 2)), Not(Connected(2, 0)), Not(Connected(1, 2)), Not(Connected(2, 1)))),
+
+		Heal(),
+		Bootstrap(2, 0, 1),
+		Eventually("convergence after healing", 5*time.Second, Converged()),
+
+		Kill(1),
+		Eventually("convergence after killing node 1", 5*time.Second, Converged()),
+
+		Restart(1),
+		Bootstrap(1, 0),
+		Eventually("convergence after restarting node 1", 5*time.Second, Converged()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestEventuallyTimesOut(t *testing.T) {
+	t.Parallel()
+
+	cluster := new(Cluster)
+
+	err := Run(context.Background(), cluster, Eventually("never", 100*time.Millisecond, func(*Cluster) bool { return false }))
+	if err == nil {
+		t.Fatal("Run() = expected an error should a condition never hold")
+	}
+}
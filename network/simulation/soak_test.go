@@ -0,0 +1,169 @@
+//go:build soak
+// +build soak
+
+package simulation
+
+import (
+	"flag"
+	"math/rand"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/perlin-network/noise/log"
+	"github.com/perlin-network/noise/network"
+)
+
+// The soak test is excluded from regular runs, and is to be run on its own:
+//
+//	go test -tags soak -run TestSoak -timeout 0 ./network/simulation -soak.duration 4h
+var (
+	soakNodes    = flag.Int("soak.nodes", 200, "number of nodes churned throughout the soak test")
+	soakDuration = flag.Duration("soak.duration", 1*time.Hour, "how long the soak test churns nodes for")
+	soakWarmup   = flag.Duration("soak.warmup", 5*time.Minute, "how long nodes churn for before a baseline is sampled")
+	soakChurn    = flag.Duration("soak.churn", 100*time.Millisecond, "how often a node is killed or restarted")
+	soakInterval = flag.Duration("soak.sample", 1*time.Minute, "how often goroutines, heap and peers are sampled")
+	soakGrowth   = flag.Float64("soak.growth", 1.5, "how many times the baseline goroutines and heap may grow to")
+	soakSeed     = flag.Int64("soak.seed", 1, "seed of the churn")
+)
+
+// soakSample is a sample of the resources held by a cluster.
+type soakSample struct {
+	goroutines int
+	heap       uint64
+	// peers is the most peer clients held by any one node.
+	peers int
+}
+
+func sampleSoak(c *Cluster) (s soakSample) {
+	runtime.GC()
+
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
+	s.goroutines, s.heap = runtime.NumGoroutine(), mem.HeapAlloc
+
+	for i := 0; i < c.Len(); i++ {
+		if node := c.Node(i); node != nil {
+			if peers := node.PeerCount(); peers > s.peers {
+				s.peers = peers
+			}
+		}
+	}
+
+	return
+}
+
+// churn kills a random node, or restarts it and bootstraps it with a few
+// random nodes.
+func churn(t *testing.T, c *Cluster, r *rand.Rand) {
+	i := r.Intn(c.Len())
+
+	if c.Alive(i) && r.Intn(2) == 0 {
+		c.Kill(i)
+		return
+	}
+
+	if err := c.Restart(i); err != nil {
+		t.Errorf("failed to restart node %d: %v", i, err)
+		return
+	}
+
+	peers := make([]int, 0, 3)
+	for attempt := 0; attempt < c.Len() && len(peers) < cap(peers); attempt++ {
+		if j := r.Intn(c.Len()); j != i && c.Alive(j) {
+			peers = append(peers, j)
+		}
+	}
+
+	c.Bootstrap(i, peers...)
+}
+
+// TestSoak continuously churns a cluster of nodes for hours, and checks that
+// goroutines, heap and peers held stay bounded throughout, such that leaks of
+// goroutines, buffers or peer clients of disconnected peers surface.
+func TestSoak(t *testing.T) {
+	cluster, err := NewCluster(*soakNodes,
+		WithSeed(*soakSeed),
+		WithBuilderOptions(network.Logger(log.Nop())),
+	)
+	if err != nil {
+		t.Fatalf("NewCluster() = expected no error, got %v", err)
+	}
+	defer cluster.Close()
+
+	for i := 1; i < cluster.Len(); i++ {
+		cluster.Bootstrap(i, 0)
+	}
+
+	// Nodes are churned on their own goroutine, as killing and restarting
+	// nodes may block for a while, which would otherwise delay samples.
+	stop, stopped := make(chan struct{}), make(chan struct{})
+	defer func() {
+		close(stop)
+		<-stopped
+	}()
+
+	go func() {
+		defer close(stopped)
+
+		r := rand.New(rand.NewSource(*soakSeed))
+
+		churning := time.NewTicker(*soakChurn)
+		defer churning.Stop()
+
+		for {
+			select {
+			case <-stop:
+				return
+			case <-churning.C:
+				churn(t, cluster, r)
+			}
+		}
+	}()
+
+	sampling := time.NewTicker(*soakInterval)
+	defer sampling.Stop()
+
+	start := time.Now()
+	warm, done := start.Add(*soakWarmup), time.After(*soakDuration)
+
+	// The baseline is the peak of samples taken throughout the warm-up, as
+	// goroutines and heap swing with every node killed or restarted.
+	var baseline soakSample
+	warmed := false
+
+	for {
+		select {
+		case <-done:
+			return
+		case now := <-sampling.C:
+			s := sampleSoak(cluster)
+
+			t.Logf("%s: %d goroutines, %d bytes of heap, at most %d peers per node", now.Sub(start).Round(time.Second), s.goroutines, s.heap, s.peers)
+
+			if s.peers >= cluster.Len() {
+				t.Fatalf("a node holds %d peers within a cluster of %d nodes", s.peers, cluster.Len())
+			}
+
+			if !warmed {
+				if s.goroutines > baseline.goroutines {
+					baseline.goroutines = s.goroutines
+				}
+				if s.heap > baseline.heap {
+					baseline.heap = s.heap
+				}
+				warmed = now.After(warm)
+				continue
+			}
+
+			if limit := int(float64(baseline.goroutines) * *soakGrowth); s.goroutines > limit {
+				t.Fatalf("goroutines grew from %d to %d, past the limit of %d", baseline.goroutines, s.goroutines, limit)
+			}
+
+			if limit := uint64(float64(baseline.heap) * *soakGrowth); s.heap > limit {
+				t.Fatalf("heap grew from %d to %d bytes, past the limit of %d", baseline.heap, s.heap, limit)
+			}
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package network
+
+import (
+	"reflect"
+	"runtime/debug"
+	"sort"
+)
+
+const (
+	// modulePath is the path of the module noise is built from.
+	modulePath = "github.com/perlin-network/noise"
+	// develVersion is reported should the version of noise not be known.
+	develVersion = "(devel)"
+)
+
+// Snapshot describes the state of a node at a point in time, for support
+// bundles and admin APIs. It may be marshaled into JSON.
+type Snapshot struct {
+	// Version is the version of noise the node is built with.
+	Version string `json:"version"`
+
+	// Address and PublicKey identify the node.
+	Address   string `json:"address"`
+	PublicKey string `json:"public_key"`
+
+	// UptimeSeconds is how long ago the node started, as told by its clock.
+	UptimeSeconds float64 `json:"uptime_seconds"`
+
+	Listening    bool `json:"listening"`
+	Bootstrapped bool `json:"bootstrapped"`
+
+	// Peers are the peer clients held by the node sorted by address.
+	Peers []PeerSnapshot `json:"peers"`
+
+	Queues            QueueStats            `json:"queues"`
+	Workers           map[string]int64      `json:"workers"`
+	HandshakeFailures HandshakeFailureStats `json:"handshake_failures"`
+
+	// Plugins are the statuses of plugins implementing PluginStatus keyed by
+	// their type, such as the routing table summary of peer discovery.
+	Plugins map[string]interface{} `json:"plugins,omitempty"`
+}
+
+// PeerSnapshot describes the state of a single peer client.
+type PeerSnapshot struct {
+	Address string `json:"address"`
+	// PublicKey is empty should the peer have yet to complete its handshake.
+	PublicKey string `json:"public_key,omitempty"`
+
+	IncomingReady bool `json:"incoming_ready"`
+	OutgoingReady bool `json:"outgoing_ready"`
+
+	PendingJobs     int `json:"pending_jobs"`
+	PendingRequests int `json:"pending_requests"`
+}
+
+// Snapshot returns a snapshot of the node's identity, uptime, peers, queue
+// depths and the statuses of its plugins.
+func (n *Network) Snapshot() Snapshot {
+	snapshot := Snapshot{
+		Version:           moduleVersion(),
+		Address:           n.Address,
+		PublicKey:         n.ID.PublicKeyHex(),
+		UptimeSeconds:     n.Clock().Now().Sub(n.started).Seconds(),
+		Listening:         n.listening(),
+		Bootstrapped:      n.Bootstrapped(),
+		Peers:             make([]PeerSnapshot, 0),
+		Queues:            n.QueueStats(),
+		Workers:           n.WorkerCounts(),
+		HandshakeFailures: n.HandshakeFailures(),
+	}
+
+	n.eachPeer(func(client *PeerClient) bool {
+		peer := PeerSnapshot{
+			Address:       client.Address,
+			IncomingReady: client.IsIncomingReady(),
+			OutgoingReady: client.IsOutgoingReady(),
+			PendingJobs:   len(client.jobs) + len(client.priorityJobs),
+		}
+
+		if id := client.ID(); id != nil {
+			peer.PublicKey = id.PublicKeyHex()
+		}
+
+		client.Requests.Range(func(_, _ interface{}) bool {
+			peer.PendingRequests++
+			return true
+		})
+
+		snapshot.Peers = append(snapshot.Peers, peer)
+		return true
+	})
+
+	sort.Slice(snapshot.Peers, func(i, j int) bool {
+		return snapshot.Peers[i].Address < snapshot.Peers[j].Address
+	})
+
+	n.plugins.Each(func(plugin PluginInterface) {
+		status, ok := plugin.(PluginStatus)
+		if !ok {
+			return
+		}
+
+		if snapshot.Plugins == nil {
+			snapshot.Plugins = make(map[string]interface{})
+		}
+
+		n.safely(plugin, "Status", nil, func() {
+			snapshot.Plugins[reflect.TypeOf(plugin).String()] = status.Status()
+		})
+	})
+
+	return snapshot
+}
+
+// listening returns whether or not the node is listening for peers.
+func (n *Network) listening() bool {
+	select {
+	case <-n.listeningCh:
+		return true
+	default:
+		return false
+	}
+}
+
+// moduleVersion returns the version of noise the running binary is built
+// with, or "(devel)" should it not be known.
+func moduleVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return develVersion
+	}
+
+	var module *debug.Module
+	if info.Main.Path == modulePath {
+		module = &info.Main
+	}
+
+	for _, dep := range info.Deps {
+		if dep.Path == modulePath {
+			module = dep
+		}
+	}
+
+	if module != nil && module.Replace != nil {
+		module = module.Replace
+	}
+
+	if module == nil || module.Version == "" {
+		return develVersion
+	}
+
+	return module.Version
+}
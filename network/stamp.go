@@ -0,0 +1,94 @@
+package network
+
+import (
+	"encoding/binary"
+	"math/bits"
+	"time"
+
+	"github.com/perlin-network/noise/crypto/blake2b"
+	"github.com/perlin-network/noise/peer"
+)
+
+const (
+	// stampSize is the size of a stamp: a unix timestamp followed by a counter.
+	stampSize = 16
+
+	// stampWindow is how far a stamps timestamp may drift from the local clock
+	// before the stamp is considered stale.
+	stampWindow = 10 * time.Minute
+)
+
+// stampHash appends the hash of a stamp over a payload on behalf of a sender
+// to dst.
+func stampHash(dst []byte, sender []byte, payload []byte, stamp []byte) []byte {
+	return blake2b.Sum(dst, sender, payload, stamp)
+}
+
+// leadingZeroBits counts the number of leading zero bits of a hash.
+func leadingZeroBits(hash []byte) int {
+	zeros := 0
+	for _, b := range hash {
+		zeros += bits.LeadingZeros8(b)
+		if b != 0 {
+			break
+		}
+	}
+	return zeros
+}
+
+// MintStamp searches for a proof-of-work stamp over a payload on behalf of a
+// sender, whose hash has at least difficulty leading zero bits. Stamps embed
+// the time they were minted at, and expire after a while.
+func MintStamp(sender []byte, payload []byte, difficulty int, now time.Time) []byte {
+	stamp := make([]byte, stampSize)
+	binary.BigEndian.PutUint64(stamp[:8], uint64(now.Unix()))
+
+	digest := make([]byte, 0, blake2b.Size)
+
+	for counter := uint64(0); ; counter++ {
+		binary.BigEndian.PutUint64(stamp[8:], counter)
+
+		if leadingZeroBits(stampHash(digest[:0], sender, payload, stamp)) >= difficulty {
+			return stamp
+		}
+	}
+}
+
+// VerifyStamp checks that a stamp over a payload on behalf of a sender has not
+// expired, and that its hash has at least difficulty leading zero bits.
+func VerifyStamp(sender []byte, payload []byte, stamp []byte, difficulty int, now time.Time) bool {
+	if len(stamp) != stampSize {
+		return false
+	}
+
+	minted := time.Unix(int64(binary.BigEndian.Uint64(stamp[:8])), 0)
+	if minted.Before(now.Add(-stampWindow)) || minted.After(now.Add(stampWindow)) {
+		return false
+	}
+
+	return leadingZeroBits(stampHash(nil, sender, payload, stamp)) >= difficulty
+}
+
+// StampDifficulty returns the number of leading zero bits stamps on pings and
+// lookups are required to have. Stamps are not required should it be zero.
+func (n *Network) StampDifficulty() int {
+	return n.opts.stampDifficulty
+}
+
+// NewStamp mints a stamp over a payload on behalf of this node, should stamps
+// be required by the network.
+func (n *Network) NewStamp(payload []byte) []byte {
+	if n.opts.stampDifficulty <= 0 {
+		return nil
+	}
+	return MintStamp(n.ID.Id, payload, n.opts.stampDifficulty, n.Clock().Now())
+}
+
+// CheckStamp verifies a stamp over a payload sent by a peer, should stamps be
+// required by the network.
+func (n *Network) CheckStamp(sender peer.ID, payload []byte, stamp []byte) bool {
+	if n.opts.stampDifficulty <= 0 {
+		return true
+	}
+	return VerifyStamp(sender.Id, payload, stamp, n.opts.stampDifficulty, n.Clock().Now())
+}
@@ -0,0 +1,50 @@
+package network
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStamp(t *testing.T) {
+	t.Parallel()
+
+	sender, payload := []byte("sender"), []byte("payload")
+	now := time.Now()
+
+	stamp := MintStamp(sender, payload, 16, now)
+	if len(stamp) != stampSize {
+		t.Fatalf("expected a %d byte stamp, got %d bytes", stampSize, len(stamp))
+	}
+
+	if !VerifyStamp(sender, payload, stamp, 16, now) {
+		t.Fatal("VerifyStamp() expected a freshly minted stamp to verify")
+	}
+
+	if VerifyStamp([]byte("other"), payload, stamp, 16, now) {
+		t.Fatal("VerifyStamp() expected a stamp to be bound to its sender")
+	}
+
+	if VerifyStamp(sender, []byte("other"), stamp, 16, now) {
+		t.Fatal("VerifyStamp() expected a stamp to be bound to its payload")
+	}
+
+	if VerifyStamp(sender, payload, stamp, 16, now.Add(2*stampWindow)) {
+		t.Fatal("VerifyStamp() expected a stale stamp to not verify")
+	}
+
+	if VerifyStamp(sender, payload, stamp[:8], 16, now) {
+		t.Fatal("VerifyStamp() expected a truncated stamp to not verify")
+	}
+}
+
+func BenchmarkMintStamp(b *testing.B) {
+	sender, payload := []byte("sender"), []byte("payload")
+	now := time.Now()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		MintStamp(sender, payload, 8, now)
+	}
+}
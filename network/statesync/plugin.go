@@ -0,0 +1,369 @@
+// Package statesync lets a fresh node catch up on the state of a service by
+// downloading a snapshot of it from its peers.
+//
+// Services provide snapshots of their state under a name. A node syncing a
+// service asks its peers which version of the state they offer, and downloads
+// the latest one through the file transfer plugin: chunks are fetched from all
+// peers offering the same snapshot in parallel and verified against its
+// manifest, and downloads interrupted part way resume from the chunks already
+// stored. The snapshot is then handed to the service to restore its state out
+// of.
+//
+// The file transfer plugin must be registered alongside this plugin.
+package statesync
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/perlin-network/noise/internal/protobuf"
+	"github.com/perlin-network/noise/network"
+	"github.com/perlin-network/noise/network/transfer"
+	"github.com/perlin-network/noise/peer"
+	"github.com/perlin-network/noise/types/content"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// defaultChunkSize is the size in bytes of the chunks snapshots are split
+	// into.
+	defaultChunkSize = 64 * 1024
+	// defaultRequestTimeout is how long a single peer is given to offer a
+	// snapshot.
+	defaultRequestTimeout = 10 * time.Second
+)
+
+var (
+	// ErrNoTransfer is returned upon serving or syncing snapshots without the
+	// file transfer plugin registered.
+	ErrNoTransfer = errors.New("statesync: file transfer plugin is not registered")
+	// ErrNoSnapshot is returned upon syncing a service no peer offers a
+	// snapshot of.
+	ErrNoSnapshot = errors.New("statesync: no peer offers a snapshot")
+)
+
+// Snapshot is the state of a service at a version, serialized as size bytes
+// readable from Data.
+type Snapshot struct {
+	Version uint64
+	Data    io.ReaderAt
+	Size    int64
+}
+
+// Provider provides snapshots of the state of a service.
+type Provider interface {
+	// Snapshot returns a snapshot of the current state of the service. Data
+	// must remain readable until a snapshot of a newer version is returned.
+	Snapshot() (Snapshot, error)
+}
+
+// Consumer restores the state of a service out of a snapshot.
+type Consumer interface {
+	// Restore restores the state of the service out of a downloaded and
+	// verified snapshot of a version.
+	Restore(version uint64, r io.Reader) error
+}
+
+// Store holds a snapshot as it is being downloaded. Snapshots partially
+// written to a store, such as before a restart, resume from the chunks they
+// hold upon syncing again.
+type Store interface {
+	io.ReaderAt
+	io.WriterAt
+}
+
+// Plugin serves snapshots of services to peers, and syncs services out of
+// snapshots offered by peers.
+type Plugin struct {
+	*network.Plugin
+
+	// ChunkSize is the size in bytes of the chunks snapshots served are split
+	// into (default: 64 KiB).
+	ChunkSize int
+	// RequestTimeout is how long a single peer is given to offer a snapshot
+	// (default: 10 seconds).
+	RequestTimeout time.Duration
+
+	net *network.Network
+
+	mutex     sync.Mutex
+	providers map[string]Provider
+
+	// Snapshots shared by this node: service -> latest snapshot shared.
+	shared map[string]*sharedSnapshot
+}
+
+// sharedSnapshot is a snapshot shared through the file transfer plugin.
+type sharedSnapshot struct {
+	version  uint64
+	manifest content.ID
+}
+
+var (
+	// PluginID is used to check existence of the state sync plugin.
+	PluginID                         = (*Plugin)(nil)
+	_        network.PluginInterface = (*Plugin)(nil)
+)
+
+// PluginOption are configurable options for the state sync plugin.
+type PluginOption func(*Plugin)
+
+// WithChunkSize sets the size in bytes of the chunks snapshots are split into.
+func WithChunkSize(n int) PluginOption {
+	return func(p *Plugin) {
+		p.ChunkSize = n
+	}
+}
+
+// WithRequestTimeout sets how long a single peer is given to offer a snapshot.
+func WithRequestTimeout(d time.Duration) PluginOption {
+	return func(p *Plugin) {
+		p.RequestTimeout = d
+	}
+}
+
+// New returns a new state sync plugin with specified options. Options left
+// unspecified take on their defaults once the plugin starts up, such that
+// new(Plugin) remains valid.
+func New(opts ...PluginOption) *Plugin {
+	p := new(Plugin)
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+// setDefaults fills in all options which have been left unspecified.
+func (p *Plugin) setDefaults() {
+	if p.ChunkSize <= 0 {
+		p.ChunkSize = defaultChunkSize
+	}
+
+	if p.RequestTimeout <= 0 {
+		p.RequestTimeout = defaultRequestTimeout
+	}
+}
+
+func (p *Plugin) Startup(net *network.Network) {
+	p.setDefaults()
+
+	p.mutex.Lock()
+	p.net = net
+	p.mutex.Unlock()
+}
+
+// transfer returns the file transfer plugin registered alongside this plugin.
+func (p *Plugin) transfer() (*transfer.Plugin, error) {
+	p.mutex.Lock()
+	net := p.net
+	p.mutex.Unlock()
+
+	if net == nil {
+		return nil, ErrNoTransfer
+	}
+
+	plugin, exists := net.Plugin(transfer.PluginID)
+	if !exists {
+		return nil, ErrNoTransfer
+	}
+
+	return plugin.(*transfer.Plugin), nil
+}
+
+// Provide serves snapshots of a service to peers, replacing any provider of
+// the service registered before.
+func (p *Plugin) Provide(service string, provider Provider) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.providers == nil {
+		p.providers = make(map[string]Provider)
+	}
+	p.providers[service] = provider
+}
+
+func (p *Plugin) Receive(ctx *network.PluginContext) error {
+	switch msg := ctx.Message().(type) {
+	case *protobuf.SnapshotRequest:
+		p.mutex.Lock()
+		provider, exists := p.providers[msg.Service]
+		p.mutex.Unlock()
+
+		if !exists {
+			return ctx.ReplyError(context.Background(), network.CodeUnknownService, "no snapshots of service "+msg.Service)
+		}
+
+		shared, err := p.share(msg.Service, provider)
+		if err != nil {
+			ctx.Network().Log("statesync").Warn().Err(err).Str("service", msg.Service).Msg("Failed to share snapshot.")
+			return ctx.ReplyError(context.Background(), network.CodeUnknownService, "no snapshot of service "+msg.Service)
+		}
+
+		return ctx.Reply(context.Background(), &protobuf.SnapshotOffer{Version: shared.version, Manifest: shared.manifest})
+	}
+
+	return nil
+}
+
+// share shares the latest snapshot of a service through the file transfer
+// plugin, unless it has already been shared, and stops sharing the snapshot
+// of the version before it.
+func (p *Plugin) share(service string, provider Provider) (*sharedSnapshot, error) {
+	transfers, err := p.transfer()
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot, err := provider.Snapshot()
+	if err != nil {
+		return nil, err
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	previous, exists := p.shared[service]
+	if exists && previous.version == snapshot.Version {
+		return previous, nil
+	}
+
+	manifest, err := transfers.Share(snapshot.Data, snapshot.Size, p.ChunkSize)
+	if err != nil {
+		return nil, err
+	}
+
+	// Snapshots of different versions may hold the same state.
+	if exists && !previous.manifest.Equals(manifest.ID()) {
+		transfers.Unshare(previous.manifest)
+	}
+
+	if p.shared == nil {
+		p.shared = make(map[string]*sharedSnapshot)
+	}
+
+	shared := &sharedSnapshot{version: snapshot.Version, manifest: manifest.ID()}
+	p.shared[service] = shared
+
+	return shared, nil
+}
+
+// offer is a snapshot offered by a peer.
+type offer struct {
+	peer    peer.ID
+	version uint64
+	id      content.ID
+}
+
+// offers asks peers in parallel which snapshot of a service they offer.
+func (p *Plugin) offers(ctx context.Context, service string, peers []peer.ID) []offer {
+	var (
+		wg     sync.WaitGroup
+		mutex  sync.Mutex
+		offers []offer
+	)
+
+	for _, peerID := range peers {
+		wg.Add(1)
+
+		go func(peerID peer.ID) {
+			defer wg.Done()
+
+			client, err := p.net.Client(peerID.Address)
+			if err != nil {
+				return
+			}
+
+			ctx, cancel := context.WithTimeout(ctx, p.RequestTimeout)
+			defer cancel()
+
+			res, err := client.Request(ctx, &protobuf.SnapshotRequest{Service: service})
+			if err != nil {
+				return
+			}
+
+			response, ok := res.(*protobuf.SnapshotOffer)
+			if !ok {
+				return
+			}
+
+			id, err := content.Parse(response.Manifest)
+			if err != nil {
+				return
+			}
+
+			mutex.Lock()
+			offers = append(offers, offer{peer: peerID, version: response.Version, id: id})
+			mutex.Unlock()
+		}(peerID)
+	}
+
+	wg.Wait()
+
+	return offers
+}
+
+// Sync downloads the latest snapshot of a service offered by peers into store,
+// and restores the service out of it. Should peers offer different snapshots
+// of the latest version, the one offered by the most peers is picked. Chunks
+// are downloaded from all peers offering the snapshot picked.
+//
+// Chunks of the snapshot already held by store are not downloaded again, such
+// that an interrupted sync may be resumed by syncing again with the same
+// store. Sync returns the version of the snapshot restored.
+func (p *Plugin) Sync(ctx context.Context, service string, store Store, consumer Consumer, peers ...peer.ID) (uint64, error) {
+	transfers, err := p.transfer()
+	if err != nil {
+		return 0, err
+	}
+
+	offers := p.offers(ctx, service, peers)
+	if len(offers) == 0 {
+		return 0, ErrNoSnapshot
+	}
+
+	var latest uint64
+	for _, offer := range offers {
+		if offer.version > latest {
+			latest = offer.version
+		}
+	}
+
+	// Group peers offering the latest version by the snapshot they offer.
+	var picked string
+	sources := make(map[string][]peer.ID)
+
+	for _, offer := range offers {
+		if offer.version != latest {
+			continue
+		}
+
+		key := string(offer.id)
+		sources[key] = append(sources[key], offer.peer)
+
+		if picked == "" || len(sources[key]) > len(sources[picked]) {
+			picked = key
+		}
+	}
+
+	manifest, err := transfers.FetchManifest(ctx, content.ID(picked), sources[picked]...)
+	if err != nil {
+		return 0, err
+	}
+
+	t := transfer.ResumeTransfer(manifest, store)
+
+	if err := transfers.Download(ctx, t, store, nil, sources[picked]...); err != nil {
+		return 0, errors.Wrapf(err, "statesync: failed to download snapshot of %s", service)
+	}
+
+	if err := consumer.Restore(latest, io.NewSectionReader(store, 0, int64(manifest.Size))); err != nil {
+		return 0, errors.Wrapf(err, "statesync: failed to restore %s", service)
+	}
+
+	return latest, nil
+}
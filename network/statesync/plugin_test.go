@@ -0,0 +1,143 @@
+package statesync
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/perlin-network/noise/internal/test"
+	"github.com/perlin-network/noise/network"
+	"github.com/perlin-network/noise/network/transfer"
+	"github.com/perlin-network/noise/peer"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/uber-go/atomic"
+)
+
+type buffer struct {
+	mutex sync.Mutex
+	data  []byte
+}
+
+func (b *buffer) ReadAt(p []byte, off int64) (int, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if off >= int64(len(b.data)) {
+		return 0, io.EOF
+	}
+
+	n := copy(p, b.data[off:])
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+func (b *buffer) WriteAt(p []byte, off int64) (int, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if end := int(off) + len(p); end > len(b.data) {
+		b.data = append(b.data, make([]byte, end-len(b.data))...)
+	}
+	return copy(b.data[off:], p), nil
+}
+
+// service provides snapshots of a fixed state, counting the reads peers make
+// of it, and records the state it restores.
+type service struct {
+	version uint64
+	state   []byte
+	reads   atomic.Int32
+
+	restored []byte
+}
+
+func (s *service) Snapshot() (Snapshot, error) {
+	return Snapshot{Version: s.version, Data: s, Size: int64(len(s.state))}, nil
+}
+
+func (s *service) ReadAt(p []byte, off int64) (int, error) {
+	s.reads.Inc()
+	return bytes.NewReader(s.state).ReadAt(p, off)
+}
+
+func (s *service) Restore(version uint64, r io.Reader) error {
+	s.version = version
+
+	var err error
+	s.restored, err = ioutil.ReadAll(r)
+	return err
+}
+
+func buildNode(t *testing.T) (*network.Network, *Plugin) {
+	plugin := New(WithChunkSize(256), WithRequestTimeout(1*time.Second))
+
+	return test.BuildNode(t, nil, transfer.New(transfer.WithRequestTimeout(1*time.Second)), plugin), plugin
+}
+
+func TestSync(t *testing.T) {
+	if testing.Short() {
+		t.Skipf("skipping %s in short mode", t.Name())
+	}
+
+	latest := bytes.Repeat([]byte("0123456789abcdef"), 100)
+
+	// Two peers offer the latest version, and one an outdated version.
+	var peers []peer.ID
+	providers := []*service{
+		{version: 2, state: latest},
+		{version: 2, state: latest},
+		{version: 1, state: []byte("outdated")},
+	}
+
+	for _, provider := range providers {
+		net, plugin := buildNode(t)
+		defer net.Close()
+
+		plugin.Provide("ledger", provider)
+		peers = append(peers, peer.CreateID(net.Address, net.ID.PublicKey))
+	}
+
+	net, plugin := buildNode(t)
+	defer net.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Have peers share their snapshots, such that only the reads of chunks
+	// fetched are counted.
+	assert.Len(t, plugin.offers(ctx, "ledger", peers), len(providers))
+	for _, provider := range providers {
+		provider.reads.Store(0)
+	}
+
+	consumer, store := &service{}, &buffer{}
+
+	version, err := plugin.Sync(ctx, "ledger", store, consumer, peers...)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), version)
+	assert.Equal(t, latest, consumer.restored)
+	assert.True(t, providers[0].reads.Load() > 0 && providers[1].reads.Load() > 0, "expected chunks to be fetched from all peers offering the latest snapshot")
+	assert.Equal(t, int32(0), providers[2].reads.Load(), "expected outdated snapshots to not be fetched")
+
+	// Syncing again into the same store resumes from the chunks it holds.
+	reads := providers[0].reads.Load() + providers[1].reads.Load()
+
+	_, err = plugin.Sync(ctx, "ledger", store, consumer, peers...)
+	assert.NoError(t, err)
+	assert.Equal(t, reads, providers[0].reads.Load()+providers[1].reads.Load(), "expected chunks held to not be fetched again")
+
+	_, err = plugin.Sync(ctx, "unknown", store, consumer, peers...)
+	assert.Equal(t, ErrNoSnapshot, err)
+}
+
+func TestNoTransfer(t *testing.T) {
+	_, err := New().Sync(context.Background(), "ledger", &buffer{}, &service{})
+	assert.Equal(t, ErrNoTransfer, err)
+}
@@ -2,14 +2,20 @@ package network
 
 import (
 	"bufio"
+	"context"
 	"encoding/binary"
 	"io"
 	"net"
 	"sync"
+	"time"
 
+	"github.com/perlin-network/noise/audit"
 	"github.com/perlin-network/noise/crypto"
 	"github.com/perlin-network/noise/internal/protobuf"
-	"github.com/perlin-network/noise/log"
+	"github.com/perlin-network/noise/network/capture"
+	"github.com/perlin-network/noise/network/fault"
+	"github.com/perlin-network/noise/peer"
+	"github.com/perlin-network/noise/tracing"
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/pkg/errors"
@@ -17,43 +23,88 @@ import (
 
 var errEmptyMsg = errors.New("received an empty message from a peer")
 
-// sendMessage marshals, signs and sends a message over a stream.
-func (n *Network) sendMessage(w io.Writer, message *protobuf.Message, writerMutex *sync.Mutex) error {
-	bytes, err := proto.Marshal(message)
+// maxMessageSize is the largest message a frame may hold.
+const maxMessageSize = 4e+6
+
+// sendMessage marshals, signs and sends a message over a stream to a peer.
+func (n *Network) sendMessage(address string, w io.Writer, message *protobuf.Message, writerMutex *sync.Mutex) error {
+	buffer, f, err := n.encodeFrame(address, message)
+	if err != nil || f.Drop {
+		return err
+	}
+
+	writerMutex.Lock()
+	err = n.writeFrame(w, buffer, f.Duplicates)
+	writerMutex.Unlock()
+
 	if err != nil {
-		return errors.Wrap(err, "failed to marshal message")
+		return err
 	}
 
-	// Serialize size.
-	buffer := make([]byte, 4)
-	binary.BigEndian.PutUint32(buffer, uint32(len(bytes)))
+	n.captureFrame(capture.Outbound, address, message.Opcode, buffer)
 
-	buffer = append(buffer, bytes...)
-	totalSize := len(buffer)
+	return nil
+}
 
-	// Write until all bytes have been written.
-	bytesWritten, totalBytesWritten := 0, 0
+// encodeFrame marshals a message into a length-prefixed frame, injecting
+// faults into the frame should the node have been built with a fault policy.
+// Frames to be dropped are reported through the fault returned.
+func (n *Network) encodeFrame(address string, message *protobuf.Message) ([]byte, fault.Fault, error) {
+	size := message.Size()
 
-	writerMutex.Lock()
+	// Peers drop frames larger than maxMessageSize, so do not bother
+	// marshaling nor sending them.
+	if size > maxMessageSize {
+		return nil, fault.Fault{}, errors.Errorf("stream: message has length of %d which exceeds the maximum of %d", size, int(maxMessageSize))
+	}
+
+	// Marshal the message right after its size, such that the frame is held
+	// in memory only once however large the message is.
+	buffer := make([]byte, 4+size)
+	binary.BigEndian.PutUint32(buffer, uint32(size))
+
+	if _, err := message.MarshalTo(buffer[4:]); err != nil {
+		return nil, fault.Fault{}, errors.Wrap(err, "failed to marshal message")
+	}
+
+	f := n.injectFault(address, message.Opcode, buffer)
+	if f.Delay > 0 {
+		time.Sleep(f.Delay)
+	}
+	if f.Truncate > 0 && f.Truncate < len(buffer) {
+		buffer = buffer[:f.Truncate]
+	}
+
+	return buffer, f, nil
+}
+
+// writeFrame writes a frame and as many duplicates of it to a stream. Expects
+// the writer of the stream to be locked.
+func (n *Network) writeFrame(w io.Writer, buffer []byte, duplicates int) error {
+	totalSize := len(buffer)
+
+	var err error
 
 	bw, isBuffered := w.(*bufio.Writer)
 	if isBuffered && (bw.Buffered() > 0) && (bw.Available() < totalSize) {
 		if err := bw.Flush(); err != nil {
-			writerMutex.Unlock()
 			return err
 		}
 	}
 
-	for totalBytesWritten < len(buffer) && err == nil {
-		bytesWritten, err = w.Write(buffer[totalBytesWritten:])
-		if err != nil {
-			log.Error().Err(err).Msg("stream: failed to write entire buffer")
+	for copies := 0; copies <= duplicates && err == nil; copies++ {
+		// Write until all bytes have been written.
+		bytesWritten, totalBytesWritten := 0, 0
+
+		for totalBytesWritten < len(buffer) && err == nil {
+			bytesWritten, err = w.Write(buffer[totalBytesWritten:])
+			if err != nil {
+				n.log().Error().Err(err).Msg("stream: failed to write entire buffer")
+			}
+			totalBytesWritten += bytesWritten
 		}
-		totalBytesWritten += bytesWritten
 	}
 
-	writerMutex.Unlock()
-
 	if err != nil {
 		return errors.Wrap(err, "stream: failed to write to socket")
 	}
@@ -61,54 +112,116 @@ func (n *Network) sendMessage(w io.Writer, message *protobuf.Message, writerMute
 	return nil
 }
 
-// receiveMessage reads, unmarshals and verifies a message from a net.Conn.
-func (n *Network) receiveMessage(conn net.Conn) (*protobuf.Message, error) {
-	var err error
-
-	// Read until all header bytes have been read.
-	buffer := make([]byte, 4)
+// readFrame reads a length-prefixed frame, returning its header and body. The
+// body is only allocated once its length is known to be within bounds.
+func readFrame(r io.Reader) ([]byte, []byte, error) {
+	return readFrameWithin(r, nil)
+}
 
-	bytesRead, totalBytesRead := 0, 0
+// readFrameWithin reads a length-prefixed frame as readFrame, reserving memory
+// for its body before it is allocated. Memory reserved is not released should
+// the body fail to be read.
+func readFrameWithin(r io.Reader, memory *peerMemory) ([]byte, []byte, error) {
+	header := make([]byte, 4)
 
-	for totalBytesRead < 4 && err == nil {
-		bytesRead, err = conn.Read(buffer[totalBytesRead:])
-		totalBytesRead += bytesRead
+	if read, err := io.ReadFull(r, header); err != nil {
+		// The stream ended or was closed in between frames.
+		if read == 0 {
+			return nil, nil, errEmptyMsg
+		}
+		return nil, nil, errors.Wrap(err, "failed to read message header")
 	}
 
 	// Decode message size.
-	size := binary.BigEndian.Uint32(buffer)
+	size := binary.BigEndian.Uint32(header)
 
 	if size == 0 {
-		return nil, errEmptyMsg
+		return nil, nil, errEmptyMsg
 	}
 
 	// Message size at most is limited to 4MB. If a big message need be sent,
 	// consider partitioning to message into chunks of 4MB.
-	if size > 4e+6 {
-		return nil, errors.Errorf("message has length of %d which is either broken or too large", size)
+	if size > maxMessageSize {
+		return nil, nil, errors.Errorf("message has length of %d which is either broken or too large", size)
 	}
 
-	// Read until all message bytes have been read.
-	buffer = make([]byte, size)
+	if memory != nil {
+		if err := memory.reserve(int64(size)); err != nil {
+			return nil, nil, err
+		}
+	}
 
-	bytesRead, totalBytesRead = 0, 0
+	body := make([]byte, size)
 
-	for totalBytesRead < int(size) && err == nil {
-		bytesRead, err = conn.Read(buffer[totalBytesRead:])
-		totalBytesRead += bytesRead
+	if _, err := io.ReadFull(r, body); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to read message body")
 	}
 
+	return header, body, nil
+}
+
+// receiveMessage reads, unmarshals and verifies a message from a net.Conn,
+// reserving memory for it should memory be non-nil. The number of bytes
+// reserved is returned, and is to be released once the message is dispatched.
+func (n *Network) receiveMessage(conn net.Conn, memory *peerMemory) (*protobuf.Message, int64, error) {
+	header, buffer, err := readFrameWithin(conn, memory)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	reserved := int64(len(buffer))
+
 	// Deserialize message.
 	msg := new(protobuf.Message)
 
 	err = proto.Unmarshal(buffer, msg)
+
+	if n.opts.capture != nil {
+		remote, opcode := conn.RemoteAddr().String(), uint32(0)
+		if err == nil && msg.Sender != nil {
+			remote, opcode = msg.Sender.Address, msg.Opcode
+		}
+		n.captureFrame(capture.Inbound, remote, opcode, append(header, buffer...))
+	}
+
+	if err != nil {
+		return nil, reserved, errors.Wrap(err, "failed to unmarshal message")
+	}
+
+	// Decompressed bodies are held onto until the message is dispatched as
+	// well, and are charged against the inbound memory limits.
+	inflated, err := decompressMessage(msg, memory)
+	reserved += inflated
+
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to unmarshal message")
+		return nil, reserved, err
+	}
+
+	// Greetings and attestation handshakes are not dispatched, and are
+	// verified by Accept should they need to be.
+	if isGreeting(msg) || isAttestation(msg) || isAttestationRequest(msg) || isAttestationChallenge(msg) {
+		return msg, reserved, nil
 	}
 
+	if err := n.verifyMessage(msg); err != nil {
+		return nil, reserved, err
+	}
+
+	return msg, reserved, nil
+}
+
+// verifyMessage checks that the headers of a message are set, and that its
+// signature verifies.
+func (n *Network) verifyMessage(msg *protobuf.Message) error {
 	// Check if any of the message headers are invalid or null.
 	if msg.Opcode == 0 || msg.Sender == nil || msg.Sender.PublicKey == nil || len(msg.Sender.Address) == 0 {
-		return nil, errors.New("received an invalid message (either no opcode, no sender, or no signature) from a peer")
+		return errors.New("received an invalid message (either no opcode, no sender, or no signature) from a peer")
+	}
+
+	// Sender IDs index into routing tables, and must thus be of the same
+	// length as ours.
+	if len(msg.Sender.Id) != len(n.ID.Id) {
+		return errors.Errorf("received a message whose sender ID has length %d", len(msg.Sender.Id))
 	}
 
 	// Verify signature of message.
@@ -119,8 +232,48 @@ func (n *Network) receiveMessage(conn net.Conn) (*protobuf.Message, error) {
 		SerializeMessage(msg.Sender, msg.Message),
 		msg.Signature,
 	) {
-		return nil, errors.New("received message had an malformed signature")
+		err := errors.New("received message had an malformed signature")
+		n.RecordHandshakeFailure(FailureCrypto, msg.Sender.Address)
+		n.AuditPeer(
+			tracing.ContextWithTraceID(context.Background(), fromTraceContext(msg.Trace).TraceID),
+			audit.InvalidSignature, audit.ReasonMessage, peer.ID(*msg.Sender), err,
+		)
+		return err
+	}
+
+	return nil
+}
+
+// injectFault returns the faults to inject into a frame about to be sent to a
+// peer, should the node have been built with a fault policy.
+func (n *Network) injectFault(remote string, opcode uint32, raw []byte) fault.Fault {
+	if n.opts.faults == nil {
+		return fault.Fault{}
+	}
+
+	return n.opts.faults.Inject(&capture.Frame{
+		Direction: capture.Outbound,
+		Time:      time.Now(),
+		Local:     n.Address,
+		Remote:    remote,
+		Opcode:    opcode,
+		Raw:       raw,
+	})
+}
+
+// captureFrame hands a frame sent to or received from a peer to the capture
+// sink of the node, should one be set.
+func (n *Network) captureFrame(direction capture.Direction, remote string, opcode uint32, raw []byte) {
+	if n.opts.capture == nil {
+		return
 	}
 
-	return msg, nil
+	n.opts.capture.Capture(&capture.Frame{
+		Direction: direction,
+		Time:      time.Now(),
+		Local:     n.Address,
+		Remote:    remote,
+		Opcode:    opcode,
+		Raw:       raw,
+	})
 }
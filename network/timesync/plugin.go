@@ -0,0 +1,336 @@
+package timesync
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/perlin-network/noise/clock"
+	"github.com/perlin-network/noise/internal/protobuf"
+	"github.com/perlin-network/noise/network"
+	"github.com/perlin-network/noise/peer"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// defaultSampleInterval is how often peer clocks are sampled.
+	defaultSampleInterval = 5 * time.Minute
+	// defaultSamplesPerRound is how many peers are sampled per round.
+	defaultSamplesPerRound = 8
+	// defaultMinSamples is how many peers must be sampled before skew is estimated.
+	defaultMinSamples = 3
+	// defaultMaxRTT is the round-trip time above which samples are discarded.
+	defaultMaxRTT = 2 * time.Second
+)
+
+// Sample is a single measurement of the clock of a peer.
+type Sample struct {
+	// Offset is how far ahead the clock of the peer is of ours.
+	Offset time.Duration
+	// RTT is the round-trip time of the exchange the sample was taken over,
+	// which bounds the error of the offset.
+	RTT time.Duration
+	// TakenAt is when the sample was taken by our clock.
+	TakenAt time.Time
+}
+
+// Plugin samples the clocks of connected peers through timestamped exchanges,
+// and estimates how far our clock is skewed from the median clock of the
+// network. Logic sensitive to bad local clocks, such as record expiry or
+// certificate validation, may compensate through Now.
+type Plugin struct {
+	*network.Plugin
+
+	// SampleInterval is how often peer clocks are sampled (default: 5 minutes).
+	SampleInterval time.Duration
+	// SamplesPerRound is how many randomly picked peers are sampled per round
+	// (default: 8).
+	SamplesPerRound int
+	// MinSamples is how many peers must have been sampled before our skew is
+	// estimated (default: 3).
+	MinSamples int
+	// MaxRTT is the round-trip time above which samples are discarded for
+	// being too imprecise (default: 2 seconds).
+	MaxRTT time.Duration
+
+	// Connected peers: address -> *network.PeerClient.
+	clients sync.Map
+
+	mutex sync.RWMutex
+
+	// Latest sample taken per peer: address -> sample.
+	samples map[string]Sample
+
+	// Clock and source of randomness of the node.
+	clock  clock.Clock
+	random *network.Random
+
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+var (
+	// PluginID is used to check existence of the time synchronization plugin.
+	PluginID                         = (*Plugin)(nil)
+	_        network.PluginInterface = (*Plugin)(nil)
+	_        network.PeerScorer      = (*Plugin)(nil)
+)
+
+// PluginOption are configurable options for the time synchronization plugin.
+type PluginOption func(*Plugin)
+
+// WithSampleInterval sets how often peer clocks are sampled.
+func WithSampleInterval(d time.Duration) PluginOption {
+	return func(p *Plugin) {
+		p.SampleInterval = d
+	}
+}
+
+// WithSamplesPerRound sets how many peers are sampled per round.
+func WithSamplesPerRound(n int) PluginOption {
+	return func(p *Plugin) {
+		p.SamplesPerRound = n
+	}
+}
+
+// WithMinSamples sets how many peers must have been sampled before our skew
+// is estimated.
+func WithMinSamples(n int) PluginOption {
+	return func(p *Plugin) {
+		p.MinSamples = n
+	}
+}
+
+// WithMaxRTT sets the round-trip time above which samples are discarded.
+func WithMaxRTT(d time.Duration) PluginOption {
+	return func(p *Plugin) {
+		p.MaxRTT = d
+	}
+}
+
+// New returns a new time synchronization plugin with specified options.
+// Options left unspecified take on their defaults once the plugin starts up,
+// such that new(Plugin) remains valid.
+func New(opts ...PluginOption) *Plugin {
+	p := new(Plugin)
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+// setDefaults fills in all options which have been left unspecified.
+func (p *Plugin) setDefaults() {
+	if p.SampleInterval <= 0 {
+		p.SampleInterval = defaultSampleInterval
+	}
+
+	if p.SamplesPerRound <= 0 {
+		p.SamplesPerRound = defaultSamplesPerRound
+	}
+
+	if p.MinSamples <= 0 {
+		p.MinSamples = defaultMinSamples
+	}
+
+	if p.MaxRTT <= 0 {
+		p.MaxRTT = defaultMaxRTT
+	}
+
+	p.samples = make(map[string]Sample)
+}
+
+func (p *Plugin) Startup(net *network.Network) {
+	p.setDefaults()
+
+	p.clock, p.random = net.Clock(), net.Random()
+
+	p.ctx, p.cancel = context.WithCancel(context.Background())
+	go p.sampleLoop(p.ctx)
+}
+
+func (p *Plugin) Cleanup(net *network.Network) {
+	if p.cancel != nil {
+		p.cancel()
+	}
+}
+
+func (p *Plugin) Receive(ctx *network.PluginContext) error {
+	switch ctx.Message().(type) {
+	case *protobuf.TimeRequest:
+		err := ctx.Reply(context.Background(), &protobuf.TimeResponse{Time: p.now().UnixNano()})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *Plugin) PeerConnect(client *network.PeerClient) {
+	p.clients.Store(client.Address, client)
+}
+
+func (p *Plugin) PeerDisconnect(client *network.PeerClient) {
+	p.clients.Delete(client.Address)
+
+	p.mutex.Lock()
+	delete(p.samples, client.Address)
+	p.mutex.Unlock()
+}
+
+// SamplePeer samples the clock of a peer, and records the sample should its
+// round-trip time be precise enough.
+func (p *Plugin) SamplePeer(ctx context.Context, client *network.PeerClient) (Sample, error) {
+	sent := p.now()
+
+	res, err := client.Request(ctx, &protobuf.TimeRequest{})
+	if err != nil {
+		return Sample{}, err
+	}
+
+	received := p.now()
+
+	response, ok := res.(*protobuf.TimeResponse)
+	if !ok {
+		return Sample{}, errors.Errorf("timesync: unexpected response %T from %s", res, client.Address)
+	}
+
+	rtt := received.Sub(sent)
+	if rtt > p.MaxRTT {
+		return Sample{}, errors.Errorf("timesync: round-trip time of %s to %s is too imprecise", rtt, client.Address)
+	}
+
+	// Assume the peer read its clock halfway through the exchange.
+	midpoint := sent.Add(rtt / 2)
+	sample := Sample{Offset: time.Unix(0, response.Time).Sub(midpoint), RTT: rtt, TakenAt: received}
+
+	p.mutex.Lock()
+	p.samples[client.Address] = sample
+	p.mutex.Unlock()
+
+	return sample, nil
+}
+
+// PeerScore rates a peer by the round-trip time of its latest sample, from 1
+// for instantaneous exchanges down to 0 for exchanges taking MaxRTT, such that
+// broadcasts may prefer peers of low latency.
+func (p *Plugin) PeerScore(id peer.ID) (float64, bool) {
+	p.mutex.RLock()
+	sample, exists := p.samples[id.Address]
+	p.mutex.RUnlock()
+
+	if !exists || p.MaxRTT <= 0 {
+		return 0, false
+	}
+
+	return math.Max(0, 1-float64(sample.RTT)/float64(p.MaxRTT)), true
+}
+
+// Sync samples the clocks of up to SamplesPerRound randomly picked connected
+// peers.
+func (p *Plugin) Sync(ctx context.Context) {
+	var clients []*network.PeerClient
+
+	p.clients.Range(func(_, client interface{}) bool {
+		clients = append(clients, client.(*network.PeerClient))
+		return true
+	})
+
+	// Peers are ranged over in no particular order, so sort them for shuffles
+	// to be reproducible from a seed.
+	sort.Slice(clients, func(i, j int) bool {
+		return clients[i].Address < clients[j].Address
+	})
+
+	p.random.Shuffle(len(clients), func(i, j int) {
+		clients[i], clients[j] = clients[j], clients[i]
+	})
+
+	if len(clients) > p.SamplesPerRound {
+		clients = clients[:p.SamplesPerRound]
+	}
+
+	var wg sync.WaitGroup
+
+	for _, client := range clients {
+		wg.Add(1)
+
+		go func(client *network.PeerClient) {
+			defer wg.Done()
+
+			ctx, cancel := context.WithTimeout(ctx, p.MaxRTT)
+			defer cancel()
+
+			if _, err := p.SamplePeer(ctx, client); err != nil {
+				client.Network.Log("timesync").Debug().Err(err).Str("peer_address", client.Address).Msg("Failed to sample peer clock.")
+			}
+		}(client)
+	}
+
+	wg.Wait()
+}
+
+func (p *Plugin) sampleLoop(ctx context.Context) {
+	t := p.clock.NewTicker(p.SampleInterval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C():
+			p.Sync(ctx)
+		}
+	}
+}
+
+// Skew estimates how far behind our clock is of the median clock of the
+// network, and returns false should too few peers have been sampled.
+func (p *Plugin) Skew() (time.Duration, bool) {
+	p.mutex.RLock()
+	offsets := make([]time.Duration, 0, len(p.samples))
+	for _, sample := range p.samples {
+		offsets = append(offsets, sample.Offset)
+	}
+	p.mutex.RUnlock()
+
+	if len(offsets) == 0 || len(offsets) < p.MinSamples {
+		return 0, false
+	}
+
+	return median(offsets), true
+}
+
+// Now returns the current time corrected by our estimated skew, or the current
+// local time should skew not yet be estimated.
+func (p *Plugin) Now() time.Time {
+	skew, _ := p.Skew()
+	return p.now().Add(skew)
+}
+
+// now returns the current time according to the clock of the node.
+func (p *Plugin) now() time.Time {
+	if p.clock == nil {
+		return time.Now()
+	}
+	return p.clock.Now()
+}
+
+// median returns the median of a number of offsets.
+func median(offsets []time.Duration) time.Duration {
+	sort.Slice(offsets, func(i, j int) bool {
+		return offsets[i] < offsets[j]
+	})
+
+	mid := len(offsets) / 2
+	if len(offsets)%2 == 0 {
+		return (offsets[mid-1] + offsets[mid]) / 2
+	}
+	return offsets[mid]
+}
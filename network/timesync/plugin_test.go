@@ -0,0 +1,85 @@
+package timesync
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/perlin-network/noise/internal/test"
+	"github.com/perlin-network/noise/peer"
+)
+
+func TestSkew(t *testing.T) {
+	t.Parallel()
+
+	p := New(WithMinSamples(3))
+	p.setDefaults()
+
+	p.samples["a"] = Sample{Offset: 2 * time.Second}
+	p.samples["b"] = Sample{Offset: -time.Hour}
+
+	if _, ok := p.Skew(); ok {
+		t.Fatal("Skew() expected no estimate given too few samples")
+	}
+
+	p.samples["c"] = Sample{Offset: 3 * time.Second}
+
+	// The median is robust against the single peer whose clock is way off.
+	if skew, ok := p.Skew(); !ok || skew != 2*time.Second {
+		t.Fatalf("Skew() = expected 2s, got %s (ok: %t)", skew, ok)
+	}
+
+	p.samples["d"] = Sample{Offset: 4 * time.Second}
+
+	if skew, _ := p.Skew(); skew != 2500*time.Millisecond {
+		t.Fatalf("Skew() = expected 2.5s, got %s", skew)
+	}
+}
+
+func TestPeerScore(t *testing.T) {
+	t.Parallel()
+
+	p := New(WithMaxRTT(2 * time.Second))
+	p.setDefaults()
+
+	p.samples["a"] = Sample{RTT: 500 * time.Millisecond}
+
+	if score, ok := p.PeerScore(peer.CreateID("a", nil)); !ok || score != 0.75 {
+		t.Fatalf("PeerScore() = expected 0.75, got %f (ok: %t)", score, ok)
+	}
+
+	if _, ok := p.PeerScore(peer.CreateID("b", nil)); ok {
+		t.Fatal("PeerScore() expected peers never sampled to not be rated")
+	}
+}
+
+func TestSync(t *testing.T) {
+	if testing.Short() {
+		t.Skipf("skipping %s in short mode", t.Name())
+	}
+
+	local := New(WithMinSamples(2))
+	net := test.BuildNode(t, nil, local)
+	defer net.Close()
+
+	for i := 0; i < 2; i++ {
+		peer := test.BuildNode(t, nil, New())
+		defer peer.Close()
+
+		net.Bootstrap(peer.Address)
+	}
+
+	time.Sleep(200 * time.Millisecond)
+
+	local.Sync(context.Background())
+
+	skew, ok := local.Skew()
+	if !ok {
+		t.Fatal("Skew() expected an estimate after sampling peers")
+	}
+
+	// All nodes share the same clock.
+	if skew > 100*time.Millisecond || skew < -100*time.Millisecond {
+		t.Fatalf("Skew() = expected next to no skew, got %s", skew)
+	}
+}
@@ -0,0 +1,225 @@
+package topology
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/perlin-network/noise/peer"
+)
+
+// Node is a peer of the overlay.
+type Node struct {
+	ID peer.ID
+	// Crawled is whether the peers of the node are known, be it the node
+	// exporting the graph or a neighbor serving its peers. Edges between
+	// nodes which were not crawled are missing from the graph.
+	Crawled bool
+}
+
+// edge connects two nodes by the hex-encoded public keys of their peer IDs,
+// with the lesser key first.
+type edge [2]string
+
+// Graph is an undirected graph of peers and the connections between them.
+type Graph struct {
+	nodes map[string]*Node
+	edges map[edge]struct{}
+}
+
+// NewGraph returns an empty graph.
+func NewGraph() *Graph {
+	return &Graph{
+		nodes: make(map[string]*Node),
+		edges: make(map[edge]struct{}),
+	}
+}
+
+// AddNode adds a node to the graph, unless it already exists. The node is
+// marked as crawled should crawled be true.
+func (g *Graph) AddNode(id peer.ID, crawled bool) {
+	key := id.PublicKeyHex()
+
+	node, exists := g.nodes[key]
+	if !exists {
+		node = &Node{ID: id}
+		g.nodes[key] = node
+	}
+	node.Crawled = node.Crawled || crawled
+}
+
+// AddEdge connects two nodes, adding them to the graph should they not exist.
+func (g *Graph) AddEdge(a peer.ID, b peer.ID) {
+	g.AddNode(a, false)
+	g.AddNode(b, false)
+
+	x, y := a.PublicKeyHex(), b.PublicKeyHex()
+	if x == y {
+		return
+	}
+	if y < x {
+		x, y = y, x
+	}
+	g.edges[edge{x, y}] = struct{}{}
+}
+
+// Merge adds all nodes and edges of other to the graph.
+func (g *Graph) Merge(other *Graph) {
+	for _, node := range other.nodes {
+		g.AddNode(node.ID, node.Crawled)
+	}
+
+	for e := range other.edges {
+		g.edges[e] = struct{}{}
+	}
+}
+
+// Nodes returns all nodes sorted by public key.
+func (g *Graph) Nodes() []Node {
+	keys := make([]string, 0, len(g.nodes))
+	for key := range g.nodes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	nodes := make([]Node, len(keys))
+	for i, key := range keys {
+		nodes[i] = *g.nodes[key]
+	}
+	return nodes
+}
+
+// Edges returns all edges sorted by the public keys of the nodes they connect.
+func (g *Graph) Edges() [][2]peer.ID {
+	sorted := g.sortedEdges()
+
+	edges := make([][2]peer.ID, len(sorted))
+	for i, e := range sorted {
+		edges[i] = [2]peer.ID{g.nodes[e[0]].ID, g.nodes[e[1]].ID}
+	}
+	return edges
+}
+
+// Degree returns the number of nodes a node is connected to.
+func (g *Graph) Degree(id peer.ID) int {
+	key, degree := id.PublicKeyHex(), 0
+	for e := range g.edges {
+		if e[0] == key || e[1] == key {
+			degree++
+		}
+	}
+	return degree
+}
+
+func (g *Graph) sortedEdges() []edge {
+	edges := make([]edge, 0, len(g.edges))
+	for e := range g.edges {
+		edges = append(edges, e)
+	}
+
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i][0] != edges[j][0] {
+			return edges[i][0] < edges[j][0]
+		}
+		return edges[i][1] < edges[j][1]
+	})
+	return edges
+}
+
+// WriteDOT writes the graph in the DOT language of Graphviz. Nodes are
+// labelled by their address, and nodes which were not crawled are dashed.
+func (g *Graph) WriteDOT(w io.Writer) error {
+	buf := bufio.NewWriter(w)
+
+	fmt.Fprintln(buf, "graph noise {")
+	for _, node := range g.Nodes() {
+		style := ""
+		if !node.Crawled {
+			style = ", style=dashed"
+		}
+		fmt.Fprintf(buf, "\t%q [label=%q%s];\n", node.ID.PublicKeyHex(), node.ID.Address, style)
+	}
+	for _, e := range g.sortedEdges() {
+		fmt.Fprintf(buf, "\t%q -- %q;\n", e[0], e[1])
+	}
+	fmt.Fprintln(buf, "}")
+
+	return buf.Flush()
+}
+
+type graphML struct {
+	XMLName xml.Name       `xml:"graphml"`
+	XMLNS   string         `xml:"xmlns,attr"`
+	Keys    []graphMLKey   `xml:"key"`
+	Graph   graphMLContent `xml:"graph"`
+}
+
+type graphMLKey struct {
+	ID   string `xml:"id,attr"`
+	For  string `xml:"for,attr"`
+	Name string `xml:"attr.name,attr"`
+	Type string `xml:"attr.type,attr"`
+}
+
+type graphMLContent struct {
+	ID          string        `xml:"id,attr"`
+	EdgeDefault string        `xml:"edgedefault,attr"`
+	Nodes       []graphMLNode `xml:"node"`
+	Edges       []graphMLEdge `xml:"edge"`
+}
+
+type graphMLNode struct {
+	ID   string        `xml:"id,attr"`
+	Data []graphMLData `xml:"data"`
+}
+
+type graphMLEdge struct {
+	Source string `xml:"source,attr"`
+	Target string `xml:"target,attr"`
+}
+
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+// WriteGraphML writes the graph in GraphML, with the address of every node
+// and whether it was crawled as attributes.
+func (g *Graph) WriteGraphML(w io.Writer) error {
+	doc := graphML{
+		XMLNS: "http://graphml.graphdrawing.org/xmlns",
+		Keys: []graphMLKey{
+			{ID: "address", For: "node", Name: "address", Type: "string"},
+			{ID: "crawled", For: "node", Name: "crawled", Type: "boolean"},
+		},
+		Graph: graphMLContent{ID: "noise", EdgeDefault: "undirected"},
+	}
+
+	for _, node := range g.Nodes() {
+		doc.Graph.Nodes = append(doc.Graph.Nodes, graphMLNode{
+			ID: node.ID.PublicKeyHex(),
+			Data: []graphMLData{
+				{Key: "address", Value: node.ID.Address},
+				{Key: "crawled", Value: fmt.Sprint(node.Crawled)},
+			},
+		})
+	}
+	for _, e := range g.sortedEdges() {
+		doc.Graph.Edges = append(doc.Graph.Edges, graphMLEdge{Source: e[0], Target: e[1]})
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "\t")
+	if err := encoder.Encode(doc); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n")
+	return err
+}
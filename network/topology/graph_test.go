@@ -0,0 +1,58 @@
+package topology
+
+import (
+	"bytes"
+	"encoding/xml"
+	"testing"
+
+	"github.com/perlin-network/noise/peer"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testID(address string, key byte) peer.ID {
+	return peer.CreateID(address, []byte{key})
+}
+
+func TestGraph(t *testing.T) {
+	t.Parallel()
+
+	a, b, c := testID("tcp://a:1", 0xa), testID("tcp://b:2", 0xb), testID("tcp://c:3", 0xc)
+
+	graph := NewGraph()
+	graph.AddNode(a, true)
+	graph.AddEdge(a, b)
+	graph.AddEdge(b, a)
+	graph.AddEdge(a, a)
+
+	other := NewGraph()
+	other.AddNode(b, true)
+	other.AddEdge(b, c)
+	graph.Merge(other)
+
+	assert.Equal(t, []Node{{ID: a, Crawled: true}, {ID: b, Crawled: true}, {ID: c}}, graph.Nodes())
+	assert.Equal(t, [][2]peer.ID{{a, b}, {b, c}}, graph.Edges(), "expected edges to be undirected and to not loop")
+	assert.Equal(t, 2, graph.Degree(b))
+	assert.Equal(t, 1, graph.Degree(c))
+
+	var dot bytes.Buffer
+	assert.NoError(t, graph.WriteDOT(&dot))
+	assert.Equal(t, `graph noise {
+	"0a" [label="tcp://a:1"];
+	"0b" [label="tcp://b:2"];
+	"0c" [label="tcp://c:3", style=dashed];
+	"0a" -- "0b";
+	"0b" -- "0c";
+}
+`, dot.String())
+
+	var graphml bytes.Buffer
+	assert.NoError(t, graph.WriteGraphML(&graphml))
+
+	var decoded graphML
+	assert.NoError(t, xml.Unmarshal(graphml.Bytes(), &decoded))
+	assert.Equal(t, "undirected", decoded.Graph.EdgeDefault)
+	assert.Len(t, decoded.Graph.Nodes, 3)
+	assert.Equal(t, []graphMLData{{Key: "address", Value: "tcp://c:3"}, {Key: "crawled", Value: "false"}}, decoded.Graph.Nodes[2].Data)
+	assert.Equal(t, []graphMLEdge{{Source: "0a", Target: "0b"}, {Source: "0b", Target: "0c"}}, decoded.Graph.Edges)
+}
@@ -0,0 +1,281 @@
+// Package topology exports the graph of the overlay a node is part of, such
+// that operators may render it, and spot partitions or peers most other peers
+// depend on.
+//
+// The graph of a node holds the peers it is connected to. It may be extended
+// by crawling: the peers of the node are asked which peers they are connected
+// to, and those peers in turn, up to a number of hops away. Peers only answer
+// should they opt in to serving their peers through WithServe, as peer lists
+// reveal the shape of the overlay to whoever asks.
+package topology
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/perlin-network/noise/internal/protobuf"
+	"github.com/perlin-network/noise/network"
+	"github.com/perlin-network/noise/peer"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// defaultRequestTimeout is how long a single peer is given to serve its
+	// peers whilst crawling.
+	defaultRequestTimeout = 5 * time.Second
+	// defaultMaxNodes is how many nodes a crawl discovers at most.
+	defaultMaxNodes = 1024
+)
+
+// ErrNotStarted is returned upon exporting the graph of a plugin which has yet
+// to start up.
+var ErrNotStarted = errors.New("topology: plugin has not started")
+
+// Plugin exports the graph of the overlay, and optionally serves the peers of
+// the node to crawling peers.
+type Plugin struct {
+	*network.Plugin
+
+	// Serve is whether the peers of the node are served to crawling peers
+	// (default: false).
+	Serve bool
+	// RequestTimeout is how long a single peer is given to serve its peers
+	// whilst crawling (default: 5 seconds).
+	RequestTimeout time.Duration
+	// MaxNodes is how many nodes a crawl discovers at most. Peers of nodes
+	// discovered past it are not crawled (default: 1024).
+	MaxNodes int
+
+	// Connected peers: address -> *network.PeerClient.
+	clients sync.Map
+
+	mutex sync.Mutex
+	net   *network.Network
+}
+
+var (
+	// PluginID is used to check existence of the topology plugin.
+	PluginID                         = (*Plugin)(nil)
+	_        network.PluginInterface = (*Plugin)(nil)
+)
+
+// PluginOption are configurable options for the topology plugin.
+type PluginOption func(*Plugin)
+
+// WithServe serves the peers of the node to crawling peers.
+func WithServe() PluginOption {
+	return func(p *Plugin) {
+		p.Serve = true
+	}
+}
+
+// WithRequestTimeout sets how long a single peer is given to serve its peers.
+func WithRequestTimeout(d time.Duration) PluginOption {
+	return func(p *Plugin) {
+		p.RequestTimeout = d
+	}
+}
+
+// WithMaxNodes sets how many nodes a crawl discovers at most.
+func WithMaxNodes(n int) PluginOption {
+	return func(p *Plugin) {
+		p.MaxNodes = n
+	}
+}
+
+// New returns a new topology plugin with specified options. Options left
+// unspecified take on their defaults once the plugin starts up, such that
+// new(Plugin) remains valid.
+func New(opts ...PluginOption) *Plugin {
+	p := new(Plugin)
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+// setDefaults fills in all options which have been left unspecified.
+func (p *Plugin) setDefaults() {
+	if p.RequestTimeout <= 0 {
+		p.RequestTimeout = defaultRequestTimeout
+	}
+
+	if p.MaxNodes <= 0 {
+		p.MaxNodes = defaultMaxNodes
+	}
+}
+
+func (p *Plugin) Startup(net *network.Network) {
+	p.setDefaults()
+
+	p.mutex.Lock()
+	p.net = net
+	p.mutex.Unlock()
+}
+
+func (p *Plugin) PeerConnect(client *network.PeerClient) {
+	p.clients.Store(client.Address, client)
+}
+
+func (p *Plugin) PeerDisconnect(client *network.PeerClient) {
+	p.clients.Delete(client.Address)
+}
+
+func (p *Plugin) Receive(ctx *network.PluginContext) error {
+	switch ctx.Message().(type) {
+	case *protobuf.TopologyRequest:
+		if !p.Serve {
+			return ctx.ReplyError(context.Background(), network.CodeUnknownService, "peers are not served")
+		}
+
+		var peers []*protobuf.ID
+		for _, id := range p.peers() {
+			id := protobuf.ID(id)
+			peers = append(peers, &id)
+		}
+
+		return ctx.Reply(context.Background(), &protobuf.TopologyResponse{Peers: peers})
+	}
+
+	return nil
+}
+
+// peers returns the IDs of all connected peers.
+func (p *Plugin) peers() []peer.ID {
+	var peers []peer.ID
+
+	p.clients.Range(func(key, value interface{}) bool {
+		if id := value.(*network.PeerClient).ID(); id != nil {
+			peers = append(peers, *id)
+		}
+		return true
+	})
+
+	return peers
+}
+
+func (p *Plugin) network() (*network.Network, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.net == nil {
+		return nil, ErrNotStarted
+	}
+	return p.net, nil
+}
+
+// Local returns the graph of the node and the peers it is connected to.
+func (p *Plugin) Local() (*Graph, error) {
+	net, err := p.network()
+	if err != nil {
+		return nil, err
+	}
+
+	graph := NewGraph()
+	graph.AddNode(net.ID, true)
+
+	for _, id := range p.peers() {
+		graph.AddEdge(net.ID, id)
+	}
+
+	return graph, nil
+}
+
+// Crawl returns the graph of the node and its peers, extended by asking peers
+// up to depth hops away which peers they are connected to. A depth of zero
+// returns the same graph as Local. Peers which do not serve their peers, or
+// fail to within RequestTimeout, are left uncrawled.
+func (p *Plugin) Crawl(ctx context.Context, depth int) (*Graph, error) {
+	graph, err := p.Local()
+	if err != nil {
+		return nil, err
+	}
+
+	frontier := p.peers()
+
+	for hop := 0; hop < depth && len(frontier) > 0; hop++ {
+		var next []peer.ID
+
+		for _, result := range p.crawl(ctx, frontier) {
+			graph.AddNode(result.id, true)
+
+			for _, id := range result.peers {
+				if _, seen := graph.nodes[id.PublicKeyHex()]; !seen {
+					if len(graph.nodes) >= p.MaxNodes {
+						continue
+					}
+					next = append(next, id)
+				}
+				graph.AddEdge(result.id, id)
+			}
+		}
+
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		frontier = next
+	}
+
+	return graph, nil
+}
+
+// crawlResult is the list of peers served by a peer.
+type crawlResult struct {
+	id    peer.ID
+	peers []peer.ID
+}
+
+// crawl asks peers in parallel which peers they are connected to.
+func (p *Plugin) crawl(ctx context.Context, ids []peer.ID) []crawlResult {
+	var (
+		wg      sync.WaitGroup
+		mutex   sync.Mutex
+		results []crawlResult
+	)
+
+	for _, id := range ids {
+		wg.Add(1)
+
+		go func(id peer.ID) {
+			defer wg.Done()
+
+			client, err := p.net.Client(id.Address)
+			if err != nil {
+				return
+			}
+
+			ctx, cancel := context.WithTimeout(ctx, p.RequestTimeout)
+			defer cancel()
+
+			res, err := client.Request(ctx, &protobuf.TopologyRequest{})
+			if err != nil {
+				return
+			}
+
+			response, ok := res.(*protobuf.TopologyResponse)
+			if !ok {
+				return
+			}
+
+			result := crawlResult{id: id}
+			for _, served := range response.Peers {
+				if served != nil {
+					result.peers = append(result.peers, peer.ID(*served))
+				}
+			}
+
+			mutex.Lock()
+			results = append(results, result)
+			mutex.Unlock()
+		}(id)
+	}
+
+	wg.Wait()
+
+	return results
+}
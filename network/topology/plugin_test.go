@@ -0,0 +1,94 @@
+package topology
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/perlin-network/noise/internal/test"
+	"github.com/perlin-network/noise/network"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func buildNode(t *testing.T, opts ...PluginOption) (*network.Network, *Plugin) {
+	plugin := New(append([]PluginOption{WithRequestTimeout(1 * time.Second)}, opts...)...)
+
+	return test.BuildNode(t, nil, plugin), plugin
+}
+
+func connect(t *testing.T, a, b *network.Network) {
+	a.Bootstrap(b.Address)
+	b.Bootstrap(a.Address)
+
+	waitFor(t, "nodes to connect", func() bool {
+		return a.ConnectionStateExists(b.Address) && b.ConnectionStateExists(a.Address)
+	})
+}
+
+func waitFor(t *testing.T, description string, cond func() bool) {
+	deadline := time.Now().Add(5 * time.Second)
+
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", description)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+func crawled(graph *Graph) map[string]bool {
+	nodes := make(map[string]bool)
+	for _, node := range graph.Nodes() {
+		nodes[node.ID.Address] = node.Crawled
+	}
+	return nodes
+}
+
+func TestNotStarted(t *testing.T) {
+	_, err := New().Crawl(context.Background(), 1)
+	assert.Equal(t, ErrNotStarted, err)
+}
+
+func TestCrawl(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping topology test in short mode")
+	}
+
+	// a -- b -- c -- d, where b and d serve their peers.
+	a, plugin := buildNode(t)
+	b, _ := buildNode(t, WithServe())
+	c, _ := buildNode(t)
+	d, _ := buildNode(t, WithServe())
+
+	defer a.Close()
+	defer b.Close()
+	defer c.Close()
+	defer d.Close()
+
+	connect(t, a, b)
+	connect(t, b, c)
+	connect(t, c, d)
+
+	// Messages sent before the connections are ready on both ends are dropped.
+	time.Sleep(300 * time.Millisecond)
+
+	local, err := plugin.Local()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]bool{a.Address: true, b.Address: false}, crawled(local))
+
+	graph, err := plugin.Crawl(context.Background(), 0)
+	assert.NoError(t, err)
+	assert.Equal(t, local.Nodes(), graph.Nodes(), "expected crawls of no depth to return the local graph")
+
+	graph, err = plugin.Crawl(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]bool{a.Address: true, b.Address: true, c.Address: false}, crawled(graph))
+	assert.Equal(t, 2, graph.Degree(b.ID))
+
+	// Peers of c are not learnt of as c does not serve them.
+	graph, err = plugin.Crawl(context.Background(), 3)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]bool{a.Address: true, b.Address: true, c.Address: false}, crawled(graph))
+	assert.Len(t, graph.Edges(), 2)
+}
@@ -0,0 +1,29 @@
+package network
+
+import (
+	"github.com/perlin-network/noise/internal/protobuf"
+	"github.com/perlin-network/noise/tracing"
+)
+
+// toTraceContext converts a span context into its wire representation.
+func toTraceContext(sc tracing.SpanContext) *protobuf.TraceContext {
+	return &protobuf.TraceContext{
+		TraceId: append([]byte{}, sc.TraceID[:]...),
+		SpanId:  append([]byte{}, sc.SpanID[:]...),
+		Sampled: sc.Sampled,
+	}
+}
+
+// fromTraceContext converts the wire representation of a span context into
+// a tracing.SpanContext. Malformed trace contexts are treated as absent.
+func fromTraceContext(tc *protobuf.TraceContext) (sc tracing.SpanContext) {
+	if tc == nil || len(tc.TraceId) != len(sc.TraceID) || len(tc.SpanId) != len(sc.SpanID) {
+		return
+	}
+
+	copy(sc.TraceID[:], tc.TraceId)
+	copy(sc.SpanID[:], tc.SpanId)
+	sc.Sampled = tc.Sampled
+
+	return
+}
@@ -0,0 +1,211 @@
+package transfer
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/perlin-network/noise/internal/protobuf"
+	"github.com/perlin-network/noise/network"
+	"github.com/perlin-network/noise/types/content"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// defaultRequestTimeout is how long a single peer is given to respond to a
+	// manifest or chunk request.
+	defaultRequestTimeout = 10 * time.Second
+	// defaultParallelism is how many chunks are fetched at once per download.
+	defaultParallelism = 4
+)
+
+// Plugin transfers files between peers. Files are shared as manifests of
+// content-addressed chunks, such that downloaders may fetch and verify chunks
+// from multiple peers in parallel, and resume downloads after disconnects.
+type Plugin struct {
+	*network.Plugin
+
+	// RequestTimeout is how long a single peer is given to respond to a
+	// manifest or chunk request (default: 10 seconds).
+	RequestTimeout time.Duration
+	// Parallelism is how many chunks are fetched at once per download
+	// (default: 4).
+	Parallelism int
+
+	net *network.Network
+
+	mutex sync.RWMutex
+
+	// Files shared by this node: manifest content ID -> shared file.
+	files map[string]*sharedFile
+
+	// Chunks of all files shared by this node: chunk content ID -> chunk.
+	chunks map[string]chunkRef
+}
+
+// sharedFile is a file shared by this node alongside its manifest.
+type sharedFile struct {
+	manifest *content.Manifest
+	reader   io.ReaderAt
+}
+
+// chunkRef locates a chunk within a shared file.
+type chunkRef struct {
+	file  *sharedFile
+	index int
+}
+
+var (
+	// PluginID is used to check existence of the file transfer plugin.
+	PluginID                         = (*Plugin)(nil)
+	_        network.PluginInterface = (*Plugin)(nil)
+)
+
+// PluginOption are configurable options for the file transfer plugin.
+type PluginOption func(*Plugin)
+
+// WithRequestTimeout sets how long a single peer is given to respond to a
+// manifest or chunk request.
+func WithRequestTimeout(d time.Duration) PluginOption {
+	return func(p *Plugin) {
+		p.RequestTimeout = d
+	}
+}
+
+// WithParallelism sets how many chunks are fetched at once per download.
+func WithParallelism(n int) PluginOption {
+	return func(p *Plugin) {
+		p.Parallelism = n
+	}
+}
+
+// New returns a new file transfer plugin with specified options. Options left
+// unspecified take on their defaults once the plugin starts up, such that
+// new(Plugin) remains valid.
+func New(opts ...PluginOption) *Plugin {
+	p := new(Plugin)
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+// setDefaults fills in all options which have been left unspecified.
+func (p *Plugin) setDefaults() {
+	if p.RequestTimeout <= 0 {
+		p.RequestTimeout = defaultRequestTimeout
+	}
+
+	if p.Parallelism <= 0 {
+		p.Parallelism = defaultParallelism
+	}
+
+	p.files = make(map[string]*sharedFile)
+	p.chunks = make(map[string]chunkRef)
+}
+
+func (p *Plugin) Startup(net *network.Network) {
+	p.setDefaults()
+	p.net = net
+}
+
+func (p *Plugin) Receive(ctx *network.PluginContext) error {
+	switch msg := ctx.Message().(type) {
+	case *protobuf.ManifestRequest:
+		response := &protobuf.ManifestResponse{}
+
+		p.mutex.RLock()
+		if file, exists := p.files[string(msg.Id)]; exists {
+			response.Manifest = file.manifest.Bytes()
+		}
+		p.mutex.RUnlock()
+
+		err := ctx.Reply(context.Background(), response)
+		if err != nil {
+			return err
+		}
+	case *protobuf.ChunkRequest:
+		response := &protobuf.ChunkResponse{}
+
+		p.mutex.RLock()
+		ref, exists := p.chunks[string(msg.Id)]
+		p.mutex.RUnlock()
+
+		if exists {
+			data, err := ref.read()
+			if err != nil {
+				ctx.Network().Log("transfer").Warn().Err(err).Msg("Failed to read shared chunk.")
+			} else {
+				response.Data = data
+			}
+		}
+
+		err := ctx.Reply(context.Background(), response)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Share makes size bytes read from r available to peers, split into chunks of
+// chunkSize bytes, and returns the manifest of the file. Peers download the
+// file by the content ID of its manifest.
+func (p *Plugin) Share(r io.ReaderAt, size int64, chunkSize int) (*content.Manifest, error) {
+	manifest, err := content.NewManifest(io.NewSectionReader(r, 0, size), chunkSize)
+	if err != nil {
+		return nil, errors.Wrap(err, "transfer: failed to build manifest")
+	}
+
+	file := &sharedFile{manifest: manifest, reader: r}
+
+	p.mutex.Lock()
+	p.files[string(manifest.ID())] = file
+	for i, id := range manifest.Chunks {
+		p.chunks[string(id)] = chunkRef{file: file, index: i}
+	}
+	p.mutex.Unlock()
+
+	return manifest, nil
+}
+
+// Unshare stops sharing a file identified by the content ID of its manifest.
+func (p *Plugin) Unshare(id content.ID) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	file, exists := p.files[string(id)]
+	if !exists {
+		return
+	}
+
+	delete(p.files, string(id))
+
+	for _, chunkID := range file.manifest.Chunks {
+		if ref, exists := p.chunks[string(chunkID)]; exists && ref.file == file {
+			delete(p.chunks, string(chunkID))
+		}
+	}
+}
+
+// read reads the chunk out of its shared file.
+func (ref chunkRef) read() ([]byte, error) {
+	manifest := ref.file.manifest
+
+	data := make([]byte, manifest.ChunkLen(ref.index))
+
+	n, err := ref.file.reader.ReadAt(data, int64(ref.index)*int64(manifest.ChunkSize))
+	if n < len(data) {
+		if err == nil || err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+
+	return data, nil
+}
@@ -0,0 +1,243 @@
+package transfer
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"github.com/perlin-network/noise/internal/protobuf"
+	"github.com/perlin-network/noise/peer"
+	"github.com/perlin-network/noise/types/content"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/pkg/errors"
+)
+
+// ErrManifestNotFound is returned when no peer shares a requested manifest.
+var ErrManifestNotFound = errors.New("transfer: no peer shares the manifest")
+
+// Progress describes how far along a transfer is.
+type Progress struct {
+	// Chunks is the number of chunks transferred so far.
+	Chunks int
+	// TotalChunks is the number of chunks the file is split into.
+	TotalChunks int
+	// Bytes is the number of bytes transferred so far.
+	Bytes uint64
+	// TotalBytes is the size of the file in bytes.
+	TotalBytes uint64
+}
+
+// ProgressFunc is called every time a chunk of a transfer completes.
+type ProgressFunc func(progress Progress)
+
+// Transfer tracks which chunks of a file have been downloaded, such that an
+// interrupted download may be resumed by downloading the transfer again.
+type Transfer struct {
+	Manifest *content.Manifest
+
+	mutex     sync.Mutex
+	completed []bool
+	progress  Progress
+}
+
+// NewTransfer starts a transfer of a file whose manifest is stored in full.
+func NewTransfer(manifest *content.Manifest) *Transfer {
+	return &Transfer{
+		Manifest:  manifest,
+		completed: make([]bool, len(manifest.Chunks)),
+		progress:  Progress{TotalChunks: len(manifest.Chunks), TotalBytes: manifest.Size},
+	}
+}
+
+// ResumeTransfer resumes a transfer of a file partially written to r, such as
+// after a restart. Chunks within r which match the manifest are considered
+// complete.
+func ResumeTransfer(manifest *content.Manifest, r io.ReaderAt) *Transfer {
+	t := NewTransfer(manifest)
+
+	for i, id := range manifest.Chunks {
+		data := make([]byte, manifest.ChunkLen(i))
+
+		n, _ := r.ReadAt(data, int64(i)*int64(manifest.ChunkSize))
+		if n == len(data) && id.Verify(data) == nil {
+			t.complete(i, len(data))
+		}
+	}
+
+	return t
+}
+
+// Progress returns how far along the transfer is.
+func (t *Transfer) Progress() Progress {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	return t.progress
+}
+
+// Done returns whether or not all chunks have been transferred.
+func (t *Transfer) Done() bool {
+	progress := t.Progress()
+	return progress.Chunks == progress.TotalChunks
+}
+
+// remaining returns the indices of all chunks yet to be transferred.
+func (t *Transfer) remaining() []int {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	var indices []int
+	for i, completed := range t.completed {
+		if !completed {
+			indices = append(indices, i)
+		}
+	}
+
+	return indices
+}
+
+// complete marks a chunk of size bytes as transferred.
+func (t *Transfer) complete(index int, size int) Progress {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if !t.completed[index] {
+		t.completed[index] = true
+		t.progress.Chunks++
+		t.progress.Bytes += uint64(size)
+	}
+
+	return t.progress
+}
+
+// FetchManifest requests the manifest identified by its content ID from a
+// number of peers, and returns the first one matching the ID.
+func (p *Plugin) FetchManifest(ctx context.Context, id content.ID, peers ...peer.ID) (*content.Manifest, error) {
+	for _, peerID := range peers {
+		res, err := p.request(ctx, peerID, &protobuf.ManifestRequest{Id: id})
+		if err != nil {
+			continue
+		}
+
+		response, ok := res.(*protobuf.ManifestResponse)
+		if !ok || id.Verify(response.Manifest) != nil {
+			continue
+		}
+
+		manifest, err := content.ParseManifest(response.Manifest)
+		if err != nil {
+			continue
+		}
+
+		return manifest, nil
+	}
+
+	return nil, ErrManifestNotFound
+}
+
+// Download downloads all remaining chunks of a transfer into w, fetching up to
+// Parallelism chunks at once spread across peers. Chunks are verified against
+// the manifest before being written, and a chunk a peer fails to serve is
+// requested from the other peers.
+//
+// Should a chunk fail to be fetched from all peers, or ctx be cancelled, an
+// error is returned once all other chunks in flight settle. Downloading the
+// transfer again resumes from the chunks which are yet to be transferred.
+func (p *Plugin) Download(ctx context.Context, t *Transfer, w io.WriterAt, progress ProgressFunc, peers ...peer.ID) error {
+	if len(peers) == 0 {
+		return errors.New("transfer: no peers to download from")
+	}
+
+	indices := make(chan int)
+
+	var (
+		wg       sync.WaitGroup
+		errMutex sync.Mutex
+		firstErr error
+	)
+
+	fail := func(err error) {
+		errMutex.Lock()
+		if firstErr == nil {
+			firstErr = err
+		}
+		errMutex.Unlock()
+	}
+
+	for worker := 0; worker < p.Parallelism; worker++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for index := range indices {
+				data, err := p.fetchChunk(ctx, t.Manifest.Chunks[index], index, peers)
+				if err != nil {
+					fail(err)
+					continue
+				}
+
+				if _, err := w.WriteAt(data, int64(index)*int64(t.Manifest.ChunkSize)); err != nil {
+					fail(errors.Wrapf(err, "transfer: failed to write chunk %d", index))
+					continue
+				}
+
+				current := t.complete(index, len(data))
+				if progress != nil {
+					progress(current)
+				}
+			}
+		}()
+	}
+
+feed:
+	for _, index := range t.remaining() {
+		select {
+		case indices <- index:
+		case <-ctx.Done():
+			fail(ctx.Err())
+			break feed
+		}
+	}
+
+	close(indices)
+	wg.Wait()
+
+	return firstErr
+}
+
+// fetchChunk requests a chunk from peers in turn, starting at a peer picked
+// by the chunk index such that chunks are spread across peers, until one
+// serves the chunk matching its content ID.
+func (p *Plugin) fetchChunk(ctx context.Context, id content.ID, index int, peers []peer.ID) ([]byte, error) {
+	for attempt := 0; attempt < len(peers); attempt++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		res, err := p.request(ctx, peers[(index+attempt)%len(peers)], &protobuf.ChunkRequest{Id: id})
+		if err != nil {
+			continue
+		}
+
+		if response, ok := res.(*protobuf.ChunkResponse); ok && id.Verify(response.Data) == nil {
+			return response.Data, nil
+		}
+	}
+
+	return nil, errors.Errorf("transfer: no peer served chunk %d", index)
+}
+
+// request sends a request to a peer, and awaits its response.
+func (p *Plugin) request(ctx context.Context, peerID peer.ID, req proto.Message) (proto.Message, error) {
+	client, err := p.net.Client(peerID.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, p.RequestTimeout)
+	defer cancel()
+
+	return client.Request(ctx, req)
+}
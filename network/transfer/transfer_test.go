@@ -0,0 +1,106 @@
+package transfer
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/perlin-network/noise/internal/test"
+	"github.com/perlin-network/noise/network"
+	"github.com/perlin-network/noise/peer"
+	"github.com/perlin-network/noise/types/content"
+)
+
+// buffer is an in-memory io.ReaderAt and io.WriterAt of a fixed size.
+type buffer struct {
+	sync.Mutex
+	data []byte
+}
+
+func (b *buffer) ReadAt(p []byte, off int64) (int, error) {
+	b.Lock()
+	defer b.Unlock()
+	return bytes.NewReader(b.data).ReadAt(p, off)
+}
+
+func (b *buffer) WriteAt(p []byte, off int64) (int, error) {
+	b.Lock()
+	defer b.Unlock()
+	return copy(b.data[off:], p), nil
+}
+
+func buildNode(t *testing.T) (*network.Network, *Plugin) {
+	plugin := New(WithRequestTimeout(1*time.Second), WithParallelism(2))
+
+	return test.BuildNode(t, nil, plugin), plugin
+}
+
+func TestDownload(t *testing.T) {
+	if testing.Short() {
+		t.Skipf("skipping %s in short mode", t.Name())
+	}
+
+	file := bytes.Repeat([]byte("0123456789abcdef"), 64)
+
+	var (
+		seeds    []peer.ID
+		manifest *content.Manifest
+		err      error
+	)
+
+	for i := 0; i < 2; i++ {
+		net, plugin := buildNode(t)
+		defer net.Close()
+
+		manifest, err = plugin.Share(bytes.NewReader(file), int64(len(file)), 100)
+		if err != nil {
+			t.Fatalf("Share() = expected no error, got %v", err)
+		}
+
+		seeds = append(seeds, net.ID)
+	}
+
+	// A peer which shares nothing, whose chunks are to be fetched from seeds instead.
+	empty, _ := buildNode(t)
+	defer empty.Close()
+
+	leech, leecher := buildNode(t)
+	defer leech.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	fetched, err := leecher.FetchManifest(ctx, manifest.ID(), empty.ID, seeds[0])
+	if err != nil || !fetched.ID().Equals(manifest.ID()) {
+		t.Fatalf("FetchManifest() = expected manifest %s, got %v", manifest.ID(), err)
+	}
+
+	out := &buffer{data: make([]byte, len(file))}
+
+	// Pretend the first half of the file was downloaded before a disconnect.
+	copy(out.data, file[:len(file)/2])
+
+	transfer := ResumeTransfer(fetched, out)
+	if progress := transfer.Progress(); progress.Chunks != 5 || progress.TotalChunks != 11 {
+		t.Fatalf("expected 5 of 11 chunks to be resumed, got %d of %d", progress.Chunks, progress.TotalChunks)
+	}
+
+	var calls int32
+	err = leecher.Download(ctx, transfer, out, func(progress Progress) {
+		atomic.AddInt32(&calls, 1)
+	}, append([]peer.ID{empty.ID}, seeds...)...)
+	if err != nil {
+		t.Fatalf("Download() = expected no error, got %v", err)
+	}
+
+	if !transfer.Done() || calls != 6 {
+		t.Fatalf("expected transfer to be done after 6 progress callbacks, got %d", calls)
+	}
+
+	if !bytes.Equal(file, out.data) {
+		t.Fatal("expected downloaded file to match the shared file")
+	}
+}
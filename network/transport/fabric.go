@@ -0,0 +1,125 @@
+package transport
+
+import (
+	"net"
+	"strconv"
+	"sync"
+)
+
+// Fabric links the simulated transports of in-process nodes, such that the
+// network between them may be partitioned and healed. As with TCP, writing to
+// a connection across a partition severs it, and dialing across a partition
+// fails.
+type Fabric struct {
+	mutex sync.RWMutex
+
+	// listeners maps ports being listened on to their transport.
+	listeners map[int]*Simulated
+	// endpoints maps the local addresses of dialed connections to the
+	// transport which dialed them.
+	endpoints map[string]*Simulated
+	// groups maps transports to the partition they are within.
+	groups map[*Simulated]int
+}
+
+// NewFabric returns a fabric with no transports attached.
+func NewFabric() *Fabric {
+	return &Fabric{
+		listeners: make(map[int]*Simulated),
+		endpoints: make(map[string]*Simulated),
+		groups:    make(map[*Simulated]int),
+	}
+}
+
+// Attach links a simulated transport to the fabric. Only connections
+// established after the transport is attached are subject to partitions.
+func (f *Fabric) Attach(s *Simulated) {
+	s.mutex.Lock()
+	s.fabric = f
+	s.mutex.Unlock()
+}
+
+// Partition splits attached transports into groups which may only reach
+// transports within the same group. Transports not listed within any group
+// form a group of their own. Partitioning replaces any prior partitions.
+func (f *Fabric) Partition(groups ...[]*Simulated) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.groups = make(map[*Simulated]int)
+
+	for i, group := range groups {
+		for _, s := range group {
+			f.groups[s] = i + 1
+		}
+	}
+}
+
+// Heal removes all partitions, such that all attached transports may reach
+// one another again.
+func (f *Fabric) Heal() {
+	f.Partition()
+}
+
+// Reachable returns whether frames written by a may reach b.
+func (f *Fabric) Reachable(a, b *Simulated) bool {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+
+	return f.groups[a] == f.groups[b]
+}
+
+func (f *Fabric) listen(port int, s *Simulated) {
+	f.mutex.Lock()
+	f.listeners[port] = s
+	f.mutex.Unlock()
+}
+
+func (f *Fabric) unlisten(port int, s *Simulated) {
+	f.mutex.Lock()
+	if f.listeners[port] == s {
+		delete(f.listeners, port)
+	}
+	f.mutex.Unlock()
+}
+
+// listener returns the transport listening on an address should it be
+// attached.
+func (f *Fabric) listener(address string) *Simulated {
+	_, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil
+	}
+
+	p, err := strconv.Atoi(port)
+	if err != nil {
+		return nil
+	}
+
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+
+	return f.listeners[p]
+}
+
+// dialed registers a connection dialed by s.
+func (f *Fabric) dialed(conn net.Conn, s *Simulated) {
+	f.mutex.Lock()
+	f.endpoints[conn.LocalAddr().String()] = s
+	f.mutex.Unlock()
+}
+
+// accepted returns the transport which dialed an accepted connection should
+// it be attached.
+func (f *Fabric) accepted(conn net.Conn) *Simulated {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+
+	return f.endpoints[conn.RemoteAddr().String()]
+}
+
+func (f *Fabric) hangup(conn net.Conn) {
+	f.mutex.Lock()
+	delete(f.endpoints, conn.LocalAddr().String())
+	f.mutex.Unlock()
+}
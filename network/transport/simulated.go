@@ -0,0 +1,423 @@
+package transport
+
+import (
+	"container/heap"
+	"encoding/binary"
+	"math/rand"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrPartitioned is returned when dialing or writing across a partition.
+var ErrPartitioned = errors.New("transport: peer is partitioned off")
+
+const (
+	// maxFrameSize is the largest frame a node sends, beyond which written
+	// bytes are assumed to not be framed and are forwarded as-is.
+	maxFrameSize = 4e6
+	// defaultReorderDelay is how long reordered frames are held back should
+	// neither latency nor jitter be configured.
+	defaultReorderDelay = 10 * time.Millisecond
+)
+
+// Conditions describes the network conditions a simulated transport subjects
+// frames to.
+type Conditions struct {
+	// Latency is how long frames take to be delivered.
+	Latency time.Duration
+	// Jitter is how far the latency of each frame may deviate from Latency,
+	// picked uniformly at random.
+	Jitter time.Duration
+	// Bandwidth caps how many bytes per second are sent over each connection,
+	// with zero being unlimited.
+	Bandwidth int
+	// Loss is the probability of a frame being dropped. As nodes dispatch the
+	// messages of a connection in order, a dropped frame holds back all frames
+	// sent after it over the same connection.
+	Loss float64
+	// Reorder is the probability of a frame being held back long enough for
+	// frames sent after it to overtake it.
+	Reorder float64
+}
+
+// Simulated wraps a transport layer, and subjects all frames written over its
+// connections to configurable latency, jitter, bandwidth caps, loss and
+// reordering, such that protocols may be evaluated between in-process nodes
+// under realistic WAN conditions.
+//
+// Conditions apply to each node's outgoing frames, to both connections it
+// dials and accepts. As noise frames messages with a length prefix, frames
+// are dropped and reordered whole, and a dropped frame amounts to a lost
+// message. Transports attached to a Fabric may furthermore be partitioned
+// from one another.
+type Simulated struct {
+	Layer
+
+	mutex      sync.Mutex
+	conditions Conditions
+	rand       *rand.Rand
+	fabric     *Fabric
+}
+
+var _ Layer = (*Simulated)(nil)
+
+// NewSimulated wraps a transport layer, subjecting its connections to the
+// given conditions. Random decisions are seeded by seed, such that runs may be
+// reproduced.
+func NewSimulated(layer Layer, conditions Conditions, seed int64) *Simulated {
+	return &Simulated{
+		Layer:      layer,
+		conditions: conditions,
+		rand:       rand.New(rand.NewSource(seed)),
+	}
+}
+
+// SetConditions changes the conditions frames written from now on are
+// subjected to.
+func (s *Simulated) SetConditions(conditions Conditions) {
+	s.mutex.Lock()
+	s.conditions = conditions
+	s.mutex.Unlock()
+}
+
+// Conditions returns the conditions frames are currently subjected to.
+func (s *Simulated) Conditions() Conditions {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.conditions
+}
+
+// Listen listens for incoming connections on a specified port, whose writes
+// are subjected to the simulated conditions.
+func (s *Simulated) Listen(port int) (net.Listener, error) {
+	listener, err := s.Layer.Listen(port)
+	if err != nil {
+		return nil, err
+	}
+
+	l := &simulatedListener{Listener: listener, sim: s, fabric: s.getFabric()}
+
+	if l.fabric != nil {
+		if _, p, err := net.SplitHostPort(listener.Addr().String()); err == nil {
+			l.port, _ = strconv.Atoi(p)
+			l.fabric.listen(l.port, s)
+		}
+	}
+
+	return l, nil
+}
+
+// Dial dials an address, and subjects writes over the connection to the
+// simulated conditions.
+func (s *Simulated) Dial(address string) (net.Conn, error) {
+	fabric := s.getFabric()
+
+	var remote *Simulated
+	if fabric != nil {
+		remote = fabric.listener(address)
+
+		if remote != nil && !fabric.Reachable(s, remote) {
+			return nil, errors.Wrap(ErrPartitioned, address)
+		}
+	}
+
+	conn, err := s.Layer.Dial(address)
+	if err != nil {
+		return nil, err
+	}
+
+	c := newSimulatedConn(conn, s)
+	c.dialed = true
+	c.remote = remote
+
+	if fabric != nil {
+		fabric.dialed(conn, s)
+	}
+
+	return c, nil
+}
+
+func (s *Simulated) getFabric() *Fabric {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.fabric
+}
+
+// plan decides whether a frame of size bytes is to be dropped, and how long
+// it is to be delayed past being transmitted otherwise.
+func (s *Simulated) plan(size int) (drop bool, delay time.Duration, transmit time.Duration) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	c := s.conditions
+
+	if c.Loss > 0 && s.rand.Float64() < c.Loss {
+		return true, 0, 0
+	}
+
+	if c.Bandwidth > 0 {
+		transmit = time.Duration(size) * time.Second / time.Duration(c.Bandwidth)
+	}
+
+	delay = c.Latency
+	if c.Jitter > 0 {
+		delay += time.Duration((s.rand.Float64()*2 - 1) * float64(c.Jitter))
+	}
+
+	if delay < 0 {
+		delay = 0
+	}
+
+	if c.Reorder > 0 && s.rand.Float64() < c.Reorder {
+		if extra := c.Latency + c.Jitter; extra > 0 {
+			delay += extra
+		} else {
+			delay += defaultReorderDelay
+		}
+	}
+
+	return false, delay, transmit
+}
+
+type simulatedListener struct {
+	net.Listener
+	sim    *Simulated
+	fabric *Fabric
+	port   int
+}
+
+func (l *simulatedListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	return newSimulatedConn(conn, l.sim), nil
+}
+
+func (l *simulatedListener) Close() error {
+	if l.fabric != nil {
+		l.fabric.unlisten(l.port, l.sim)
+	}
+	return l.Listener.Close()
+}
+
+// packet is a frame scheduled for delivery.
+type packet struct {
+	data      []byte
+	deliverAt time.Time
+	seq       uint64
+}
+
+// packetQueue is a min-heap of packets ordered by delivery time, with ties
+// broken by the order frames were written in.
+type packetQueue []*packet
+
+func (q packetQueue) Len() int { return len(q) }
+
+func (q packetQueue) Less(i, j int) bool {
+	if q[i].deliverAt.Equal(q[j].deliverAt) {
+		return q[i].seq < q[j].seq
+	}
+	return q[i].deliverAt.Before(q[j].deliverAt)
+}
+
+func (q packetQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *packetQueue) Push(x interface{}) { *q = append(*q, x.(*packet)) }
+
+func (q *packetQueue) Pop() interface{} {
+	old := *q
+	p := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return p
+}
+
+// simulatedConn splits bytes written to it into frames, and delivers each
+// frame to the underlying connection once its simulated delay has passed.
+// Writes never block, as though buffered by the kernel.
+type simulatedConn struct {
+	net.Conn
+	sim    *Simulated
+	fabric *Fabric
+	dialed bool
+
+	mutex    sync.Mutex
+	remote   *Simulated
+	pending  []byte
+	queue    packetQueue
+	seq      uint64
+	nextFree time.Time
+	err      error
+
+	wake      chan struct{}
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+func newSimulatedConn(conn net.Conn, sim *Simulated) *simulatedConn {
+	c := &simulatedConn{
+		Conn:   conn,
+		sim:    sim,
+		fabric: sim.getFabric(),
+		wake:   make(chan struct{}, 1),
+		closed: make(chan struct{}),
+	}
+
+	go c.deliverLoop()
+
+	return c
+}
+
+func (c *simulatedConn) Write(p []byte) (int, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.err != nil {
+		return 0, c.err
+	}
+
+	c.pending = append(c.pending, p...)
+
+	for len(c.pending) >= 4 {
+		size := 4 + int(binary.BigEndian.Uint32(c.pending[:4]))
+		if size-4 > maxFrameSize {
+			size = len(c.pending)
+		}
+
+		if len(c.pending) < size {
+			break
+		}
+
+		frame := make([]byte, size)
+		copy(frame, c.pending)
+		c.pending = c.pending[size:]
+
+		if !c.reachable() {
+			c.err = ErrPartitioned
+			c.Conn.Close()
+			return 0, c.err
+		}
+
+		c.schedule(frame)
+	}
+
+	if len(c.pending) == 0 {
+		c.pending = nil
+	}
+
+	select {
+	case c.wake <- struct{}{}:
+	default:
+	}
+
+	return len(p), nil
+}
+
+// reachable returns whether frames may reach the remote end of the
+// connection, should it be partitioned off by a fabric.
+func (c *simulatedConn) reachable() bool {
+	if c.fabric == nil {
+		return true
+	}
+
+	// The transport which dialed an accepted connection is only known once it
+	// has registered the connection with the fabric.
+	if c.remote == nil && !c.dialed {
+		c.remote = c.fabric.accepted(c.Conn)
+	}
+
+	if c.remote == nil {
+		return true
+	}
+
+	return c.fabric.Reachable(c.sim, c.remote)
+}
+
+// schedule queues a frame for delivery, should it not be dropped.
+func (c *simulatedConn) schedule(frame []byte) {
+	drop, delay, transmit := c.sim.plan(len(frame))
+	if drop {
+		return
+	}
+
+	// Frames are transmitted one after another, and arrive once their latency
+	// has passed after being transmitted.
+	start := time.Now()
+	if c.nextFree.After(start) {
+		start = c.nextFree
+	}
+	c.nextFree = start.Add(transmit)
+
+	c.seq++
+	heap.Push(&c.queue, &packet{data: frame, deliverAt: c.nextFree.Add(delay), seq: c.seq})
+}
+
+func (c *simulatedConn) deliverLoop() {
+	timer := time.NewTimer(time.Hour)
+	defer timer.Stop()
+
+	for {
+		var due [][]byte
+
+		c.mutex.Lock()
+		now := time.Now()
+		for len(c.queue) > 0 && !c.queue[0].deliverAt.After(now) {
+			due = append(due, heap.Pop(&c.queue).(*packet).data)
+		}
+
+		wait := time.Hour
+		if len(c.queue) > 0 {
+			wait = c.queue[0].deliverAt.Sub(now)
+		}
+		c.mutex.Unlock()
+
+		for _, data := range due {
+			if _, err := c.Conn.Write(data); err != nil {
+				c.mutex.Lock()
+				c.err = err
+				c.mutex.Unlock()
+				return
+			}
+		}
+
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+		timer.Reset(wait)
+
+		select {
+		case <-c.closed:
+			return
+		case <-c.wake:
+		case <-timer.C:
+		}
+	}
+}
+
+// SetDeadline only applies to reads, as writes never block.
+func (c *simulatedConn) SetDeadline(t time.Time) error {
+	return c.Conn.SetReadDeadline(t)
+}
+
+// SetWriteDeadline has no effect, as writes never block.
+func (c *simulatedConn) SetWriteDeadline(t time.Time) error {
+	return nil
+}
+
+func (c *simulatedConn) Close() error {
+	c.closeOnce.Do(func() {
+		close(c.closed)
+
+		if c.fabric != nil && c.dialed {
+			c.fabric.hangup(c.Conn)
+		}
+	})
+	return c.Conn.Close()
+}
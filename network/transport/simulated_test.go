@@ -0,0 +1,230 @@
+package transport
+
+import (
+	"encoding/binary"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+func frame(b byte) []byte {
+	return []byte{0, 0, 0, 1, b}
+}
+
+// pair returns a connection dialed through sim, and the accepted end of it.
+func pair(t *testing.T, sim *Simulated) (net.Conn, net.Conn) {
+	listener, err := NewTCP().Listen(0)
+	if err != nil {
+		t.Fatalf("Listen() = expected no error, got %v", err)
+	}
+	defer listener.Close()
+
+	accepted := make(chan net.Conn, 1)
+	go func() {
+		conn, _ := listener.Accept()
+		accepted <- conn
+	}()
+
+	conn, err := sim.Dial(listener.Addr().String())
+	if err != nil {
+		t.Fatalf("Dial() = expected no error, got %v", err)
+	}
+
+	return conn, <-accepted
+}
+
+func readFrames(t *testing.T, conn net.Conn, n int) []byte {
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+
+	var payloads []byte
+	for i := 0; i < n; i++ {
+		buf := make([]byte, 5)
+		if _, err := io.ReadFull(conn, buf); err != nil {
+			t.Fatalf("expected %d frames, failed reading frame %d: %v", n, i, err)
+		}
+		if size := binary.BigEndian.Uint32(buf[:4]); size != 1 {
+			t.Fatalf("expected frame of size 1, got %d", size)
+		}
+		payloads = append(payloads, buf[4])
+	}
+	return payloads
+}
+
+func TestSimulatedLatency(t *testing.T) {
+	t.Parallel()
+
+	sim := NewSimulated(NewTCP(), Conditions{Latency: 100 * time.Millisecond}, 1)
+
+	conn, remote := pair(t, sim)
+	defer conn.Close()
+	defer remote.Close()
+
+	start := time.Now()
+
+	// Frames may be split and coalesced across writes.
+	conn.Write([]byte{0, 0})
+	conn.Write([]byte{0, 1, 1, 0, 0, 0, 1, 2})
+
+	if payloads := readFrames(t, remote, 2); string(payloads) != string([]byte{1, 2}) {
+		t.Fatalf("expected frames to be delivered in order, got %v", payloads)
+	}
+
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Fatalf("expected frames to be delayed by at least 100ms, got %s", elapsed)
+	}
+}
+
+func TestSimulatedLoss(t *testing.T) {
+	t.Parallel()
+
+	sim := NewSimulated(NewTCP(), Conditions{Loss: 1}, 1)
+
+	conn, remote := pair(t, sim)
+	defer conn.Close()
+	defer remote.Close()
+
+	conn.Write(frame(1))
+
+	sim.SetConditions(Conditions{})
+	conn.Write(frame(2))
+
+	if payloads := readFrames(t, remote, 1); payloads[0] != 2 {
+		t.Fatalf("expected the first frame to be dropped, got frame %d", payloads[0])
+	}
+}
+
+func TestSimulatedReorder(t *testing.T) {
+	t.Parallel()
+
+	sim := NewSimulated(NewTCP(), Conditions{Reorder: 1}, 1)
+
+	conn, remote := pair(t, sim)
+	defer conn.Close()
+	defer remote.Close()
+
+	conn.Write(frame(1))
+
+	sim.SetConditions(Conditions{})
+	conn.Write(frame(2))
+
+	if payloads := readFrames(t, remote, 2); string(payloads) != string([]byte{2, 1}) {
+		t.Fatalf("expected the first frame to be overtaken, got %v", payloads)
+	}
+}
+
+func TestSimulatedBandwidth(t *testing.T) {
+	t.Parallel()
+
+	sim := NewSimulated(NewTCP(), Conditions{Bandwidth: 50}, 1)
+
+	conn, remote := pair(t, sim)
+	defer conn.Close()
+	defer remote.Close()
+
+	start := time.Now()
+
+	for i := byte(0); i < 4; i++ {
+		conn.Write(frame(i))
+	}
+
+	readFrames(t, remote, 4)
+
+	// 20 bytes at 50 bytes per second take 400ms to transmit.
+	if elapsed := time.Since(start); elapsed < 350*time.Millisecond {
+		t.Fatalf("expected frames to take at least 400ms to transmit, got %s", elapsed)
+	}
+}
+
+func TestSimulatedSeed(t *testing.T) {
+	t.Parallel()
+
+	conditions := Conditions{Latency: 50 * time.Millisecond, Jitter: 40 * time.Millisecond, Loss: 0.3, Reorder: 0.3}
+
+	a := NewSimulated(NewTCP(), conditions, 42)
+	b := NewSimulated(NewTCP(), conditions, 42)
+
+	for i := 0; i < 100; i++ {
+		dropA, delayA, _ := a.plan(10)
+		dropB, delayB, _ := b.plan(10)
+
+		if dropA != dropB || delayA != delayB {
+			t.Fatalf("expected identically seeded transports to make identical decisions")
+		}
+	}
+}
+
+func TestFabricPartition(t *testing.T) {
+	t.Parallel()
+
+	fabric := NewFabric()
+
+	a := NewSimulated(NewTCP(), Conditions{}, 1)
+	b := NewSimulated(NewTCP(), Conditions{}, 2)
+	fabric.Attach(a)
+	fabric.Attach(b)
+
+	listener, err := b.Listen(0)
+	if err != nil {
+		t.Fatalf("Listen() = expected no error, got %v", err)
+	}
+	defer listener.Close()
+
+	accepted := make(chan net.Conn, 1)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			accepted <- conn
+		}
+	}()
+
+	conn, err := a.Dial(listener.Addr().String())
+	if err != nil {
+		t.Fatalf("Dial() = expected no error, got %v", err)
+	}
+	defer conn.Close()
+
+	remote := <-accepted
+	defer remote.Close()
+
+	conn.Write(frame(1))
+	readFrames(t, remote, 1)
+
+	fabric.Partition([]*Simulated{a}, []*Simulated{b})
+
+	if fabric.Reachable(a, b) {
+		t.Fatal("expected partitioned transports to not be reachable")
+	}
+
+	if _, err := remote.Write(frame(2)); errors.Cause(err) != ErrPartitioned {
+		t.Fatalf("expected writing across a partition to fail, got %v", err)
+	}
+
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	if _, err := conn.Read(make([]byte, 1)); err != io.EOF {
+		t.Fatalf("expected connection across a partition to be severed, got %v", err)
+	}
+
+	if _, err := a.Dial(listener.Addr().String()); errors.Cause(err) != ErrPartitioned {
+		t.Fatalf("expected dialing across a partition to fail, got %v", err)
+	}
+
+	fabric.Heal()
+
+	healed, err := a.Dial(listener.Addr().String())
+	if err != nil {
+		t.Fatalf("Dial() = expected no error once healed, got %v", err)
+	}
+	defer healed.Close()
+
+	healed.Write(frame(3))
+
+	if payloads := readFrames(t, <-accepted, 1); payloads[0] != 3 {
+		t.Fatalf("expected frame 3, got frame %d", payloads[0])
+	}
+}
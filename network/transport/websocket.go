@@ -0,0 +1,128 @@
+// +build !js
+
+package transport
+
+import (
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/pkg/errors"
+	"golang.org/x/net/websocket"
+)
+
+// WebSocket represents the WebSocket transport protocol, through which
+// clients built for js/wasm running in a browser may dial nodes.
+type WebSocket struct {
+	// Path is the HTTP path connections are upgraded at.
+	Path string
+}
+
+// NewWebSocket instantiates a new instance of the WebSocket transport protocol.
+func NewWebSocket() *WebSocket {
+	return &WebSocket{
+		Path: "/",
+	}
+}
+
+// Listen listens for incoming WebSocket connections on a specified port.
+func (t *WebSocket) Listen(port int) (net.Listener, error) {
+	listener, err := net.Listen("tcp", ":"+strconv.Itoa(port))
+	if err != nil {
+		return nil, err
+	}
+
+	l := &webSocketListener{
+		Listener: listener,
+		conns:    make(chan net.Conn),
+		done:     make(chan struct{}),
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(t.Path, websocket.Server{Handler: l.handle})
+
+	go http.Serve(listener, mux)
+
+	return l, nil
+}
+
+// Dial dials an address via. the WebSocket protocol.
+func (t *WebSocket) Dial(address string) (net.Conn, error) {
+	conn, err := websocket.Dial("ws://"+address+t.Path, "", "http://"+address)
+	if err != nil {
+		return nil, err
+	}
+
+	conn.PayloadType = websocket.BinaryFrame
+
+	return conn, nil
+}
+
+// webSocketListener hands out connections upgraded by its HTTP server.
+type webSocketListener struct {
+	net.Listener
+
+	conns chan net.Conn
+
+	done chan struct{}
+	once sync.Once
+}
+
+// handle hands out an upgraded connection, and holds on to it until it is
+// closed, as the HTTP server closes connections once their handler returns.
+func (l *webSocketListener) handle(ws *websocket.Conn) {
+	ws.PayloadType = websocket.BinaryFrame
+
+	conn := &webSocketConn{Conn: ws, closed: make(chan struct{})}
+
+	select {
+	case l.conns <- conn:
+	case <-l.done:
+		ws.Close()
+		return
+	}
+
+	select {
+	case <-conn.closed:
+	case <-l.done:
+	}
+}
+
+// Accept waits for and returns the next upgraded connection.
+func (l *webSocketListener) Accept() (net.Conn, error) {
+	select {
+	case conn := <-l.conns:
+		return conn, nil
+	case <-l.done:
+		return nil, errors.New("transport: websocket listener closed")
+	}
+}
+
+// Close stops listening for connections.
+func (l *webSocketListener) Close() error {
+	var err error
+
+	l.once.Do(func() {
+		close(l.done)
+		err = l.Listener.Close()
+	})
+
+	return err
+}
+
+// webSocketConn signals its handler once it is closed.
+type webSocketConn struct {
+	*websocket.Conn
+
+	closed chan struct{}
+	once   sync.Once
+}
+
+func (c *webSocketConn) Close() error {
+	c.once.Do(func() {
+		close(c.closed)
+	})
+
+	return c.Conn.Close()
+}
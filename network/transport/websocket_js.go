@@ -0,0 +1,183 @@
+package transport
+
+import (
+	"net"
+	"sync"
+	"syscall/js"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// WebSocket represents the WebSocket transport protocol, through which
+// clients built for js/wasm running in a browser may dial nodes.
+type WebSocket struct {
+	// Path is the HTTP path connections are upgraded at.
+	Path string
+}
+
+// NewWebSocket instantiates a new instance of the WebSocket transport protocol.
+func NewWebSocket() *WebSocket {
+	return &WebSocket{
+		Path: "/",
+	}
+}
+
+// Listen fails, as browsers may not listen for incoming connections.
+func (t *WebSocket) Listen(port int) (net.Listener, error) {
+	return nil, errors.New("transport: cannot listen for websocket connections within a browser")
+}
+
+// Dial dials an address via. the browsers WebSocket API.
+func (t *WebSocket) Dial(address string) (net.Conn, error) {
+	ws := js.Global().Get("WebSocket").New("ws://" + address + t.Path)
+	ws.Set("binaryType", "arraybuffer")
+
+	conn := &webSocketConn{
+		ws:       ws,
+		address:  webSocketAddr(address),
+		incoming: make(chan []byte, 1024),
+		closed:   make(chan struct{}),
+	}
+
+	opened := make(chan error, 1)
+
+	conn.funcs = []js.Func{
+		js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			opened <- nil
+			return nil
+		}),
+		js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			select {
+			case opened <- errors.Errorf("transport: failed to dial websocket at %s", address):
+			default:
+			}
+			return nil
+		}),
+		js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			data := js.Global().Get("Uint8Array").New(args[0].Get("data"))
+			buf := make([]byte, data.Get("length").Int())
+			js.CopyBytesToGo(buf, data)
+
+			select {
+			case conn.incoming <- buf:
+			case <-conn.closed:
+			}
+			return nil
+		}),
+		js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			conn.Close()
+			return nil
+		}),
+	}
+
+	ws.Set("onopen", conn.funcs[0])
+	ws.Set("onerror", conn.funcs[1])
+	ws.Set("onmessage", conn.funcs[2])
+	ws.Set("onclose", conn.funcs[3])
+
+	if err := <-opened; err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+// webSocketAddr is the address of a WebSocket connection.
+type webSocketAddr string
+
+func (a webSocketAddr) Network() string { return "websocket" }
+func (a webSocketAddr) String() string  { return string(a) }
+
+// webSocketConn is a net.Conn over a browser WebSocket.
+type webSocketConn struct {
+	ws      js.Value
+	address webSocketAddr
+	funcs   []js.Func
+
+	incoming chan []byte
+	buf      []byte
+
+	mutex        sync.Mutex
+	readDeadline time.Time
+
+	closed chan struct{}
+	once   sync.Once
+}
+
+func (c *webSocketConn) Read(b []byte) (int, error) {
+	if len(c.buf) == 0 {
+		c.mutex.Lock()
+		deadline := c.readDeadline
+		c.mutex.Unlock()
+
+		var timeout <-chan time.Time
+		if !deadline.IsZero() {
+			timer := time.NewTimer(time.Until(deadline))
+			defer timer.Stop()
+			timeout = timer.C
+		}
+
+		select {
+		case c.buf = <-c.incoming:
+		case <-c.closed:
+			return 0, errors.New("transport: websocket closed")
+		case <-timeout:
+			return 0, errors.New("transport: websocket read timed out")
+		}
+	}
+
+	n := copy(b, c.buf)
+	c.buf = c.buf[n:]
+
+	return n, nil
+}
+
+func (c *webSocketConn) Write(b []byte) (int, error) {
+	select {
+	case <-c.closed:
+		return 0, errors.New("transport: websocket closed")
+	default:
+	}
+
+	data := js.Global().Get("Uint8Array").New(len(b))
+	js.CopyBytesToJS(data, b)
+	c.ws.Call("send", data)
+
+	return len(b), nil
+}
+
+func (c *webSocketConn) Close() error {
+	c.once.Do(func() {
+		close(c.closed)
+		c.ws.Call("close")
+
+		for _, fn := range c.funcs {
+			fn.Release()
+		}
+	})
+
+	return nil
+}
+
+func (c *webSocketConn) LocalAddr() net.Addr  { return webSocketAddr("") }
+func (c *webSocketConn) RemoteAddr() net.Addr { return c.address }
+
+func (c *webSocketConn) SetDeadline(t time.Time) error {
+	return c.SetReadDeadline(t)
+}
+
+func (c *webSocketConn) SetReadDeadline(t time.Time) error {
+	c.mutex.Lock()
+	c.readDeadline = t
+	c.mutex.Unlock()
+
+	return nil
+}
+
+// SetWriteDeadline is a no-op, as writes to a browser WebSocket are buffered
+// and never block.
+func (c *webSocketConn) SetWriteDeadline(t time.Time) error {
+	return nil
+}
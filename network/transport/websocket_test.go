@@ -0,0 +1,72 @@
+package transport
+
+import (
+	"bytes"
+	"io"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestWebSocket(t *testing.T) {
+	t.Parallel()
+
+	ws := NewWebSocket()
+
+	listener, err := ws.Listen(0)
+	if err != nil {
+		t.Fatalf("Listen() = expected no error, got %v", err)
+	}
+	defer listener.Close()
+
+	accepted := make(chan net.Conn, 1)
+	go func() {
+		conn, _ := listener.Accept()
+		accepted <- conn
+	}()
+
+	port := listener.Addr().(*net.TCPAddr).Port
+
+	conn, err := ws.Dial("localhost:" + strconv.Itoa(port))
+	if err != nil {
+		t.Fatalf("Dial() = expected no error, got %v", err)
+	}
+	defer conn.Close()
+
+	server := <-accepted
+	defer server.Close()
+
+	// Frames written may be read back in parts.
+	expected := []byte{0, 0, 0, 3, 'a', 'b', 'c'}
+
+	if _, err := conn.Write(expected[:4]); err != nil {
+		t.Fatalf("Write() = expected no error, got %v", err)
+	}
+	if _, err := conn.Write(expected[4:]); err != nil {
+		t.Fatalf("Write() = expected no error, got %v", err)
+	}
+
+	server.SetReadDeadline(time.Now().Add(2 * time.Second))
+
+	received := make([]byte, len(expected))
+	if _, err := io.ReadFull(server, received); err != nil {
+		t.Fatalf("ReadFull() = expected no error, got %v", err)
+	}
+
+	if !bytes.Equal(received, expected) {
+		t.Fatalf("expected to receive %v, got %v", expected, received)
+	}
+
+	server.Close()
+
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	if _, err := conn.Read(received); err == nil {
+		t.Fatal("expected reads to fail once the accepted end is closed")
+	}
+
+	listener.Close()
+	if _, err := listener.Accept(); err == nil {
+		t.Fatal("expected Accept() to fail once the listener is closed")
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"net"
 
 	"github.com/perlin-network/noise/internal/protobuf"
+
+	"github.com/pkg/errors"
 )
 
 // SerializeMessage compactly packs all bytes of a message together for cryptographic signing purposes.
@@ -58,9 +60,27 @@ func FilterPeers(address string, peers []string) (filtered []string) {
 	return filtered
 }
 
-// GetRandomUnusedPort returns a random unused port
+// GetRandomUnusedPort returns a random unused port.
+//
+// The port is released before being returned, such that another process may
+// bind to it before the caller does. Nodes should rather be handed a listener
+// bound through ListenOnRandomPort, leaving GetRandomUnusedPort for addresses
+// which are meant to be unreachable.
 func GetRandomUnusedPort() int {
 	listener, _ := net.Listen("tcp", ":0")
 	defer listener.Close()
 	return listener.Addr().(*net.TCPAddr).Port
 }
+
+// ListenOnRandomPort binds a TCP listener to a random unused port, and returns
+// it alongside the address of the port on localhost. Unlike
+// GetRandomUnusedPort, the port stays bound until the listener is closed, such
+// that no other process may claim it before a node serves peers from it.
+func ListenOnRandomPort() (net.Listener, string, error) {
+	listener, err := net.Listen("tcp", ":0")
+	if err != nil {
+		return nil, "", errors.Wrap(err, "network: failed to listen on a random port")
+	}
+
+	return listener, FormatAddress("tcp", "localhost", uint16(listener.Addr().(*net.TCPAddr).Port)), nil
+}
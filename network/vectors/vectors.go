@@ -0,0 +1,470 @@
+// Package vectors generates canonical test vectors of the wire protocol, which
+// cover peer identities, proof-of-work stamps, signed message frames and the
+// ping/pong handshake, such that alternative implementations may prove they
+// are wire compatible with this package.
+//
+// The vectors are published as JSON under testdata/vectors.json, and are
+// regenerated with:
+//
+//	go test ./network/vectors -update
+//
+// Vectors produced by another implementation may in turn be checked against
+// this package through Verify.
+package vectors
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"time"
+
+	"github.com/perlin-network/noise/crypto"
+	"github.com/perlin-network/noise/crypto/blake2b"
+	"github.com/perlin-network/noise/crypto/ed25519"
+	"github.com/perlin-network/noise/internal/protobuf"
+	"github.com/perlin-network/noise/network"
+	"github.com/perlin-network/noise/peer"
+	"github.com/perlin-network/noise/types/opcode"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/pkg/errors"
+)
+
+// StampDifficulty is the difficulty stamps within the vectors are minted at.
+const StampDifficulty = 8
+
+// minted is the time all stamps within the vectors are minted at.
+var minted = time.Unix(1546300800, 0)
+
+// Hex is a byte slice encoded in JSON as a hex string.
+type Hex []byte
+
+// MarshalText encodes h as a hex string.
+func (h Hex) MarshalText() ([]byte, error) {
+	return []byte(hex.EncodeToString(h)), nil
+}
+
+// UnmarshalText decodes a hex string into h.
+func (h *Hex) UnmarshalText(text []byte) error {
+	decoded, err := hex.DecodeString(string(text))
+	if err != nil {
+		return err
+	}
+	*h = decoded
+	return nil
+}
+
+// Vectors is a set of test vectors.
+type Vectors struct {
+	Identities []Identity `json:"identities"`
+	Stamps     []Stamp    `json:"stamps"`
+	Frames     []Frame    `json:"frames"`
+
+	// Handshake is the transcript of a node bootstrapping with a peer: a
+	// signed ping carrying a challenge and a stamp over it, and the signed
+	// pong echoing the challenge back.
+	Handshake []Frame `json:"handshake"`
+}
+
+// Identity is the identity of a peer derived from a seed.
+type Identity struct {
+	// Seed is the ed25519 seed the keys of the peer are derived from.
+	Seed       Hex    `json:"seed"`
+	PublicKey  Hex    `json:"public_key"`
+	PrivateKey Hex    `json:"private_key"`
+	Address    string `json:"address"`
+	// ID is the blake2b-256 hash of the public key.
+	ID Hex `json:"id"`
+}
+
+// Stamp is a proof-of-work stamp over a payload on behalf of a peer.
+type Stamp struct {
+	// Sender indexes into the identities of the vectors.
+	Sender     int `json:"sender"`
+	Payload    Hex `json:"payload"`
+	Difficulty int `json:"difficulty"`
+	// Minted is the unix time the stamp was minted at.
+	Minted int64 `json:"minted"`
+	// Stamp is the minted time followed by a counter, both as big-endian
+	// 64-bit integers.
+	Stamp Hex `json:"stamp"`
+	// Hash is the blake2b-256 hash of the sender ID, payload and stamp, which
+	// has at least Difficulty leading zero bits.
+	Hash Hex `json:"hash"`
+}
+
+// Frame is a message as written to a stream.
+type Frame struct {
+	Description string `json:"description"`
+	// Sender indexes into the identities of the vectors.
+	Sender       int    `json:"sender"`
+	Opcode       uint32 `json:"opcode"`
+	Body         Hex    `json:"body"`
+	RequestNonce uint64 `json:"request_nonce"`
+	ReplyFlag    bool   `json:"reply_flag"`
+	MessageNonce uint64 `json:"message_nonce"`
+	// SignedPayload is the serialized sender and body the signature is over,
+	// or empty should the message not be signed.
+	SignedPayload Hex `json:"signed_payload"`
+	// Signature is the ed25519 signature of the blake2b-256 hash of the
+	// signed payload.
+	Signature Hex `json:"signature"`
+	// Frame is the protobuf-encoded message prefixed by its length as a
+	// big-endian 32-bit integer.
+	Frame Hex `json:"frame"`
+}
+
+// keyPair derives an ed25519 key pair from a seed.
+func keyPair(seed []byte) (*crypto.KeyPair, error) {
+	publicKey, privateKey, err := ed25519.GenerateKey(bytes.NewReader(seed))
+	if err != nil {
+		return nil, err
+	}
+	return &crypto.KeyPair{PublicKey: publicKey, PrivateKey: privateKey}, nil
+}
+
+func newIdentity(i int) (Identity, error) {
+	seed := bytes.Repeat([]byte{byte(i + 1)}, 32)
+
+	keys, err := keyPair(seed)
+	if err != nil {
+		return Identity{}, err
+	}
+
+	address := network.FormatAddress("tcp", "127.0.0.1", uint16(3000+i))
+	id := peer.CreateID(address, keys.PublicKey)
+
+	return Identity{
+		Seed:       seed,
+		PublicKey:  keys.PublicKey,
+		PrivateKey: keys.PrivateKey,
+		Address:    address,
+		ID:         id.Id,
+	}, nil
+}
+
+func newStamp(identities []Identity, sender int, payload []byte) Stamp {
+	stamp := network.MintStamp(identities[sender].ID, payload, StampDifficulty, minted)
+
+	return Stamp{
+		Sender:     sender,
+		Payload:    payload,
+		Difficulty: StampDifficulty,
+		Minted:     minted.Unix(),
+		Stamp:      stamp,
+		Hash:       blake2b.Sum(nil, identities[sender].ID, payload, stamp),
+	}
+}
+
+// frame prefixes an encoded message by its length.
+func frame(msg *protobuf.Message) ([]byte, error) {
+	raw, err := proto.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	buffer := make([]byte, 4, 4+len(raw))
+	binary.BigEndian.PutUint32(buffer, uint32(len(raw)))
+
+	return append(buffer, raw...), nil
+}
+
+// sender returns the protobuf ID of an identity.
+func (i Identity) sender() *protobuf.ID {
+	return &protobuf.ID{PublicKey: i.PublicKey, Address: i.Address, Id: i.ID}
+}
+
+func newFrame(identities []Identity, f Frame, body proto.Message, sign bool) (Frame, error) {
+	code, err := opcode.GetOpcode(body)
+	if err != nil {
+		return f, err
+	}
+
+	raw, err := proto.Marshal(body)
+	if err != nil {
+		return f, err
+	}
+
+	identity := identities[f.Sender]
+
+	msg := &protobuf.Message{
+		Message:      raw,
+		Sender:       identity.sender(),
+		RequestNonce: f.RequestNonce,
+		ReplyFlag:    f.ReplyFlag,
+		MessageNonce: f.MessageNonce,
+		Opcode:       uint32(code),
+	}
+
+	if sign {
+		keys, err := keyPair(identity.Seed)
+		if err != nil {
+			return f, err
+		}
+
+		f.SignedPayload = network.SerializeMessage(msg.Sender, raw)
+
+		if msg.Signature, err = keys.Sign(ed25519.New(), blake2b.New(), f.SignedPayload); err != nil {
+			return f, err
+		}
+	}
+
+	if f.Frame, err = frame(msg); err != nil {
+		return f, err
+	}
+
+	f.Opcode = msg.Opcode
+	f.Body = raw
+	f.Signature = msg.Signature
+
+	return f, nil
+}
+
+// Generate deterministically generates the canonical test vectors.
+func Generate() (*Vectors, error) {
+	v := new(Vectors)
+
+	for i := 0; i < 2; i++ {
+		identity, err := newIdentity(i)
+		if err != nil {
+			return nil, err
+		}
+		v.Identities = append(v.Identities, identity)
+	}
+
+	challenge := bytes.Repeat([]byte{0xab}, 16)
+
+	v.Stamps = []Stamp{
+		newStamp(v.Identities, 0, challenge),
+		newStamp(v.Identities, 1, []byte("noise")),
+	}
+
+	frames := []struct {
+		frame Frame
+		body  proto.Message
+		sign  bool
+	}{
+		{Frame{Description: "unsigned bytes", Sender: 0, MessageNonce: 1}, &protobuf.Bytes{Data: []byte("noise")}, false},
+		{Frame{Description: "signed bytes", Sender: 0, MessageNonce: 2}, &protobuf.Bytes{Data: []byte("noise")}, true},
+		{Frame{Description: "signed message with an empty body", Sender: 1, MessageNonce: 1}, &protobuf.Ping{}, true},
+		{Frame{Description: "signed request", Sender: 0, RequestNonce: 7, MessageNonce: 3}, &protobuf.LookupNodeRequest{Target: v.Identities[1].sender()}, true},
+		{Frame{Description: "signed reply", Sender: 1, RequestNonce: 7, ReplyFlag: true, MessageNonce: 2}, &protobuf.LookupNodeResponse{Peers: []*protobuf.ID{v.Identities[0].sender()}}, true},
+	}
+
+	for _, f := range frames {
+		generated, err := newFrame(v.Identities, f.frame, f.body, f.sign)
+		if err != nil {
+			return nil, errors.Wrapf(err, "vectors: failed to generate frame %q", f.frame.Description)
+		}
+		v.Frames = append(v.Frames, generated)
+	}
+
+	ping, err := newFrame(v.Identities, Frame{Description: "ping", Sender: 0, MessageNonce: 1}, &protobuf.Ping{Nonce: challenge, Stamp: v.Stamps[0].Stamp}, true)
+	if err != nil {
+		return nil, errors.Wrap(err, "vectors: failed to generate ping")
+	}
+
+	pong, err := newFrame(v.Identities, Frame{Description: "pong", Sender: 1, MessageNonce: 1}, &protobuf.Pong{Nonce: challenge}, true)
+	if err != nil {
+		return nil, errors.Wrap(err, "vectors: failed to generate pong")
+	}
+
+	v.Handshake = []Frame{ping, pong}
+
+	return v, nil
+}
+
+// Verify checks a set of test vectors, which may have been generated by
+// another implementation, against this package.
+func Verify(v *Vectors) error {
+	for i, identity := range v.Identities {
+		if err := verifyIdentity(identity); err != nil {
+			return errors.Wrapf(err, "vectors: identity %d", i)
+		}
+	}
+
+	for i, stamp := range v.Stamps {
+		if err := verifyStamp(v.Identities, stamp); err != nil {
+			return errors.Wrapf(err, "vectors: stamp %d", i)
+		}
+	}
+
+	for _, f := range append(append([]Frame(nil), v.Frames...), v.Handshake...) {
+		if err := verifyFrame(v.Identities, f); err != nil {
+			return errors.Wrapf(err, "vectors: frame %q", f.Description)
+		}
+	}
+
+	if len(v.Handshake) != 2 {
+		return errors.New("vectors: handshake must consist of a ping and a pong")
+	}
+
+	return verifyHandshake(v.Identities, v.Handshake[0], v.Handshake[1])
+}
+
+func verifyIdentity(identity Identity) error {
+	keys, err := keyPair(identity.Seed)
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(keys.PublicKey, identity.PublicKey) || !bytes.Equal(keys.PrivateKey, identity.PrivateKey) {
+		return errors.New("keys are not derived from the seed")
+	}
+
+	if !bytes.Equal(peer.CreateID(identity.Address, identity.PublicKey).Id, identity.ID) {
+		return errors.New("id is not the hash of the public key")
+	}
+
+	return nil
+}
+
+func lookupSender(identities []Identity, sender int) (Identity, error) {
+	if sender < 0 || sender >= len(identities) {
+		return Identity{}, errors.Errorf("unknown sender %d", sender)
+	}
+	return identities[sender], nil
+}
+
+func verifyStamp(identities []Identity, stamp Stamp) error {
+	sender, err := lookupSender(identities, stamp.Sender)
+	if err != nil {
+		return err
+	}
+
+	if !network.VerifyStamp(sender.ID, stamp.Payload, stamp.Stamp, stamp.Difficulty, time.Unix(stamp.Minted, 0)) {
+		return errors.New("stamp does not verify")
+	}
+
+	if !bytes.Equal(blake2b.Sum(nil, sender.ID, stamp.Payload, stamp.Stamp), stamp.Hash) {
+		return errors.New("hash does not match")
+	}
+
+	return nil
+}
+
+// decodeFrame decodes the message within a frame.
+func decodeFrame(raw []byte) (*protobuf.Message, error) {
+	if len(raw) < 4 || int(binary.BigEndian.Uint32(raw)) != len(raw)-4 {
+		return nil, errors.New("length prefix does not match the frame")
+	}
+
+	msg := new(protobuf.Message)
+	if err := proto.Unmarshal(raw[4:], msg); err != nil {
+		return nil, err
+	}
+
+	return msg, nil
+}
+
+func verifyFrame(identities []Identity, f Frame) error {
+	sender, err := lookupSender(identities, f.Sender)
+	if err != nil {
+		return err
+	}
+
+	msg, err := decodeFrame(f.Frame)
+	if err != nil {
+		return err
+	}
+
+	if !proto.Equal(msg.Sender, sender.sender()) {
+		return errors.New("sender does not match")
+	}
+
+	if msg.Opcode != f.Opcode || !bytes.Equal(msg.Message, f.Body) || msg.RequestNonce != f.RequestNonce ||
+		msg.ReplyFlag != f.ReplyFlag || msg.MessageNonce != f.MessageNonce || !bytes.Equal(msg.Signature, f.Signature) {
+		return errors.New("decoded message does not match")
+	}
+
+	if _, err := decodeBody(msg); err != nil {
+		return err
+	}
+
+	if len(f.Signature) > 0 {
+		if !bytes.Equal(network.SerializeMessage(msg.Sender, msg.Message), f.SignedPayload) {
+			return errors.New("signed payload does not match")
+		}
+
+		if !crypto.Verify(ed25519.New(), blake2b.New(), sender.PublicKey, f.SignedPayload, f.Signature) {
+			return errors.New("signature does not verify")
+		}
+	}
+
+	// Messages must encode canonically, such that frames are reproducible.
+	encoded, err := frame(msg)
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(encoded, f.Frame) {
+		return errors.New("frame is not canonically encoded")
+	}
+
+	return nil
+}
+
+// decodeBody decodes the body of a message based on its opcode.
+func decodeBody(msg *protobuf.Message) (proto.Message, error) {
+	body, err := opcode.GetMessageType(opcode.Opcode(msg.Opcode))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := proto.Unmarshal(msg.Message, body); err != nil {
+		return nil, errors.Wrap(err, "failed to decode body")
+	}
+
+	return body, nil
+}
+
+func verifyHandshake(identities []Identity, pingFrame Frame, pongFrame Frame) error {
+	pingMsg, err := decodeFrame(pingFrame.Frame)
+	if err != nil {
+		return err
+	}
+
+	pongMsg, err := decodeFrame(pongFrame.Frame)
+	if err != nil {
+		return err
+	}
+
+	ping, err := decodeBody(pingMsg)
+	if err != nil {
+		return err
+	}
+
+	pong, err := decodeBody(pongMsg)
+	if err != nil {
+		return err
+	}
+
+	challenge, ok := ping.(*protobuf.Ping)
+	if !ok {
+		return errors.New("vectors: handshake must open with a ping")
+	}
+
+	answer, ok := pong.(*protobuf.Pong)
+	if !ok {
+		return errors.New("vectors: handshake must be answered by a pong")
+	}
+
+	if len(challenge.Nonce) != 16 || !bytes.Equal(challenge.Nonce, answer.Nonce) {
+		return errors.New("vectors: pong must echo the 16-byte challenge of the ping")
+	}
+
+	// Stamps are only required by networks which set a stamp difficulty.
+	if len(challenge.Stamp) > 0 {
+		if len(challenge.Stamp) != 16 {
+			return errors.New("vectors: stamp of the ping must be 16 bytes")
+		}
+
+		minted := time.Unix(int64(binary.BigEndian.Uint64(challenge.Stamp[:8])), 0)
+
+		if !network.VerifyStamp(pingMsg.Sender.Id, challenge.Nonce, challenge.Stamp, StampDifficulty, minted) {
+			return errors.New("vectors: stamp of the ping does not verify")
+		}
+	}
+
+	return nil
+}
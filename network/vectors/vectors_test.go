@@ -0,0 +1,90 @@
+package vectors
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "regenerate the published test vectors")
+
+const published = "testdata/vectors.json"
+
+func encode(t *testing.T, v *Vectors) []byte {
+	encoded, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return append(encoded, '\n')
+}
+
+func TestPublishedVectors(t *testing.T) {
+	v, err := Generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Verify(v); err != nil {
+		t.Fatalf("Verify() = expected generated vectors to verify, got %v", err)
+	}
+
+	generated := encode(t, v)
+
+	if *update {
+		if err := ioutil.WriteFile(published, generated, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	raw, err := ioutil.ReadFile(published)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(raw, generated) {
+		t.Fatalf("published vectors are out of date; regenerate them with -update")
+	}
+
+	var loaded Vectors
+	if err := json.Unmarshal(raw, &loaded); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Verify(&loaded); err != nil {
+		t.Fatalf("Verify() = expected published vectors to verify, got %v", err)
+	}
+}
+
+func TestVerifyRejectsTampering(t *testing.T) {
+	t.Parallel()
+
+	tamper := []struct {
+		description string
+		tamper      func(v *Vectors)
+	}{
+		{"public key", func(v *Vectors) { v.Identities[0].PublicKey[0] ^= 1 }},
+		{"id", func(v *Vectors) { v.Identities[1].ID[0] ^= 1 }},
+		{"stamp", func(v *Vectors) { v.Stamps[0].Stamp[15] ^= 1 }},
+		{"frame length", func(v *Vectors) { v.Frames[0].Frame[3]++ }},
+		{"signature", func(v *Vectors) { v.Frames[1].Signature[0] ^= 1 }},
+		{"frame body", func(v *Vectors) { v.Frames[1].Frame[len(v.Frames[1].Frame)-1] ^= 1 }},
+		{"sender", func(v *Vectors) { v.Frames[3].Sender = 1 }},
+		{"unknown sender", func(v *Vectors) { v.Frames[3].Sender = 5 }},
+		{"pong", func(v *Vectors) { v.Handshake = v.Handshake[:1] }},
+	}
+
+	for _, tc := range tamper {
+		v, err := Generate()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		tc.tamper(v)
+
+		if err := Verify(v); err == nil {
+			t.Fatalf("Verify() = expected vectors with a tampered %s to not verify", tc.description)
+		}
+	}
+}
@@ -0,0 +1,226 @@
+package network
+
+import (
+	"bytes"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/perlin-network/noise/internal/protobuf"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/pkg/errors"
+)
+
+// greetingTimeout is how long connections accepted by VirtualHosts are given
+// to send their first frame before they are dropped.
+const greetingTimeout = 10 * time.Second
+
+// VirtualHosts hosts several nodes on one listener, such as for gateways and
+// test rigs running many logical nodes per process.
+//
+// Nodes are told apart by the name their addresses carry, such as alice in
+// tcp://localhost:3000/alice. Connections dialed to a named address open with
+// a greeting naming the address dialed, by which they are routed to the node
+// of that name. Connections opening without a greeting are routed to the one
+// node whose address carries no name, should there be one.
+//
+// Example:
+//
+//	hosts := network.NewVirtualHosts(listener)
+//	go hosts.Serve(alice)
+//	go hosts.Serve(bob)
+//	go hosts.Listen()
+type VirtualHosts struct {
+	listener net.Listener
+
+	mutex sync.RWMutex
+	hosts map[string]*virtualListener
+
+	closed chan struct{}
+	once   sync.Once
+}
+
+// NewVirtualHosts creates virtual hosts sharing a listener.
+func NewVirtualHosts(listener net.Listener) *VirtualHosts {
+	return &VirtualHosts{
+		listener: listener,
+		hosts:    make(map[string]*virtualListener),
+		closed:   make(chan struct{}),
+	}
+}
+
+// Serve hands connections dialed to the address of a node over to it, until
+// the node is closed. Serve blocks as Listen does, and errors should another
+// node of the same name be served already.
+func (v *VirtualHosts) Serve(n *Network) error {
+	info, err := ParseAddress(n.Address)
+	if err != nil {
+		return err
+	}
+
+	l := &virtualListener{
+		addr:   v.listener.Addr(),
+		conns:  make(chan net.Conn),
+		closed: make(chan struct{}),
+	}
+
+	v.mutex.Lock()
+	if _, exists := v.hosts[info.Name]; exists {
+		v.mutex.Unlock()
+		return errors.Errorf("network: virtual host %q is already served", info.Name)
+	}
+	v.hosts[info.Name] = l
+	v.mutex.Unlock()
+
+	defer func() {
+		v.mutex.Lock()
+		delete(v.hosts, info.Name)
+		v.mutex.Unlock()
+	}()
+
+	n.Serve(l)
+
+	return nil
+}
+
+// Listen accepts connections off of the listener and routes them to the
+// nodes they were dialed to, until closed.
+func (v *VirtualHosts) Listen() error {
+	for {
+		conn, err := v.listener.Accept()
+		if err != nil {
+			select {
+			case <-v.closed:
+				return nil
+			default:
+				return err
+			}
+		}
+
+		go v.route(conn)
+	}
+}
+
+// Close closes the listener. Nodes served are left running until closed
+// themselves.
+func (v *VirtualHosts) Close() error {
+	var err error
+	v.once.Do(func() {
+		close(v.closed)
+		err = v.listener.Close()
+	})
+	return err
+}
+
+// route reads the first frame of a connection to find out which node it was
+// dialed to, and hands the connection over to the node. Frames other than
+// greetings are replayed to the node.
+func (v *VirtualHosts) route(conn net.Conn) {
+	conn.SetReadDeadline(time.Now().Add(greetingTimeout))
+	header, body, err := readFrame(conn)
+	conn.SetReadDeadline(time.Time{})
+
+	if err != nil {
+		conn.Close()
+		return
+	}
+
+	msg := new(protobuf.Message)
+	if err := proto.Unmarshal(body, msg); err != nil {
+		conn.Close()
+		return
+	}
+
+	var name string
+
+	if isGreeting(msg) {
+		info, err := ParseAddress(msg.Recipient)
+		if err != nil {
+			conn.Close()
+			return
+		}
+		name = info.Name
+	} else {
+		conn = &replayConn{Conn: conn, reader: io.MultiReader(bytes.NewReader(append(header, body...)), conn)}
+	}
+
+	v.mutex.RLock()
+	l, exists := v.hosts[name]
+	v.mutex.RUnlock()
+
+	if !exists || !l.deliver(conn) {
+		conn.Close()
+	}
+}
+
+// greet opens a connection dialed to an address naming a virtual host with
+// a greeting, such that the listener routes the connection to the node of
+// that name.
+func (n *Network) greet(conn net.Conn, address string) error {
+	info, err := ParseAddress(address)
+	if err != nil || info.Name == "" {
+		return err
+	}
+
+	conn.SetWriteDeadline(time.Now().Add(n.opts.writeTimeout))
+
+	return n.sendMessage(address, conn, &protobuf.Message{Recipient: address}, new(sync.Mutex))
+}
+
+// isGreeting returns whether or not a message is a greeting, which carries
+// solely the address a connection was dialed to.
+func isGreeting(msg *protobuf.Message) bool {
+	return msg.Opcode == 0 && msg.Recipient != ""
+}
+
+// virtualListener is the listener of a node hosted by VirtualHosts, which
+// accepts the connections routed to the node.
+type virtualListener struct {
+	addr  net.Addr
+	conns chan net.Conn
+
+	closed chan struct{}
+	once   sync.Once
+}
+
+func (l *virtualListener) Accept() (net.Conn, error) {
+	select {
+	case conn := <-l.conns:
+		return conn, nil
+	case <-l.closed:
+		return nil, errors.New("network: virtual host closed")
+	}
+}
+
+func (l *virtualListener) Close() error {
+	l.once.Do(func() { close(l.closed) })
+	return nil
+}
+
+func (l *virtualListener) Addr() net.Addr {
+	return l.addr
+}
+
+// deliver hands a connection over to the node, returning false should the
+// node be closed.
+func (l *virtualListener) deliver(conn net.Conn) bool {
+	select {
+	case l.conns <- conn:
+		return true
+	case <-l.closed:
+		return false
+	}
+}
+
+// replayConn is a connection which replays frames read off of it whilst
+// routing it before reading further.
+type replayConn struct {
+	net.Conn
+	reader io.Reader
+}
+
+func (c *replayConn) Read(b []byte) (int, error) {
+	return c.reader.Read(b)
+}
@@ -0,0 +1,82 @@
+package network_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/perlin-network/noise/crypto/ed25519"
+	"github.com/perlin-network/noise/network"
+	"github.com/perlin-network/noise/network/discovery"
+	"github.com/perlin-network/noise/peer"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func buildDiscoveryNode(t *testing.T, address string) *network.Network {
+	builder := network.NewBuilder()
+	builder.SetKeys(ed25519.RandomKeyPair())
+	builder.SetAddress(address)
+	assert.Equal(t, nil, builder.AddPlugin(new(discovery.Plugin)))
+
+	node, err := builder.Build()
+	if err != nil {
+		t.Fatalf("Build() = expected no error, got %v", err)
+	}
+	return node
+}
+
+// awaitRoute waits for a node to hold a peer within its routing table.
+func awaitRoute(node *network.Network, id peer.ID) bool {
+	plugin, _ := node.Plugin(discovery.PluginID)
+	routes := plugin.(*discovery.Plugin).Routes
+
+	for i := 0; i < 100; i++ {
+		if routes.PeerExists(id) {
+			return true
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	return false
+}
+
+func TestVirtualHosts(t *testing.T) {
+	t.Parallel()
+
+	listener, shared, err := network.ListenOnRandomPort()
+	assert.Equal(t, nil, err)
+
+	hosts := network.NewVirtualHosts(listener)
+	defer hosts.Close()
+
+	alice, bob, fallback := buildDiscoveryNode(t, shared+"/alice"), buildDiscoveryNode(t, shared+"/bob"), buildDiscoveryNode(t, shared)
+
+	for _, node := range []*network.Network{alice, bob, fallback} {
+		defer node.Close()
+
+		go hosts.Serve(node)
+		node.BlockUntilListening()
+	}
+
+	assert.NotEqual(t, nil, hosts.Serve(buildDiscoveryNode(t, shared+"/alice")), "expected names to be served at most once")
+
+	go hosts.Listen()
+
+	dialer := buildDiscoveryNode(t, network.FormatAddress("tcp", "localhost", uint16(network.GetRandomUnusedPort())))
+	defer dialer.Close()
+
+	go dialer.Listen()
+	dialer.BlockUntilListening()
+
+	result := dialer.Bootstrap(alice.Address, bob.Address, fallback.Address)
+	assert.Equal(t, 3, result.Succeeded())
+
+	// Every node sharing the listener is reached under its own identity.
+	for _, node := range []*network.Network{alice, bob, fallback} {
+		assert.True(t, awaitRoute(dialer, node.ID), "expected %s to be reached", node.Address)
+		assert.True(t, awaitRoute(node, dialer.ID), "expected %s to be reached by the dialer", node.Address)
+	}
+
+	// Nodes sharing the listener reach one another.
+	assert.Equal(t, 1, alice.Bootstrap(bob.Address).Succeeded())
+	assert.True(t, awaitRoute(bob, alice.ID))
+}
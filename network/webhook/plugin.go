@@ -0,0 +1,450 @@
+// Package webhook forwards messages of selected opcodes received from peers
+// to an HTTPS endpoint in signed batches, such that back-office systems may
+// consume overlay traffic without running a node.
+//
+// Batches are POSTed as JSON:
+//
+//	{
+//	  "node": "tcp://localhost:3000",
+//	  "messages": [{
+//	    "opcode": 1000,
+//	    "sender_address": "tcp://localhost:3001",
+//	    "sender_public_key": "...",
+//	    "trace_id": "...",
+//	    "received_at": "2019-01-01T00:00:00Z",
+//	    "body": "<base64-encoded protobuf>",
+//	    "json": { ... }
+//	  }]
+//	}
+//
+// Should a secret be set, requests carry the unix time they were signed at
+// under X-Noise-Timestamp, and the hex-encoded HMAC-SHA256 of the timestamp, a
+// dot and the request body under X-Noise-Signature, such that the endpoint may
+// authenticate requests and reject stale ones.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/perlin-network/noise/network"
+	"github.com/perlin-network/noise/network/backoff"
+	"github.com/perlin-network/noise/types/opcode"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/pkg/errors"
+)
+
+const (
+	// defaultBatchSize is the most messages sent within a single request.
+	defaultBatchSize = 100
+	// defaultFlushInterval is how long messages are held back for batching.
+	defaultFlushInterval = time.Second
+	// defaultQueueSize is the most messages queued for delivery.
+	defaultQueueSize = 10000
+	// defaultMaxAttempts is how many times a batch is sent before dropping it.
+	defaultMaxAttempts = 5
+	// defaultTimeout is how long a request may take.
+	defaultTimeout = 10 * time.Second
+)
+
+const (
+	// HeaderTimestamp holds the unix time a request was signed at.
+	HeaderTimestamp = "X-Noise-Timestamp"
+	// HeaderSignature holds the hex-encoded HMAC-SHA256 signature of a request.
+	HeaderSignature = "X-Noise-Signature"
+)
+
+// Message is a message forwarded to the endpoint.
+type Message struct {
+	Opcode          uint32          `json:"opcode"`
+	SenderAddress   string          `json:"sender_address"`
+	SenderPublicKey string          `json:"sender_public_key"`
+	TraceID         string          `json:"trace_id,omitempty"`
+	ReceivedAt      time.Time       `json:"received_at"`
+	Body            []byte          `json:"body"`
+	JSON            json.RawMessage `json:"json,omitempty"`
+}
+
+// Batch is the body of a request to the endpoint.
+type Batch struct {
+	Node     string    `json:"node"`
+	Messages []Message `json:"messages"`
+}
+
+// Stats counts the messages handled by the plugin.
+type Stats struct {
+	// Delivered is the number of messages accepted by the endpoint.
+	Delivered uint64
+	// Dropped is the number of messages dropped for the queue being full.
+	Dropped uint64
+	// Failed is the number of messages dropped for the endpoint rejecting
+	// them, or failing to accept them within the maximum number of attempts.
+	Failed uint64
+}
+
+// Plugin forwards messages of selected opcodes to an HTTPS endpoint.
+type Plugin struct {
+	*network.Plugin
+
+	// URL is the HTTPS endpoint messages are forwarded to.
+	URL string
+	// Secret signs requests should it be set (default: requests are not
+	// signed).
+	Secret []byte
+	// Opcodes are the opcodes of messages forwarded.
+	Opcodes []opcode.Opcode
+	// BatchSize is the most messages sent within a single request (default:
+	// 100).
+	BatchSize int
+	// FlushInterval is how long messages are held back for batching (default:
+	// 1 second).
+	FlushInterval time.Duration
+	// QueueSize is the most messages queued for delivery, beyond which
+	// messages are dropped (default: 10000).
+	QueueSize int
+	// MaxAttempts is how many times a batch is sent before it is dropped
+	// (default: 5).
+	MaxAttempts int
+	// Client sends requests (default: a client timing out after 10 seconds).
+	Client *http.Client
+	// AllowHTTP permits endpoints not served over HTTPS, such as for testing.
+	AllowHTTP bool
+
+	selected map[uint32]struct{}
+	queue    chan Message
+
+	delivered, dropped, failed uint64
+
+	net    *network.Network
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+var (
+	// PluginID is used to check existence of the webhook plugin.
+	PluginID                         = (*Plugin)(nil)
+	_        network.PluginInterface = (*Plugin)(nil)
+)
+
+// PluginOption are configurable options for the webhook plugin.
+type PluginOption func(*Plugin)
+
+// WithSecret sets the secret requests are signed with.
+func WithSecret(secret []byte) PluginOption {
+	return func(p *Plugin) {
+		p.Secret = secret
+	}
+}
+
+// WithBatchSize sets the most messages sent within a single request.
+func WithBatchSize(n int) PluginOption {
+	return func(p *Plugin) {
+		p.BatchSize = n
+	}
+}
+
+// WithFlushInterval sets how long messages are held back for batching.
+func WithFlushInterval(d time.Duration) PluginOption {
+	return func(p *Plugin) {
+		p.FlushInterval = d
+	}
+}
+
+// WithQueueSize sets the most messages queued for delivery.
+func WithQueueSize(n int) PluginOption {
+	return func(p *Plugin) {
+		p.QueueSize = n
+	}
+}
+
+// WithMaxAttempts sets how many times a batch is sent before it is dropped.
+func WithMaxAttempts(n int) PluginOption {
+	return func(p *Plugin) {
+		p.MaxAttempts = n
+	}
+}
+
+// WithClient sets the client requests are sent with.
+func WithClient(client *http.Client) PluginOption {
+	return func(p *Plugin) {
+		p.Client = client
+	}
+}
+
+// WithAllowHTTP permits endpoints not served over HTTPS.
+func WithAllowHTTP() PluginOption {
+	return func(p *Plugin) {
+		p.AllowHTTP = true
+	}
+}
+
+// New returns a new webhook plugin forwarding messages of the given opcodes to
+// an endpoint, with specified options.
+func New(endpoint string, opcodes []opcode.Opcode, opts ...PluginOption) *Plugin {
+	p := &Plugin{URL: endpoint, Opcodes: opcodes}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+func (p *Plugin) setDefaults() {
+	if p.BatchSize <= 0 {
+		p.BatchSize = defaultBatchSize
+	}
+
+	if p.FlushInterval <= 0 {
+		p.FlushInterval = defaultFlushInterval
+	}
+
+	if p.QueueSize <= 0 {
+		p.QueueSize = defaultQueueSize
+	}
+
+	if p.MaxAttempts <= 0 {
+		p.MaxAttempts = defaultMaxAttempts
+	}
+
+	if p.Client == nil {
+		p.Client = &http.Client{Timeout: defaultTimeout}
+	}
+}
+
+// validate checks that messages may be forwarded to the endpoint.
+func (p *Plugin) validate() error {
+	endpoint, err := url.Parse(p.URL)
+	if err != nil {
+		return errors.Wrap(err, "webhook: invalid endpoint")
+	}
+
+	if endpoint.Scheme != "https" && !(p.AllowHTTP && endpoint.Scheme == "http") {
+		return errors.Errorf("webhook: endpoint %s is not served over https", p.URL)
+	}
+
+	return nil
+}
+
+// Startup starts forwarding messages to the endpoint.
+func (p *Plugin) Startup(net *network.Network) {
+	p.setDefaults()
+
+	p.net = net
+
+	if err := p.validate(); err != nil {
+		net.Log("webhook").Error().Err(err).Msg("Not forwarding messages.")
+		return
+	}
+
+	p.selected = make(map[uint32]struct{}, len(p.Opcodes))
+	for _, code := range p.Opcodes {
+		p.selected[uint32(code)] = struct{}{}
+	}
+
+	p.queue = make(chan Message, p.QueueSize)
+
+	var ctx context.Context
+	ctx, p.cancel = context.WithCancel(context.Background())
+
+	p.wg.Add(1)
+	go p.deliver(ctx)
+}
+
+// Cleanup stops forwarding messages, and sends messages still queued once.
+func (p *Plugin) Cleanup(net *network.Network) {
+	if p.cancel != nil {
+		p.cancel()
+		p.wg.Wait()
+	}
+}
+
+// Receive queues messages of selected opcodes for delivery.
+func (p *Plugin) Receive(ctx *network.PluginContext) error {
+	if p.queue == nil {
+		return nil
+	}
+
+	code, err := opcode.GetOpcode(ctx.Message())
+	if err != nil {
+		return nil
+	}
+
+	if _, selected := p.selected[uint32(code)]; !selected {
+		return nil
+	}
+
+	body, err := proto.Marshal(ctx.Message())
+	if err != nil {
+		return errors.Wrap(err, "webhook: failed to marshal message")
+	}
+
+	msg := Message{
+		Opcode:          uint32(code),
+		SenderAddress:   ctx.Sender().Address,
+		SenderPublicKey: ctx.Sender().PublicKeyHex(),
+		ReceivedAt:      ctx.Network().Clock().Now().UTC(),
+		Body:            body,
+	}
+
+	if traceID := ctx.TraceID(); !traceID.IsZero() {
+		msg.TraceID = traceID.String()
+	}
+
+	if encoded, err := json.Marshal(ctx.Message()); err == nil {
+		msg.JSON = encoded
+	}
+
+	select {
+	case p.queue <- msg:
+	default:
+		atomic.AddUint64(&p.dropped, 1)
+	}
+
+	return nil
+}
+
+// Stats returns the number of messages handled by the plugin.
+func (p *Plugin) Stats() Stats {
+	return Stats{
+		Delivered: atomic.LoadUint64(&p.delivered),
+		Dropped:   atomic.LoadUint64(&p.dropped),
+		Failed:    atomic.LoadUint64(&p.failed),
+	}
+}
+
+// deliver batches queued messages and sends them to the endpoint until ctx is
+// cancelled.
+func (p *Plugin) deliver(ctx context.Context) {
+	defer p.wg.Done()
+
+	ticker := p.net.Clock().NewTicker(p.FlushInterval)
+	defer ticker.Stop()
+
+	var batch []Message
+
+	flush := func(ctx context.Context) {
+		if len(batch) > 0 {
+			p.send(ctx, batch)
+			batch = nil
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			// Send whatever is left once, without retrying.
+			for {
+				select {
+				case msg := <-p.queue:
+					batch = append(batch, msg)
+					if len(batch) >= p.BatchSize {
+						flush(ctx)
+					}
+				default:
+					flush(ctx)
+					return
+				}
+			}
+		case msg := <-p.queue:
+			batch = append(batch, msg)
+			if len(batch) >= p.BatchSize {
+				flush(ctx)
+			}
+		case <-ticker.C():
+			flush(ctx)
+		}
+	}
+}
+
+// send sends a batch to the endpoint, and retries under exponential backoff
+// should the endpoint fail to accept it. Retries stop once ctx is cancelled.
+func (p *Plugin) send(ctx context.Context, messages []Message) {
+	body, err := json.Marshal(Batch{Node: p.net.Address, Messages: messages})
+	if err != nil {
+		atomic.AddUint64(&p.failed, uint64(len(messages)))
+		return
+	}
+
+	b := backoff.DefaultBackoff()
+	b.MinInterval = 100 * time.Millisecond
+	b.Float64 = p.net.Random().Float64
+
+	for attempt := 1; ; attempt++ {
+		retry, err := p.post(body)
+		if err == nil {
+			atomic.AddUint64(&p.delivered, uint64(len(messages)))
+			return
+		}
+
+		if !retry || attempt >= p.MaxAttempts || ctx.Err() != nil {
+			p.net.Log("webhook").Warn().Err(err).Int("messages", len(messages)).Msg("Failed to forward messages.")
+			atomic.AddUint64(&p.failed, uint64(len(messages)))
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-p.net.Clock().After(b.NextDuration()):
+		}
+	}
+}
+
+// post sends a request to the endpoint, and returns whether it may be retried
+// should it fail.
+func (p *Plugin) post(body []byte) (bool, error) {
+	req, err := http.NewRequest(http.MethodPost, p.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	if len(p.Secret) > 0 {
+		timestamp := strconv.FormatInt(p.net.Clock().Now().Unix(), 10)
+
+		req.Header.Set(HeaderTimestamp, timestamp)
+		req.Header.Set(HeaderSignature, Sign(p.Secret, timestamp, body))
+	}
+
+	res, err := p.Client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	res.Body.Close()
+
+	switch {
+	case res.StatusCode >= 200 && res.StatusCode < 300:
+		return false, nil
+	case res.StatusCode == http.StatusTooManyRequests || res.StatusCode >= 500:
+		return true, errors.Errorf("webhook: endpoint responded with %s", res.Status)
+	default:
+		return false, errors.Errorf("webhook: endpoint rejected messages with %s", res.Status)
+	}
+}
+
+// Sign returns the hex-encoded HMAC-SHA256 signature of a request body sent
+// at a unix timestamp.
+func Sign(secret []byte, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks the signature of a request body sent at a unix timestamp.
+func Verify(secret []byte, timestamp string, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
@@ -0,0 +1,231 @@
+package webhook_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/perlin-network/noise/internal/test"
+	"github.com/perlin-network/noise/internal/test/protobuf"
+	"github.com/perlin-network/noise/network"
+	"github.com/perlin-network/noise/network/webhook"
+	"github.com/perlin-network/noise/types/opcode"
+)
+
+func init() {
+	opcode.RegisterMessageType(opcode.Opcode(1000), &protobuf.TestMessage{})
+}
+
+// send sends test messages from one node to another once both are connected.
+func send(t *testing.T, from, to *network.Network, messages ...string) {
+	from.Bootstrap(to.Address)
+	to.Bootstrap(from.Address)
+
+	waitFor(t, "nodes to connect", func() bool {
+		return from.ConnectionStateExists(to.Address) && to.ConnectionStateExists(from.Address)
+	})
+
+	// Let handshake messages settle, such that messages sent after are not
+	// received out of order.
+	time.Sleep(300 * time.Millisecond)
+
+	client, err := from.Client(to.Address)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, msg := range messages {
+		if err := client.Tell(context.Background(), &protobuf.TestMessage{Message: msg}); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func waitFor(t *testing.T, description string, cond func() bool) {
+	deadline := time.Now().Add(5 * time.Second)
+
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", description)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+func TestSign(t *testing.T) {
+	t.Parallel()
+
+	secret, body := []byte("secret"), []byte(`{"node":"tcp://localhost:3000"}`)
+	signature := webhook.Sign(secret, "1546300800", body)
+
+	if !webhook.Verify(secret, "1546300800", body, signature) {
+		t.Fatalf("Verify() = expected signature to verify")
+	}
+
+	if webhook.Verify(secret, "1546300801", body, signature) {
+		t.Fatalf("Verify() = expected signature to not verify under another timestamp")
+	}
+
+	if webhook.Verify([]byte("other"), "1546300800", body, signature) {
+		t.Fatalf("Verify() = expected signature to not verify under another secret")
+	}
+}
+
+func TestForward(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping webhook test in short mode")
+	}
+
+	secret := []byte("secret")
+
+	var (
+		mutex    sync.Mutex
+		batches  []webhook.Batch
+		requests int32
+	)
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Fail the first request, such that it is retried.
+		if atomic.AddInt32(&requests, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		body, _ := ioutil.ReadAll(r.Body)
+
+		if !webhook.Verify(secret, r.Header.Get(webhook.HeaderTimestamp), body, r.Header.Get(webhook.HeaderSignature)) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		var batch webhook.Batch
+		if err := json.Unmarshal(body, &batch); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		mutex.Lock()
+		batches = append(batches, batch)
+		mutex.Unlock()
+	}))
+	defer server.Close()
+
+	sink := webhook.New(server.URL, []opcode.Opcode{1000},
+		webhook.WithSecret(secret),
+		webhook.WithBatchSize(2),
+		webhook.WithFlushInterval(100*time.Millisecond),
+		webhook.WithClient(server.Client()),
+	)
+
+	receiver := test.BuildNode(t, nil, sink)
+	defer receiver.Close()
+
+	sender := test.BuildNode(t, nil, new(network.Plugin))
+	defer sender.Close()
+
+	// Pings sent upon bootstrapping are not selected for forwarding.
+	send(t, sender, receiver, "a", "b", "c")
+
+	waitFor(t, "messages to be delivered", func() bool {
+		return sink.Stats().Delivered == 3
+	})
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	var forwarded []string
+	for _, batch := range batches {
+		if batch.Node != receiver.Address || len(batch.Messages) > 2 {
+			t.Fatalf("expected batches of at most 2 messages from the receiver, got %+v", batch)
+		}
+
+		for _, msg := range batch.Messages {
+			if msg.Opcode != 1000 || msg.SenderAddress != sender.Address || msg.SenderPublicKey != sender.ID.PublicKeyHex() {
+				t.Fatalf("expected forwarded message to describe the test message sent, got %+v", msg)
+			}
+
+			var decoded protobuf.TestMessage
+			if err := decoded.Unmarshal(msg.Body); err != nil {
+				t.Fatal(err)
+			}
+			forwarded = append(forwarded, decoded.Message)
+		}
+	}
+
+	if len(forwarded) != 3 {
+		t.Fatalf("expected 3 messages to be forwarded, got %v", forwarded)
+	}
+
+	if stats := sink.Stats(); stats.Failed != 0 || stats.Dropped != 0 {
+		t.Fatalf("expected no messages to fail or be dropped, got %+v", stats)
+	}
+}
+
+func TestRejected(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping webhook test in short mode")
+	}
+
+	var requests int32
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	sink := webhook.New(server.URL, []opcode.Opcode{1000},
+		webhook.WithFlushInterval(50*time.Millisecond),
+		webhook.WithClient(server.Client()),
+	)
+
+	receiver := test.BuildNode(t, nil, sink)
+	defer receiver.Close()
+
+	sender := test.BuildNode(t, nil, new(network.Plugin))
+	defer sender.Close()
+
+	send(t, sender, receiver, "a")
+
+	waitFor(t, "message to fail", func() bool {
+		return sink.Stats().Failed == 1
+	})
+
+	if atomic.LoadInt32(&requests) != 1 {
+		t.Fatalf("expected rejected messages to not be retried, got %d requests", requests)
+	}
+}
+
+func TestRequiresHTTPS(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping webhook test in short mode")
+	}
+
+	var requests int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+	}))
+	defer server.Close()
+
+	sink := webhook.New(server.URL, []opcode.Opcode{1000}, webhook.WithFlushInterval(50*time.Millisecond))
+
+	receiver := test.BuildNode(t, nil, sink)
+	defer receiver.Close()
+
+	sender := test.BuildNode(t, nil, new(network.Plugin))
+	defer sender.Close()
+
+	send(t, sender, receiver, "a")
+	time.Sleep(300 * time.Millisecond)
+
+	if atomic.LoadInt32(&requests) != 0 {
+		t.Fatalf("expected messages to not be forwarded to endpoints not served over https")
+	}
+}
@@ -0,0 +1,112 @@
+package network
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"sync"
+	"testing"
+
+	"github.com/perlin-network/noise/internal/protobuf"
+	"github.com/perlin-network/noise/types/opcode"
+
+	"github.com/gogo/protobuf/proto"
+)
+
+// TestPublishedVectors checks that the frames of the published test vectors
+// make it through the same decoding and verification messages read off of
+// streams go through.
+func TestPublishedVectors(t *testing.T) {
+	raw, err := ioutil.ReadFile("vectors/testdata/vectors.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var vectors struct {
+		Frames    []struct{ Description, Frame string }
+		Handshake []struct{ Description, Frame string }
+	}
+
+	if err := json.Unmarshal(raw, &vectors); err != nil {
+		t.Fatal(err)
+	}
+
+	net, err := buildNetwork(port)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, f := range append(vectors.Frames, vectors.Handshake...) {
+		encoded, err := hex.DecodeString(f.Frame)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, body, err := readFrame(bytes.NewReader(encoded))
+		if err != nil {
+			t.Fatalf("%s: readFrame() = %v", f.Description, err)
+		}
+
+		msg := new(protobuf.Message)
+		if err := proto.Unmarshal(body, msg); err != nil {
+			t.Fatalf("%s: failed to unmarshal message: %v", f.Description, err)
+		}
+
+		if err := net.verifyMessage(msg); err != nil {
+			t.Fatalf("%s: verifyMessage() = %v", f.Description, err)
+		}
+
+		if _, err := decodeMessageBody(opcode.Opcode(msg.Opcode), msg.Message); err != nil {
+			t.Fatalf("%s: decodeMessageBody() = %v", f.Description, err)
+		}
+	}
+}
+
+// TestSendMessageFrame checks that frames written by sendMessage read back
+// into the message sent, and that messages too large for peers to read are not
+// written at all.
+func TestSendMessageFrame(t *testing.T) {
+	net, err := buildNetwork(port)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msg, err := net.PrepareMessage(context.Background(), &protobuf.Bytes{Data: []byte("frame")})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		buf   bytes.Buffer
+		mutex sync.Mutex
+	)
+
+	if err := net.sendMessage("tcp://localhost:3000", &buf, msg, &mutex); err != nil {
+		t.Fatalf("sendMessage() = %v", err)
+	}
+
+	_, body, err := readFrame(&buf)
+	if err != nil {
+		t.Fatalf("readFrame() = %v", err)
+	}
+
+	decoded := new(protobuf.Message)
+	if err := proto.Unmarshal(body, decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	if !proto.Equal(msg, decoded) {
+		t.Fatalf("expected frame to hold %v, got %v", msg, decoded)
+	}
+
+	msg.Message = make([]byte, maxMessageSize)
+	if err := net.sendMessage("tcp://localhost:3000", &buf, msg, &mutex); err == nil {
+		t.Fatal("sendMessage() expected oversized messages to be rejected")
+	}
+
+	if buf.Len() != 0 {
+		t.Fatalf("expected oversized messages to not be written, got %d bytes", buf.Len())
+	}
+}
@@ -0,0 +1,194 @@
+package network
+
+import (
+	"sync/atomic"
+)
+
+// Worker pools whose goroutines are accounted for by the network.
+const (
+	workerAccept = iota
+	workerRecv
+	workerJobs
+	workerDispatch
+	workerFlush
+	workerPlugin
+	workerResources
+	workerRequests
+	workerSend
+	workerSeeds
+
+	numWorkerPools
+)
+
+var workerPoolNames = [numWorkerPools]string{
+	workerAccept:    "accept",
+	workerRecv:      "recv",
+	workerJobs:      "jobs",
+	workerDispatch:  "dispatch",
+	workerFlush:     "flush",
+	workerPlugin:    "plugin",
+	workerResources: "resources",
+	workerRequests:  "requests",
+	workerSend:      "send",
+	workerSeeds:     "seeds",
+}
+
+// workerPool accounts for the live goroutines of a subsystem, bounded by an
+// optional cap.
+type workerPool struct {
+	live int64
+
+	// slots holds a token per live goroutine should the pool be capped, and
+	// is nil otherwise.
+	slots chan struct{}
+}
+
+func newWorkerPool(max int) *workerPool {
+	pool := new(workerPool)
+	if max > 0 {
+		pool.slots = make(chan struct{}, max)
+	}
+	return pool
+}
+
+// newWorkerPools creates the worker pools of the network capped as
+// configured.
+func newWorkerPools(limits map[string]int) (pools [numWorkerPools]*workerPool) {
+	for pool, name := range workerPoolNames {
+		pools[pool] = newWorkerPool(limits[name])
+	}
+	return
+}
+
+// spawn spawns fn in a new goroutine accounted for under the pool, releasing
+// the slot acquired for it once it exits should it hold one.
+func (p *workerPool) spawn(fn func(), slot bool) {
+	atomic.AddInt64(&p.live, 1)
+
+	go func() {
+		defer func() {
+			if slot {
+				<-p.slots
+			}
+			atomic.AddInt64(&p.live, -1)
+		}()
+		fn()
+	}()
+}
+
+// goWorker spawns fn in a new goroutine accounted for under a given worker
+// pool. Should the pool be at its cap, goWorker blocks until a goroutine of
+// the pool exits, applying backpressure to whichever loop spawns workers.
+func (n *Network) goWorker(pool int, fn func()) {
+	p := n.workers[pool]
+
+	if p.slots != nil {
+		select {
+		case p.slots <- struct{}{}:
+		case <-n.kill:
+			// Whilst shutting down, workers are spawned regardless of the cap
+			// such that they get to clean up after themselves.
+			p.spawn(fn, false)
+			return
+		}
+	}
+
+	p.spawn(fn, p.slots != nil)
+}
+
+// Go spawns fn in a new goroutine accounted for under a subsystem, such as
+// a plugin, capped as configured through MaxWorkers. Unlike the workers of
+// the network, Go never blocks: it returns false without spawning fn should
+// the subsystem be at its cap, leaving it up to the caller to drop or defer
+// the work.
+func (n *Network) Go(subsystem string, fn func()) bool {
+	p := n.subsystem(subsystem)
+
+	if p.slots != nil {
+		select {
+		case p.slots <- struct{}{}:
+		default:
+			return false
+		}
+	}
+
+	p.spawn(fn, p.slots != nil)
+
+	return true
+}
+
+// subsystem returns the worker pool of a subsystem, creating it should it
+// not exist.
+func (n *Network) subsystem(name string) *workerPool {
+	for pool, poolName := range workerPoolNames {
+		if poolName == name {
+			return n.workers[pool]
+		}
+	}
+
+	if p, ok := n.subsystems.Load(name); ok {
+		return p.(*workerPool)
+	}
+
+	p, _ := n.subsystems.LoadOrStore(name, newWorkerPool(n.opts.maxWorkers[name]))
+	return p.(*workerPool)
+}
+
+// WorkerCounts returns the number of live goroutines spawned by the network
+// keyed by the name of the worker pool or subsystem they belong to.
+func (n *Network) WorkerCounts() map[string]int64 {
+	counts := make(map[string]int64, numWorkerPools)
+	for pool, name := range workerPoolNames {
+		counts[name] = atomic.LoadInt64(&n.workers[pool].live)
+	}
+	n.subsystems.Range(func(key, value interface{}) bool {
+		counts[key.(string)] = atomic.LoadInt64(&value.(*workerPool).live)
+		return true
+	})
+	return counts
+}
+
+// QueueStats holds the depths of a node's internal queues.
+type QueueStats struct {
+	// Peers is the number of peer clients held by the node.
+	Peers int `json:"peers"`
+	// Connections is the number of established connections.
+	Connections int `json:"connections"`
+	// PendingJobs is the number of messages queued for dispatch across all peers.
+	PendingJobs int `json:"pending_jobs"`
+	// PendingRequests is the number of requests awaiting a response across all peers.
+	PendingRequests int `json:"pending_requests"`
+	// BufferedWrites is the number of bytes buffered across all connection writers.
+	BufferedWrites int `json:"buffered_writes"`
+	// Plugins are the queues of plugins with limits keyed by their type.
+	Plugins map[string]PluginQueueStats `json:"plugins,omitempty"`
+}
+
+// QueueStats returns a snapshot of the depths of the node's internal queues.
+func (n *Network) QueueStats() (stats QueueStats) {
+	n.eachPeer(func(client *PeerClient) bool {
+		stats.Peers++
+		stats.PendingJobs += len(client.jobs) + len(client.priorityJobs)
+		client.Requests.Range(func(_, _ interface{}) bool {
+			stats.PendingRequests++
+			return true
+		})
+		return true
+	})
+
+	n.connections.Range(func(_, value interface{}) bool {
+		if state, ok := value.(*ConnState); ok {
+			stats.Connections++
+
+			state.writerMutex.Lock()
+			stats.BufferedWrites += state.writer.Buffered()
+			state.writerMutex.Unlock()
+		}
+
+		return true
+	})
+
+	stats.Plugins = n.pluginQueueStats()
+
+	return
+}
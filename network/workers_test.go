@@ -0,0 +1,67 @@
+package network
+
+import (
+	"testing"
+	"time"
+
+	"github.com/perlin-network/noise/crypto/ed25519"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaxWorkers(t *testing.T) {
+	t.Parallel()
+
+	builder := NewBuilderWithOptions(MaxWorkers("requests", 1), MaxWorkers("test", 1))
+	builder.SetKeys(ed25519.RandomKeyPair())
+	listener, address, err := ListenOnRandomPort()
+	assert.Equal(t, nil, err)
+	builder.SetListener(listener)
+	builder.SetAddress(address)
+
+	node, err := builder.Build()
+	assert.Equal(t, nil, err)
+	defer node.Close()
+
+	release := make(chan struct{})
+	block := func() { <-release }
+
+	// Subsystems refuse to spawn goroutines past their cap.
+	assert.True(t, node.Go("test", block))
+	assert.False(t, node.Go("test", block), "expected goroutines past the cap of a subsystem to be refused")
+	assert.True(t, node.Go("uncapped", block))
+	assert.True(t, node.Go("uncapped", block))
+
+	// Workers of the network block until a slot is freed.
+	node.goWorker(workerRequests, block)
+
+	spawned := make(chan struct{})
+	go func() {
+		node.goWorker(workerRequests, func() {})
+		close(spawned)
+	}()
+
+	select {
+	case <-spawned:
+		t.Fatal("expected workers past the cap of a pool to be delayed")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	counts := node.WorkerCounts()
+	assert.Equal(t, int64(1), counts["test"])
+	assert.Equal(t, int64(2), counts["uncapped"])
+	assert.Equal(t, int64(1), counts["requests"])
+
+	close(release)
+
+	select {
+	case <-spawned:
+	case <-time.After(time.Second):
+		t.Fatal("expected delayed workers to be spawned once a slot is freed")
+	}
+
+	for i := 0; i < 100 && node.WorkerCounts()["test"] > 0; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.True(t, node.Go("test", func() {}), "expected slots to be freed as goroutines exit")
+}
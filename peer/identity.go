@@ -2,6 +2,7 @@ package peer
 
 import (
 	"bytes"
+	"encoding/binary"
 	"encoding/hex"
 	"fmt"
 	"math/bits"
@@ -44,23 +45,44 @@ func (id ID) PublicKeyHex() string {
 // Xor performs XOR (^) over another peer ID's public key.
 func (id ID) Xor(other ID) ID {
 	result := make([]byte, len(id.PublicKey))
+	XorInto(result, id.PublicKey, other.PublicKey)
 
-	for i := 0; i < len(id.PublicKey) && i < len(other.PublicKey); i++ {
-		result[i] = id.PublicKey[i] ^ other.PublicKey[i]
-	}
 	return ID{Address: id.Address, PublicKey: result}
 }
 
 // XorID performs XOR (^) over another peer ID's public key hash.
 func (id ID) XorID(other ID) ID {
 	result := make([]byte, len(id.Id))
+	XorInto(result, id.Id, other.Id)
 
-	for i := 0; i < len(id.Id) && i < len(other.Id); i++ {
-		result[i] = id.Id[i] ^ other.Id[i]
-	}
 	return ID{Address: id.Address, Id: result}
 }
 
+// XorInto writes a XOR (^) b into dst a machine word at a time, and returns the
+// XOR'd bytes. Only as many bytes as the shorter of a and b are XOR'd, and dst
+// is reallocated should it lack the capacity to hold them.
+func XorInto(dst []byte, a []byte, b []byte) []byte {
+	n := len(a)
+	if len(b) < n {
+		n = len(b)
+	}
+
+	if cap(dst) < n {
+		dst = make([]byte, n)
+	}
+	dst = dst[:n]
+
+	i := 0
+	for ; i+8 <= n; i += 8 {
+		binary.LittleEndian.PutUint64(dst[i:], binary.LittleEndian.Uint64(a[i:])^binary.LittleEndian.Uint64(b[i:]))
+	}
+	for ; i < n; i++ {
+		dst[i] = a[i] ^ b[i]
+	}
+
+	return dst
+}
+
 // PrefixLen returns the number of prefixed zeros in a peer ID.
 func (id ID) PrefixLen() int {
 	for i, b := range id.Id {
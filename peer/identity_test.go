@@ -110,6 +110,27 @@ func TestXor(t *testing.T) {
 	}
 }
 
+func TestXorInto(t *testing.T) {
+	t.Parallel()
+
+	a := []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}
+	b := []byte{11, 10, 9, 8, 7, 6, 5, 4, 3, 2}
+
+	expected := make([]byte, len(b))
+	for i := range expected {
+		expected[i] = a[i] ^ b[i]
+	}
+
+	if result := XorInto(nil, a, b); !bytes.Equal(expected, result) {
+		t.Errorf("XorInto() = %v, want %v", result, expected)
+	}
+
+	dst := make([]byte, 0, 32)
+	if result := XorInto(dst, b, a); !bytes.Equal(expected, result) || &result[0] != &dst[:1][0] {
+		t.Errorf("XorInto() expected result %v to be written into dst, got %v", expected, result)
+	}
+}
+
 func TestPrefixLen(t *testing.T) {
 	t.Parallel()
 
@@ -134,3 +155,22 @@ func TestPrefixLen(t *testing.T) {
 		}
 	}
 }
+
+func BenchmarkCreateID(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		CreateID(address, publicKey1)
+	}
+}
+
+func BenchmarkXorInto(b *testing.B) {
+	dst := make([]byte, len(id1.Id))
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		XorInto(dst, id1.Id, id2.Id)
+	}
+}
@@ -0,0 +1,412 @@
+package tracing
+
+import (
+	"context"
+	crand "crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+type (
+	spanCtxKeyType string
+)
+
+const (
+	spanCtxKey    spanCtxKeyType = "span"
+	traceIDCtxKey spanCtxKeyType = "trace_id"
+)
+
+// TraceID uniquely identifies a trace spanning across multiple nodes.
+type TraceID [16]byte
+
+// String returns the hex representation of the trace ID.
+func (id TraceID) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+// IsZero returns true if the trace ID has not been set.
+func (id TraceID) IsZero() bool {
+	return id == TraceID{}
+}
+
+// NewTraceID generates a random trace ID.
+func NewTraceID() (id TraceID) {
+	crand.Read(id[:])
+	return
+}
+
+// SpanID uniquely identifies a span within a trace.
+type SpanID [8]byte
+
+// String returns the hex representation of the span ID.
+func (id SpanID) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+// SpanContext is the portion of a span which is propagated across the wire.
+type SpanContext struct {
+	TraceID TraceID
+	SpanID  SpanID
+	Sampled bool
+}
+
+// IsValid returns true if the span context references an existing trace.
+func (sc SpanContext) IsValid() bool {
+	return !sc.TraceID.IsZero()
+}
+
+// HasSpan returns true if the span context references a span within its trace,
+// rather than only the trace itself.
+func (sc SpanContext) HasSpan() bool {
+	return sc.SpanID != SpanID{}
+}
+
+// Exporter receives finished spans, and is expected to forward them to a
+// tracing backend (such as an OpenTelemetry collector, Jaeger, or Tempo).
+type Exporter interface {
+	Export(span *Span)
+}
+
+// ExporterFunc is an adapter to allow the use of ordinary functions as exporters.
+type ExporterFunc func(span *Span)
+
+// Export calls f(span).
+func (f ExporterFunc) Export(span *Span) {
+	f(span)
+}
+
+// Sampler decides whether or not a trace is recorded. It is consulted once per
+// trace as its root span starts, and its decision is carried by every span
+// descending from the root, including those started by other nodes the trace
+// propagates to.
+type Sampler func(span *Span) bool
+
+// AlwaysSample records every single trace.
+func AlwaysSample() Sampler {
+	return func(span *Span) bool {
+		return true
+	}
+}
+
+// NeverSample records no traces, besides those sampled by other nodes.
+func NeverSample() Sampler {
+	return func(span *Span) bool {
+		return false
+	}
+}
+
+// RatioSample records a fraction of all traces, between 0 and 1. The decision
+// is derived from the trace ID.
+func RatioSample(ratio float64) Sampler {
+	if ratio >= 1 {
+		return AlwaysSample()
+	}
+
+	bound := uint64(ratio * (1 << 63))
+
+	return func(span *Span) bool {
+		return binary.BigEndian.Uint64(span.Context.TraceID[8:])>>1 < bound
+	}
+}
+
+// Tracer creates spans and hands them off to an exporter once finished.
+//
+// A nil *Tracer is valid, and creates nil spans which are no-ops.
+type Tracer struct {
+	exporter Exporter
+	sampler  Sampler
+
+	// slowThreshold is how long the local root span of a trace must take for
+	// the trace to be exported. Zero if all sampled traces are exported.
+	slowThreshold time.Duration
+
+	mutex *sync.Mutex
+	rand  *rand.Rand
+}
+
+// TracerOption configures a tracer.
+type TracerOption func(*Tracer)
+
+// WithSampler sets the sampler used to decide which traces get recorded
+// (default: AlwaysSample).
+func WithSampler(sampler Sampler) TracerOption {
+	return func(t *Tracer) {
+		t.sampler = sampler
+	}
+}
+
+// WithSlowThreshold only exports the spans of sampled traces whose local root
+// span took at least a given threshold to complete, or in which a span failed.
+// Spans are held onto until the local root span they descend from finishes,
+// such that a trace is exported either in full or not at all (default:
+// sampled traces are always exported).
+func WithSlowThreshold(threshold time.Duration) TracerOption {
+	return func(t *Tracer) {
+		t.slowThreshold = threshold
+	}
+}
+
+// NewTracer returns a new tracer which exports finished spans to exporter.
+func NewTracer(exporter Exporter, opts ...TracerOption) *Tracer {
+	t := &Tracer{
+		exporter: exporter,
+		sampler:  AlwaysSample(),
+		mutex:    new(sync.Mutex),
+		rand:     rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	return t
+}
+
+// StartSpan starts a new span as a child of the span stored in ctx. Should
+// no span be stored in ctx, the span joins the trace whose ID is stored in
+// ctx, or else starts a new trace.
+func (t *Tracer) StartSpan(ctx context.Context, name string) (context.Context, *Span) {
+	if t == nil {
+		return ctx, nil
+	}
+
+	var span *Span
+
+	if parent := SpanFromContext(ctx); parent != nil {
+		span = t.startSpan(name, parent.Context, parent.localRoot())
+	} else {
+		span = t.startSpan(name, SpanContext{TraceID: TraceIDFromContext(ctx)}, nil)
+	}
+
+	return ContextWithSpan(ctx, span), span
+}
+
+// StartSpanWithParent starts a new span as a child of a (possibly remote)
+// parent span context. Should the parent be invalid, a new trace is started.
+// Should the parent not reference a span, the span is the root of its trace.
+func (t *Tracer) StartSpanWithParent(name string, parent SpanContext) *Span {
+	if t == nil {
+		return nil
+	}
+
+	return t.startSpan(name, parent, nil)
+}
+
+// startSpan starts a new span as a child of a parent span context, descending
+// from a local root span should it not be nil.
+func (t *Tracer) startSpan(name string, parent SpanContext, root *Span) *Span {
+	span := &Span{
+		Name:   name,
+		Start:  time.Now(),
+		Tags:   make(map[string]interface{}),
+		tracer: t,
+		root:   root,
+	}
+
+	t.mutex.Lock()
+	if parent.IsValid() {
+		span.Context.TraceID = parent.TraceID
+		span.ParentID = parent.SpanID
+	} else {
+		t.rand.Read(span.Context.TraceID[:])
+	}
+	t.rand.Read(span.Context.SpanID[:])
+	t.mutex.Unlock()
+
+	// Spans with a parent span carry its sampling decision. Otherwise, the
+	// span is the root of its trace, and decides upon it.
+	if parent.HasSpan() {
+		span.Context.Sampled = parent.Sampled
+	} else {
+		span.Context.Sampled = t.sampler(span)
+	}
+
+	return span
+}
+
+// Span represents a single timed operation within a trace.
+//
+// All methods on a nil *Span are no-ops.
+type Span struct {
+	Name     string
+	Context  SpanContext
+	ParentID SpanID
+
+	Start time.Time
+	End   time.Time
+
+	Tags map[string]interface{}
+	Err  error
+
+	tracer *Tracer
+	mutex  sync.Mutex
+	once   sync.Once
+
+	// root is the span this span locally descends from, which decides whether
+	// or not its spans are exported should the tracer only export slow traces.
+	// Nil should the span be a local root itself.
+	root *Span
+
+	// Spans descending from this local root which finished before it did.
+	held []*Span
+	// Whether or not this local root has finished, and was exported.
+	finished bool
+	exported bool
+}
+
+// localRoot returns the local root span this span descends from.
+func (s *Span) localRoot() *Span {
+	if s.root != nil {
+		return s.root
+	}
+	return s
+}
+
+// SetTag annotates the span with a key/value pair.
+func (s *Span) SetTag(key string, value interface{}) {
+	if s == nil {
+		return
+	}
+
+	s.mutex.Lock()
+	s.Tags[key] = value
+	s.mutex.Unlock()
+}
+
+// SetError marks the span as failed should err not be nil.
+func (s *Span) SetError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+
+	s.mutex.Lock()
+	s.Err = err
+	s.mutex.Unlock()
+}
+
+// Duration returns how long the span took to complete.
+func (s *Span) Duration() time.Duration {
+	if s == nil {
+		return 0
+	}
+	return s.End.Sub(s.Start)
+}
+
+// Finish marks the end of the span, and exports it should its trace be
+// sampled. Calling Finish more than once has no effect.
+func (s *Span) Finish() {
+	if s == nil {
+		return
+	}
+
+	s.once.Do(func() {
+		s.mutex.Lock()
+		s.End = time.Now()
+		s.mutex.Unlock()
+
+		if s.tracer.exporter == nil || !s.Context.Sampled {
+			return
+		}
+
+		if s.tracer.slowThreshold <= 0 {
+			s.tracer.exporter.Export(s)
+			return
+		}
+
+		if s.root != nil {
+			s.finishChild()
+		} else {
+			s.finishRoot()
+		}
+	})
+}
+
+// finishChild holds onto a finished span until its local root finishes, or
+// exports it right away should its local root have already been exported.
+func (s *Span) finishChild() {
+	root := s.root
+
+	root.mutex.Lock()
+	if !root.finished {
+		root.held = append(root.held, s)
+		root.mutex.Unlock()
+		return
+	}
+	exported := root.exported
+	root.mutex.Unlock()
+
+	if exported {
+		s.tracer.exporter.Export(s)
+	}
+}
+
+// finishRoot exports a finished local root span alongside all spans held onto
+// on its behalf, should it have been slow, or should any of them have failed.
+func (s *Span) finishRoot() {
+	s.mutex.Lock()
+	export := s.Err != nil || s.Duration() >= s.tracer.slowThreshold
+
+	for _, span := range s.held {
+		span.mutex.Lock()
+		export = export || span.Err != nil
+		span.mutex.Unlock()
+	}
+
+	held := s.held
+	s.held, s.finished, s.exported = nil, true, export
+	s.mutex.Unlock()
+
+	if !export {
+		return
+	}
+
+	for _, span := range held {
+		s.tracer.exporter.Export(span)
+	}
+	s.tracer.exporter.Export(s)
+}
+
+// ContextWithSpan returns a copy of ctx which holds a span.
+func ContextWithSpan(ctx context.Context, span *Span) context.Context {
+	if span == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, spanCtxKey, span)
+}
+
+// SpanFromContext returns the span stored in ctx, or nil otherwise.
+func SpanFromContext(ctx context.Context) *Span {
+	if ctx == nil {
+		return nil
+	}
+	span, _ := ctx.Value(spanCtxKey).(*Span)
+	return span
+}
+
+// ContextWithTraceID returns a copy of ctx which holds a trace ID, such that
+// messages sent under ctx are correlated under it even with tracing disabled.
+func ContextWithTraceID(ctx context.Context, id TraceID) context.Context {
+	if id.IsZero() {
+		return ctx
+	}
+	return context.WithValue(ctx, traceIDCtxKey, id)
+}
+
+// TraceIDFromContext returns the trace ID of the span stored in ctx, or else
+// the trace ID stored in ctx through ContextWithTraceID. A zero trace ID is
+// returned otherwise.
+func TraceIDFromContext(ctx context.Context) TraceID {
+	if span := SpanFromContext(ctx); span != nil {
+		return span.Context.TraceID
+	}
+
+	if ctx == nil {
+		return TraceID{}
+	}
+
+	id, _ := ctx.Value(traceIDCtxKey).(TraceID)
+	return id
+}
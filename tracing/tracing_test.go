@@ -0,0 +1,196 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type recorder struct {
+	spans []*Span
+}
+
+func (r *recorder) Export(span *Span) {
+	r.spans = append(r.spans, span)
+}
+
+func TestNilTracer(t *testing.T) {
+	t.Parallel()
+
+	var tracer *Tracer
+
+	ctx, span := tracer.StartSpan(context.Background(), "test")
+	if span != nil {
+		t.Fatalf("StartSpan() on a nil tracer should return a nil span")
+	}
+	if SpanFromContext(ctx) != nil {
+		t.Fatalf("SpanFromContext() expected nil span")
+	}
+
+	// None of these should panic.
+	span.SetTag("key", "value")
+	span.SetError(errors.New("error"))
+	span.Finish()
+}
+
+func TestChildSpan(t *testing.T) {
+	t.Parallel()
+
+	r := new(recorder)
+	tracer := NewTracer(r)
+
+	ctx, parent := tracer.StartSpan(context.Background(), "parent")
+	_, child := tracer.StartSpan(ctx, "child")
+
+	if parent.Context.TraceID.IsZero() {
+		t.Fatalf("expected a new trace ID to be generated")
+	}
+	if child.Context.TraceID != parent.Context.TraceID {
+		t.Fatalf("child trace ID = %s, expected %s", child.Context.TraceID, parent.Context.TraceID)
+	}
+	if child.ParentID != parent.Context.SpanID {
+		t.Fatalf("child parent ID = %s, expected %s", child.ParentID, parent.Context.SpanID)
+	}
+	if child.Context.SpanID == parent.Context.SpanID {
+		t.Fatalf("child and parent should not share a span ID")
+	}
+
+	child.Finish()
+	parent.Finish()
+	parent.Finish()
+
+	if len(r.spans) != 2 {
+		t.Fatalf("expected 2 exported spans, got %d", len(r.spans))
+	}
+	if r.spans[0] != child || r.spans[1] != parent {
+		t.Fatalf("spans were exported in the wrong order")
+	}
+}
+
+func TestRemoteParent(t *testing.T) {
+	t.Parallel()
+
+	tracer := NewTracer(nil)
+
+	remote := SpanContext{Sampled: true}
+	remote.TraceID[0] = 1
+	remote.SpanID[0] = 2
+
+	span := tracer.StartSpanWithParent("remote", remote)
+	if span.Context.TraceID != remote.TraceID {
+		t.Fatalf("trace ID = %s, expected %s", span.Context.TraceID, remote.TraceID)
+	}
+	if span.ParentID != remote.SpanID {
+		t.Fatalf("parent ID = %s, expected %s", span.ParentID, remote.SpanID)
+	}
+
+	span.Finish()
+}
+
+func TestTraceIDFromContext(t *testing.T) {
+	t.Parallel()
+
+	if id := TraceIDFromContext(context.Background()); !id.IsZero() {
+		t.Fatalf("TraceIDFromContext() = %s, expected zero trace ID", id)
+	}
+
+	id := NewTraceID()
+	if id.IsZero() || id == NewTraceID() {
+		t.Fatalf("NewTraceID() expected random trace IDs")
+	}
+
+	ctx := ContextWithTraceID(context.Background(), id)
+	if got := TraceIDFromContext(ctx); got != id {
+		t.Fatalf("TraceIDFromContext() = %s, expected %s", got, id)
+	}
+
+	// Spans started under ctx should join its trace.
+	ctx, span := NewTracer(nil).StartSpan(ctx, "joined")
+	if span.Context.TraceID != id || TraceIDFromContext(ctx) != id {
+		t.Fatalf("trace ID = %s, expected %s", span.Context.TraceID, id)
+	}
+
+	span.Finish()
+}
+
+func TestSampling(t *testing.T) {
+	t.Parallel()
+
+	r := new(recorder)
+	tracer := NewTracer(r, WithSampler(NeverSample()))
+
+	// Spans descending from an unsampled root are not exported.
+	ctx, root := tracer.StartSpan(context.Background(), "root")
+	_, child := tracer.StartSpan(ctx, "child")
+
+	if root.Context.Sampled || child.Context.Sampled {
+		t.Fatalf("expected root and child spans to not be sampled")
+	}
+
+	child.Finish()
+	root.Finish()
+
+	// Spans of remote parents carry their sampling decision.
+	remote := SpanContext{Sampled: true}
+	remote.TraceID[0] = 1
+	remote.SpanID[0] = 2
+
+	sampled := tracer.StartSpanWithParent("sampled", remote)
+	if !sampled.Context.Sampled {
+		t.Fatalf("expected span to carry the sampling decision of its remote parent")
+	}
+	sampled.Finish()
+
+	if len(r.spans) != 1 || r.spans[0] != sampled {
+		t.Fatalf("expected only the span of the sampled remote parent to be exported, got %d spans", len(r.spans))
+	}
+
+	// Spans of traces whose ID alone is known are roots, and decide upon the trace.
+	ctx = ContextWithTraceID(context.Background(), NewTraceID())
+	if _, span := NewTracer(nil).StartSpan(ctx, "root"); !span.Context.Sampled {
+		t.Fatalf("expected root span of a known trace to be sampled")
+	}
+
+	if RatioSample(0)(root) || !RatioSample(1)(root) {
+		t.Fatalf("expected ratios of 0 and 1 to sample no and all traces")
+	}
+}
+
+func TestSlowThreshold(t *testing.T) {
+	t.Parallel()
+
+	r := new(recorder)
+	tracer := NewTracer(r, WithSlowThreshold(20*time.Millisecond))
+
+	// Fast traces are dropped in full.
+	ctx, fast := tracer.StartSpan(context.Background(), "fast")
+	_, fastChild := tracer.StartSpan(ctx, "fast child")
+	fastChild.Finish()
+	fast.Finish()
+
+	// Traces in which any span failed are exported in full.
+	ctx, failed := tracer.StartSpan(context.Background(), "failed")
+	_, failedChild := tracer.StartSpan(ctx, "failed child")
+	failedChild.SetError(errors.New("failed"))
+	failedChild.Finish()
+	failed.Finish()
+
+	// Slow traces are exported in full, including spans finishing after their root.
+	ctx, slow := tracer.StartSpan(context.Background(), "slow")
+	_, slowChild := tracer.StartSpan(ctx, "slow child")
+	time.Sleep(30 * time.Millisecond)
+	slow.Finish()
+	slowChild.Finish()
+
+	expected := []*Span{failedChild, failed, slow, slowChild}
+	if len(r.spans) != len(expected) {
+		t.Fatalf("expected only the failed and slow traces to be exported, got %d spans", len(r.spans))
+	}
+
+	for i, span := range expected {
+		if r.spans[i] != span {
+			t.Fatalf("span %d = %q, expected %q", i, r.spans[i].Name, span.Name)
+		}
+	}
+}
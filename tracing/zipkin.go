@@ -0,0 +1,255 @@
+package tracing
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// defaultZipkinBatchSize is the largest number of spans posted to a
+	// collector at once.
+	defaultZipkinBatchSize = 256
+
+	// defaultZipkinFlushInterval is how often spans are posted to a collector
+	// should a batch not fill up sooner.
+	defaultZipkinFlushInterval = 1 * time.Second
+
+	// defaultZipkinQueueSize is how many finished spans are queued up for
+	// posting before further spans are dropped.
+	defaultZipkinQueueSize = 4096
+)
+
+// ZipkinExporter posts finished spans in batches to a collector accepting the
+// Zipkin v2 JSON format, which Zipkin, Jaeger and Grafana Tempo all accept
+// (e.g. http://localhost:9411/api/v2/spans).
+//
+// Spans are posted in the background. Should the collector fall behind, spans
+// are dropped rather than block the spans' callers.
+type ZipkinExporter struct {
+	url         string
+	serviceName string
+	client      *http.Client
+
+	batchSize     int
+	flushInterval time.Duration
+
+	spans chan *Span
+
+	closeOnce sync.Once
+	closing   chan struct{}
+	closed    chan struct{}
+
+	dropped uint64 // for atomic ops
+}
+
+var _ Exporter = (*ZipkinExporter)(nil)
+
+// ZipkinOption configures a Zipkin exporter.
+type ZipkinOption func(*ZipkinExporter)
+
+// WithZipkinClient sets the HTTP client spans are posted with (default:
+// http.DefaultClient).
+func WithZipkinClient(client *http.Client) ZipkinOption {
+	return func(e *ZipkinExporter) {
+		e.client = client
+	}
+}
+
+// WithZipkinBatching sets the largest number of spans posted at once, and how
+// often spans are posted should a batch not fill up sooner (default: 256
+// spans, every second).
+func WithZipkinBatching(size int, interval time.Duration) ZipkinOption {
+	return func(e *ZipkinExporter) {
+		e.batchSize, e.flushInterval = size, interval
+	}
+}
+
+// NewZipkinExporter returns an exporter posting spans to the collector at url,
+// under the service name given. The exporter must be closed once no longer
+// used to post all spans still queued up.
+func NewZipkinExporter(url string, serviceName string, opts ...ZipkinOption) *ZipkinExporter {
+	e := &ZipkinExporter{
+		url:           url,
+		serviceName:   serviceName,
+		client:        http.DefaultClient,
+		batchSize:     defaultZipkinBatchSize,
+		flushInterval: defaultZipkinFlushInterval,
+		spans:         make(chan *Span, defaultZipkinQueueSize),
+		closing:       make(chan struct{}),
+		closed:        make(chan struct{}),
+	}
+
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	if e.batchSize <= 0 {
+		e.batchSize = defaultZipkinBatchSize
+	}
+
+	if e.flushInterval <= 0 {
+		e.flushInterval = defaultZipkinFlushInterval
+	}
+
+	go e.run()
+
+	return e
+}
+
+// Export queues up a finished span for posting, or drops it should the queue
+// be full or the exporter be closed.
+func (e *ZipkinExporter) Export(span *Span) {
+	select {
+	case <-e.closing:
+		atomic.AddUint64(&e.dropped, 1)
+		return
+	default:
+	}
+
+	select {
+	case e.spans <- span:
+	default:
+		atomic.AddUint64(&e.dropped, 1)
+	}
+}
+
+// Dropped returns how many spans were dropped, either for the queue having
+// been full, or for the collector having failed to accept them.
+func (e *ZipkinExporter) Dropped() uint64 {
+	return atomic.LoadUint64(&e.dropped)
+}
+
+// Close posts all spans still queued up, and stops the exporter.
+func (e *ZipkinExporter) Close() error {
+	e.closeOnce.Do(func() {
+		close(e.closing)
+	})
+	<-e.closed
+
+	return nil
+}
+
+func (e *ZipkinExporter) run() {
+	defer close(e.closed)
+
+	ticker := time.NewTicker(e.flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]*Span, 0, e.batchSize)
+
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+
+		if err := e.post(batch); err != nil {
+			atomic.AddUint64(&e.dropped, uint64(len(batch)))
+		}
+
+		batch = batch[:0]
+	}
+
+	for {
+		select {
+		case span := <-e.spans:
+			if batch = append(batch, span); len(batch) >= e.batchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-e.closing:
+			for {
+				select {
+				case span := <-e.spans:
+					if batch = append(batch, span); len(batch) >= e.batchSize {
+						flush()
+					}
+				default:
+					flush()
+					return
+				}
+			}
+		}
+	}
+}
+
+// post posts a batch of spans to the collector.
+func (e *ZipkinExporter) post(batch []*Span) error {
+	spans := make([]zipkinSpan, 0, len(batch))
+	for _, span := range batch {
+		spans = append(spans, e.zipkinSpan(span))
+	}
+
+	body, err := json.Marshal(spans)
+	if err != nil {
+		return errors.Wrap(err, "tracing: failed to encode spans")
+	}
+
+	res, err := e.client.Post(e.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "tracing: failed to post spans")
+	}
+	res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return errors.Errorf("tracing: collector refused spans with status %d", res.StatusCode)
+	}
+
+	return nil
+}
+
+type zipkinEndpoint struct {
+	ServiceName string `json:"serviceName"`
+}
+
+type zipkinSpan struct {
+	TraceID       string            `json:"traceId"`
+	ID            string            `json:"id"`
+	ParentID      string            `json:"parentId,omitempty"`
+	Name          string            `json:"name"`
+	Timestamp     int64             `json:"timestamp"`
+	Duration      int64             `json:"duration"`
+	LocalEndpoint zipkinEndpoint    `json:"localEndpoint"`
+	Tags          map[string]string `json:"tags,omitempty"`
+}
+
+// zipkinSpan converts a finished span into the Zipkin v2 format. Tags are
+// formatted as strings, and a failed span is tagged with its error.
+func (e *ZipkinExporter) zipkinSpan(span *Span) zipkinSpan {
+	span.mutex.Lock()
+	defer span.mutex.Unlock()
+
+	s := zipkinSpan{
+		TraceID:       span.Context.TraceID.String(),
+		ID:            span.Context.SpanID.String(),
+		Name:          span.Name,
+		Timestamp:     span.Start.UnixNano() / int64(time.Microsecond),
+		Duration:      int64(span.End.Sub(span.Start) / time.Microsecond),
+		LocalEndpoint: zipkinEndpoint{ServiceName: e.serviceName},
+	}
+
+	if span.ParentID != (SpanID{}) {
+		s.ParentID = span.ParentID.String()
+	}
+
+	if len(span.Tags) > 0 || span.Err != nil {
+		s.Tags = make(map[string]string, len(span.Tags)+1)
+
+		for key, value := range span.Tags {
+			s.Tags[key] = fmt.Sprint(value)
+		}
+
+		if span.Err != nil {
+			s.Tags["error"] = span.Err.Error()
+		}
+	}
+
+	return s
+}
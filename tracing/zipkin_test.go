@@ -0,0 +1,112 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestZipkinExporter(t *testing.T) {
+	t.Parallel()
+
+	var mutex sync.Mutex
+	var received []map[string]interface{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var spans []map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&spans); err != nil {
+			t.Errorf("expected spans to be posted as JSON, got %v", err)
+		}
+
+		mutex.Lock()
+		received = append(received, spans...)
+		mutex.Unlock()
+
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	exporter := NewZipkinExporter(server.URL, "noise", WithZipkinBatching(2, time.Hour))
+	tracer := NewTracer(exporter)
+
+	ctx, root := tracer.StartSpan(context.Background(), "request")
+	root.SetTag("peer", "tcp://localhost:3000")
+
+	_, child := tracer.StartSpan(ctx, "dispatch")
+	child.SetError(errors.New("handler failed"))
+	child.Finish()
+	root.Finish()
+
+	_, other := tracer.StartSpan(context.Background(), "send")
+	other.Finish()
+
+	if err := exporter.Close(); err != nil {
+		t.Fatalf("Close() = expected no error, got %v", err)
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	if len(received) != 3 {
+		t.Fatalf("expected all 3 spans to be posted, got %d", len(received))
+	}
+
+	dispatch, request := received[0], received[1]
+
+	if dispatch["traceId"] != root.Context.TraceID.String() || dispatch["parentId"] != root.Context.SpanID.String() {
+		t.Fatalf("expected child span to reference its trace and parent, got %v", dispatch)
+	}
+
+	if tags, _ := dispatch["tags"].(map[string]interface{}); tags["error"] != "handler failed" {
+		t.Fatalf("expected failed span to be tagged with its error, got %v", dispatch["tags"])
+	}
+
+	if _, ok := request["parentId"]; ok {
+		t.Fatalf("expected root span to have no parent, got %v", request["parentId"])
+	}
+
+	if tags, _ := request["tags"].(map[string]interface{}); tags["peer"] != "tcp://localhost:3000" {
+		t.Fatalf("expected span tags to be posted, got %v", request["tags"])
+	}
+
+	if endpoint, _ := request["localEndpoint"].(map[string]interface{}); endpoint["serviceName"] != "noise" {
+		t.Fatalf("expected spans to be posted under the service name, got %v", request["localEndpoint"])
+	}
+
+	if exporter.Dropped() != 0 {
+		t.Fatalf("expected no spans to be dropped, got %d", exporter.Dropped())
+	}
+}
+
+func TestZipkinExporterRefused(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	exporter := NewZipkinExporter(server.URL, "noise")
+	tracer := NewTracer(exporter)
+
+	_, span := tracer.StartSpan(context.Background(), "send")
+	span.Finish()
+
+	exporter.Close()
+
+	if exporter.Dropped() != 1 {
+		t.Fatalf("expected spans refused by the collector to be dropped, got %d", exporter.Dropped())
+	}
+
+	_, span = tracer.StartSpan(context.Background(), "send")
+	span.Finish()
+
+	if exporter.Dropped() != 2 {
+		t.Fatalf("expected spans exported once closed to be dropped, got %d", exporter.Dropped())
+	}
+}
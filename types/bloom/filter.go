@@ -0,0 +1,79 @@
+package bloom
+
+import (
+	"encoding/binary"
+
+	"github.com/perlin-network/noise/crypto/blake2b"
+
+	"github.com/pkg/errors"
+)
+
+// bitsPerItem is the number of bits allocated per item a filter is sized for,
+// yielding a false positive rate of roughly 2.4% with numHashes hashes.
+const (
+	bitsPerItem = 8
+	numHashes   = 4
+)
+
+// Filter is a Bloom filter: a compact, probabilistic set which may report false
+// positives but never false negatives. Filters are not concurrent-safe.
+type Filter struct {
+	bits   []byte
+	hashes uint32
+}
+
+// New instantiates a Bloom filter sized to hold a number of items.
+func New(items int) *Filter {
+	if items < 1 {
+		items = 1
+	}
+	return &Filter{bits: make([]byte, (items*bitsPerItem+7)/8), hashes: numHashes}
+}
+
+// FromBytes reconstructs a Bloom filter out of its bits and number of hashes.
+func FromBytes(bits []byte, hashes uint32) (*Filter, error) {
+	if len(bits) == 0 || hashes == 0 {
+		return nil, errors.New("bloom: filter must have bits and hashes")
+	}
+	return &Filter{bits: bits, hashes: hashes}, nil
+}
+
+// Bytes returns the bits of the filter.
+func (f *Filter) Bytes() []byte {
+	return f.bits
+}
+
+// Hashes returns the number of hashes the filter sets per item.
+func (f *Filter) Hashes() uint32 {
+	return f.hashes
+}
+
+// indices derives the bit indices of an item through double hashing.
+func (f *Filter) indices(item []byte, fn func(i uint64)) {
+	hash := blake2b.New().HashBytes(item)
+
+	a, b := binary.LittleEndian.Uint64(hash[:8]), binary.LittleEndian.Uint64(hash[8:16])|1
+	m := uint64(len(f.bits)) * 8
+
+	for i := uint64(0); i < uint64(f.hashes); i++ {
+		fn((a + i*b) % m)
+	}
+}
+
+// Add inserts an item into the filter.
+func (f *Filter) Add(item []byte) {
+	f.indices(item, func(i uint64) {
+		f.bits[i/8] |= 1 << (i % 8)
+	})
+}
+
+// Has returns whether or not an item may have been inserted into the filter.
+func (f *Filter) Has(item []byte) bool {
+	has := true
+	f.indices(item, func(i uint64) {
+		if f.bits[i/8]&(1<<(i%8)) == 0 {
+			has = false
+		}
+	})
+	return has
+}
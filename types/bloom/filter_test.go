@@ -0,0 +1,41 @@
+package bloom
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestFilter(t *testing.T) {
+	t.Parallel()
+
+	filter := New(100)
+	for i := 0; i < 100; i++ {
+		filter.Add([]byte(fmt.Sprintf("item %d", i)))
+	}
+
+	for i := 0; i < 100; i++ {
+		if !filter.Has([]byte(fmt.Sprintf("item %d", i))) {
+			t.Fatalf("expected item %d to be within the filter", i)
+		}
+	}
+
+	falsePositives := 0
+	for i := 0; i < 1000; i++ {
+		if filter.Has([]byte(fmt.Sprintf("other %d", i))) {
+			falsePositives++
+		}
+	}
+
+	if falsePositives > 100 {
+		t.Fatalf("expected a false positive rate below 10%%, got %d/1000", falsePositives)
+	}
+
+	decoded, err := FromBytes(filter.Bytes(), filter.Hashes())
+	if err != nil || !decoded.Has([]byte("item 0")) {
+		t.Fatalf("expected filter to round-trip through its bytes, got %v", err)
+	}
+
+	if _, err := FromBytes(nil, 4); err == nil {
+		t.Fatal("FromBytes() expected a filter without bits to be rejected")
+	}
+}
@@ -0,0 +1,161 @@
+package content
+
+import (
+	"encoding/binary"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultChunkSize is the size content is split into chunks of by default.
+const DefaultChunkSize = 256 * 1024
+
+// ErrInvalidManifest is returned when decoding a malformed manifest.
+var ErrInvalidManifest = errors.New("content: invalid manifest")
+
+// Split splits data into chunks of at most size bytes. The chunks share
+// memory with data.
+func Split(data []byte, size int) [][]byte {
+	if size <= 0 {
+		size = DefaultChunkSize
+	}
+
+	chunks := make([][]byte, 0, (len(data)+size-1)/size)
+	for len(data) > size {
+		chunks = append(chunks, data[:size:size])
+		data = data[size:]
+	}
+
+	if len(data) > 0 {
+		chunks = append(chunks, data)
+	}
+
+	return chunks
+}
+
+// Chunk reads r until EOF in chunks of at most size bytes, calling fn with the
+// content ID and bytes of every chunk in order. The chunk passed to fn is only
+// valid until fn returns.
+func Chunk(r io.Reader, size int, fn func(id ID, chunk []byte) error) error {
+	if size <= 0 {
+		size = DefaultChunkSize
+	}
+
+	buf := make([]byte, size)
+
+	for {
+		n, err := io.ReadFull(r, buf)
+		if n > 0 {
+			if err := fn(Sum(buf[:n]), buf[:n]); err != nil {
+				return err
+			}
+		}
+
+		switch err {
+		case nil:
+		case io.EOF, io.ErrUnexpectedEOF:
+			return nil
+		default:
+			return errors.Wrap(err, "content: failed to read chunk")
+		}
+	}
+}
+
+// Manifest describes content split into chunks, such that chunks may be
+// fetched and verified independently of one another.
+type Manifest struct {
+	// Size is the total size of the content in bytes.
+	Size uint64
+	// ChunkSize is the size of every chunk but the last in bytes.
+	ChunkSize uint64
+	// Chunks are the content IDs of every chunk, in order.
+	Chunks []ID
+}
+
+// NewManifest reads r until EOF, and builds a manifest of its chunks.
+func NewManifest(r io.Reader, chunkSize int) (*Manifest, error) {
+	if chunkSize <= 0 {
+		chunkSize = DefaultChunkSize
+	}
+
+	m := &Manifest{ChunkSize: uint64(chunkSize)}
+
+	err := Chunk(r, chunkSize, func(id ID, chunk []byte) error {
+		m.Size += uint64(len(chunk))
+		m.Chunks = append(m.Chunks, id)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// ChunkLen returns the size in bytes of the i'th chunk.
+func (m *Manifest) ChunkLen(i int) uint64 {
+	if i == len(m.Chunks)-1 {
+		return m.Size - uint64(i)*m.ChunkSize
+	}
+	return m.ChunkSize
+}
+
+// Bytes encodes the manifest.
+func (m *Manifest) Bytes() []byte {
+	buf := appendUvarint(nil, m.Size)
+	buf = appendUvarint(buf, m.ChunkSize)
+	buf = appendUvarint(buf, uint64(len(m.Chunks)))
+
+	for _, id := range m.Chunks {
+		buf = appendUvarint(buf, uint64(len(id)))
+		buf = append(buf, id...)
+	}
+
+	return buf
+}
+
+// ID returns the content ID of the encoded manifest, which addresses the
+// content as a whole.
+func (m *Manifest) ID() ID {
+	return Sum(m.Bytes())
+}
+
+// ParseManifest decodes and validates a manifest encoded by Manifest.Bytes.
+func ParseManifest(b []byte) (*Manifest, error) {
+	var fields [3]uint64
+
+	for i := range fields {
+		x, n := binary.Uvarint(b)
+		if n <= 0 {
+			return nil, ErrInvalidManifest
+		}
+		fields[i], b = x, b[n:]
+	}
+
+	m := &Manifest{Size: fields[0], ChunkSize: fields[1]}
+
+	if m.ChunkSize == 0 || fields[2] != (m.Size+m.ChunkSize-1)/m.ChunkSize {
+		return nil, ErrInvalidManifest
+	}
+
+	for i := uint64(0); i < fields[2]; i++ {
+		length, n := binary.Uvarint(b)
+		if n <= 0 || uint64(len(b)-n) < length {
+			return nil, ErrInvalidManifest
+		}
+
+		id, err := Parse(b[n : n+int(length)])
+		if err != nil {
+			return nil, errors.Wrap(ErrInvalidManifest, err.Error())
+		}
+
+		m.Chunks = append(m.Chunks, id)
+		b = b[n+int(length):]
+	}
+
+	if len(b) != 0 {
+		return nil, ErrInvalidManifest
+	}
+
+	return m, nil
+}
@@ -0,0 +1,61 @@
+package content
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestSplit(t *testing.T) {
+	t.Parallel()
+
+	chunks := Split([]byte("abcdefghij"), 4)
+
+	expected := []string{"abcd", "efgh", "ij"}
+	if len(chunks) != len(expected) {
+		t.Fatalf("expected %d chunks, got %d", len(expected), len(chunks))
+	}
+
+	for i, chunk := range chunks {
+		if string(chunk) != expected[i] {
+			t.Fatalf("chunks[%d] = %q, expected %q", i, chunk, expected[i])
+		}
+	}
+
+	if chunks := Split(nil, 4); len(chunks) != 0 {
+		t.Fatalf("expected no chunks given no data, got %d", len(chunks))
+	}
+}
+
+func TestManifest(t *testing.T) {
+	t.Parallel()
+
+	data := bytes.Repeat([]byte("0123456789"), 10)
+
+	m, err := NewManifest(bytes.NewReader(data), 32)
+	if err != nil {
+		t.Fatalf("NewManifest() = expected no error, got %v", err)
+	}
+
+	if m.Size != uint64(len(data)) || len(m.Chunks) != 4 || m.ChunkLen(3) != 4 {
+		t.Fatalf("unexpected manifest: size %d, %d chunks, last chunk of %d bytes", m.Size, len(m.Chunks), m.ChunkLen(3))
+	}
+
+	for i, chunk := range Split(data, 32) {
+		if err := m.Chunks[i].Verify(chunk); err != nil {
+			t.Fatalf("chunk %d = expected to verify against the manifest, got %v", i, err)
+		}
+	}
+
+	parsed, err := ParseManifest(m.Bytes())
+	if err != nil {
+		t.Fatalf("ParseManifest() = expected no error, got %v", err)
+	}
+
+	if !parsed.ID().Equals(m.ID()) {
+		t.Fatalf("expected parsed manifest ID %s, got %s", m.ID(), parsed.ID())
+	}
+
+	if _, err := ParseManifest(m.Bytes()[:len(m.Bytes())-1]); err != ErrInvalidManifest {
+		t.Fatalf("ParseManifest() = expected ErrInvalidManifest given a truncated manifest, got %v", err)
+	}
+}
@@ -0,0 +1,107 @@
+package content
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+
+	"github.com/perlin-network/noise/crypto/blake2b"
+
+	"github.com/pkg/errors"
+)
+
+// Blake2b256 is the multihash code of the BLAKE2b-256 hash function, which
+// content is addressed by.
+const Blake2b256 uint64 = 0xb220
+
+var (
+	// ErrInvalidID is returned when parsing a malformed content ID.
+	ErrInvalidID = errors.New("content: invalid content ID")
+
+	// ErrUnsupportedHash is returned when parsing a content ID addressed by a
+	// hash function other than BLAKE2b-256.
+	ErrUnsupportedHash = errors.New("content: unsupported hash function")
+
+	// ErrMismatch is returned when content does not hash to its content ID.
+	ErrMismatch = errors.New("content: content does not match its ID")
+)
+
+// ID identifies content by its hash. Following multihash, it is comprised of
+// the uvarint-encoded code of the hash function used, the uvarint-encoded
+// length of the digest, and the digest itself.
+//
+// The bytes of an ID double as the key content is stored and provided under
+// within the DHT.
+type ID []byte
+
+// Sum computes the content ID of data.
+func Sum(data []byte) ID {
+	id := make([]byte, 0, 2*binary.MaxVarintLen64+blake2b.Size)
+	id = appendUvarint(id, Blake2b256)
+	id = appendUvarint(id, blake2b.Size)
+
+	return blake2b.Sum(id, data)
+}
+
+// Parse validates and returns the content ID held in b.
+func Parse(b []byte) (ID, error) {
+	code, n := binary.Uvarint(b)
+	if n <= 0 {
+		return nil, ErrInvalidID
+	}
+
+	if code != Blake2b256 {
+		return nil, errors.Wrapf(ErrUnsupportedHash, "code %#x", code)
+	}
+
+	length, m := binary.Uvarint(b[n:])
+	if m <= 0 || length != blake2b.Size || uint64(len(b)-n-m) != length {
+		return nil, ErrInvalidID
+	}
+
+	return ID(b), nil
+}
+
+// ParseHex validates and returns the content ID held in a hex string.
+func ParseHex(s string) (ID, error) {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidID, err.Error())
+	}
+	return Parse(b)
+}
+
+// Digest returns the hash digest of content the ID addresses.
+func (id ID) Digest() []byte {
+	_, n := binary.Uvarint(id)
+	_, m := binary.Uvarint(id[n:])
+	return id[n+m:]
+}
+
+// Key returns the key content is stored and provided under within the DHT.
+func (id ID) Key() []byte {
+	return id
+}
+
+// Equals returns whether or not two content IDs address the same content.
+func (id ID) Equals(other ID) bool {
+	return bytes.Equal(id, other)
+}
+
+// Verify checks that data hashes to the content ID.
+func (id ID) Verify(data []byte) error {
+	if !id.Equals(Sum(data)) {
+		return ErrMismatch
+	}
+	return nil
+}
+
+// String returns the hex representation of the content ID.
+func (id ID) String() string {
+	return hex.EncodeToString(id)
+}
+
+func appendUvarint(dst []byte, x uint64) []byte {
+	var buf [binary.MaxVarintLen64]byte
+	return append(dst, buf[:binary.PutUvarint(buf[:], x)]...)
+}
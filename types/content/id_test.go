@@ -0,0 +1,37 @@
+package content
+
+import (
+	"testing"
+)
+
+func TestID(t *testing.T) {
+	t.Parallel()
+
+	data := []byte("content")
+	id := Sum(data)
+
+	if len(id.Digest()) != 32 {
+		t.Fatalf("expected a 32 byte digest, got %d bytes", len(id.Digest()))
+	}
+
+	if err := id.Verify(data); err != nil {
+		t.Fatalf("Verify() = expected no error, got %v", err)
+	}
+
+	if err := id.Verify([]byte("tampered")); err != ErrMismatch {
+		t.Fatalf("Verify() = expected ErrMismatch, got %v", err)
+	}
+
+	parsed, err := ParseHex(id.String())
+	if err != nil || !parsed.Equals(id) {
+		t.Fatalf("ParseHex() = expected %s, got %s (err: %v)", id, parsed, err)
+	}
+
+	if _, err := Parse(id[:len(id)-1]); err != ErrInvalidID {
+		t.Fatalf("Parse() = expected ErrInvalidID given a truncated ID, got %v", err)
+	}
+
+	if _, err := Parse(append([]byte{0x12, 0x20}, id.Digest()...)); err == nil {
+		t.Fatal("Parse() expected an error given an unsupported hash function")
+	}
+}
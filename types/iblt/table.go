@@ -0,0 +1,224 @@
+// Package iblt implements invertible Bloom lookup tables, which allow two
+// parties to tell apart the items of their sets by exchanging a table sized to
+// the number of items their sets differ by rather than to the sets themselves.
+package iblt
+
+import (
+	"encoding/binary"
+
+	"github.com/perlin-network/noise/crypto/blake2b"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// IDSize is the size in bytes of the IDs tables hold.
+	IDSize = 32
+
+	// numHashes is the number of cells every ID is inserted into.
+	numHashes = 3
+
+	// cellSize is the size in bytes of an encoded cell: its count, the XOR of
+	// the IDs and the XOR of the checksums of the IDs inserted into it.
+	cellSize = 8 + IDSize + 8
+)
+
+type cell struct {
+	count   int64
+	idSum   [IDSize]byte
+	hashSum uint64
+}
+
+// empty returns whether or not the cell holds no IDs.
+func (c *cell) empty() bool {
+	return c.count == 0 && c.hashSum == 0 && c.idSum == [IDSize]byte{}
+}
+
+// pure returns whether or not the cell holds exactly one ID, inserted into
+// or subtracted from the table.
+func (c *cell) pure() bool {
+	return (c.count == 1 || c.count == -1) && c.hashSum == checksum(c.idSum[:])
+}
+
+func (c *cell) toggle(id []byte, count int64) {
+	c.count += count
+	for i := range c.idSum {
+		c.idSum[i] ^= id[i]
+	}
+	c.hashSum ^= checksum(id)
+}
+
+// checksum hashes an ID such that the XOR of several IDs may be told apart
+// from a single ID.
+func checksum(id []byte) uint64 {
+	return binary.LittleEndian.Uint64(blake2b.New().HashBytes(id))
+}
+
+// ID returns the ID an item is held in tables under.
+func ID(item []byte) []byte {
+	return blake2b.New().HashBytes(item)
+}
+
+// Table is an invertible Bloom lookup table of IDs. Subtracting the table of
+// one set from that of another leaves a table which decodes into the IDs held
+// by only either of both sets, so long as they differ by at most roughly
+// two-thirds as many IDs as the table has cells. Tables are not
+// concurrent-safe.
+type Table struct {
+	cells  []cell
+	hashes uint32
+}
+
+// New instantiates a table with a number of cells, rounded up to a multiple
+// of the number of hashes.
+func New(cells int) *Table {
+	if cells < numHashes {
+		cells = numHashes
+	}
+	cells = (cells + numHashes - 1) / numHashes * numHashes
+
+	return &Table{cells: make([]cell, cells), hashes: numHashes}
+}
+
+// FromBytes reconstructs a table out of its encoded cells and number of hashes.
+func FromBytes(encoded []byte, hashes uint32) (*Table, error) {
+	if hashes == 0 || len(encoded) == 0 || len(encoded)%cellSize != 0 {
+		return nil, errors.New("iblt: table must have hashes and whole cells")
+	}
+
+	cells := len(encoded) / cellSize
+	if cells%int(hashes) != 0 {
+		return nil, errors.Errorf("iblt: %d cells may not be split among %d hashes", cells, hashes)
+	}
+
+	t := &Table{cells: make([]cell, cells), hashes: hashes}
+
+	for i := range t.cells {
+		c, buf := &t.cells[i], encoded[i*cellSize:(i+1)*cellSize]
+
+		c.count = int64(binary.LittleEndian.Uint64(buf[:8]))
+		copy(c.idSum[:], buf[8:8+IDSize])
+		c.hashSum = binary.LittleEndian.Uint64(buf[8+IDSize:])
+	}
+
+	return t, nil
+}
+
+// Bytes returns the encoded cells of the table.
+func (t *Table) Bytes() []byte {
+	encoded := make([]byte, len(t.cells)*cellSize)
+
+	for i := range t.cells {
+		c, buf := &t.cells[i], encoded[i*cellSize:(i+1)*cellSize]
+
+		binary.LittleEndian.PutUint64(buf[:8], uint64(c.count))
+		copy(buf[8:8+IDSize], c.idSum[:])
+		binary.LittleEndian.PutUint64(buf[8+IDSize:], c.hashSum)
+	}
+
+	return encoded
+}
+
+// Cells returns the number of cells of the table.
+func (t *Table) Cells() int {
+	return len(t.cells)
+}
+
+// Hashes returns the number of cells every ID is inserted into.
+func (t *Table) Hashes() uint32 {
+	return t.hashes
+}
+
+// indices derives the cell indices of an ID, one within each of as many
+// equally-sized partitions of the table as there are hashes, such that no ID
+// is ever inserted into the same cell twice.
+func (t *Table) indices(id []byte, fn func(i int)) {
+	a, b := binary.LittleEndian.Uint64(id[:8]), binary.LittleEndian.Uint64(id[8:16])|1
+	size := uint64(len(t.cells)) / uint64(t.hashes)
+
+	for i := uint64(0); i < uint64(t.hashes); i++ {
+		fn(int(i*size + (a+i*b)%size))
+	}
+}
+
+// Insert inserts an ID into the table. IDs must be IDSize bytes, such as those
+// returned by ID.
+func (t *Table) Insert(id []byte) {
+	t.indices(id, func(i int) {
+		t.cells[i].toggle(id, 1)
+	})
+}
+
+// Subtract subtracts the IDs of another table from the table. Both tables must
+// have the same number of cells and hashes.
+func (t *Table) Subtract(other *Table) error {
+	if len(t.cells) != len(other.cells) || t.hashes != other.hashes {
+		return errors.Errorf("iblt: table of %d cells and %d hashes may not be subtracted from one of %d cells and %d hashes",
+			len(other.cells), other.hashes, len(t.cells), t.hashes)
+	}
+
+	for i := range t.cells {
+		c, o := &t.cells[i], &other.cells[i]
+
+		c.count -= o.count
+		for j := range c.idSum {
+			c.idSum[j] ^= o.idSum[j]
+		}
+		c.hashSum ^= o.hashSum
+	}
+
+	return nil
+}
+
+// Decode lists the IDs inserted into the table, and those subtracted from it
+// that were never inserted. It returns false should the table hold too many
+// IDs to be listed in full, in which case the IDs listed are incomplete.
+// The table is left untouched.
+func (t *Table) Decode() (inserted [][]byte, subtracted [][]byte, ok bool) {
+	cells := make([]cell, len(t.cells))
+	copy(cells, t.cells)
+
+	peeled := &Table{cells: cells, hashes: t.hashes}
+
+	var pure []int
+	for i := range cells {
+		if cells[i].pure() {
+			pure = append(pure, i)
+		}
+	}
+
+	for len(pure) > 0 {
+		i := pure[len(pure)-1]
+		pure = pure[:len(pure)-1]
+
+		// The cell may have been peeled since it was found to be pure.
+		if !cells[i].pure() {
+			continue
+		}
+
+		id := make([]byte, IDSize)
+		copy(id, cells[i].idSum[:])
+
+		count := cells[i].count
+		if count == 1 {
+			inserted = append(inserted, id)
+		} else {
+			subtracted = append(subtracted, id)
+		}
+
+		peeled.indices(id, func(j int) {
+			cells[j].toggle(id, -count)
+			if cells[j].pure() {
+				pure = append(pure, j)
+			}
+		})
+	}
+
+	for i := range cells {
+		if !cells[i].empty() {
+			return inserted, subtracted, false
+		}
+	}
+
+	return inserted, subtracted, true
+}
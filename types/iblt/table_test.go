@@ -0,0 +1,93 @@
+package iblt
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"testing"
+)
+
+func sorted(ids [][]byte) [][]byte {
+	sort.Slice(ids, func(i, j int) bool {
+		return bytes.Compare(ids[i], ids[j]) < 0
+	})
+	return ids
+}
+
+func TestDecode(t *testing.T) {
+	t.Parallel()
+
+	ours, theirs := New(90), New(90)
+
+	// Both sets share 1000 items, and differ by 20 each.
+	for i := 0; i < 1000; i++ {
+		ours.Insert(ID([]byte(fmt.Sprintf("shared %d", i))))
+		theirs.Insert(ID([]byte(fmt.Sprintf("shared %d", i))))
+	}
+
+	var onlyOurs, onlyTheirs [][]byte
+	for i := 0; i < 20; i++ {
+		id := ID([]byte(fmt.Sprintf("ours %d", i)))
+		ours.Insert(id)
+		onlyOurs = append(onlyOurs, id)
+
+		id = ID([]byte(fmt.Sprintf("theirs %d", i)))
+		theirs.Insert(id)
+		onlyTheirs = append(onlyTheirs, id)
+	}
+
+	received, err := FromBytes(theirs.Bytes(), theirs.Hashes())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ours.Subtract(received); err != nil {
+		t.Fatal(err)
+	}
+
+	inserted, subtracted, ok := ours.Decode()
+	if !ok {
+		t.Fatal("expected the table to decode")
+	}
+
+	if fmt.Sprint(sorted(inserted)) != fmt.Sprint(sorted(onlyOurs)) {
+		t.Fatalf("expected to decode the %d IDs only we hold, got %d", len(onlyOurs), len(inserted))
+	}
+
+	if fmt.Sprint(sorted(subtracted)) != fmt.Sprint(sorted(onlyTheirs)) {
+		t.Fatalf("expected to decode the %d IDs only they hold, got %d", len(onlyTheirs), len(subtracted))
+	}
+}
+
+func TestDecodeOverflow(t *testing.T) {
+	t.Parallel()
+
+	table := New(12)
+	for i := 0; i < 100; i++ {
+		table.Insert(ID([]byte(fmt.Sprintf("item %d", i))))
+	}
+
+	if _, _, ok := table.Decode(); ok {
+		t.Fatal("expected a table holding far more IDs than cells to not decode")
+	}
+}
+
+func TestFromBytes(t *testing.T) {
+	t.Parallel()
+
+	if _, err := FromBytes(nil, numHashes); err == nil {
+		t.Fatal("expected an empty table to be rejected")
+	}
+
+	if _, err := FromBytes(make([]byte, cellSize+1), numHashes); err == nil {
+		t.Fatal("expected partial cells to be rejected")
+	}
+
+	if _, err := FromBytes(make([]byte, 4*cellSize), numHashes); err == nil {
+		t.Fatal("expected cells not split evenly among hashes to be rejected")
+	}
+
+	if err := New(3).Subtract(New(6)); err == nil {
+		t.Fatal("expected tables of different sizes to not be subtracted")
+	}
+}
@@ -20,6 +20,38 @@ func init() {
 		{&protobuf.Pong{}, PongCode},
 		{&protobuf.LookupNodeRequest{}, LookupNodeRequestCode},
 		{&protobuf.LookupNodeResponse{}, LookupNodeResponseCode},
+		{&protobuf.StoreRequest{}, StoreRequestCode},
+		{&protobuf.StoreResponse{}, StoreResponseCode},
+		{&protobuf.FindValueRequest{}, FindValueRequestCode},
+		{&protobuf.FindValueResponse{}, FindValueResponseCode},
+		{&protobuf.AddProviderRequest{}, AddProviderRequestCode},
+		{&protobuf.AddProviderResponse{}, AddProviderResponseCode},
+		{&protobuf.GetProvidersRequest{}, GetProvidersRequestCode},
+		{&protobuf.GetProvidersResponse{}, GetProvidersResponseCode},
+		{&protobuf.IdentityLink{}, IdentityLinkCode},
+		{&protobuf.RoutingSummaryRequest{}, RoutingSummaryRequestCode},
+		{&protobuf.RoutingSummaryResponse{}, RoutingSummaryResponseCode},
+		{&protobuf.BlockRequest{}, BlockRequestCode},
+		{&protobuf.BlockResponse{}, BlockResponseCode},
+		{&protobuf.ManifestRequest{}, ManifestRequestCode},
+		{&protobuf.ManifestResponse{}, ManifestResponseCode},
+		{&protobuf.ChunkRequest{}, ChunkRequestCode},
+		{&protobuf.ChunkResponse{}, ChunkResponseCode},
+		{&protobuf.TimeRequest{}, TimeRequestCode},
+		{&protobuf.TimeResponse{}, TimeResponseCode},
+		{&protobuf.BridgeMessage{}, BridgeMessageCode},
+		{&protobuf.ErrorReply{}, ErrorReplyCode},
+		{&protobuf.MetadataGossip{}, MetadataGossipCode},
+		{&protobuf.ReconcileRequest{}, ReconcileRequestCode},
+		{&protobuf.ReconcileResponse{}, ReconcileResponseCode},
+		{&protobuf.SnapshotRequest{}, SnapshotRequestCode},
+		{&protobuf.SnapshotOffer{}, SnapshotOfferCode},
+		{&protobuf.GroupKey{}, GroupKeyCode},
+		{&protobuf.GroupMessage{}, GroupMessageCode},
+		{&protobuf.TopologyRequest{}, TopologyRequestCode},
+		{&protobuf.TopologyResponse{}, TopologyResponseCode},
+		{&protobuf.RevocationGossip{}, RevocationGossipCode},
+		{&protobuf.StoreBatchRequest{}, StoreBatchRequestCode},
 	}
 
 	for _, pair := range msgOpcodePairs {
@@ -32,12 +64,44 @@ func init() {
 type Opcode uint32
 
 const (
-	UnregisteredCode       Opcode = 0x00000 // 0
-	BytesCode              Opcode = 0x00001 // 1
-	PingCode               Opcode = 0x0000a // 10
-	PongCode               Opcode = 0x0000b // 11
-	LookupNodeRequestCode  Opcode = 0x0000c // 12
-	LookupNodeResponseCode Opcode = 0x0000d // 13
+	UnregisteredCode           Opcode = 0x00000 // 0
+	BytesCode                  Opcode = 0x00001 // 1
+	PingCode                   Opcode = 0x0000a // 10
+	PongCode                   Opcode = 0x0000b // 11
+	LookupNodeRequestCode      Opcode = 0x0000c // 12
+	LookupNodeResponseCode     Opcode = 0x0000d // 13
+	StoreRequestCode           Opcode = 0x0000e // 14
+	StoreResponseCode          Opcode = 0x0000f // 15
+	FindValueRequestCode       Opcode = 0x00010 // 16
+	FindValueResponseCode      Opcode = 0x00011 // 17
+	AddProviderRequestCode     Opcode = 0x00012 // 18
+	AddProviderResponseCode    Opcode = 0x00013 // 19
+	GetProvidersRequestCode    Opcode = 0x00014 // 20
+	GetProvidersResponseCode   Opcode = 0x00015 // 21
+	IdentityLinkCode           Opcode = 0x00016 // 22
+	RoutingSummaryRequestCode  Opcode = 0x00017 // 23
+	RoutingSummaryResponseCode Opcode = 0x00018 // 24
+	BlockRequestCode           Opcode = 0x00019 // 25
+	BlockResponseCode          Opcode = 0x0001a // 26
+	ManifestRequestCode        Opcode = 0x0001b // 27
+	ManifestResponseCode       Opcode = 0x0001c // 28
+	ChunkRequestCode           Opcode = 0x0001d // 29
+	ChunkResponseCode          Opcode = 0x0001e // 30
+	TimeRequestCode            Opcode = 0x0001f // 31
+	TimeResponseCode           Opcode = 0x00020 // 32
+	BridgeMessageCode          Opcode = 0x00021 // 33
+	ErrorReplyCode             Opcode = 0x00022 // 34
+	MetadataGossipCode         Opcode = 0x00023 // 35
+	ReconcileRequestCode       Opcode = 0x00024 // 36
+	ReconcileResponseCode      Opcode = 0x00025 // 37
+	SnapshotRequestCode        Opcode = 0x00026 // 38
+	SnapshotOfferCode          Opcode = 0x00027 // 39
+	GroupKeyCode               Opcode = 0x00028 // 40
+	GroupMessageCode           Opcode = 0x00029 // 41
+	TopologyRequestCode        Opcode = 0x0002a // 42
+	TopologyResponseCode       Opcode = 0x0002b // 43
+	RevocationGossipCode       Opcode = 0x0002c // 44
+	StoreBatchRequestCode      Opcode = 0x0002d // 45
 )
 
 var (
@@ -47,6 +47,38 @@ func TestGetMessageType(t *testing.T) {
 		{&pb.Pong{}, PongCode},
 		{&pb.LookupNodeRequest{}, LookupNodeRequestCode},
 		{&pb.LookupNodeResponse{}, LookupNodeResponseCode},
+		{&pb.StoreRequest{}, StoreRequestCode},
+		{&pb.StoreResponse{}, StoreResponseCode},
+		{&pb.FindValueRequest{}, FindValueRequestCode},
+		{&pb.FindValueResponse{}, FindValueResponseCode},
+		{&pb.AddProviderRequest{}, AddProviderRequestCode},
+		{&pb.AddProviderResponse{}, AddProviderResponseCode},
+		{&pb.GetProvidersRequest{}, GetProvidersRequestCode},
+		{&pb.GetProvidersResponse{}, GetProvidersResponseCode},
+		{&pb.IdentityLink{}, IdentityLinkCode},
+		{&pb.RoutingSummaryRequest{}, RoutingSummaryRequestCode},
+		{&pb.RoutingSummaryResponse{}, RoutingSummaryResponseCode},
+		{&pb.BlockRequest{}, BlockRequestCode},
+		{&pb.BlockResponse{}, BlockResponseCode},
+		{&pb.ManifestRequest{}, ManifestRequestCode},
+		{&pb.ManifestResponse{}, ManifestResponseCode},
+		{&pb.ChunkRequest{}, ChunkRequestCode},
+		{&pb.ChunkResponse{}, ChunkResponseCode},
+		{&pb.TimeRequest{}, TimeRequestCode},
+		{&pb.TimeResponse{}, TimeResponseCode},
+		{&pb.BridgeMessage{}, BridgeMessageCode},
+		{&pb.ErrorReply{}, ErrorReplyCode},
+		{&pb.MetadataGossip{}, MetadataGossipCode},
+		{&pb.ReconcileRequest{}, ReconcileRequestCode},
+		{&pb.ReconcileResponse{}, ReconcileResponseCode},
+		{&pb.SnapshotRequest{}, SnapshotRequestCode},
+		{&pb.SnapshotOffer{}, SnapshotOfferCode},
+		{&pb.GroupKey{}, GroupKeyCode},
+		{&pb.GroupMessage{}, GroupMessageCode},
+		{&pb.TopologyRequest{}, TopologyRequestCode},
+		{&pb.TopologyResponse{}, TopologyResponseCode},
+		{&pb.RevocationGossip{}, RevocationGossipCode},
+		{&pb.StoreBatchRequest{}, StoreBatchRequestCode},
 	}
 
 	for _, tt := range testCases {
@@ -77,6 +109,38 @@ func TestGetOpcode(t *testing.T) {
 		{&pb.Pong{}, PongCode},
 		{&pb.LookupNodeRequest{}, LookupNodeRequestCode},
 		{&pb.LookupNodeResponse{}, LookupNodeResponseCode},
+		{&pb.StoreRequest{}, StoreRequestCode},
+		{&pb.StoreResponse{}, StoreResponseCode},
+		{&pb.FindValueRequest{}, FindValueRequestCode},
+		{&pb.FindValueResponse{}, FindValueResponseCode},
+		{&pb.AddProviderRequest{}, AddProviderRequestCode},
+		{&pb.AddProviderResponse{}, AddProviderResponseCode},
+		{&pb.GetProvidersRequest{}, GetProvidersRequestCode},
+		{&pb.GetProvidersResponse{}, GetProvidersResponseCode},
+		{&pb.IdentityLink{}, IdentityLinkCode},
+		{&pb.RoutingSummaryRequest{}, RoutingSummaryRequestCode},
+		{&pb.RoutingSummaryResponse{}, RoutingSummaryResponseCode},
+		{&pb.BlockRequest{}, BlockRequestCode},
+		{&pb.BlockResponse{}, BlockResponseCode},
+		{&pb.ManifestRequest{}, ManifestRequestCode},
+		{&pb.ManifestResponse{}, ManifestResponseCode},
+		{&pb.ChunkRequest{}, ChunkRequestCode},
+		{&pb.ChunkResponse{}, ChunkResponseCode},
+		{&pb.TimeRequest{}, TimeRequestCode},
+		{&pb.TimeResponse{}, TimeResponseCode},
+		{&pb.BridgeMessage{}, BridgeMessageCode},
+		{&pb.ErrorReply{}, ErrorReplyCode},
+		{&pb.MetadataGossip{}, MetadataGossipCode},
+		{&pb.ReconcileRequest{}, ReconcileRequestCode},
+		{&pb.ReconcileResponse{}, ReconcileResponseCode},
+		{&pb.SnapshotRequest{}, SnapshotRequestCode},
+		{&pb.SnapshotOffer{}, SnapshotOfferCode},
+		{&pb.GroupKey{}, GroupKeyCode},
+		{&pb.GroupMessage{}, GroupMessageCode},
+		{&pb.TopologyRequest{}, TopologyRequestCode},
+		{&pb.TopologyResponse{}, TopologyResponseCode},
+		{&pb.RevocationGossip{}, RevocationGossipCode},
+		{&pb.StoreBatchRequest{}, StoreBatchRequestCode},
 	}
 
 	for _, tt := range testCases {